@@ -35,10 +35,10 @@ func (s *OpenAPISuite) TestOpenAPIContract() {
 	}
 
 	paths := mustMap(t, contract["paths"], "paths")
-	if len(paths) != 4 {
-		t.Fatalf("paths has %d entries, want exactly four", len(paths))
+	if len(paths) != 5 {
+		t.Fatalf("paths has %d entries, want exactly five", len(paths))
 	}
-	for _, path := range []string{"/", "/merge", "/ui"} {
+	for _, path := range []string{"/", "/merge", "/ui", "/export"} {
 		pathItem := mustMap(t, paths[path], "paths."+path)
 		if len(pathItem) != 1 || pathItem["post"] == nil {
 			t.Fatalf("%s must expose only POST, got %#v", path, pathItem)
@@ -80,12 +80,14 @@ func (s *OpenAPISuite) TestReusableSchemasMatchGoWireTypes() {
 		"Dataset":            shared.Dataset{},
 		"Theme":              shared.Theme{},
 		"HistoryEntry":       shared.HistoryEntry{},
+		"Commit":             shared.Commit{},
 		"Meta":               shared.Meta{},
 		"CPUInfo":            shared.CPUInfo{},
 		"Axis":               shared.Axis{},
 		"DataPoint":          shared.DataPoint{},
 		"Stat":               shared.Stat{},
 		"Sort":               shared.Sort{},
+		"Annotation":         shared.Annotation{},
 		"StatisticsConfig":   shared.StatConfig{},
 		"BarChartConfig":     bar.Config{},
 		"LineChartConfig":    line.Config{},
@@ -108,6 +110,7 @@ func (s *OpenAPISuite) TestReusableSchemasMatchGoWireTypes() {
 		"HistoryEntry":       {"tag", "timestamp"},
 		"Axis":               {"key"},
 		"Sort":               {"enabled", "order"},
+		"Annotation":         {"type"},
 		"StatisticsConfig":   {"enabled", "math"},
 		"BarChartConfig":     {"type"},
 		"LineChartConfig":    {"type"},
@@ -136,7 +139,7 @@ func (s *OpenAPISuite) TestMergeDeclaresNotAcceptableResponse() {
 func (s *OpenAPISuite) TestOperationsDeclareRequestProblemResponses() {
 	contract := readContract(s.T())
 	paths := mustMap(s.T(), contract["paths"], "paths")
-	for _, path := range []string{"/", "/merge", "/ui"} {
+	for _, path := range []string{"/", "/merge", "/ui", "/export"} {
 		s.Run(path, func() {
 			operation := mustMap(s.T(), mustMap(s.T(), paths[path], "paths."+path)["post"], "paths."+path+".post")
 			responses := mustMap(s.T(), operation["responses"], "paths."+path+".post.responses")
@@ -224,7 +227,7 @@ func (s *OpenAPISuite) TestRootConversionContract() {
 	schemas := mustMap(t, components["schemas"], "components.schemas")
 	request := mustMap(t, schemas["ConvertRequest"], "components.schemas.ConvertRequest")
 	s.Equal(
-		[]string{"charts", "description", "grouping", "id", "input", "jsonPath", "name", "output", "parser", "round", "select", "tag", "theme", "themes", "title", "units"},
+		[]string{"better", "charts", "description", "grouping", "id", "input", "jsonPath", "jsonPathKeys", "name", "output", "parser", "round", "select", "tag", "theme", "themes", "title", "units"},
 		propertyNames(t, request, "ConvertRequest"),
 	)
 	// themes is the data-owned catalog; theme remains as legacy convert input.
@@ -0,0 +1,119 @@
+package api
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	_ "github.com/goptics/vizb/cmd/charts/bar"
+	_ "github.com/goptics/vizb/cmd/charts/bump"
+	_ "github.com/goptics/vizb/cmd/charts/calendar"
+	_ "github.com/goptics/vizb/cmd/charts/chord"
+	_ "github.com/goptics/vizb/cmd/charts/heatmap"
+	_ "github.com/goptics/vizb/cmd/charts/histogram"
+	_ "github.com/goptics/vizb/cmd/charts/line"
+	_ "github.com/goptics/vizb/cmd/charts/parallel"
+	_ "github.com/goptics/vizb/cmd/charts/pie"
+	_ "github.com/goptics/vizb/cmd/charts/radar"
+	_ "github.com/goptics/vizb/cmd/charts/sankey"
+	_ "github.com/goptics/vizb/cmd/charts/scatter"
+	_ "github.com/goptics/vizb/cmd/charts/sunburst"
+	_ "github.com/goptics/vizb/cmd/charts/treemap"
+	_ "github.com/goptics/vizb/cmd/charts/waterfall"
+	"github.com/goptics/vizb/shared"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/yaml.v3"
+)
+
+var update = flag.Bool("update", false, "rewrite dataset.schema.json from the Go types")
+
+type DatasetSchemaSuite struct {
+	suite.Suite
+}
+
+func schemaPath(t *testing.T) string {
+	t.Helper()
+	_, thisFile, _, ok := runtime.Caller(0)
+	if !ok {
+		t.Fatal("locating schema_test.go")
+	}
+	return filepath.Join(filepath.Dir(thisFile), "dataset.schema.json")
+}
+
+func readDatasetSchema(t *testing.T) map[string]any {
+	t.Helper()
+	content, err := os.ReadFile(schemaPath(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// YAML is a JSON superset and decodes numbers the way validateSchema expects.
+	var schema map[string]any
+	if err := yaml.Unmarshal(content, &schema); err != nil {
+		t.Fatalf("parse dataset.schema.json: %v", err)
+	}
+	return schema
+}
+
+func (s *DatasetSchemaSuite) TestGeneratedFromGoTypes() {
+	got, err := shared.DatasetSchema()
+	s.Require().NoError(err)
+	path := schemaPath(s.T())
+	if *update {
+		s.Require().NoError(os.WriteFile(path, got, 0o644))
+	}
+	want, err := os.ReadFile(path)
+	s.Require().NoError(err, "run go test ./api -update to create %s", path)
+	s.Equal(string(want), string(got), "dataset.schema.json is stale; rerun go test ./api -update")
+}
+
+func (s *DatasetSchemaSuite) TestDatasetMatchesOpenAPI() {
+	t := s.T()
+	schema := readDatasetSchema(t)
+	defs := mustMap(t, schema["$defs"], "$defs")
+	openapi := mustMap(t, mustMap(t, readContract(t)["components"], "components")["schemas"], "components.schemas")
+
+	for _, name := range []string{"Dataset", "Axis", "HistoryEntry", "Meta", "Theme", "BarChartConfig", "PieChartConfig"} {
+		s.Equal(
+			propertyNames(t, mustMap(t, openapi[name], name), name),
+			propertyNames(t, mustMap(t, defs[name], name), name),
+			"%s properties differ between openapi.yaml and dataset.schema.json", name,
+		)
+	}
+	s.Equal(stringSliceValue(mustMap(t, openapi["Dataset"], "Dataset")["required"]),
+		stringSliceValue(mustMap(t, defs["Dataset"], "Dataset")["required"]))
+}
+
+func (s *DatasetSchemaSuite) TestValidatesDataFiles() {
+	t := s.T()
+	schema := readDatasetSchema(t)
+	parse := func(doc string) any {
+		var v any
+		s.Require().NoError(yaml.Unmarshal([]byte(doc), &v))
+		return v
+	}
+
+	for name, doc := range map[string]string{
+		"single dataset": `{"schemaVersion":3,"name":"API","axes":[{"key":"name"}],` +
+			`"settings":[{"type":"bar","sort":{"enabled":true,"order":"desc"},"min":"p5","max":100},{"type":"pie"}],` +
+			`"data":[{"name":"west","stats":[{"type":"Latency (ms)","value":12}]}]}`,
+		"array of datasets": `[{"name":"a","axes":[],"settings":[],"data":[]},{"name":"b","axes":[],"settings":[{"type":"line"}],"data":[]}]`,
+	} {
+		s.NoError(validateSchema(schema, schema, parse(doc), "#"), name)
+	}
+
+	for name, doc := range map[string]string{
+		"v0.12.0 settings object": `{"name":"a","axes":[],"settings":{"charts":["bar"]},"data":[]}`,
+		"unknown chart type":      `{"name":"a","axes":[],"settings":[{"type":"gauge"}],"data":[]}`,
+		"field of another chart":  `{"name":"a","axes":[],"settings":[{"type":"pie","stack":true}],"data":[]}`,
+		"flat legacy meta":        `{"name":"a","os":"linux","axes":[],"settings":[],"data":[]}`,
+		"bad percentile bound":    `{"name":"a","axes":[],"settings":[{"type":"bar","min":"95%"}],"data":[]}`,
+	} {
+		s.Error(validateSchema(schema, schema, parse(doc), "#"), name)
+	}
+}
+
+func TestDatasetSchemaSuite(t *testing.T) {
+	suite.Run(t, new(DatasetSchemaSuite))
+}
@@ -1,24 +1,13 @@
-// Package bar registers the bar chart type: it plugs the typed Config factory
-// into the charts registry, stores the flag descriptors, and advertises cobra
-// metadata for the `vizb bar` subcommand.
+// Package bar advertises cobra metadata for the `vizb bar` subcommand. The
+// chart type itself is registered by internal/charts/all.
 package bar
 
 import (
-	"slices"
-
 	"github.com/goptics/vizb/cmd/cli"
-	"github.com/goptics/vizb/internal/charts"
-	barchart "github.com/goptics/vizb/internal/charts/bar"
+	_ "github.com/goptics/vizb/internal/charts/all"
 )
 
 func init() {
-	charts.Register(charts.Spec{Type: "bar", Factory: barchart.New})
-	charts.SetFlags("bar", append(slices.Clone(charts.BaseChartFlags),
-		charts.ScaleFlag, charts.StackFlag, charts.ThreeDFlag, charts.ThreeDRotateFlag, charts.ThreeDVisualMapFlag,
-		charts.HorizontalFlag,
-		charts.BorderRadiusFlag,
-		charts.BgFlag,
-	))
 	cli.SetChartMeta(cli.ChartMeta{
 		Type:  "bar",
 		Use:   "bar [target]",
@@ -0,0 +1,15 @@
+package bump
+
+import (
+	"github.com/goptics/vizb/cmd/cli"
+	_ "github.com/goptics/vizb/internal/charts/all"
+)
+
+func init() {
+	cli.SetChartMeta(cli.ChartMeta{
+		Type:  "bump",
+		Use:   "bump [target]",
+		Short: "Generate a bump chart",
+		Long:  "Generate an interactive bump chart (HTML or JSON) from merged vizb JSON (vizb merge). Each benchmark is one line tracing its rank by the stat at every tag of the history; the tag dimension is detected, or named with --tag-axis.",
+	})
+}
@@ -0,0 +1,15 @@
+package calendar
+
+import (
+	"github.com/goptics/vizb/cmd/cli"
+	_ "github.com/goptics/vizb/internal/charts/all"
+)
+
+func init() {
+	cli.SetChartMeta(cli.ChartMeta{
+		Type:  "calendar",
+		Use:   "calendar [target]",
+		Short: "Generate a calendar heatmap chart",
+		Long:  "Generate an interactive calendar heatmap (HTML or JSON) from CSV, JSON, or benchmark output. The x dimension holds dates (e.g. 2024-01-31; use --swap to move a date column onto x); each day is one cell in a year/month grid, colored by the stat summed over the other dimensions.",
+	})
+}
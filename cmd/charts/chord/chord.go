@@ -1,16 +1,12 @@
 package chord
 
 import (
-	"slices"
-
 	"github.com/goptics/vizb/cmd/cli"
-	"github.com/goptics/vizb/internal/charts"
+	_ "github.com/goptics/vizb/internal/charts/all"
 	chordchart "github.com/goptics/vizb/internal/charts/chord"
 )
 
 func init() {
-	charts.Register(charts.Spec{Type: chordchart.Type, Factory: chordchart.New})
-	charts.SetFlags(chordchart.Type, slices.Clone(charts.BaseChartFlags))
 	cli.SetChartMeta(cli.ChartMeta{
 		Type:  chordchart.Type,
 		Use:   "chord [target]",
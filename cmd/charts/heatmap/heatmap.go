@@ -1,16 +1,11 @@
 package heatmap
 
 import (
-	"slices"
-
 	"github.com/goptics/vizb/cmd/cli"
-	"github.com/goptics/vizb/internal/charts"
-	heatmapchart "github.com/goptics/vizb/internal/charts/heatmap"
+	_ "github.com/goptics/vizb/internal/charts/all"
 )
 
 func init() {
-	charts.Register(charts.Spec{Type: "heatmap", Factory: heatmapchart.New})
-	charts.SetFlags("heatmap", slices.Clone(charts.BaseChartFlags))
 	cli.SetChartMeta(cli.ChartMeta{
 		Type:  "heatmap",
 		Use:   "heatmap [target]",
@@ -0,0 +1,15 @@
+package histogram
+
+import (
+	"github.com/goptics/vizb/cmd/cli"
+	_ "github.com/goptics/vizb/internal/charts/all"
+)
+
+func init() {
+	cli.SetChartMeta(cli.ChartMeta{
+		Type:  "histogram",
+		Use:   "histogram [target]",
+		Short: "Generate a histogram chart",
+		Long:  "Generate an interactive histogram chart (HTML or JSON) from CSV, JSON, or benchmark output. Raw values are binned before output (count, width, Freedman–Diaconis, or log bins); each value of the outermost group dimension is an overlaid series.",
+	})
+}
@@ -0,0 +1,15 @@
+package parallel
+
+import (
+	"github.com/goptics/vizb/cmd/cli"
+	_ "github.com/goptics/vizb/internal/charts/all"
+)
+
+func init() {
+	cli.SetChartMeta(cli.ChartMeta{
+		Type:  "parallel",
+		Use:   "parallel [target]",
+		Short: "Generate a parallel-coordinates chart",
+		Long:  "Generate an interactive parallel-coordinates chart (HTML or JSON) from CSV, JSON, or benchmark output. One axis per stat type (e.g. sec/op, B/op, allocs/op) and one line per row, colored by the outermost dimension; --axis-scale sets log or linear per axis, and dragging along an axis brushes lines.",
+	})
+}
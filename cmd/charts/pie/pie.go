@@ -1,16 +1,11 @@
 package pie
 
 import (
-	"slices"
-
 	"github.com/goptics/vizb/cmd/cli"
-	"github.com/goptics/vizb/internal/charts"
-	piechart "github.com/goptics/vizb/internal/charts/pie"
+	_ "github.com/goptics/vizb/internal/charts/all"
 )
 
 func init() {
-	charts.Register(charts.Spec{Type: "pie", Factory: piechart.New})
-	charts.SetFlags("pie", slices.Clone(charts.BaseChartFlags))
 	cli.SetChartMeta(cli.ChartMeta{
 		Type:  "pie",
 		Use:   "pie [target]",
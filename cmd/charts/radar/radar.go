@@ -1,16 +1,11 @@
 package radar
 
 import (
-	"slices"
-
 	"github.com/goptics/vizb/cmd/cli"
-	"github.com/goptics/vizb/internal/charts"
-	radarchart "github.com/goptics/vizb/internal/charts/radar"
+	_ "github.com/goptics/vizb/internal/charts/all"
 )
 
 func init() {
-	charts.Register(charts.Spec{Type: "radar", Factory: radarchart.New})
-	charts.SetFlags("radar", slices.Clone(charts.BaseChartFlags))
 	cli.SetChartMeta(cli.ChartMeta{
 		Type:  "radar",
 		Use:   "radar [target]",
@@ -1,16 +1,11 @@
 package sankey
 
 import (
-	"slices"
-
 	"github.com/goptics/vizb/cmd/cli"
-	"github.com/goptics/vizb/internal/charts"
-	sankeychart "github.com/goptics/vizb/internal/charts/sankey"
+	_ "github.com/goptics/vizb/internal/charts/all"
 )
 
 func init() {
-	charts.Register(charts.Spec{Type: "sankey", Factory: sankeychart.New})
-	charts.SetFlags("sankey", slices.Clone(charts.BaseChartFlags))
 	cli.SetChartMeta(cli.ChartMeta{
 		Type:  "sankey",
 		Use:   "sankey [target]",
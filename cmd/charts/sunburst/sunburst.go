@@ -0,0 +1,15 @@
+package sunburst
+
+import (
+	"github.com/goptics/vizb/cmd/cli"
+	_ "github.com/goptics/vizb/internal/charts/all"
+)
+
+func init() {
+	cli.SetChartMeta(cli.ChartMeta{
+		Type:  "sunburst",
+		Use:   "sunburst [target]",
+		Short: "Generate a sunburst chart",
+		Long:  "Generate an interactive sunburst chart (HTML or JSON) from CSV, JSON, or benchmark output. Hierarchy: name → x → y → z (after swap), one ring per level; leaves sized by the active stat or --value-stat.",
+	})
+}
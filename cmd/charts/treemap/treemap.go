@@ -0,0 +1,15 @@
+package treemap
+
+import (
+	"github.com/goptics/vizb/cmd/cli"
+	_ "github.com/goptics/vizb/internal/charts/all"
+)
+
+func init() {
+	cli.SetChartMeta(cli.ChartMeta{
+		Type:  "treemap",
+		Use:   "treemap [target]",
+		Short: "Generate a treemap chart",
+		Long:  "Generate an interactive treemap chart (HTML or JSON) from CSV, JSON, or benchmark output. Hierarchy: name → x → y → z (after swap); leaves sized by the active stat or --value-stat.",
+	})
+}
@@ -0,0 +1,15 @@
+package waterfall
+
+import (
+	"github.com/goptics/vizb/cmd/cli"
+	_ "github.com/goptics/vizb/internal/charts/all"
+)
+
+func init() {
+	cli.SetChartMeta(cli.ChartMeta{
+		Type:  "waterfall",
+		Use:   "waterfall [target]",
+		Short: "Generate a waterfall chart",
+		Long:  "Generate an interactive waterfall chart (HTML or JSON) from merged vizb JSON (vizb merge). The first bar is the stat total at --from (default: oldest tag), each benchmark adds a floating step for its change up to --to (default: latest tag), and the last bar is the new total.",
+	})
+}
@@ -5,13 +5,20 @@ import (
 
 	// Chart configs self-register so ChartCommands has specs to build from.
 	_ "github.com/goptics/vizb/cmd/charts/bar"
+	_ "github.com/goptics/vizb/cmd/charts/bump"
+	_ "github.com/goptics/vizb/cmd/charts/calendar"
 	_ "github.com/goptics/vizb/cmd/charts/chord"
 	_ "github.com/goptics/vizb/cmd/charts/heatmap"
+	_ "github.com/goptics/vizb/cmd/charts/histogram"
 	_ "github.com/goptics/vizb/cmd/charts/line"
+	_ "github.com/goptics/vizb/cmd/charts/parallel"
 	_ "github.com/goptics/vizb/cmd/charts/pie"
 	_ "github.com/goptics/vizb/cmd/charts/radar"
 	_ "github.com/goptics/vizb/cmd/charts/sankey"
 	_ "github.com/goptics/vizb/cmd/charts/scatter"
+	_ "github.com/goptics/vizb/cmd/charts/sunburst"
+	_ "github.com/goptics/vizb/cmd/charts/treemap"
+	_ "github.com/goptics/vizb/cmd/charts/waterfall"
 	"github.com/goptics/vizb/cmd/cli"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/suite"
@@ -31,7 +38,7 @@ func (s *CommandSuite) SetupTest() {
 }
 
 func (s *CommandSuite) TestBuildsOneCommandPerChart() {
-	for _, name := range []string{"bar", "line", "scatter", "pie", "heatmap", "radar", "sankey", "chord"} {
+	for _, name := range []string{"bar", "line", "scatter", "pie", "heatmap", "radar", "sankey", "chord", "treemap", "sunburst", "histogram", "parallel", "calendar", "bump", "waterfall"} {
 		s.Contains(s.byUse, name, "missing %s subcommand", name)
 	}
 }
@@ -64,7 +71,54 @@ func (s *CommandSuite) TestVariableFlagsBoundPerChart() {
 	s.Nil(chord.Flags().Lookup("visualmap"))
 	s.NotNil(chord.Flags().Lookup("swap"))
 
-	// scatter is the only chart with the 2D --visualmap flag.
+	// treemap and sunburst add the hierarchy flags on top of BaseChartFlags.
+	for _, name := range []string{"treemap", "sunburst"} {
+		c := s.byUse[name]
+		s.NotNil(c.Flags().Lookup("leaf-depth"))
+		s.NotNil(c.Flags().Lookup("label-levels"))
+		s.NotNil(c.Flags().Lookup("value-stat"))
+		s.Nil(c.Flags().Lookup("scale"))
+		s.Nil(c.Flags().Lookup("3d"))
+	}
+	s.Nil(s.byUse["pie"].Flags().Lookup("leaf-depth"))
+
+	// histogram adds the bin flags and the cumulative toggle.
+	histogram := s.byUse["histogram"]
+	for _, name := range []string{"bins", "bin-width", "bin-method", "cumulative"} {
+		s.NotNil(histogram.Flags().Lookup(name), "histogram missing --%s", name)
+	}
+	s.Nil(histogram.Flags().Lookup("scale"))
+	s.Nil(s.byUse["bar"].Flags().Lookup("bins"))
+
+	// parallel keeps --scale as the default and adds per-axis scale and brushing,
+	// but has no data labels.
+	parallel := s.byUse["parallel"]
+	for _, name := range []string{"scale", "axis-scale", "brush"} {
+		s.NotNil(parallel.Flags().Lookup(name), "parallel missing --%s", name)
+	}
+	s.Nil(parallel.Flags().Lookup("show-labels"))
+	s.Nil(s.byUse["radar"].Flags().Lookup("axis-scale"))
+
+	// calendar adds the grid options and its own --visualmap, but no --sort.
+	calendar := s.byUse["calendar"]
+	for _, name := range []string{"week-start", "years", "cell-size", "visualmap"} {
+		s.NotNil(calendar.Flags().Lookup(name), "calendar missing --%s", name)
+	}
+	s.Nil(calendar.Flags().Lookup("sort"))
+
+	// bump and waterfall read the merged history and drop --swap.
+	bump := s.byUse["bump"]
+	for _, name := range []string{"tag-axis", "rank"} {
+		s.NotNil(bump.Flags().Lookup(name), "bump missing --%s", name)
+	}
+	waterfall := s.byUse["waterfall"]
+	for _, name := range []string{"tag-axis", "from", "to", "sort"} {
+		s.NotNil(waterfall.Flags().Lookup(name), "waterfall missing --%s", name)
+	}
+	s.Nil(bump.Flags().Lookup("swap"))
+	s.Nil(waterfall.Flags().Lookup("swap"))
+
+	// scatter is the only other chart with --visualmap.
 	s.NotNil(s.byUse["scatter"].Flags().Lookup("visualmap"))
 }
 
@@ -2,6 +2,7 @@ package cli
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/goptics/vizb/internal/flags"
@@ -27,9 +28,17 @@ var DataFlags = []flags.Flag{
 		SoftValidate: style.ValidateTheme,
 	},
 	{Name: "description", Shorthand: "d", Usage: "Dataset description", Kind: flags.KindString},
-	{Name: "output", Shorthand: "o", Usage: "Output path (.html or .json)", Kind: flags.KindString},
-	{Name: "tag", Shorthand: "t", Usage: "Tag for merge/compare", Kind: flags.KindString},
+	{Name: "output", Shorthand: "o", Usage: "Output path (.html, .json, .svg, .vl.json, or .echarts.json)", Kind: flags.KindString},
+	{
+		Name: "svg-layout", Default: SVGLayoutGrid, Kind: flags.KindString,
+		Usage:      "SVG output with several charts: one grid image, or one file per chart (grid, files)",
+		Label:      "svg layout",
+		ValidSet:   []string{SVGLayoutGrid, SVGLayoutFiles},
+		Normalizer: strings.ToLower,
+	},
+	{Name: "tag", Shorthand: "t", Usage: "Tag for merge/compare; auto or git derives it from the git checkout", Kind: flags.KindString},
 	{Name: "id", Usage: "Dataset id for ?id= deep links", Kind: flags.KindString},
+	{Name: "capture-env", Usage: "Record host, CPU, memory, toolchain and git state in meta.env", Kind: flags.KindBool},
 	{
 		Name: "parser", Shorthand: "P", Default: "auto", Kind: flags.KindString,
 		Usage:        "Input parser (auto, " + strings.Join(parser.AvailableParsers(), ", ") + ")",
@@ -70,13 +79,61 @@ var DataFlags = []flags.Flag{
 		Usage: "Round values to 2 decimals in written output (irreversible)",
 	},
 	{Name: "select", Usage: "CSV/JSON: pick metrics or x,y[,z] coordinates (repeatable)", Kind: flags.KindStringArray},
+	{Name: "better", Usage: "Which way a stat improves: name=lower|higher|neutral (repeatable)", Kind: flags.KindStringArray},
 	{
 		Name: "col-axis", Shorthand: "A", Kind: flags.KindString,
 		Usage:    "Put numeric column names on this axis (n, x, y, z)",
 		Label:    "col-axis",
 		ValidSet: []string{"n", "x", "y", "z"},
 	},
-	{Name: "json-path", Usage: "JSON: jq-like path to the array to chart", Kind: flags.KindString},
+	{Name: "json-path", Usage: "JSON/YAML/TOML: jq-style path to the rows to chart", Kind: flags.KindString},
+	{Name: "json-path-keys", Usage: "JSON/YAML/TOML: add keys/indices walked by --json-path wildcards as columns", Kind: flags.KindBool},
+	{
+		Name: "delimiter", Kind: flags.KindString,
+		Usage:        "CSV: field separator (',', ';', tab, '|', whitespace); omit to sniff",
+		Label:        "delimiter",
+		Normalizer:   parser.NormalizeDelimiter,
+		SoftValidate: parser.ValidateDelimiter,
+	},
+	{
+		Name: "quote", Kind: flags.KindString,
+		Usage:        "CSV: quote character (default '\"')",
+		Label:        "quote",
+		SoftValidate: parser.ValidateDialectChar,
+	},
+	{
+		Name: "escape", Kind: flags.KindString,
+		Usage:        "CSV: escape character inside quotes (default doubled quote)",
+		Label:        "escape",
+		SoftValidate: parser.ValidateDialectChar,
+	},
+	{
+		Name: "comment", Kind: flags.KindString,
+		Usage:        "CSV: skip lines starting with this character; omit to sniff '#'",
+		Label:        "comment",
+		SoftValidate: parser.ValidateDialectChar,
+	},
+	{
+		Name: "skip-lines", Kind: flags.KindInt,
+		Usage:    "CSV: drop this many preamble lines before the header",
+		Validate: validateSkipLines,
+	},
+	{Name: "no-header", Usage: "CSV: first row is data; columns become col1..colN", Kind: flags.KindBool},
+	{
+		Name: "encoding", Kind: flags.KindString,
+		Usage:        "CSV: text encoding (" + strings.Join(parser.TextEncodings, ", ") + "); omit to sniff",
+		Label:        "encoding",
+		Normalizer:   parser.NormalizeEncoding,
+		SoftValidate: parser.ValidateEncoding,
+	},
+}
+
+// validateSkipLines rejects a negative --skip-lines count.
+func validateSkipLines(s string) error {
+	if n, err := strconv.Atoi(s); err != nil || n < 0 {
+		return fmt.Errorf("--skip-lines must be a non-negative integer, got %s", s)
+	}
+	return nil
 }
 
 // normalizeMemUnit canonicalises lowercase memory units (kb/mb/gb) to their
@@ -0,0 +1,39 @@
+package cli
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/goptics/vizb/pkg/cliout"
+	"github.com/goptics/vizb/pkg/export"
+	"github.com/goptics/vizb/shared"
+)
+
+// WriteExport writes datasets to w as a Vega-Lite spec or ECharts options
+// (format is export.FormatVegaLite or export.FormatECharts). Chart types
+// without an exporter are skipped with a warning; having none to export at
+// all is an error.
+func WriteExport(w io.Writer, datasets []*shared.Dataset, format string) {
+	doc, skipped, err := export.Document(format, datasets)
+	if len(skipped) > 0 {
+		cliout.Warnf("no %s exporter for %s charts; skipped", format, strings.Join(skipped, ", "))
+	}
+	if errors.Is(err, export.ErrNoCharts) {
+		shared.ExitWithError("No chart to export (supported: bar, line, scatter, pie)", nil)
+	}
+	if err != nil {
+		shared.ExitWithError("Failed to export charts", err)
+	}
+	if _, err := w.Write(doc); err != nil {
+		shared.ExitWithError("Failed to write output file", err)
+	}
+	cliout.Info("Generated " + exportLabel(format) + " successfully")
+}
+
+func exportLabel(format string) string {
+	if format == export.FormatECharts {
+		return "ECharts options"
+	}
+	return "Vega-Lite spec"
+}
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	barchart "github.com/goptics/vizb/internal/charts/bar"
+	heatmapchart "github.com/goptics/vizb/internal/charts/heatmap"
+	"github.com/goptics/vizb/pkg/export"
+	"github.com/goptics/vizb/shared"
+	"github.com/goptics/vizb/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+// ExportOutputSuite covers WriteExport's output and warnings.
+type ExportOutputSuite struct {
+	suite.Suite
+	restoreOsExit func()
+	exitCalled    *bool
+}
+
+func (s *ExportOutputSuite) SetupTest() {
+	s.restoreOsExit, s.exitCalled = testutil.TrapOsExitPanic(s.T())
+}
+
+func (s *ExportOutputSuite) TearDownTest() {
+	s.restoreOsExit()
+}
+
+func (s *ExportOutputSuite) TestWritesVegaLiteSpec() {
+	var out bytes.Buffer
+	WriteExport(&out, []*shared.Dataset{svgDataset(&barchart.Config{Type: "bar"})}, export.FormatVegaLite)
+
+	var spec map[string]any
+	s.Require().NoError(json.Unmarshal(out.Bytes(), &spec))
+	s.Equal("https://vega.github.io/schema/vega-lite/v5.json", spec["$schema"])
+	s.Equal(map[string]any{"type": "bar", "tooltip": true}, spec["mark"])
+}
+
+func (s *ExportOutputSuite) TestSkipsUnsupportedChartsWithWarning() {
+	var out bytes.Buffer
+	stderr := testutil.CaptureStderr(func() {
+		WriteExport(&out, []*shared.Dataset{svgDataset(&barchart.Config{Type: "bar"}, &heatmapchart.Config{Type: "heatmap"})}, export.FormatECharts)
+	})
+	s.Contains(stderr, "no echarts exporter for heatmap charts; skipped")
+	s.True(json.Valid(out.Bytes()))
+}
+
+func (s *ExportOutputSuite) TestNoExportableChartExits() {
+	var out bytes.Buffer
+	s.Panics(func() {
+		WriteExport(&out, []*shared.Dataset{svgDataset(&heatmapchart.Config{Type: "heatmap"})}, export.FormatECharts)
+	})
+	s.True(*s.exitCalled)
+	s.Zero(out.Len())
+}
+
+func TestExportOutputSuite(t *testing.T) {
+	suite.Run(t, new(ExportOutputSuite))
+}
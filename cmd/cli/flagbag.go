@@ -26,7 +26,7 @@ type FlagBag struct {
 	bools        map[string]*bool
 	floats       map[string]*float64
 	ints         map[string]*int
-	stringSlices map[string]*[]string // backs KindStringSlice, KindStat, and KindStringArray
+	stringSlices map[string]*[]string // backs KindStringSlice, KindStat, KindStringArray, and repeatable KindObject
 }
 
 // NewFlagBag allocates a bag and one typed pointer per flag.
@@ -41,7 +41,13 @@ func NewFlagBag(fl []flags.Flag) *FlagBag {
 	}
 	for _, f := range fl {
 		switch f.Kind {
-		case flags.KindString, flags.KindObject:
+		case flags.KindObject:
+			if f.Repeatable {
+				b.stringSlices[f.Name] = new([]string)
+			} else {
+				b.strs[f.Name] = new(string)
+			}
+		case flags.KindString:
 			b.strs[f.Name] = new(string)
 		case flags.KindBool:
 			b.bools[f.Name] = new(bool)
@@ -104,7 +110,10 @@ func (b *FlagBag) Bind(fs *pflag.FlagSet) {
 			}
 			fs.Lookup(f.Name).NoOptDefVal = statFlagAll
 		case flags.KindObject:
-			ov := &objectValue{value: b.strs[f.Name]}
+			var ov pflag.Value = &objectValue{value: b.strs[f.Name]}
+			if f.Repeatable {
+				ov = &objectArrayValue{values: b.stringSlices[f.Name]}
+			}
 			if f.Shorthand != "" {
 				fs.VarP(ov, f.Name, f.Shorthand, f.Usage)
 			} else {
@@ -154,12 +163,13 @@ func (b *FlagBag) validateObjectFlag(cmd *cobra.Command, f flags.Flag) {
 	if !cmd.Flags().Changed(f.Name) {
 		return
 	}
-	raw := *b.strs[f.Name]
-	if raw == objectFlagOn {
-		return
-	}
-	if _, err := shared.ParseObjectBagString(raw, f.ObjectFields); err != nil {
-		shared.ExitWithError(fmt.Sprintf("--%s: %v", f.Name, err), nil)
+	for _, raw := range b.objectRaws(f) {
+		if raw == objectFlagOn {
+			continue
+		}
+		if _, err := shared.ParseObjectBagString(raw, f.ObjectFields); err != nil {
+			shared.ExitWithError(fmt.Sprintf("--%s: %v", f.Name, err), nil)
+		}
 	}
 }
 
@@ -305,7 +315,15 @@ func (b *FlagBag) ChartSeed(cmd *cobra.Command) map[string]any {
 			if !changed {
 				continue
 			}
-			seed[f.JSONKey] = encodeFlag(f, b.objectBagValue(f))
+			if f.Repeatable {
+				bags := make([]any, 0, len(*b.stringSlices[f.Name]))
+				for _, raw := range *b.stringSlices[f.Name] {
+					bags = append(bags, encodeFlag(f, b.objectBag(f, raw)))
+				}
+				seed[f.JSONKey] = bags
+				continue
+			}
+			seed[f.JSONKey] = encodeFlag(f, b.objectBag(f, *b.strs[f.Name]))
 		case flags.KindBool:
 			if changed {
 				seed[f.JSONKey] = encodeFlag(f, *b.bools[f.Name])
@@ -332,7 +350,13 @@ func (b *FlagBag) ChartSeed(cmd *cobra.Command) map[string]any {
 func (b *FlagBag) Reset() {
 	for _, f := range b.flags {
 		switch f.Kind {
-		case flags.KindString, flags.KindObject:
+		case flags.KindObject:
+			if f.Repeatable {
+				*b.stringSlices[f.Name] = nil
+			} else {
+				*b.strs[f.Name], _ = f.Default.(string)
+			}
+		case flags.KindString:
 			*b.strs[f.Name], _ = f.Default.(string)
 		case flags.KindBool:
 			*b.bools[f.Name], _ = f.Default.(bool)
@@ -364,7 +388,27 @@ func (b *FlagBag) ParseConfig() parser.Config {
 		shared.ExitWithError(err.Error(), nil)
 	}
 	cfg.JSONPath = b.String("json-path")
+	cfg.JSONPathKeys = b.Bool("json-path-keys")
+	cfg.CSV = parser.CSVDialect{
+		Delimiter: b.String("delimiter"),
+		Quote:     b.String("quote"),
+		Escape:    b.String("escape"),
+		Comment:   b.String("comment"),
+		SkipLines: b.Int("skip-lines"),
+		NoHeader:  b.Bool("no-header"),
+		Encoding:  b.String("encoding"),
+	}
 	cfg.ColAxis = b.String("col-axis")
+	for _, raw := range b.StringArray("better") {
+		name, d, err := shared.ParseDirectionRule(raw)
+		if err != nil {
+			shared.ExitWithError(err.Error(), nil)
+		}
+		if cfg.Better == nil {
+			cfg.Better = map[string]shared.Direction{}
+		}
+		cfg.Better[name] = d
+	}
 	selectRaws := b.StringArray("select")
 	if len(selectRaws) > 0 {
 		if parser.IsExplicitGrouping(cfg) {
@@ -423,15 +467,25 @@ func (b *FlagBag) Meta() RunMeta {
 		Description: b.String("description"),
 		Tag:         b.String("tag"),
 		OutputFile:  b.String("output"),
+		SVGLayout:   b.String("svg-layout"),
 		Parser:      b.String("parser"),
+		CaptureEnv:  b.Bool("capture-env"),
+	}
+}
+
+// objectRaws returns a changed object flag's raw values: one per occurrence
+// for repeatable flags, else the single stored value.
+func (b *FlagBag) objectRaws(f flags.Flag) []string {
+	if f.Repeatable {
+		return *b.stringSlices[f.Name]
 	}
+	return []string{*b.strs[f.Name]}
 }
 
-// objectBagValue parses a changed object flag's raw value into its typed bag
-// payload: the bare sentinel maps to the empty bag. Validate already rejected
-// invalid bags, so a parse error here exits defensively.
-func (b *FlagBag) objectBagValue(f flags.Flag) map[string]any {
-	raw := *b.strs[f.Name]
+// objectBag parses one raw object-flag value into its typed bag payload: the
+// bare sentinel maps to the empty bag. Validate already rejected invalid bags,
+// so a parse error here exits defensively.
+func (b *FlagBag) objectBag(f flags.Flag, raw string) map[string]any {
 	if raw == objectFlagOn {
 		return map[string]any{}
 	}
@@ -6,7 +6,9 @@ import (
 
 	internal_charts "github.com/goptics/vizb/internal/charts"
 	"github.com/goptics/vizb/internal/flags"
+	"github.com/goptics/vizb/pkg/parser"
 	"github.com/goptics/vizb/pkg/style"
+	"github.com/goptics/vizb/shared"
 	"github.com/goptics/vizb/testutil"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/suite"
@@ -165,6 +167,24 @@ func (s *FlagBagSuite) TestParseConfigRejectsInvalidSelect() {
 	s.True(*exitCalled)
 }
 
+func (s *FlagBagSuite) TestParseConfigParsesBetter() {
+	cmd, bag := s.newCmdBag(slices.Clone(DataFlags))
+	s.Require().NoError(cmd.Flags().Set("better", "rps=higher"))
+	s.Require().NoError(cmd.Flags().Set("better", "samples=Neutral"))
+	cfg := bag.ParseConfig()
+	s.Equal(map[string]shared.Direction{"rps": shared.DirectionHigher, "samples": shared.DirectionNeutral}, cfg.Better)
+}
+
+func (s *FlagBagSuite) TestParseConfigRejectsInvalidBetter() {
+	restore, exitCalled := testutil.TrapOsExitPanic(s.T())
+	defer restore()
+
+	cmd, bag := s.newCmdBag(slices.Clone(DataFlags))
+	s.Require().NoError(cmd.Flags().Set("better", "rps=up"))
+	s.Panics(func() { bag.ParseConfig() })
+	s.True(*exitCalled)
+}
+
 func (s *FlagBagSuite) TestParseConfigMapsFields() {
 	cmd, bag := s.newCmdBag(slices.Clone(DataFlags))
 	s.Require().NoError(cmd.Flags().Set("group-pattern", "n/x"))
@@ -338,6 +358,43 @@ func (s *FlagBagSuite) TestValidateObjectFlagSkippedWhenUnset() {
 	s.Equal("", bag.String("bg"))
 }
 
+func (s *FlagBagSuite) TestChartSeedRepeatableObjectFlag() {
+	fl := append(slices.Clone(DataFlags), internal_charts.MarkFlag)
+
+	s.Run("unset: mark omitted", func() {
+		cmd, bag := s.newCmdBag(fl)
+		s.NotContains(bag.ChartSeed(cmd), "mark")
+	})
+	s.Run("each --mark appends one bag", func() {
+		cmd, bag := s.newCmdBag(fl)
+		s.Require().NoError(cmd.Flags().Set("mark", "type=HLine;value=50;stat=ns/op"))
+		s.Require().NoError(cmd.Flags().Set("mark", "type=band;from=0;to=10"))
+		bag.Validate(cmd) // must not exit
+		s.Equal([]any{
+			map[string]any{"type": "hline", "value": float64(50), "stat": "ns/op"},
+			map[string]any{"type": "band", "from": float64(0), "to": float64(10)},
+		}, bag.ChartSeed(cmd)["mark"])
+	})
+	s.Run("reset clears the marks", func() {
+		cmd, bag := s.newCmdBag(fl)
+		s.Require().NoError(cmd.Flags().Set("mark", "type=vline;at=1024"))
+		bag.Reset()
+		s.Empty(bag.StringSlice("mark"))
+	})
+}
+
+func (s *FlagBagSuite) TestValidateRepeatableObjectFlagRejectsInvalidBag() {
+	fl := append(slices.Clone(DataFlags), internal_charts.MarkFlag)
+	cmd, bag := s.newCmdBag(fl)
+	s.Require().NoError(cmd.Flags().Set("mark", "type=hline;value=1"))
+	s.Require().NoError(cmd.Flags().Set("mark", "type=arrow"))
+
+	restore, exitCalled := testutil.TrapOsExitPanic(s.T())
+	defer restore()
+	s.Panics(func() { bag.Validate(cmd) })
+	s.True(*exitCalled)
+}
+
 func (s *FlagBagSuite) TestChartSeedTriStateStatAndScale() {
 	fl := append(slices.Clone(DataFlags), internal_charts.BaseChartFlags...)
 	fl = append(fl, internal_charts.ScaleFlag)
@@ -411,6 +468,37 @@ func (s *FlagBagSuite) TestParseConfigSetsJSONPath() {
 	s.Equal(".data.items", cfg.JSONPath)
 }
 
+func (s *FlagBagSuite) TestParseConfigSetsCSVDialect() {
+	cmd, bag := s.newCmdBag(slices.Clone(DataFlags))
+	s.Require().NoError(cmd.Flags().Set("delimiter", "tab"))
+	s.Require().NoError(cmd.Flags().Set("quote", "'"))
+	s.Require().NoError(cmd.Flags().Set("escape", "\\"))
+	s.Require().NoError(cmd.Flags().Set("comment", "#"))
+	s.Require().NoError(cmd.Flags().Set("skip-lines", "2"))
+	s.Require().NoError(cmd.Flags().Set("no-header", "true"))
+	s.Require().NoError(cmd.Flags().Set("encoding", "Latin1"))
+	bag.Validate(cmd)
+
+	cfg := bag.ParseConfig()
+	s.Equal(parser.CSVDialect{
+		Delimiter: "\t",
+		Quote:     "'",
+		Escape:    "\\",
+		Comment:   "#",
+		SkipLines: 2,
+		NoHeader:  true,
+		Encoding:  "latin-1",
+	}, cfg.CSV)
+}
+
+func (s *FlagBagSuite) TestValidateResetsInvalidDelimiter() {
+	cmd, bag := s.newCmdBag(slices.Clone(DataFlags))
+	s.Require().NoError(cmd.Flags().Set("delimiter", ";;"))
+	out := testutil.CaptureStderr(func() { bag.Validate(cmd) })
+	s.Contains(out, "delimiter")
+	s.Empty(bag.String("delimiter"))
+}
+
 func (s *FlagBagSuite) TestResetRestoresDefaults() {
 	fl := append(slices.Clone(DataFlags),
 		internal_charts.SymbolSizeFlag,
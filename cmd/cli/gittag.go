@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"slices"
+	"time"
+
+	"github.com/goptics/vizb/internal/gitrepo"
+	"github.com/goptics/vizb/pkg/cliout"
+	"github.com/goptics/vizb/shared"
+)
+
+// gitTagValues are the --tag values that derive the tag from git.
+var gitTagValues = []string{"auto", "git"}
+
+// resolveGitTag turns --tag auto (or git) into a tag read from the repository
+// around dir, without running git: `git describe --tags` output when a tag is
+// reachable, else the short commit hash, else — on a branch with no commits —
+// the branch name. It also returns the commit, which the dataset records. Any
+// other tag is returned unchanged with no commit.
+func resolveGitTag(tag, dir string) (string, *shared.Commit) {
+	if !slices.Contains(gitTagValues, tag) {
+		return tag, nil
+	}
+	repo, err := gitrepo.Open(dir)
+	if err != nil {
+		shared.ExitWithError("--tag "+tag+": cannot read the git repository", err)
+	}
+	defer repo.Close()
+
+	branch, sha, err := repo.Head()
+	if err != nil {
+		shared.ExitWithError("--tag "+tag+": cannot read HEAD", err)
+	}
+	if sha == "" {
+		if branch == "" {
+			shared.ExitWithError("--tag "+tag+": HEAD points at no commit", nil)
+		}
+		cliout.InfoPair("Git tag", branch)
+		return branch, nil
+	}
+	c, err := repo.Commit(sha)
+	if err != nil {
+		shared.ExitWithError("--tag "+tag+": cannot read commit "+gitrepo.ShortSHA(sha), err)
+	}
+
+	name, ok, err := repo.Describe(sha)
+	if err != nil {
+		cliout.Warnf("--tag %s: describe failed, using the commit hash: %v", tag, err)
+	}
+	if !ok {
+		name = gitrepo.ShortSHA(sha)
+	}
+	cliout.InfoPair("Git tag", name)
+	return name, &shared.Commit{
+		SHA:     sha,
+		Time:    c.CommitTime.Format(time.RFC3339),
+		Author:  c.Author,
+		Subject: c.Subject,
+	}
+}
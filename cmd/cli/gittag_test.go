@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/goptics/vizb/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+// GitTagSuite covers --tag auto against throwaway repositories.
+type GitTagSuite struct {
+	suite.Suite
+	dir string
+}
+
+func (s *GitTagSuite) SetupTest() {
+	if _, err := exec.LookPath("git"); err != nil {
+		s.T().Skip("git not installed")
+	}
+	s.dir = s.T().TempDir()
+	s.git("init", "-q", "-b", "main")
+}
+
+func (s *GitTagSuite) git(args ...string) string {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = s.dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Ada Lovelace", "GIT_AUTHOR_EMAIL=ada@example.com",
+		"GIT_COMMITTER_NAME=Ada Lovelace", "GIT_COMMITTER_EMAIL=ada@example.com",
+		"GIT_AUTHOR_DATE=2026-05-01T10:00:00+02:00", "GIT_COMMITTER_DATE=2026-05-01T10:00:00+02:00",
+		"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_NOSYSTEM=1",
+	)
+	out, err := cmd.CombinedOutput()
+	s.Require().NoError(err, "git %v: %s", args, out)
+	return strings.TrimSpace(string(out))
+}
+
+func (s *GitTagSuite) commit(subject string) string {
+	s.Require().NoError(os.WriteFile(filepath.Join(s.dir, "f.txt"), []byte(subject), 0o644))
+	s.git("add", "f.txt")
+	s.git("commit", "-q", "-m", subject)
+	return s.git("rev-parse", "HEAD")
+}
+
+func (s *GitTagSuite) TestPassesThroughOtherTags() {
+	tag, commit := resolveGitTag("v1.2.0", s.dir)
+	s.Equal("v1.2.0", tag)
+	s.Nil(commit)
+}
+
+func (s *GitTagSuite) TestUsesShortHashWithoutTags() {
+	sha := s.commit("initial import")
+
+	tag, commit := resolveGitTag("auto", s.dir)
+	s.Equal(sha[:7], tag)
+	s.Require().NotNil(commit)
+	s.Equal(sha, commit.SHA)
+	s.Equal("2026-05-01T10:00:00+02:00", commit.Time)
+	s.Equal("Ada Lovelace", commit.Author)
+	s.Equal("initial import", commit.Subject)
+}
+
+func (s *GitTagSuite) TestDescribesFromNearestTag() {
+	s.commit("first")
+	s.git("tag", "-a", "v1.0.0", "-m", "release")
+	tag, _ := resolveGitTag("git", s.dir)
+	s.Equal("v1.0.0", tag)
+
+	sha := s.commit("second")
+	tag, commit := resolveGitTag("auto", s.dir)
+	s.Equal("v1.0.0-1-g"+sha[:7], tag)
+	s.Equal(s.git("describe", "--tags"), tag)
+	s.Equal("second", commit.Subject)
+}
+
+func (s *GitTagSuite) TestUnbornBranchUsesBranchName() {
+	tag, commit := resolveGitTag("auto", s.dir)
+	s.Equal("main", tag)
+	s.Nil(commit)
+}
+
+func (s *GitTagSuite) TestExitsOutsideRepository() {
+	restore, exitCalled := testutil.TrapOsExitPanic(s.T())
+	defer restore()
+
+	s.Require().NoError(os.RemoveAll(filepath.Join(s.dir, ".git")))
+	if _, err := exec.Command("git", "-C", s.dir, "rev-parse").CombinedOutput(); err == nil {
+		s.T().Skip("temp dir sits inside a git checkout")
+	}
+	s.Panics(func() { resolveGitTag("auto", s.dir) })
+	s.True(*exitCalled)
+}
+
+func TestGitTagSuite(t *testing.T) {
+	suite.Run(t, new(GitTagSuite))
+}
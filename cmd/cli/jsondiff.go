@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// DiffJSON compares two JSON documents structurally and returns one line per
+// change, keyed by JSON Pointer: "- /os: \"linux\"" for a removed member,
+// "+ /meta: {...}" for an added one and "~ /path: old → new" for a changed
+// scalar. Objects are compared member by member regardless of key order;
+// arrays index by index, with extra items added or removed at the tail.
+// Values are printed as compact JSON. No lines means the documents are equal.
+func DiffJSON(before, after []byte) ([]string, error) {
+	var a, b any
+	if err := json.Unmarshal(before, &a); err != nil {
+		return nil, fmt.Errorf("before: %w", err)
+	}
+	if err := json.Unmarshal(after, &b); err != nil {
+		return nil, fmt.Errorf("after: %w", err)
+	}
+	var lines []string
+	diffValue(&lines, "", a, b)
+	return lines, nil
+}
+
+func diffValue(lines *[]string, path string, a, b any) {
+	if reflect.DeepEqual(a, b) {
+		return
+	}
+	switch av := a.(type) {
+	case map[string]any:
+		if bv, ok := b.(map[string]any); ok {
+			keys := make([]string, 0, len(av)+len(bv))
+			for k := range av {
+				keys = append(keys, k)
+			}
+			for k := range bv {
+				if _, ok := av[k]; !ok {
+					keys = append(keys, k)
+				}
+			}
+			slices.Sort(keys)
+			for _, k := range keys {
+				child := path + "/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(k)
+				x, inA := av[k]
+				y, inB := bv[k]
+				switch {
+				case !inB:
+					*lines = append(*lines, "- "+pointer(child)+": "+compact(x))
+				case !inA:
+					*lines = append(*lines, "+ "+pointer(child)+": "+compact(y))
+				default:
+					diffValue(lines, child, x, y)
+				}
+			}
+			return
+		}
+	case []any:
+		if bv, ok := b.([]any); ok {
+			for i := range max(len(av), len(bv)) {
+				child := path + "/" + strconv.Itoa(i)
+				switch {
+				case i >= len(bv):
+					*lines = append(*lines, "- "+child+": "+compact(av[i]))
+				case i >= len(av):
+					*lines = append(*lines, "+ "+child+": "+compact(bv[i]))
+				default:
+					diffValue(lines, child, av[i], bv[i])
+				}
+			}
+			return
+		}
+	}
+	*lines = append(*lines, "~ "+pointer(path)+": "+compact(a)+" → "+compact(b))
+}
+
+// pointer renders the document root as "/".
+func pointer(path string) string {
+	if path == "" {
+		return "/"
+	}
+	return path
+}
+
+func compact(v any) string {
+	out, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(out)
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type JSONDiffSuite struct {
+	suite.Suite
+}
+
+func (s *JSONDiffSuite) TestEqualDocumentsIgnoreKeyOrder() {
+	lines, err := DiffJSON([]byte(`{"a":1,"b":[1,{"c":true}]}`), []byte(`{"b":[1,{"c":true}],"a":1}`))
+	s.Require().NoError(err)
+	s.Empty(lines)
+}
+
+func (s *JSONDiffSuite) TestMembersAndItems() {
+	lines, err := DiffJSON(
+		[]byte(`{"os":"linux","n":1,"list":[1,2,3],"obj":{"x":"a/b"},"kind":{"k":1}}`),
+		[]byte(`{"meta":{"os":"linux"},"n":2,"list":[1,5],"obj":{"x":"a/b","a/b":true},"kind":[1]}`),
+	)
+	s.Require().NoError(err)
+	s.Equal([]string{
+		`~ /kind: {"k":1} → [1]`,
+		`~ /list/1: 2 → 5`,
+		`- /list/2: 3`,
+		`+ /meta: {"os":"linux"}`,
+		`~ /n: 1 → 2`,
+		`+ /obj/a~1b: true`,
+		`- /os: "linux"`,
+	}, lines, "members in key order, items in index order")
+}
+
+func (s *JSONDiffSuite) TestRootAndInvalidInput() {
+	lines, err := DiffJSON([]byte(`1`), []byte(`"x"`))
+	s.Require().NoError(err)
+	s.Equal([]string{`~ /: 1 → "x"`}, lines)
+
+	_, err = DiffJSON([]byte(`{`), []byte(`{}`))
+	s.ErrorContains(err, "before")
+	_, err = DiffJSON([]byte(`{}`), []byte(`nope`))
+	s.ErrorContains(err, "after")
+}
+
+func TestJSONDiffSuite(t *testing.T) {
+	suite.Run(t, new(JSONDiffSuite))
+}
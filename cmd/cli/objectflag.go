@@ -36,6 +36,25 @@ func (o *objectValue) Set(val string) error {
 
 func (o *objectValue) Type() string { return "string" }
 
+// objectArrayValue is the repeatable form of objectValue (Flag.Repeatable):
+// every occurrence appends one raw bag, e.g. --mark type=hline;value=50
+// --mark type=band;from=10;to=20.
+type objectArrayValue struct{ values *[]string }
+
+func (o *objectArrayValue) String() string {
+	if o.values == nil {
+		return ""
+	}
+	return strings.Join(*o.values, " ")
+}
+
+func (o *objectArrayValue) Set(val string) error {
+	*o.values = append(*o.values, val)
+	return nil
+}
+
+func (o *objectArrayValue) Type() string { return "stringArray" }
+
 // RewriteObjectArg rewrites `--bg VALUE` (space-separated) to `--bg=VALUE` so
 // pflag can parse optional-value object flags despite the NoOptDefVal. Without
 // this rewrite, pflag consumes the sentinel "on" and treats VALUE as a
@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	"github.com/goptics/vizb/pkg/cliout"
+	"github.com/goptics/vizb/pkg/export"
 	"github.com/goptics/vizb/shared"
 )
 
@@ -30,11 +31,21 @@ func ResolveOutputFileName(outFile string) string {
 	return outFile
 }
 
-// InferFormatFromExtension returns "json" for .json output, otherwise "html".
+// InferFormatFromExtension returns "vega-lite" for .vl.json output, "echarts"
+// for .echarts.json, "json" for other .json, "svg" for .svg, otherwise "html".
 func InferFormatFromExtension(outFile string) string {
-	switch ext := strings.ToLower(filepath.Ext(outFile)); ext {
+	lower := strings.ToLower(outFile)
+	switch {
+	case strings.HasSuffix(lower, ".vl.json"):
+		return export.FormatVegaLite
+	case strings.HasSuffix(lower, ".echarts.json"):
+		return export.FormatECharts
+	}
+	switch ext := filepath.Ext(lower); ext {
 	case ".json":
 		return "json"
+	case ".svg":
+		return "svg"
 	default:
 		return "html"
 	}
@@ -57,9 +68,11 @@ func HandleOutputResult(f *os.File, userOutput string) {
 	fmt.Println(string(content))
 }
 
-// convertToDataset tries to read filePath as an existing vizb Dataset JSON
-// (single object). Returns nil when the content is not Dataset JSON.
-func convertToDataset(filePath string) (dataSet *shared.Dataset) {
+// convertToDatasets tries to read filePath as existing vizb Dataset JSON: a
+// single object, or an array (vizb merge output) whose every element carries
+// "settings" and "data" so raw record arrays still parse as data. Returns nil
+// when the content is not Dataset JSON.
+func convertToDatasets(filePath string) []*shared.Dataset {
 	f := shared.MustOpenFile(filePath)
 	defer f.Close()
 
@@ -68,11 +81,44 @@ func convertToDataset(filePath string) (dataSet *shared.Dataset) {
 		shared.ExitWithError("Failed to read file: %v", err)
 	}
 
-	if err := json.Unmarshal(content, &dataSet); err != nil {
+	if trimmed := bytes.TrimLeft(content, " \t\r\n"); len(trimmed) > 0 && trimmed[0] == '[' {
+		var elems []map[string]json.RawMessage
+		if err := json.Unmarshal(content, &elems); err != nil || len(elems) == 0 {
+			return nil
+		}
+		for _, elem := range elems {
+			_, hasSettings := elem["settings"]
+			_, hasData := elem["data"]
+			if !hasSettings || !hasData {
+				return nil
+			}
+		}
+		dataSets, err := ParseDatasetFile(filePath)
+		if err != nil {
+			return nil
+		}
+		out := make([]*shared.Dataset, len(dataSets))
+		for i := range dataSets {
+			out[i] = &dataSets[i]
+		}
+		return out
+	}
+
+	var dataSet *shared.Dataset
+	if err := json.Unmarshal(content, &dataSet); err != nil || dataSet == nil {
 		return nil
 	}
 	shared.MigrateDataset(dataSet, content)
-	return dataSet
+	return []*shared.Dataset{dataSet}
+}
+
+// warnNewerSchema flags a dataset written by a newer vizb: it still loads, but
+// fields this build does not know are dropped.
+func warnNewerSchema(file string, ds shared.Dataset) {
+	if ds.SchemaVersion > shared.CurrentSchemaVersion {
+		cliout.Warnf("%s: schemaVersion %d is newer than this vizb supports (%d); unknown fields are ignored",
+			file, ds.SchemaVersion, shared.CurrentSchemaVersion)
+	}
 }
 
 // ParseDatasetFile reads a vizb Dataset JSON file (single object or array) and
@@ -82,38 +128,12 @@ func ParseDatasetFile(file string) ([]shared.Dataset, error) {
 	if err != nil {
 		return nil, fmt.Errorf("cannot read file: %w", err)
 	}
-
-	trimmed := bytes.TrimLeft(content, " \t\r\n")
-	if len(trimmed) == 0 {
-		return nil, fmt.Errorf("file is empty")
+	dataSets, err := shared.DecodeDatasets(content)
+	if err != nil {
+		return nil, err
 	}
-
-	switch trimmed[0] {
-	case '[':
-		// Two-pass: decode each element from its own raw bytes so MigrateDataset
-		// can recover the legacy top-level axisLabels field (lost after Unmarshal).
-		var rawElems []json.RawMessage
-		if err := json.Unmarshal(content, &rawElems); err != nil {
-			return nil, fmt.Errorf("invalid data set array: %w", err)
-		}
-		dataSets := make([]shared.Dataset, 0, len(rawElems))
-		for _, rawElem := range rawElems {
-			var ds shared.Dataset
-			if err := json.Unmarshal(rawElem, &ds); err != nil {
-				return nil, fmt.Errorf("invalid data set array: %w", err)
-			}
-			shared.MigrateDataset(&ds, rawElem)
-			dataSets = append(dataSets, ds)
-		}
-		return dataSets, nil
-	case '{':
-		var ds shared.Dataset
-		if err := json.Unmarshal(content, &ds); err != nil {
-			return nil, fmt.Errorf("invalid data set object: %w", err)
-		}
-		shared.MigrateDataset(&ds, content)
-		return []shared.Dataset{ds}, nil
-	default:
-		return nil, fmt.Errorf("not valid JSON")
+	for _, ds := range dataSets {
+		warnNewerSchema(file, ds)
 	}
+	return dataSets, nil
 }
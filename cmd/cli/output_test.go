@@ -88,6 +88,10 @@ func (s *OutputSuite) TestInferFormatFromExtension() {
 		{"txt defaults to html", "test.txt", "html"},
 		{"no extension defaults to html", "test", "html"},
 		{"path with json", "/path/to/file.json", "json"},
+		{"svg extension", "report.SVG", "svg"},
+		{"vega-lite extension", "report.vl.json", "vega-lite"},
+		{"echarts extension", "/out/Report.ECharts.json", "echarts"},
+		{"json with other inner dot", "report.v1.json", "json"},
 	}
 
 	for _, tt := range tests {
@@ -145,23 +149,43 @@ func (s *OutputSuite) TestConvertToDataset() {
 		s.Require().NoError(err)
 		s.Require().NoError(os.WriteFile(valid, data, 0644))
 
-		result := convertToDataset(valid)
-		s.Require().NotNil(result)
-		s.Equal("bench-v1", result.ID)
-		s.Equal("Test", result.Name)
-		s.Len(result.Data, 1)
+		result := convertToDatasets(valid)
+		s.Require().Len(result, 1)
+		s.Equal("bench-v1", result[0].ID)
+		s.Equal("Test", result[0].Name)
+		s.Len(result[0].Data, 1)
+	})
+
+	s.Run("merged dataset array", func() {
+		merged := filepath.Join(dir, "merged.json")
+		testutil.WriteJSON(s.T(), merged, []shared.Dataset{
+			{Name: "A", Tag: "v2", Data: []shared.DataPoint{{Name: "v1"}, {Name: "v2"}}},
+			{Name: "B", Data: []shared.DataPoint{{Name: "B1"}}},
+		})
+
+		result := convertToDatasets(merged)
+		s.Require().Len(result, 2)
+		s.Equal("A", result[0].Name)
+		s.Equal("v2", result[0].Tag)
+		s.Equal("B", result[1].Name)
+	})
+
+	s.Run("raw record array returns nil", func() {
+		records := filepath.Join(dir, "records.json")
+		s.Require().NoError(os.WriteFile(records, []byte(`[{"name":"a","data":1},{"name":"b","data":2}]`), 0644))
+		s.Nil(convertToDatasets(records))
 	})
 
 	s.Run("invalid JSON returns nil", func() {
 		invalid := filepath.Join(dir, "invalid.json")
 		s.Require().NoError(os.WriteFile(invalid, []byte("not json"), 0644))
-		s.Nil(convertToDataset(invalid))
+		s.Nil(convertToDatasets(invalid))
 	})
 
 	s.Run("plain text returns nil", func() {
 		plain := filepath.Join(dir, "bench.txt")
 		s.Require().NoError(os.WriteFile(plain, []byte("BenchmarkFoo-8 1000 1234 ns/op"), 0644))
-		s.Nil(convertToDataset(plain))
+		s.Nil(convertToDatasets(plain))
 	})
 }
 
@@ -6,13 +6,16 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"slices"
 	"strings"
 
 	internal_charts "github.com/goptics/vizb/internal/charts"
+	"github.com/goptics/vizb/internal/envinfo"
 	"github.com/goptics/vizb/pkg/cliout"
 	"github.com/goptics/vizb/pkg/core"
+	"github.com/goptics/vizb/pkg/export"
 	"github.com/goptics/vizb/pkg/parser"
 	_ "github.com/goptics/vizb/pkg/parser/golang"
 	jsonparser "github.com/goptics/vizb/pkg/parser/json"
@@ -24,7 +27,7 @@ import (
 
 // RunLinear runs the full linear pipeline shared by the root command and every
 // linear chart subcommand: resolve input (file/stdin) → optional Dataset JSON
-// passthrough → parse → assemble Dataset → write HTML/JSON → handle output.
+// passthrough → parse → assemble Dataset → write HTML/JSON/SVG/export → handle output.
 //
 // applyOnPassthrough controls whether the provided configs override a
 // passed-through Dataset's baked chart selection. Chart subcommands pass true
@@ -44,9 +47,10 @@ func RunLinear(cmd *cobra.Command, args []string, meta RunMeta, cfg parser.Confi
 	// auto-selected parser so the choice is never silent.
 	if meta.Parser == "auto" {
 		detected := parser.DetectParser(target)
-		// --json-path only makes sense for JSON; an envelope file starts with '{'
-		// which auto-detect reads as the "go" fallback, so nudge it to json.
-		if cfg.JSONPath != "" && detected != "json" {
+		// --json-path only makes sense for JSON-shaped documents; an envelope
+		// file starts with '{' which auto-detect reads as the "go" fallback, so
+		// nudge it to json (yaml/toml navigate the path themselves).
+		if cfg.JSONPath != "" && !parser.SupportsJSONPath(detected) {
 			detected = "json"
 		}
 		meta.Parser = detected
@@ -56,7 +60,7 @@ func RunLinear(cmd *cobra.Command, args []string, meta RunMeta, cfg parser.Confi
 	// Enable auto-grouping for the csv/json parsers when the user supplied no
 	// explicit grouping. The csv/json parsers infer the category axis from the
 	// data so `vizb data.csv` produces a usable chart without -g/-p/-r.
-	if (meta.Parser == "csv" || meta.Parser == "json") && parser.NoExplicitGrouping(cfg) && !parser.HasSelect(cfg) {
+	if parser.IsTabular(meta.Parser) && parser.NoExplicitGrouping(cfg) && !parser.HasSelect(cfg) {
 		cfg.AutoGroup = true
 	}
 	for _, c := range configs {
@@ -76,17 +80,24 @@ func RunLinear(cmd *cobra.Command, args []string, meta RunMeta, cfg parser.Confi
 	// empty Dataset and silently produce no output).
 	var datasets []*shared.Dataset
 	if cfg.JSONPath == "" {
-		if ds := convertToDataset(target); ds != nil {
+		if ds := convertToDatasets(target); len(ds) > 0 {
 			warnTitleIgnored(meta.Title)
-			datasets = []*shared.Dataset{ds}
+			if meta.CaptureEnv {
+				cliout.Warn("--capture-env is ignored for vizb dataset input; the environment is recorded when raw results are converted")
+			}
+			datasets = ds
 		}
 	}
 	if len(datasets) == 0 {
 		// Not Dataset JSON: parse raw/bench input into data points.
 		if meta.Parser == "json" && cfg.JSONPath != "" {
-			target = applyJSONPath(target, cfg.JSONPath)
+			target = applyJSONPath(target, cfg.JSONPath, cfg.JSONPathKeys)
 		}
+		meta.Tag, meta.Commit = resolveGitTag(meta.Tag, ".")
 		results, effectiveCfg, system := prepareData(target, meta.Parser, cfg, meta.Title)
+		if meta.CaptureEnv {
+			system = captureEnv(target, system)
+		}
 		datasets = []*shared.Dataset{assembleDataset(results, meta, configs, effectiveCfg, system)}
 		// Validate swap only for chart subcommands (applyOnPassthrough true).
 		// The root command stores swap as-is, trusting the UI to handle it.
@@ -102,7 +113,9 @@ func RunLinear(cmd *cobra.Command, args []string, meta RunMeta, cfg parser.Confi
 			}
 		}
 	} else if applyOnPassthrough {
-		applySelections(datasets[0], configs)
+		for _, dataSet := range datasets {
+			applySelections(dataSet, configs)
+		}
 	}
 
 	// Phase B: evaluate applicability rules on materialised configs with
@@ -113,8 +126,8 @@ func RunLinear(cmd *cobra.Command, args []string, meta RunMeta, cfg parser.Confi
 		for _, a := range dataSet.Axes {
 			ruleAxes = append(ruleAxes, internal_charts.AxisInfo{Key: a.Key, Type: a.Type})
 		}
-		ruleCtx := internal_charts.RuleContext{Axes: ruleAxes}
-		warnings, fatal := internal_charts.ApplyRules(ruleCtx, configs)
+		ruleCtx := internal_charts.RuleContext{Axes: ruleAxes, StatTypes: dataSet.StatTypes(), DateAxes: dataSet.DateAxes(), Tags: dataSet.Tags(), TagAxes: dataSet.TagAxes()}
+		warnings, fatal := internal_charts.ApplyRules(ruleCtx, dataSet.Settings)
 		if fatal != nil {
 			shared.ExitWithError(fatal.Error(), nil)
 		}
@@ -123,6 +136,11 @@ func RunLinear(cmd *cobra.Command, args []string, meta RunMeta, cfg parser.Confi
 		}
 	}
 
+	if InferFormatFromExtension(outFile) == "svg" {
+		writeSVG(outFile, datasets, meta.SVGLayout)
+		return
+	}
+
 	f := shared.MustCreateFile(outFile)
 	defer f.Close()
 
@@ -157,7 +175,11 @@ type RunMeta struct {
 	Description string
 	Tag         string
 	OutputFile  string
+	SVGLayout   string
 	Parser      string
+	CaptureEnv  bool
+	// Commit is the revision --tag auto resolved the tag from.
+	Commit *shared.Commit
 }
 
 // resolveInput returns the input file path. It accepts a file arg, else reads
@@ -235,10 +257,11 @@ func writeStdinPipedInputs(tempfilePath string) {
 	_ = inputTempFile.Sync()
 }
 
-// applyJSONPath extracts the array named by cfg.JSONPath from the input file and
-// writes it to a temp file, which is returned for the JSON parser to consume.
-func applyJSONPath(filePath, path string) string {
-	bytes, err := jsonparser.SelectPath(filePath, path)
+// applyJSONPath extracts the rows selected by cfg.JSONPath from the input file
+// and writes them to a temp file, which is returned for the JSON parser to
+// consume.
+func applyJSONPath(filePath, path string, parentKeys bool) string {
+	bytes, err := jsonparser.SelectPath(filePath, path, parentKeys)
 	if err != nil {
 		shared.ExitWithError(err.Error(), nil)
 	}
@@ -264,16 +287,16 @@ func prepareData(filePath, parserKey string, cfg parser.Config, titles ...string
 		shared.ExitWithError(err.Error(), nil)
 	}
 
-	if cfg.JSONPath != "" && parserKey != "json" {
-		cliout.Warn("--json-path is only supported for the json parser; ignoring")
+	if cfg.JSONPath != "" && !parser.SupportsJSONPath(parserKey) {
+		cliout.Warn("--json-path is only supported for the json, yaml and toml parsers; ignoring")
 	}
 
-	if parser.HasSelect(cfg) && parserKey != "csv" && parserKey != "json" {
-		cliout.Warn("--select is only supported for csv/json parsers; ignoring")
+	if parser.HasSelect(cfg) && !parser.IsTabular(parserKey) {
+		cliout.Warn("--select is only supported for csv/json/yaml/toml parsers; ignoring")
 	}
 
-	if len(cfg.Axes) > 0 && parserKey != "csv" && parserKey != "json" {
-		shared.ExitWithError("--axes is only supported for csv/json parsers", nil)
+	if len(cfg.Axes) > 0 && !parser.IsTabular(parserKey) {
+		shared.ExitWithError("--axes is only supported for csv/json/yaml/toml parsers", nil)
 	}
 
 	// Parse phase: delayed green shimmer (250ms); silent when fast on TTY.
@@ -290,6 +313,14 @@ func prepareData(filePath, parserKey string, cfg parser.Config, titles ...string
 	}
 	defer input.Close()
 
+	// Streaming parsers report rows/bytes as they go; surface them on the
+	// spinner's detail row and keep the row count for the aggregation summary.
+	rowsRead := 0
+	cfg.Progress = func(rows int, bytes int64) {
+		rowsRead = rows
+		parseSpin.Describe(formatIngestProgress(rows, bytes))
+	}
+
 	data, effectiveCfg, system, err := parseFn(input, cfg)
 	if err != nil {
 		_ = parseSpin.Finish()
@@ -298,16 +329,19 @@ func prepareData(filePath, parserKey string, cfg parser.Config, titles ...string
 
 	// CSV/JSON emit one DataPoint per row; when grouping is inactive, collapse rows
 	// that share the same (name, x, y, z) by appending stats (no sum/average).
-	if tabularParser(parserKey) && len(effectiveCfg.Group) == 0 {
+	if parser.IsTabular(parserKey) && len(effectiveCfg.Group) == 0 {
 		data = shared.CollapseDataPointsByKey(data)
 	}
 	_ = parseSpin.Finish()
 
 	// Aggregate phase: separate spinner + phrases so the live title is not
 	// still "Parsing data" while summing groups (large CSVs spend time here).
-	if tabularParser(parserKey) && len(effectiveCfg.Group) > 0 {
+	// Histograms bin the raw rows at assembly instead.
+	if parser.IsTabular(parserKey) && len(effectiveCfg.Group) > 0 && !parser.HasDistributionChart(effectiveCfg) {
 		aggSpin := NewAggregateSpinner(os.Stderr)
-		before := len(data)
+		// Streaming parsers may already have folded rows into groups, so the
+		// row count they reported is the honest "before" figure.
+		before := max(len(data), rowsRead)
 		data = shared.AggregateDataPoints(data)
 		// Sum reintroduces float residue; re-apply 2dp when requested.
 		if effectiveCfg.Round {
@@ -350,6 +384,26 @@ func logCollectionResult(n int) {
 	cliout.Info(fmt.Sprintf("Collected %s benchmark records", count))
 }
 
+// formatIngestProgress renders the spinner detail for streaming ingest,
+// e.g. "Read 120000 rows - 14.2 MB".
+func formatIngestProgress(rows int, bytes int64) string {
+	return fmt.Sprintf("Read %d rows - %s", rows, formatByteSize(bytes))
+}
+
+// formatByteSize renders a byte count with a binary-scaled unit (B, KB, MB, GB).
+func formatByteSize(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit && exp < 2; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMG"[exp])
+}
+
 // logAggregationResult prints one completion line after summing grouped rows.
 // In-progress work is the aggregate spinner; we do not log a second "Aggregating…" line.
 // Digits and the group series use brand accents for scannability.
@@ -395,16 +449,37 @@ func formatAggregationGroup(cfg parser.Config) string {
 	return colPhrase + " (" + strings.Join(dims, ", ") + ")"
 }
 
+// captureEnv adds the run environment (see envinfo.Capture) to the parser's
+// system metadata, scanning the input's head for toolchain versions and the
+// current directory's git checkout.
+func captureEnv(target string, system *shared.Meta) *shared.Meta {
+	sample, err := envinfo.ReadSample(target)
+	if err != nil {
+		cliout.Warnf("--capture-env: reading input: %v", err)
+	}
+	if system == nil {
+		system = &shared.Meta{}
+	}
+	if system.Env == nil {
+		system.Env = map[string]string{}
+	}
+	maps.Copy(system.Env, envinfo.Capture(sample, "."))
+	return system
+}
+
 // assembleDataset builds the output Dataset from parsed results plus the
 // command's metadata and the resolved per-chart configs.
 func assembleDataset(results []shared.DataPoint, m RunMeta, configs []internal_charts.ChartConfig, cfg parser.Config, system *shared.Meta) *shared.Dataset {
+	for _, name := range shared.UnmatchedDirectionRules(results, cfg.Better) {
+		cliout.Warnf("--better: no stat named %q", name)
+	}
 	return core.Assemble(core.AssembleInput{
 		Points: results,
 		Parser: m.Parser,
 		Config: cfg,
 		Metadata: core.Metadata{
 			ID: m.ID, Name: m.Name, Themes: resolveRunThemes(m), Description: m.Description, Tag: m.Tag,
-			System: system,
+			Commit: m.Commit, System: system,
 		},
 		Charts: configs,
 	})
@@ -433,14 +508,11 @@ func resolveRunThemes(m RunMeta) []shared.Theme {
 	return out
 }
 
-func tabularParser(parserKey string) bool {
-	return parserKey == "csv" || parserKey == "json"
-}
-
 // applySelections overrides a passed-through Dataset's chart selection so e.g.
-// `vizb bar data.json` re-renders with the new configs.
+// `vizb bar data.json` re-renders with the new configs. Each Dataset gets its
+// own slice: rules filter Settings per Dataset (merged arrays differ in tags).
 func applySelections(dataSet *shared.Dataset, configs []internal_charts.ChartConfig) {
-	dataSet.Settings = configs
+	dataSet.Settings = slices.Clone(configs)
 }
 
 // settingsNeedCorrelation reports whether any chart setting in the slice needs
@@ -465,9 +537,9 @@ func chartTypeNames(settings []internal_charts.ChartConfig) []string {
 	return out
 }
 
-// writeOutput writes one or more datasets to f as HTML or JSON. HTML embeds an
-// array when N>1 (like vizb ui); JSON keeps a single object when N=1 for backward
-// compatibility.
+// writeOutput writes one or more datasets to f as HTML, JSON, or a Vega-Lite or
+// ECharts export. HTML embeds an array when N>1 (like vizb ui); JSON keeps a
+// single object when N=1 for backward compatibility.
 func writeOutput(f *os.File, datasets []*shared.Dataset, format string) {
 	if len(datasets) == 0 {
 		return
@@ -500,6 +572,9 @@ func writeOutput(f *os.File, datasets []*shared.Dataset, format string) {
 			shared.ExitWithError("Failed to write output file", err)
 		}
 		cliout.Info("Generated JSON successfully")
+
+	case export.FormatVegaLite, export.FormatECharts:
+		WriteExport(f, datasets, format)
 	}
 }
 
@@ -45,7 +45,7 @@ func (s *PipelineSuite) writeFile(name, content string) string {
 func (s *PipelineSuite) TestApplyJSONPathEnvelope() {
 	envelope := s.writeFile("env.json", `{"data":[{"impl":"a","ops":120},{"impl":"b","ops":80}]}`)
 
-	extracted := applyJSONPath(envelope, ".data")
+	extracted := applyJSONPath(envelope, ".data", false)
 	s.FileExists(extracted)
 
 	cfg := parser.Config{GroupPattern: "x", Group: []string{"impl"}, JSONPath: ".data"}
@@ -97,6 +97,29 @@ func (s *PipelineSuite) TestGoBenchmarkMetadataPreservesDatasetWireFormat() {
 	}`, string(wire.Meta))
 }
 
+func (s *PipelineSuite) TestCaptureEnvAddsToParserMeta() {
+	input := s.writeFile("bench.txt", "go version go1.22.3 linux/amd64\ngoos: linux\nBenchmarkExample-8 100 1234 ns/op\n")
+
+	_, _, system := prepareData(input, "go", parser.Config{GroupPattern: "y"})
+	system = captureEnv(input, system)
+
+	s.Require().NotNil(system)
+	s.Equal("linux", system.OS)
+	s.Equal("1.22.3", system.Env["go"])
+	s.NotEmpty(system.Env["cpu.cores"])
+}
+
+func (s *PipelineSuite) TestCaptureEnvCreatesMetaForTabularInput() {
+	input := s.writeFile("data.csv", "region,latency\nwest,12\n")
+
+	system := captureEnv(input, nil)
+
+	s.Require().NotNil(system)
+	s.Nil(system.CPU)
+	s.NotEmpty(system.Env["cpu.cores"])
+	s.NotContains(system.Env, "go")
+}
+
 func (s *PipelineSuite) TestPrepareDataWarnsJSONPathIgnoredForNonJSONParser() {
 	benchFile := s.writeFile("valid.txt", `BenchmarkExample-8    1000000    1234 ns/op`)
 	cfg := parser.Config{GroupPattern: "y", TimeUnit: "ns", MemUnit: "B", JSONPath: ".data"}
@@ -105,7 +128,7 @@ func (s *PipelineSuite) TestPrepareDataWarnsJSONPathIgnoredForNonJSONParser() {
 		results, _, _ := prepareData(benchFile, "go", cfg)
 		s.NotEmpty(results)
 	})
-	s.Contains(errOut, "--json-path is only supported for the json parser")
+	s.Contains(errOut, "--json-path is only supported for the json, yaml and toml parsers")
 }
 
 func (s *PipelineSuite) TestPrepareDataWarnsSelectIgnoredForGoParser() {
@@ -121,7 +144,7 @@ func (s *PipelineSuite) TestPrepareDataWarnsSelectIgnoredForGoParser() {
 		results, _, _ := prepareData(benchFile, "go", cfg)
 		s.NotEmpty(results)
 	})
-	s.Contains(errOut, "--select is only supported for csv/json parsers")
+	s.Contains(errOut, "--select is only supported for csv/json/yaml/toml parsers")
 }
 
 func (s *PipelineSuite) TestPrepareData() {
@@ -602,6 +625,26 @@ func (s *PipelineSuite) TestPrepareDataAutoGroupAggregatesCSV() {
 	s.Equal(5.0, byX["East"])
 }
 
+func (s *PipelineSuite) TestPrepareDataReportsStreamedRowCount() {
+	csvFile := s.writeFile("streamed.csv", "region,sells\nWest,10\nWest,20\nEast,5\n")
+	cfg := parser.Config{GroupPattern: "x", Group: []string{"region"}}
+
+	out := testutil.CaptureStderr(func() {
+		results, _, _ := prepareData(csvFile, "csv", cfg)
+		s.Len(results, 2)
+	})
+	// The csv parser folds groups while streaming; the summary still counts rows.
+	s.Contains(out, "3")
+	s.Contains(out, "into")
+}
+
+func (s *PipelineSuite) TestFormatIngestProgress() {
+	s.Equal("Read 42 rows - 512 B", formatIngestProgress(42, 512))
+	s.Equal("Read 10000 rows - 1.5 KB", formatIngestProgress(10000, 1536))
+	s.Equal("Read 2 rows - 3.0 GB", formatIngestProgress(2, 3<<30))
+	s.Equal("5.0 GB", formatByteSize(5<<30))
+}
+
 func (s *PipelineSuite) TestPrepareDataAggregatesCSV() {
 	csvFile := s.writeFile("grouped.csv", "name,sells,date\nalpha,10,2024-01\nalpha,20,2024-01\nbeta,5,2025-02\n")
 	cfg := parser.Config{GroupPattern: "name,x", Group: []string{"name", "date"}}
@@ -945,12 +988,24 @@ func (s *PipelineSuite) TestWriteOutputEmptyDatasets() {
 	s.Equal(int64(0), stat.Size())
 }
 
+func (s *PipelineSuite) TestApplyJSONPathWildcardWithParentKeys() {
+	envelope := s.writeFile("runs.json", `{"runs":{"v1":{"results":[{"impl":"a","ops":120}]},"v2":{"results":[{"impl":"a","ops":150}]}}}`)
+
+	extracted := applyJSONPath(envelope, ".runs[].results", true)
+
+	cfg := parser.Config{GroupPattern: "x,y", Group: []string{"runs", "impl"}}
+	results, _, _ := prepareData(extracted, "json", cfg)
+	s.Require().Len(results, 2)
+	s.Equal("v1", results[0].XAxis)
+	s.Equal("v2", results[1].XAxis)
+}
+
 func (s *PipelineSuite) TestApplyJSONPathInvalidExits() {
 	restore, exitCalled := testutil.TrapOsExitPanic(s.T())
 	defer restore()
 
 	bad := s.writeFile("bad.json", `{"items":[]}`)
-	s.Panics(func() { applyJSONPath(bad, ".missing.path") })
+	s.Panics(func() { applyJSONPath(bad, ".missing.path", false) })
 	s.True(*exitCalled)
 }
 
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/goptics/vizb/pkg/cliout"
+	"github.com/goptics/vizb/pkg/svg"
+	"github.com/goptics/vizb/shared"
+)
+
+// --svg-layout values: how several charts land in SVG output.
+const (
+	SVGLayoutGrid  = "grid"  // one sprite image, two charts per row
+	SVGLayoutFiles = "files" // <output>-<chart>-<stat>.svg per chart
+)
+
+const svgGridColumns = 2
+
+// writeSVG renders every dataset's bar/line/scatter/pie charts (one per stat
+// type) and writes them per layout. A single chart always goes to outFile
+// itself. Chart types without an SVG renderer are skipped with a warning.
+func writeSVG(outFile string, datasets []*shared.Dataset, layout string) {
+	var images []svg.Image
+	var skipped []string
+	for _, ds := range datasets {
+		imgs, skip := svg.Render(ds)
+		if len(datasets) > 1 {
+			for i := range imgs {
+				imgs[i].Name = datasetSlug(ds) + "-" + imgs[i].Name
+			}
+		}
+		images = append(images, imgs...)
+		for _, t := range skip {
+			if !slices.Contains(skipped, t) {
+				skipped = append(skipped, t)
+			}
+		}
+	}
+	if len(skipped) > 0 {
+		cliout.Warnf("no SVG renderer for %s charts; skipped", strings.Join(skipped, ", "))
+	}
+	if len(images) == 0 {
+		shared.ExitWithError("No chart to render as SVG (supported: bar, line, scatter, pie)", nil)
+	}
+
+	if len(images) == 1 || layout != SVGLayoutFiles {
+		content := images[0].SVG
+		if len(images) > 1 {
+			content = svg.Grid(images, svgGridColumns)
+		}
+		writeSVGFile(outFile, content)
+		cliout.Info("Generated SVG successfully")
+		return
+	}
+
+	base := strings.TrimSuffix(outFile, filepath.Ext(outFile))
+	for _, img := range images {
+		writeSVGFile(base+"-"+img.Name+".svg", img.SVG)
+	}
+	cliout.Info(fmt.Sprintf("Generated %d SVG files successfully", len(images)))
+}
+
+func writeSVGFile(path string, content []byte) {
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		shared.ExitWithError("Failed to write output file", err)
+	}
+	cliout.InfoPairAccent("Output file", path, cliout.FormatAccent("svg"))
+}
+
+// datasetSlug names a dataset's files when several share one output: its id,
+// else its tag, else its name.
+func datasetSlug(ds *shared.Dataset) string {
+	for _, s := range []string{ds.ID, ds.Tag, ds.Name} {
+		if s = svg.Slug(s); s != "" {
+			return s
+		}
+	}
+	return "dataset"
+}
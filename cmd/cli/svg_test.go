@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	internal_charts "github.com/goptics/vizb/internal/charts"
+	barchart "github.com/goptics/vizb/internal/charts/bar"
+	heatmapchart "github.com/goptics/vizb/internal/charts/heatmap"
+	piechart "github.com/goptics/vizb/internal/charts/pie"
+	"github.com/goptics/vizb/shared"
+	"github.com/goptics/vizb/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+// SVGOutputSuite covers writeSVG's layouts and warnings.
+type SVGOutputSuite struct {
+	suite.Suite
+	restoreOsExit func()
+}
+
+func (s *SVGOutputSuite) SetupTest() {
+	s.restoreOsExit, _ = testutil.TrapOsExitPanic(s.T())
+}
+
+func (s *SVGOutputSuite) TearDownTest() {
+	s.restoreOsExit()
+}
+
+func svgDataset(settings ...internal_charts.ChartConfig) *shared.Dataset {
+	return &shared.Dataset{
+		Name:     "bench",
+		Axes:     []shared.Axis{{Key: "x"}},
+		Settings: settings,
+		Data: []shared.DataPoint{
+			{XAxis: "Sort", Stats: []shared.Stat{{Type: "Execution Time (ns/op)", Value: shared.F64(40)}}},
+			{XAxis: "Map", Stats: []shared.Stat{{Type: "Execution Time (ns/op)", Value: shared.F64(30)}}},
+		},
+	}
+}
+
+func (s *SVGOutputSuite) TestSingleChartWritesOutputFile() {
+	out := filepath.Join(s.T().TempDir(), "report.svg")
+	writeSVG(out, []*shared.Dataset{svgDataset(&barchart.Config{Type: "bar"})}, SVGLayoutFiles)
+
+	content, err := os.ReadFile(out)
+	s.Require().NoError(err)
+	s.Contains(string(content), "<svg")
+	s.Contains(string(content), "Execution Time (ns/op)")
+}
+
+func (s *SVGOutputSuite) TestGridLayoutNestsEveryChart() {
+	out := filepath.Join(s.T().TempDir(), "report.svg")
+	ds := svgDataset(&barchart.Config{Type: "bar"}, &piechart.Config{Type: "pie"})
+	writeSVG(out, []*shared.Dataset{ds}, SVGLayoutGrid)
+
+	content, err := os.ReadFile(out)
+	s.Require().NoError(err)
+	s.Contains(string(content), `width="1600" height="450"`)
+}
+
+func (s *SVGOutputSuite) TestFilesLayoutWritesOneFilePerChart() {
+	dir := s.T().TempDir()
+	out := filepath.Join(dir, "report.svg")
+	ds := svgDataset(&barchart.Config{Type: "bar"}, &heatmapchart.Config{Type: "heatmap"}, &piechart.Config{Type: "pie"})
+
+	stderr := testutil.CaptureStderr(func() {
+		writeSVG(out, []*shared.Dataset{ds}, SVGLayoutFiles)
+	})
+
+	s.Contains(stderr, "no SVG renderer for heatmap charts")
+	s.FileExists(filepath.Join(dir, "report-bar-execution-time-ns-op.svg"))
+	s.FileExists(filepath.Join(dir, "report-pie-execution-time-ns-op.svg"))
+	s.NoFileExists(out)
+}
+
+func (s *SVGOutputSuite) TestNoRenderableChartExits() {
+	out := filepath.Join(s.T().TempDir(), "report.svg")
+	ds := svgDataset(&heatmapchart.Config{Type: "heatmap"})
+	s.Panics(func() {
+		writeSVG(out, []*shared.Dataset{ds}, SVGLayoutGrid)
+	})
+	s.NoFileExists(out)
+}
+
+func TestSVGOutputSuite(t *testing.T) {
+	suite.Run(t, new(SVGOutputSuite))
+}
@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/goptics/vizb/cmd/cli"
+	"github.com/goptics/vizb/shared"
+	"github.com/goptics/vizb/shared/utils"
+	"github.com/spf13/cobra"
+)
+
+const (
+	diffFormatText = "text"
+	diffFormatJSON = "json"
+)
+
+// Exit statuses follow diff(1) and cmp(1): 0 same, 1 different, 2 trouble.
+const (
+	diffExitDifferent = 1
+	diffExitTrouble   = 2
+)
+
+// diffOptions holds the flags for the diff subcommand.
+type diffOptions struct {
+	Format    string
+	Tolerance float64
+}
+
+var diffOpts diffOptions
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <before.json> <after.json>",
+	Short: "Compare two Dataset files",
+	Long: `Compare two Dataset JSON files: datasets added or removed, axes changes,
+chart settings that differ per chart type, and data points added, removed or
+changed with the numeric delta of every changed stat.
+
+Datasets pair up by name and data points by their name/x/y/z values. Stats
+recorded in convertible units (ns and ms, say) are compared in the newer
+file's unit.
+
+Exits 0 when the files match and 1 when they differ, so the command doubles
+as a golden-file check. Unreadable files and bad flags exit 2, as diff(1) does.`,
+	Args: cobra.ExactArgs(2),
+	Run:  runDiff,
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+	diffCmd.Flags().StringVarP(&diffOpts.Format, "format", "f", diffFormatText, "Output format (text, json)")
+	diffCmd.Flags().Float64Var(&diffOpts.Tolerance, "tolerance", 0,
+		"Ignore stat changes within this percentage of the old value")
+}
+
+func runDiff(cmd *cobra.Command, args []string) {
+	utils.ApplyValidationRules([]utils.ValidationRule{{
+		Label:      "diff format",
+		Value:      &diffOpts.Format,
+		ValidSet:   []string{diffFormatText, diffFormatJSON},
+		Normalizer: strings.ToLower,
+		Default:    diffFormatText,
+	}})
+	if diffOpts.Tolerance < 0 {
+		shared.ExitWithErrorCode(diffExitTrouble, "--tolerance must not be negative", nil)
+	}
+
+	before := readDiffFile(args[0])
+	after := readDiffFile(args[1])
+	diff := shared.DiffDatasets(before, after, diffOpts.Tolerance)
+
+	out := cmd.OutOrStdout()
+	if diffOpts.Format == diffFormatJSON {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(diff); err != nil {
+			shared.ExitWithErrorCode(diffExitTrouble, "Failed to encode diff", err)
+		}
+	} else {
+		writeDiffText(out, diff)
+	}
+	if !diff.IsZero() {
+		shared.OsExit(diffExitDifferent)
+	}
+}
+
+func readDiffFile(file string) []shared.Dataset {
+	datasets, err := cli.ParseDatasetFile(file)
+	if err != nil {
+		shared.ExitWithErrorCode(diffExitTrouble, file, err)
+	}
+	return datasets
+}
+
+// writeDiffText prints diff as +/-/~ lines, nested by dataset and point.
+func writeDiffText(out io.Writer, diff shared.Diff) {
+	if diff.IsZero() {
+		fmt.Fprintln(out, "No differences")
+		return
+	}
+	for _, name := range diff.Removed {
+		fmt.Fprintf(out, "- dataset %s\n", name)
+	}
+	for _, name := range diff.Added {
+		fmt.Fprintf(out, "+ dataset %s\n", name)
+	}
+	for _, d := range diff.Changed {
+		fmt.Fprintf(out, "~ dataset %s\n", d.Name)
+		if d.Axes != nil {
+			fmt.Fprintf(out, "    axes: %s → %s\n", formatDiffAxes(d.Axes.Before), formatDiffAxes(d.Axes.After))
+		}
+		for _, chart := range d.RemovedCharts {
+			fmt.Fprintf(out, "  - chart %s\n", chart)
+		}
+		for _, chart := range d.AddedCharts {
+			fmt.Fprintf(out, "  + chart %s\n", chart)
+		}
+		for _, s := range d.Settings {
+			fmt.Fprintf(out, "    %s.%s: %s → %s\n", s.Chart, s.Field, formatDiffSetting(s.Before), formatDiffSetting(s.After))
+		}
+		for _, p := range d.RemovedPoints {
+			fmt.Fprintf(out, "  - point %s\n", p)
+		}
+		for _, p := range d.AddedPoints {
+			fmt.Fprintf(out, "  + point %s\n", p)
+		}
+		for _, p := range d.ChangedPoints {
+			fmt.Fprintf(out, "  ~ point %s\n", p.Point)
+			for _, st := range p.Stats {
+				fmt.Fprintf(out, "      %s\n", formatStatChange(st))
+			}
+		}
+	}
+}
+
+func formatStatChange(st shared.StatChange) string {
+	switch {
+	case st.Before == nil && st.After == nil:
+		return st.Type
+	case st.Before == nil:
+		return fmt.Sprintf("+ %s: %s", st.Type, formatDiffNumber(*st.After))
+	case st.After == nil:
+		return fmt.Sprintf("- %s: %s", st.Type, formatDiffNumber(*st.Before))
+	case st.BeforeType != "":
+		return fmt.Sprintf("%s → %s: %s → %s", st.BeforeType, st.Type,
+			formatDiffNumber(*st.Before), formatDiffNumber(*st.After))
+	}
+	line := fmt.Sprintf("%s: %s → %s", st.Type, formatDiffNumber(*st.Before), formatDiffNumber(*st.After))
+	if st.Delta == nil {
+		return line
+	}
+	delta := formatDiffNumber(*st.Delta)
+	if *st.Delta > 0 {
+		delta = "+" + delta
+	}
+	if st.Percent == nil {
+		return fmt.Sprintf("%s (%s)", line, delta)
+	}
+	return fmt.Sprintf("%s (%s, %+.2f%%)", line, delta, *st.Percent)
+}
+
+// formatDiffNumber prints v without exponent or float noise past six decimals.
+func formatDiffNumber(v float64) string {
+	return strconv.FormatFloat(math.Round(v*1e6)/1e6, 'f', -1, 64)
+}
+
+func formatDiffAxes(axes []shared.Axis) string {
+	parts := make([]string, len(axes))
+	for i, a := range axes {
+		parts[i] = a.Key
+		if a.Label != "" {
+			parts[i] += fmt.Sprintf("(%s)", a.Label)
+		}
+		if a.Type != "" {
+			parts[i] += ":" + a.Type
+		}
+	}
+	return "[" + strings.Join(parts, ", ") + "]"
+}
+
+func formatDiffSetting(v any) string {
+	if v == nil {
+		return "unset"
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(b)
+}
@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/goptics/vizb/shared"
+	"github.com/goptics/vizb/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+// DiffSuite covers the diff subcommand end-to-end via rootCmd.Execute.
+type DiffSuite struct {
+	suite.Suite
+	restoreOsExit func()
+	exitCalled    *bool
+	exitCode      int
+	out           *bytes.Buffer
+}
+
+func (s *DiffSuite) SetupTest() {
+	ResetTestState()
+	s.restoreOsExit, s.exitCalled = testutil.TrapOsExitPanic(s.T())
+	trap := shared.OsExit
+	s.exitCode = -1
+	shared.OsExit = func(code int) {
+		s.exitCode = code
+		trap(code)
+	}
+	s.out = &bytes.Buffer{}
+	rootCmd.SetOut(s.out)
+}
+
+func (s *DiffSuite) TearDownTest() {
+	rootCmd.SetOut(nil)
+	s.restoreOsExit()
+}
+
+func (s *DiffSuite) write(name string, datasets ...shared.Dataset) string {
+	path := filepath.Join(s.T().TempDir(), name)
+	testutil.WriteJSON(s.T(), path, datasets)
+	return path
+}
+
+func (s *DiffSuite) files() (string, string) {
+	stat := func(v float64) []shared.Stat {
+		return []shared.Stat{{Type: "Execution Time (ns/op)", Unit: "ns", Per: "op", Value: shared.F64(v)}}
+	}
+	before := s.write("before.json",
+		shared.Dataset{Name: "Sort", Axes: []shared.Axis{{Key: "x"}}, Data: []shared.DataPoint{
+			{XAxis: "1024", Stats: stat(100)}, {XAxis: "64", Stats: stat(10)},
+		}},
+		shared.Dataset{Name: "Old"},
+	)
+	after := s.write("after.json",
+		shared.Dataset{Name: "Sort", Axes: []shared.Axis{{Key: "x", Label: "size"}}, Data: []shared.DataPoint{
+			{XAxis: "1024", Stats: stat(80)}, {XAxis: "4096", Stats: stat(400)},
+		}},
+		shared.Dataset{Name: "New"},
+	)
+	return before, after
+}
+
+func (s *DiffSuite) TestTextReportExitsNonZero() {
+	before, after := s.files()
+	rootCmd.SetArgs([]string{"diff", before, after})
+	s.Panics(func() { _ = rootCmd.Execute() })
+	s.True(*s.exitCalled)
+	s.Equal(1, s.exitCode)
+	s.Equal(`- dataset Old
++ dataset New
+~ dataset Sort
+    axes: [x] → [x(size)]
+  - point 64
+  + point 4096
+  ~ point 1024
+      Execution Time (ns/op): 100 → 80 (-20, -20.00%)
+`, s.out.String())
+}
+
+func (s *DiffSuite) TestJSONReport() {
+	before, after := s.files()
+	rootCmd.SetArgs([]string{"diff", "--format", "json", before, after})
+	s.Panics(func() { _ = rootCmd.Execute() })
+
+	var diff shared.Diff
+	s.Require().NoError(json.Unmarshal(s.out.Bytes(), &diff))
+	s.Equal([]string{"Old"}, diff.Removed)
+	s.Equal([]string{"New"}, diff.Added)
+	s.Require().Len(diff.Changed, 1)
+	s.Require().Len(diff.Changed[0].ChangedPoints, 1)
+	s.InDelta(-20.0, *diff.Changed[0].ChangedPoints[0].Stats[0].Delta, 1e-9)
+}
+
+func (s *DiffSuite) TestIdenticalFilesExitZero() {
+	before, _ := s.files()
+	rootCmd.SetArgs([]string{"diff", before, before})
+	s.Require().NoError(rootCmd.Execute())
+	s.False(*s.exitCalled)
+	s.Equal("No differences\n", s.out.String())
+
+	s.out.Reset()
+	rootCmd.SetArgs([]string{"diff", "-f", "json", before, before})
+	s.Require().NoError(rootCmd.Execute())
+	s.JSONEq("{}", s.out.String())
+}
+
+func (s *DiffSuite) TestToleranceIgnoresSmallChanges() {
+	stat := func(v float64) []shared.Stat { return []shared.Stat{{Type: "ops", Value: shared.F64(v)}} }
+	before := s.write("a.json", shared.Dataset{Name: "Sort", Data: []shared.DataPoint{{XAxis: "1", Stats: stat(100)}}})
+	after := s.write("b.json", shared.Dataset{Name: "Sort", Data: []shared.DataPoint{{XAxis: "1", Stats: stat(103)}}})
+	rootCmd.SetArgs([]string{"diff", "--tolerance", "5", before, after})
+	s.Require().NoError(rootCmd.Execute())
+	s.False(*s.exitCalled)
+}
+
+func (s *DiffSuite) TestBadInputExits() {
+	before, _ := s.files()
+	for _, args := range [][]string{
+		{"diff", before, filepath.Join(s.T().TempDir(), "missing.json")},
+		{"diff", "--tolerance", "-1", before, before},
+	} {
+		ResetTestState()
+		*s.exitCalled = false
+		s.exitCode = -1
+		rootCmd.SetArgs(args)
+		s.Panics(func() { _ = rootCmd.Execute() }, args)
+		s.True(*s.exitCalled, args)
+		s.Equal(2, s.exitCode, args)
+	}
+}
+
+func (s *DiffSuite) TestUsageErrorsExitTwo() {
+	before, _ := s.files()
+	rootCmd.SetArgs([]string{"diff", before})
+	s.Panics(Execute)
+	s.Equal(2, s.exitCode)
+}
+
+func TestDiffSuite(t *testing.T) {
+	suite.Run(t, new(DiffSuite))
+}
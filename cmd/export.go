@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/goptics/vizb/cmd/cli"
+	"github.com/goptics/vizb/pkg/export"
+	"github.com/goptics/vizb/shared"
+	"github.com/goptics/vizb/shared/utils"
+	"github.com/spf13/cobra"
+)
+
+// exportOptions holds the flags for the export subcommand.
+type exportOptions struct {
+	OutputFile string
+	Format     string
+}
+
+var exportOpts exportOptions
+
+var exportCmd = &cobra.Command{
+	Use:   "export [file]",
+	Short: "Export Dataset JSON as Vega-Lite or ECharts specs",
+	Long: `Convert the charts in a Vizb Dataset JSON file (single object or array)
+into a Vega-Lite spec or raw ECharts option JSON for use in other tools.
+Bar, line, scatter, and pie charts are exported once per stat type; other
+chart types are skipped with a warning.
+
+Without --format, an -o ending in .echarts.json selects echarts; otherwise
+the format is vega-lite.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runExport,
+}
+
+func init() {
+	rootCmd.AddCommand(exportCmd)
+	exportCmd.Flags().StringVarP(&exportOpts.OutputFile, "output", "o", "", "Output path (.json)")
+	exportCmd.Flags().StringVarP(&exportOpts.Format, "format", "f", export.FormatVegaLite,
+		"Export format (vega-lite, echarts)")
+}
+
+func runExport(cmd *cobra.Command, args []string) {
+	if !cmd.Flags().Changed("format") && cli.InferFormatFromExtension(exportOpts.OutputFile) == export.FormatECharts {
+		exportOpts.Format = export.FormatECharts
+	}
+	utils.ApplyValidationRules([]utils.ValidationRule{{
+		Label:      "export format",
+		Value:      &exportOpts.Format,
+		ValidSet:   export.Formats,
+		Normalizer: strings.ToLower,
+		Default:    export.FormatVegaLite,
+	}})
+
+	datasets, err := cli.ParseDatasetFile(args[0])
+	if err != nil {
+		shared.ExitWithError("Failed to parse DataSet file: %v", err)
+	}
+	if len(datasets) == 0 {
+		shared.ExitWithError("No dataset found in file", nil)
+	}
+	ptrs := make([]*shared.Dataset, len(datasets))
+	for i := range datasets {
+		ptrs[i] = &datasets[i]
+	}
+
+	outFile := exportOpts.OutputFile
+	if outFile == "" {
+		outFile = shared.MustCreateTempFile(shared.TempBenchFilePrefix, "json")
+		shared.TempFiles.Store(outFile)
+	} else if filepath.Ext(outFile) == "" {
+		outFile += ".json"
+	}
+
+	f := shared.MustCreateFile(outFile)
+	defer f.Close()
+	defer cli.HandleOutputResult(f, exportOpts.OutputFile)
+
+	cli.WriteExport(f, ptrs, exportOpts.Format)
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	internal_charts "github.com/goptics/vizb/internal/charts"
+	barchart "github.com/goptics/vizb/internal/charts/bar"
+	piechart "github.com/goptics/vizb/internal/charts/pie"
+	"github.com/goptics/vizb/shared"
+	"github.com/goptics/vizb/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+// ExportSuite covers the export subcommand end-to-end via rootCmd.Execute.
+type ExportSuite struct {
+	suite.Suite
+	restoreOsExit func()
+	exitCalled    *bool
+}
+
+func (s *ExportSuite) SetupTest() {
+	ResetTestState()
+	s.restoreOsExit, s.exitCalled = testutil.TrapOsExitPanic(s.T())
+}
+
+func (s *ExportSuite) TearDownTest() {
+	s.restoreOsExit()
+}
+
+func (s *ExportSuite) writeDataset(dir string) string {
+	path := filepath.Join(dir, "bench.json")
+	testutil.WriteJSON(s.T(), path, shared.Dataset{
+		Name:     "Bench",
+		Axes:     []shared.Axis{{Key: "x"}},
+		Settings: []internal_charts.ChartConfig{&barchart.Config{Type: "bar"}, &piechart.Config{Type: "pie"}},
+		Data: []shared.DataPoint{
+			{XAxis: "Sort", Stats: []shared.Stat{{Type: "Execution Time (ns/op)", Value: shared.F64(40)}}},
+			{XAxis: "Map", Stats: []shared.Stat{{Type: "Execution Time (ns/op)", Value: shared.F64(30)}}},
+		},
+	})
+	return path
+}
+
+func (s *ExportSuite) readJSON(path string, target any) {
+	content, err := os.ReadFile(path)
+	s.Require().NoError(err)
+	s.Require().NoError(json.Unmarshal(content, target))
+}
+
+func (s *ExportSuite) TestDefaultsToVegaLite() {
+	dir := s.T().TempDir()
+	out := filepath.Join(dir, "charts")
+	rootCmd.SetArgs([]string{"export", s.writeDataset(dir), "-o", out})
+	s.Require().NoError(rootCmd.Execute())
+
+	var spec map[string]any
+	s.readJSON(out+".json", &spec)
+	s.Equal("https://vega.github.io/schema/vega-lite/v5.json", spec["$schema"])
+	s.Len(spec["vconcat"], 2)
+}
+
+func (s *ExportSuite) TestFormatFlagSelectsECharts() {
+	dir := s.T().TempDir()
+	out := filepath.Join(dir, "charts.json")
+	rootCmd.SetArgs([]string{"export", "--format", "ECharts", s.writeDataset(dir), "-o", out})
+	s.Require().NoError(rootCmd.Execute())
+
+	var options []map[string]any
+	s.readJSON(out, &options)
+	s.Require().Len(options, 2)
+	s.Contains(options[0], "xAxis")
+}
+
+func (s *ExportSuite) TestOutputExtensionSelectsECharts() {
+	dir := s.T().TempDir()
+	out := filepath.Join(dir, "charts.echarts.json")
+	rootCmd.SetArgs([]string{"export", s.writeDataset(dir), "-o", out})
+	s.Require().NoError(rootCmd.Execute())
+
+	var options []map[string]any
+	s.readJSON(out, &options)
+	s.Len(options, 2)
+}
+
+func (s *ExportSuite) TestInvalidFormatFallsBackToVegaLite() {
+	dir := s.T().TempDir()
+	out := filepath.Join(dir, "x.json")
+	rootCmd.SetArgs([]string{"export", "--format", "plotly", s.writeDataset(dir), "-o", out})
+	stderr := testutil.CaptureStderr(func() { s.Require().NoError(rootCmd.Execute()) })
+	s.Contains(stderr, "Invalid export format 'plotly'")
+
+	var spec map[string]any
+	s.readJSON(out, &spec)
+	s.Contains(spec, "vconcat")
+}
+
+func (s *ExportSuite) TestMissingFileExits() {
+	rootCmd.SetArgs([]string{"export", filepath.Join(s.T().TempDir(), "missing.json")})
+	s.Panics(func() { _ = rootCmd.Execute() })
+	s.True(*s.exitCalled)
+}
+
+func TestExportSuite(t *testing.T) {
+	suite.Run(t, new(ExportSuite))
+}
@@ -0,0 +1,304 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/goptics/vizb/cmd/cli"
+	"github.com/goptics/vizb/pkg/cliout"
+	"github.com/goptics/vizb/shared"
+	"github.com/goptics/vizb/shared/utils"
+	"github.com/spf13/cobra"
+)
+
+// historyOptions holds the flags shared by the history subcommands.
+type historyOptions struct {
+	OutputFile string
+	TagAxis    string
+	Name       string
+	DryRun     bool
+	Retention  retentionFlags
+}
+
+var historyOpts historyOptions
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List, prune or remove the tags of a merged Dataset file",
+	Long: `Inspect and edit the tag history of a Dataset file written by vizb merge.
+
+Removing a tag drops its history entry and the data points carrying it on the
+tag dimension, so charts and history stay consistent. The tag dimension is
+inferred from the data unless -A names it. Files are rewritten in place
+unless -o names a new path.`,
+}
+
+var historyListCmd = &cobra.Command{
+	Use:   "list <file>",
+	Short: "List each dataset's tags, oldest first",
+	Args:  cobra.ExactArgs(1),
+	Run:   runHistoryList,
+}
+
+var historyPruneCmd = &cobra.Command{
+	Use:   "prune <file>",
+	Short: "Remove the tags a retention policy does not keep",
+	Long: `Remove the tags a retention policy does not keep. Rules combine as a
+union — a tag is kept when any rule keeps it — and each dataset's newest tag
+is always kept. --dry-run prints what would be removed and writes nothing.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runHistoryPrune,
+}
+
+var historyRmCmd = &cobra.Command{
+	Use:   "rm <file> <tag>...",
+	Short: "Remove tags; removing the newest promotes the one before it",
+	Args:  cobra.MinimumNArgs(2),
+	Run:   runHistoryRm,
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+	historyCmd.AddCommand(historyListCmd, historyPruneCmd, historyRmCmd)
+
+	historyCmd.PersistentFlags().StringVarP(&historyOpts.Name, "name", "n", "",
+		"Only act on the dataset with this name")
+	for _, c := range []*cobra.Command{historyPruneCmd, historyRmCmd} {
+		c.Flags().StringVarP(&historyOpts.OutputFile, "output", "o", "", "Write the result here instead of in place")
+		c.Flags().StringVarP(&historyOpts.TagAxis, "tag-axis", "A", "",
+			"Dimension the tags were merged onto (n, x, y, z); inferred when omitted")
+		c.Flags().BoolVar(&historyOpts.DryRun, "dry-run", false, "Print the changes without writing")
+	}
+	historyOpts.Retention.register(historyPruneCmd.Flags())
+}
+
+func runHistoryList(cmd *cobra.Command, args []string) {
+	datasets := readHistoryFile(args[0])
+	out := cmd.OutOrStdout()
+	for i, ds := range selectHistoryDatasets(datasets) {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		writeHistoryTable(out, ds)
+	}
+}
+
+// writeHistoryTable prints one dataset's runs, marking the current tag.
+func writeHistoryTable(out io.Writer, ds *shared.Dataset) {
+	fmt.Fprintf(out, "%s\n", historyDatasetName(ds))
+	runs := ds.Runs()
+	if len(runs) == 0 {
+		fmt.Fprintln(out, "  (untagged)")
+		return
+	}
+	points := tagPointCounts(ds, historyDimension(ds))
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "  TAG\tTIMESTAMP\tCOMMIT\tPOINTS\t")
+	for i, run := range runs {
+		tag := run.Tag
+		if i == len(runs)-1 {
+			tag += " *"
+		}
+		commit := "-"
+		if run.Commit != nil {
+			commit = run.Commit.SHA[:min(7, len(run.Commit.SHA))]
+			if run.Commit.Subject != "" {
+				commit += " " + run.Commit.Subject
+			}
+		}
+		fmt.Fprintf(tw, "  %s\t%s\t%s\t%d\t\n", tag, orDash(run.Timestamp), commit, points[run.Tag])
+	}
+	_ = tw.Flush()
+}
+
+// tagPointCounts counts the data points carrying each value on dim.
+func tagPointCounts(ds *shared.Dataset, dim shared.Dimension) map[string]int {
+	counts := map[string]int{}
+	for _, p := range ds.Data {
+		counts[dim.Value(p)]++
+	}
+	return counts
+}
+
+func runHistoryPrune(cmd *cobra.Command, args []string) {
+	retention := historyOpts.Retention.retention()
+	if retention.IsZero() {
+		shared.ExitWithError("Nothing to prune: set --keep-last, --keep-within or --thin-after", nil)
+	}
+	editHistory(cmd, args[0], func(ds *shared.Dataset, dim shared.Dimension) ([]string, error) {
+		pruned, removed := shared.ApplyRetention(*ds, dim, retention)
+		*ds = pruned
+		return removed, nil
+	})
+}
+
+func runHistoryRm(cmd *cobra.Command, args []string) {
+	tags := args[1:]
+	found := map[string]bool{}
+	editHistory(cmd, args[0], func(ds *shared.Dataset, dim shared.Dimension) ([]string, error) {
+		var present []string
+		for _, run := range ds.Runs() {
+			if slices.Contains(tags, run.Tag) {
+				present = append(present, run.Tag)
+				found[run.Tag] = true
+			}
+		}
+		if len(present) == 0 {
+			return nil, nil
+		}
+		out, err := shared.RemoveTags(*ds, dim, present)
+		if err != nil {
+			return nil, err
+		}
+		*ds = out
+		return present, nil
+	})
+	var missing []string
+	for _, tag := range tags {
+		if !found[tag] {
+			missing = append(missing, tag)
+		}
+	}
+	if len(missing) > 0 {
+		cliout.Warnf("no dataset has tag %s", strings.Join(missing, ", "))
+	}
+}
+
+// editHistory applies edit to each selected dataset, reports the removed tags
+// and, unless --dry-run, writes the file back.
+func editHistory(cmd *cobra.Command, file string, edit func(*shared.Dataset, shared.Dimension) ([]string, error)) {
+	utils.ApplyValidationRules([]utils.ValidationRule{{
+		Label:    "tag axis",
+		Value:    &historyOpts.TagAxis,
+		ValidSet: []string{"n", "x", "y", "z"},
+		Default:  "",
+	}})
+
+	datasets := readHistoryFile(file)
+	out := cmd.OutOrStdout()
+	changed := false
+	for _, ds := range selectHistoryDatasets(datasets) {
+		dim := historyDimension(ds)
+		points := tagPointCounts(ds, dim)
+		removed, err := edit(ds, dim)
+		if err != nil {
+			shared.ExitWithError(historyDatasetName(ds), err)
+		}
+		if len(removed) == 0 {
+			continue
+		}
+		changed = true
+		verb := "Removed"
+		if historyOpts.DryRun {
+			verb = "Would remove"
+		}
+		fmt.Fprintf(out, "%s: %s %s\n", historyDatasetName(ds), verb, strings.Join(removed, ", "))
+		var untracked []string
+		for _, tag := range removed {
+			if points[tag] == 0 {
+				untracked = append(untracked, tag)
+			}
+		}
+		if len(untracked) > 0 {
+			cliout.Warnf("%s: no data points carry %s on the %s axis; only history entries change (see -A)",
+				historyDatasetName(ds), strings.Join(untracked, ", "), dim.AxisKey())
+		}
+	}
+
+	if historyOpts.DryRun {
+		return
+	}
+	if !changed && historyOpts.OutputFile == "" {
+		cliout.InfoPair(file, "no tags removed")
+		return
+	}
+	target := file
+	if historyOpts.OutputFile != "" {
+		target = historyOpts.OutputFile
+	}
+	if err := writeHistoryFile(file, target, datasets); err != nil {
+		shared.ExitWithError("Failed to write "+target, err)
+	}
+	cliout.InfoPair("Updated", target)
+}
+
+func readHistoryFile(file string) []shared.Dataset {
+	datasets, err := cli.ParseDatasetFile(file)
+	if err != nil {
+		shared.ExitWithError(file, err)
+	}
+	return datasets
+}
+
+// selectHistoryDatasets returns pointers to the datasets --name selects, or
+// all of them.
+func selectHistoryDatasets(datasets []shared.Dataset) []*shared.Dataset {
+	var selected []*shared.Dataset
+	for i := range datasets {
+		if historyOpts.Name == "" || datasets[i].Name == historyOpts.Name {
+			selected = append(selected, &datasets[i])
+		}
+	}
+	if len(selected) == 0 {
+		shared.ExitWithError(fmt.Sprintf("No dataset named %q", historyOpts.Name), nil)
+	}
+	return selected
+}
+
+// historyDimension is -A when given, else the dimension the tags sit on.
+func historyDimension(ds *shared.Dataset) shared.Dimension {
+	if historyOpts.TagAxis != "" {
+		return shared.Dimension(historyOpts.TagAxis)
+	}
+	if dim, ok := ds.TagDimension(); ok {
+		return dim
+	}
+	return shared.DimensionName
+}
+
+func historyDatasetName(ds *shared.Dataset) string {
+	if ds.Name == "" {
+		return "(unnamed)"
+	}
+	return ds.Name
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// writeHistoryFile writes datasets to target in the source file's layout: a
+// single object stays an object and an indented file stays indented.
+func writeHistoryFile(source, target string, datasets []shared.Dataset) error {
+	before, err := os.ReadFile(source)
+	if err != nil {
+		return err
+	}
+	trimmed := bytes.TrimSpace(before)
+	var doc any = datasets
+	if len(trimmed) > 0 && trimmed[0] == '{' && len(datasets) == 1 {
+		doc = datasets[0]
+	}
+	var after []byte
+	if bytes.ContainsRune(trimmed, '\n') {
+		after, err = json.MarshalIndent(doc, "", "  ")
+	} else {
+		after, err = json.Marshal(doc)
+	}
+	if err != nil {
+		return err
+	}
+	if bytes.HasSuffix(before, []byte("\n")) {
+		after = append(after, '\n')
+	}
+	return writeMigrated(source, target, after)
+}
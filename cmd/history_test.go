@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goptics/vizb/shared"
+	"github.com/goptics/vizb/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+// HistorySuite covers the history subcommands end-to-end via rootCmd.Execute.
+type HistorySuite struct {
+	suite.Suite
+	restoreOsExit func()
+	exitCalled    *bool
+	out           *bytes.Buffer
+}
+
+func (s *HistorySuite) SetupTest() {
+	ResetTestState()
+	s.restoreOsExit, s.exitCalled = testutil.TrapOsExitPanic(s.T())
+	s.out = &bytes.Buffer{}
+	rootCmd.SetOut(s.out)
+}
+
+func (s *HistorySuite) TearDownTest() {
+	rootCmd.SetOut(nil)
+	s.restoreOsExit()
+}
+
+// writeMerged merges one run per tag onto the x dimension and writes the
+// result as a single indented object.
+func (s *HistorySuite) writeMerged(tags ...string) string {
+	var runs []shared.Dataset
+	for i, tag := range tags {
+		runs = append(runs, shared.Dataset{
+			Name:      "Bench",
+			Tag:       tag,
+			Timestamp: "2026-05-0" + string(rune('1'+i)) + "T00:00:00Z",
+			Axes:      []shared.Axis{{Key: "name"}, {Key: "x"}},
+			Data:      []shared.DataPoint{{Name: "Sort"}, {Name: "Search"}},
+		})
+	}
+	merged := shared.MergeDatasets(runs, shared.DimensionXAxis)
+	s.Require().Len(merged, 1)
+	b, err := json.MarshalIndent(merged[0], "", "  ")
+	s.Require().NoError(err)
+	path := filepath.Join(s.T().TempDir(), "merged.json")
+	s.Require().NoError(os.WriteFile(path, append(b, '\n'), 0o600))
+	return path
+}
+
+func (s *HistorySuite) read(path string) shared.Dataset {
+	content, err := os.ReadFile(path)
+	s.Require().NoError(err)
+	var ds shared.Dataset
+	s.Require().NoError(json.Unmarshal(content, &ds), "single object stays an object")
+	return ds
+}
+
+func (s *HistorySuite) TestList() {
+	path := s.writeMerged("v1", "v2", "v3")
+	rootCmd.SetArgs([]string{"history", "list", path})
+	s.Require().NoError(rootCmd.Execute())
+
+	out := s.out.String()
+	s.Contains(out, "Bench\n")
+	s.Regexp(`v1\s+2026-05-01T00:00:00Z\s+-\s+2`, out)
+	s.Contains(out, "v3 *")
+}
+
+func (s *HistorySuite) TestPruneKeepLast() {
+	path := s.writeMerged("v1", "v2", "v3", "v4")
+	rootCmd.SetArgs([]string{"history", "prune", path, "--keep-last", "2"})
+	s.Require().NoError(rootCmd.Execute())
+
+	s.Contains(s.out.String(), "Bench: Removed v1, v2")
+	ds := s.read(path)
+	s.Equal([]string{"v3", "v4"}, ds.Tags())
+	s.Len(ds.Data, 4)
+	for _, p := range ds.Data {
+		s.Contains([]string{"v3", "v4"}, p.XAxis)
+	}
+}
+
+func (s *HistorySuite) TestPruneDryRunWritesNothing() {
+	path := s.writeMerged("v1", "v2", "v3")
+	before, err := os.ReadFile(path)
+	s.Require().NoError(err)
+
+	rootCmd.SetArgs([]string{"history", "prune", path, "--keep-last", "1", "--pin", "v1", "--dry-run"})
+	s.Require().NoError(rootCmd.Execute())
+
+	s.Contains(s.out.String(), "Bench: Would remove v2")
+	after, err := os.ReadFile(path)
+	s.Require().NoError(err)
+	s.Equal(before, after)
+}
+
+func (s *HistorySuite) TestPruneWithoutRulesExits() {
+	path := s.writeMerged("v1", "v2")
+	for _, args := range [][]string{
+		{"history", "prune", path},
+		{"history", "prune", path, "--pin", "v1"},
+	} {
+		ResetTestState()
+		*s.exitCalled = false
+		rootCmd.SetArgs(args)
+		s.Panics(func() { _ = rootCmd.Execute() }, args)
+		s.True(*s.exitCalled, args)
+	}
+	ds := s.read(path)
+	s.Equal([]string{"v1", "v2"}, ds.Tags())
+}
+
+func (s *HistorySuite) TestPruneRejectsBadAge() {
+	path := s.writeMerged("v1", "v2")
+	rootCmd.SetArgs([]string{"history", "prune", path, "--keep-within", "soon"})
+	s.Panics(func() { _ = rootCmd.Execute() })
+	s.True(*s.exitCalled)
+}
+
+func (s *HistorySuite) TestRmCurrentTagToOutput() {
+	path := s.writeMerged("v1", "v2", "v3")
+	out := filepath.Join(filepath.Dir(path), "out.json")
+	rootCmd.SetArgs([]string{"history", "rm", path, "v3", "-o", out})
+	s.Require().NoError(rootCmd.Execute())
+
+	ds := s.read(out)
+	s.Equal("v2", ds.Tag)
+	s.Equal("2026-05-02T00:00:00Z", ds.Timestamp)
+	s.Equal([]string{"v1", "v2"}, ds.Tags())
+	s.Len(ds.Data, 4)
+	src := s.read(path)
+	s.Equal([]string{"v1", "v2", "v3"}, src.Tags(), "source is untouched")
+}
+
+func (s *HistorySuite) TestRmEveryTagExits() {
+	path := s.writeMerged("v1", "v2")
+	rootCmd.SetArgs([]string{"history", "rm", path, "v1", "v2"})
+	s.Panics(func() { _ = rootCmd.Execute() })
+	s.True(*s.exitCalled)
+}
+
+func (s *HistorySuite) TestRmUnknownNameExits() {
+	path := s.writeMerged("v1", "v2")
+	rootCmd.SetArgs([]string{"history", "rm", path, "v1", "--name", "Other"})
+	s.Panics(func() { _ = rootCmd.Execute() })
+	s.True(*s.exitCalled)
+}
+
+func TestHistorySuite(t *testing.T) {
+	suite.Run(t, new(HistorySuite))
+}
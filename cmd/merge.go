@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 
 	"github.com/goptics/vizb/cmd/cli"
+	"github.com/goptics/vizb/internal/gitrepo"
 	"github.com/goptics/vizb/pkg/cliout"
 	"github.com/goptics/vizb/pkg/core"
 	"github.com/goptics/vizb/shared"
@@ -17,6 +18,8 @@ import (
 type mergeOptions struct {
 	OutputFile string
 	TagAxis    string
+	Retention  retentionFlags
+	Reconcile  reconcileFlags
 }
 
 var mergeOpts mergeOptions
@@ -26,7 +29,17 @@ var mergeCmd = &cobra.Command{
 	Use:   "merge [files/directories...]",
 	Short: "Merge multiple Dataset JSON files",
 	Long: `Merge Dataset JSON files (or directories of them) into one report.
-Tags from each dataset are injected onto the chosen dimension.`,
+Tags from each dataset are injected onto the chosen dimension.
+
+The retention flags prune each merged dataset's tags, removing their data and
+history entries together. Rules combine as a union — a tag is kept when any
+rule keeps it — and the newest tag is always kept. Without them every tag is
+kept.
+
+--alias, --rename-axis and --rename-stat (or a --reconcile file) map names
+that changed between releases onto their current form first, so renamed
+benchmarks keep one history. Inputs that still disagree on axes or units, or
+runs replaced by newer ones, are reported as warnings.`,
 	Run: runMerge,
 }
 
@@ -35,6 +48,8 @@ func init() {
 	mergeCmd.Flags().StringVarP(&mergeOpts.OutputFile, "output", "o", "", "Output path (.html or .json)")
 	mergeCmd.Flags().StringVarP(&mergeOpts.TagAxis, "tag-axis", "A", "n",
 		"Dimension for tags (n, x, y, z)")
+	mergeOpts.Retention.register(mergeCmd.Flags())
+	mergeOpts.Reconcile.register(mergeCmd.Flags())
 }
 
 func runMerge(cmd *cobra.Command, args []string) {
@@ -54,16 +69,33 @@ func runMerge(cmd *cobra.Command, args []string) {
 		shared.ExitWithError("No valid files found to merge", nil)
 	}
 
-	dataSets := readFiles(files)
+	opts := core.MergeOptions{
+		Dimension: shared.Dimension(mergeOpts.TagAxis),
+		Retention: mergeOpts.Retention.retention(),
+		Reconcile: mergeOpts.Reconcile.reconcile(),
+	}
+
+	dataSets, sources := readFiles(files)
 	if len(dataSets) == 0 {
 		shared.ExitWithError("No valid data set files processed", nil)
 	}
+	opts.Sources = sources
 
-	writeMergeOutput(mergeDatasets(dataSets, shared.Dimension(mergeOpts.TagAxis)))
+	writeMergeOutput(mergeDatasets(dataSets, opts))
 }
 
-func mergeDatasets(dataSets []shared.Dataset, dimension shared.Dimension) []shared.Dataset {
-	merged, err := core.Merge(dataSets, dimension)
+// mergeDatasets merges in the git repository around the working directory, if
+// any, so runs tagged with --tag auto order by commit ancestry. Conflicts
+// between the inputs and rename rules that match nothing are printed as
+// warnings.
+func mergeDatasets(dataSets []shared.Dataset, opts core.MergeOptions) []shared.Dataset {
+	opts.OnConflict = func(c shared.MergeConflict) { cliout.Warn(c.String()) }
+	opts.OnWarning = cliout.Warn
+	if repo, err := gitrepo.Open("."); err == nil {
+		defer repo.Close()
+		opts.IsAncestor = repo.IsAncestor
+	}
+	merged, err := core.MergeWithOptions(dataSets, opts)
 	if err != nil {
 		shared.ExitWithError(err.Error(), nil)
 	}
@@ -93,8 +125,11 @@ func collectJSONFiles(args []string) []string {
 	return files
 }
 
-func readFiles(files []string) []shared.Dataset {
+// readFiles parses every file, skipping unreadable ones with a warning, and
+// returns the datasets with the file each came from.
+func readFiles(files []string) ([]shared.Dataset, []string) {
 	var dataSets []shared.Dataset
+	var sources []string
 	for _, file := range files {
 		parsed, err := cli.ParseDatasetFile(file)
 		if err != nil {
@@ -102,8 +137,11 @@ func readFiles(files []string) []shared.Dataset {
 			continue
 		}
 		dataSets = append(dataSets, parsed...)
+		for range parsed {
+			sources = append(sources, file)
+		}
 	}
-	return dataSets
+	return dataSets, sources
 }
 
 func writeMergeOutput(dataSets []shared.Dataset) {
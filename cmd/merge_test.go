@@ -2,10 +2,12 @@ package cmd
 
 import (
 	"encoding/json"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
 
+	"github.com/goptics/vizb/pkg/core"
 	"github.com/goptics/vizb/shared"
 	"github.com/goptics/vizb/testutil"
 	"github.com/stretchr/testify/suite"
@@ -166,10 +168,126 @@ func (s *MergeSuite) TestMergeNoValidFilesExits() {
 
 func (s *MergeSuite) TestMergeDatasetsCoreErrorExits() {
 	s.Panics(func() {
-		mergeDatasets([]shared.Dataset{{Name: "Bench"}}, shared.Dimension("invalid"))
+		mergeDatasets([]shared.Dataset{{Name: "Bench"}}, core.MergeOptions{Dimension: "invalid"})
 	})
 }
 
+func (s *MergeSuite) TestMergeRetentionPrunesTags() {
+	dir := s.T().TempDir()
+	for i, tag := range []string{"v1", "v2", "v3"} {
+		testutil.WriteJSON(s.T(), filepath.Join(dir, tag+".json"), shared.Dataset{
+			Name: "Bench", Tag: tag, Timestamp: "2026-05-0" + string(rune('1'+i)) + "T00:00:00Z",
+			Data: []shared.DataPoint{{XAxis: "sort"}},
+		})
+	}
+
+	out := filepath.Join(dir, "merged.json")
+	rootCmd.SetArgs([]string{"merge", "-o", out, "--keep-last", "1", "--pin", "v1", dir})
+	s.Require().NoError(rootCmd.Execute())
+
+	parsed := s.readDatasets(out)
+	s.Require().Len(parsed, 1)
+	s.Equal([]string{"v1", "v3"}, parsed[0].Tags())
+	s.Equal([]string{"v1", "v3"}, []string{parsed[0].Data[0].Name, parsed[0].Data[1].Name})
+}
+
+func (s *MergeSuite) TestMergeAliasJoinsRenamedBenchmark() {
+	dir := s.T().TempDir()
+	testutil.WriteJSON(s.T(), filepath.Join(dir, "v1.json"), shared.Dataset{
+		Name: "BenchmarkOld", Tag: "v1", Timestamp: "2026-05-01T00:00:00Z",
+		Data: []shared.DataPoint{{XAxis: "sort", Stats: []shared.Stat{{Type: "Allocs", Value: shared.F64(1)}}}},
+	})
+	testutil.WriteJSON(s.T(), filepath.Join(dir, "v2.json"), shared.Dataset{
+		Name: "BenchmarkNew", Tag: "v2", Timestamp: "2026-05-02T00:00:00Z",
+		Data: []shared.DataPoint{{XAxis: "sort", Stats: []shared.Stat{{Type: "Allocations", Value: shared.F64(2)}}}},
+	})
+
+	out := filepath.Join(dir, "merged.json")
+	rootCmd.SetArgs([]string{"merge", "-o", out,
+		"--alias", "BenchmarkOld=BenchmarkNew", "--rename-stat", "Allocs=Allocations", dir})
+	s.Require().NoError(rootCmd.Execute())
+
+	parsed := s.readDatasets(out)
+	s.Require().Len(parsed, 1)
+	s.Equal("BenchmarkNew", parsed[0].Name)
+	s.Equal([]string{"v1", "v2"}, parsed[0].Tags())
+	for _, p := range parsed[0].Data {
+		s.Equal("Allocations", p.Stats[0].Type)
+	}
+}
+
+func (s *MergeSuite) TestMergeAliasMatchesGoBenchmarkNamesAndWarnsOnTypos() {
+	dir := s.T().TempDir()
+	for i, name := range []string{"Old", "New"} {
+		testutil.WriteJSON(s.T(), filepath.Join(dir, name+".json"), shared.Dataset{
+			Name: "Benchmarks", Tag: fmt.Sprintf("v%d", i+1), Timestamp: fmt.Sprintf("2026-05-0%dT00:00:00Z", i+1),
+			Data: []shared.DataPoint{{Name: name, XAxis: "1024", Stats: []shared.Stat{{Type: "ns", Value: shared.F64(1)}}}},
+		})
+	}
+
+	out := filepath.Join(dir, "merged.json")
+	stderr := testutil.CaptureStderr(func() {
+		rootCmd.SetArgs([]string{"merge", "-o", out, "-A", "x",
+			"--alias", "BenchmarkOld=BenchmarkNew", "--rename-axis", "n=size", "--rename-stat", "Alocs=Allocations", dir})
+		s.Require().NoError(rootCmd.Execute())
+	})
+
+	parsed := s.readDatasets(out)
+	s.Require().Len(parsed, 1)
+	for _, p := range parsed[0].Data {
+		s.Equal("New", p.Name, "the Go parser stores BenchmarkOld as Old")
+	}
+	s.NotContains(stderr, `alias "BenchmarkOld"`)
+	s.Contains(stderr, `axis label "n" matches nothing`)
+	s.Contains(stderr, `stat "Alocs" matches nothing`)
+}
+
+func (s *MergeSuite) TestMergeReconcileFileWithFlagOverride() {
+	dir := s.T().TempDir()
+	inputs := filepath.Join(dir, "in")
+	s.Require().NoError(os.Mkdir(inputs, 0o755))
+	for _, name := range []string{"A", "B"} {
+		testutil.WriteJSON(s.T(), filepath.Join(inputs, name+".json"), shared.Dataset{
+			Name: name, Data: []shared.DataPoint{{XAxis: "sort"}},
+			Axes: []shared.Axis{{Key: "x", Label: "n"}},
+		})
+	}
+	rules := filepath.Join(dir, "rules.yaml")
+	s.Require().NoError(os.WriteFile(rules,
+		[]byte("aliases:\n  A: Old\n  B: New\naxisLabels:\n  n: size\n"), 0o644))
+
+	out := filepath.Join(dir, "merged.json")
+	rootCmd.SetArgs([]string{"merge", "-o", out, "--reconcile", rules, "--alias", "A=New", inputs})
+	s.Require().NoError(rootCmd.Execute())
+
+	parsed := s.readDatasets(out)
+	s.Require().Len(parsed, 1, "--alias overrides the file's A: Old")
+	s.Equal("New", parsed[0].Name)
+	s.Equal("size", parsed[0].Axes[0].Label)
+}
+
+func (s *MergeSuite) TestMergeReconcileRejectsBadRules() {
+	dir := s.T().TempDir()
+	testutil.WriteJSON(s.T(), filepath.Join(dir, "a.json"), shared.Dataset{Name: "A"})
+	typo := filepath.Join(dir, "rules.json")
+	s.Require().NoError(os.WriteFile(typo, []byte(`{"alias": {"A": "B"}}`), 0o644))
+
+	for _, args := range [][]string{
+		{"--alias", "A"},
+		{"--alias", "A=B", "--alias", "B=A"},
+		{"--reconcile", typo},
+		{"--reconcile", filepath.Join(dir, "missing.yaml")},
+	} {
+		ResetTestState()
+		restore, exitCalled := testutil.TrapOsExitPanic(s.T())
+		rootCmd.SetArgs(append(append([]string{"merge", "-o", filepath.Join(dir, "out.json")}, args...),
+			filepath.Join(dir, "a.json")))
+		s.Panics(func() { _ = rootCmd.Execute() }, args)
+		s.True(*exitCalled, args)
+		restore()
+	}
+}
+
 func (s *MergeSuite) readDatasets(path string) []shared.Dataset {
 	content, err := os.ReadFile(path)
 	s.Require().NoError(err)
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/goptics/vizb/cmd/cli"
+	internal_charts "github.com/goptics/vizb/internal/charts"
+	"github.com/goptics/vizb/pkg/cliout"
+	"github.com/goptics/vizb/shared"
+	"github.com/spf13/cobra"
+)
+
+// migrateOptions holds the flags for the migrate subcommand.
+type migrateOptions struct {
+	OutputFile string
+	DryRun     bool
+}
+
+var migrateOpts migrateOptions
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate [files...]",
+	Short: "Upgrade Dataset JSON files to the current schema version",
+	Long: fmt.Sprintf(`Upgrade Vizb Dataset JSON files (single object or array) written by older
+releases to schemaVersion %d, running each pending migration step in order.
+
+Files are rewritten in place unless -o names a new path (one input only).
+--dry-run prints what would change, one line per JSON Pointer, and writes
+nothing. Files that are already current are left untouched.`, shared.CurrentSchemaVersion),
+	Args: cobra.MinimumNArgs(1),
+	Run:  runMigrate,
+}
+
+func init() {
+	rootCmd.AddCommand(migrateCmd)
+	migrateCmd.Flags().StringVarP(&migrateOpts.OutputFile, "output", "o", "",
+		"Write the upgraded file here instead of in place (single input only)")
+	migrateCmd.Flags().BoolVar(&migrateOpts.DryRun, "dry-run", false, "Print the changes without writing")
+}
+
+func runMigrate(cmd *cobra.Command, args []string) {
+	if migrateOpts.OutputFile != "" && len(args) > 1 {
+		shared.ExitWithError("-o takes a single input file", nil)
+	}
+
+	failed := 0
+	for _, file := range args {
+		before, after, applied, err := migrateFile(file)
+		if err != nil {
+			cliout.Warnf("%s: %v", file, err)
+			failed++
+			continue
+		}
+		changes, err := cli.DiffJSON(before, after)
+		if err != nil {
+			cliout.Warnf("%s: %v", file, err)
+			failed++
+			continue
+		}
+
+		if migrateOpts.DryRun {
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "%s: %s\n", file, migrateSummary(changes, applied))
+			for _, line := range changes {
+				fmt.Fprintln(out, "  "+line)
+			}
+			continue
+		}
+
+		target := file
+		if migrateOpts.OutputFile != "" {
+			target = migrateOpts.OutputFile
+		} else if len(changes) == 0 {
+			cliout.InfoPair(file, migrateSummary(changes, applied))
+			continue
+		}
+		if err := writeMigrated(file, target, after); err != nil {
+			cliout.Warnf("%s: %v", target, err)
+			failed++
+			continue
+		}
+		cliout.InfoPair(target, migrateSummary(changes, applied))
+	}
+
+	if failed > 0 {
+		shared.ExitWithError(fmt.Sprintf("%d of %d files could not be migrated", failed, len(args)), nil)
+	}
+}
+
+// migrateFile reads a Dataset file and returns its bytes before and after
+// migrating every dataset in it, plus the descriptions of the steps that
+// changed something (each once, in version order). The upgraded document
+// keeps the input's layout: an object stays an object, and an indented file
+// is written indented.
+func migrateFile(file string) (before, after []byte, applied []string, err error) {
+	before, err = os.ReadFile(file)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("cannot read file: %w", err)
+	}
+	trimmed := bytes.TrimSpace(before)
+	if len(trimmed) == 0 {
+		return nil, nil, nil, fmt.Errorf("file is empty")
+	}
+
+	var raws []json.RawMessage
+	switch trimmed[0] {
+	case '[':
+		if err := json.Unmarshal(trimmed, &raws); err != nil {
+			return nil, nil, nil, fmt.Errorf("invalid data set array: %w", err)
+		}
+	case '{':
+		raws = []json.RawMessage{trimmed}
+	default:
+		return nil, nil, nil, fmt.Errorf("not valid JSON")
+	}
+
+	datasets := make([]shared.Dataset, len(raws))
+	seen := map[int]bool{}
+	for i, raw := range raws {
+		if err := json.Unmarshal(raw, &datasets[i]); err != nil {
+			return nil, nil, nil, fmt.Errorf("invalid data set: %w", err)
+		}
+		if v := datasets[i].SchemaVersion; v > shared.CurrentSchemaVersion {
+			return nil, nil, nil, fmt.Errorf("schemaVersion %d is newer than this vizb supports (%d); upgrade vizb",
+				v, shared.CurrentSchemaVersion)
+		}
+		for _, m := range shared.MigrateDataset(&datasets[i], raw) {
+			seen[m.Version] = true
+		}
+		// The schema requires these arrays; write [] rather than null.
+		ds := &datasets[i]
+		if ds.Axes == nil {
+			ds.Axes = []shared.Axis{}
+		}
+		if ds.Settings == nil {
+			ds.Settings = []internal_charts.ChartConfig{}
+		}
+		if ds.Data == nil {
+			ds.Data = []shared.DataPoint{}
+		}
+	}
+	for _, m := range shared.Migrations() {
+		if seen[m.Version] {
+			applied = append(applied, m.Description)
+		}
+	}
+
+	var doc any = datasets
+	if trimmed[0] == '{' {
+		doc = datasets[0]
+	}
+	if bytes.ContainsRune(trimmed, '\n') {
+		after, err = json.MarshalIndent(doc, "", "  ")
+	} else {
+		after, err = json.Marshal(doc)
+	}
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("marshal migrated data set: %w", err)
+	}
+	if bytes.HasSuffix(before, []byte("\n")) {
+		after = append(after, '\n')
+	}
+	return before, after, applied, nil
+}
+
+// migrateSummary is the one-line report for a file.
+func migrateSummary(changes, applied []string) string {
+	switch {
+	case len(changes) == 0:
+		return fmt.Sprintf("already at schemaVersion %d", shared.CurrentSchemaVersion)
+	case len(applied) == 0:
+		return fmt.Sprintf("stamped schemaVersion %d", shared.CurrentSchemaVersion)
+	}
+	return fmt.Sprintf("upgraded to schemaVersion %d (%s)", shared.CurrentSchemaVersion, strings.Join(applied, "; "))
+}
+
+// writeMigrated writes content to target with the source file's permissions.
+func writeMigrated(source, target string, content []byte) error {
+	mode := os.FileMode(0o644)
+	if info, err := os.Stat(source); err == nil {
+		mode = info.Mode().Perm()
+	}
+	return os.WriteFile(target, content, mode)
+}
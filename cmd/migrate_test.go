@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/goptics/vizb/shared"
+	"github.com/goptics/vizb/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+// legacyDataset is a v0.12.0-era file: flat cpu/os, a theme string and the
+// settings object.
+const legacyDataset = `{
+  "name": "legacy",
+  "cpu": {"name": "Intel i7", "cores": 8},
+  "os": "linux",
+  "theme": "roma",
+  "settings": {"charts": ["bar", "pie"], "sort": {"enabled": true, "order": "desc"}, "showLabels": false, "scale": "linear"},
+  "data": [{"name": "a", "xAxis": "1", "stats": [{"type": "Execution Time (ns/op)", "value": 3}]}]
+}
+`
+
+// MigrateSuite covers the migrate subcommand end-to-end via rootCmd.Execute.
+type MigrateSuite struct {
+	suite.Suite
+	restoreOsExit func()
+	exitCalled    *bool
+}
+
+func (s *MigrateSuite) SetupTest() {
+	ResetTestState()
+	s.restoreOsExit, s.exitCalled = testutil.TrapOsExitPanic(s.T())
+}
+
+func (s *MigrateSuite) TearDownTest() {
+	s.restoreOsExit()
+}
+
+func (s *MigrateSuite) writeFile(name, content string) string {
+	path := filepath.Join(s.T().TempDir(), name)
+	s.Require().NoError(os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func (s *MigrateSuite) readDataset(path string) map[string]any {
+	content, err := os.ReadFile(path)
+	s.Require().NoError(err)
+	var doc map[string]any
+	s.Require().NoError(json.Unmarshal(content, &doc))
+	return doc
+}
+
+func (s *MigrateSuite) TestUpgradesInPlace() {
+	path := s.writeFile("old.json", legacyDataset)
+	rootCmd.SetArgs([]string{"migrate", path})
+	s.Require().NoError(rootCmd.Execute())
+
+	doc := s.readDataset(path)
+	s.EqualValues(shared.CurrentSchemaVersion, doc["schemaVersion"])
+	s.NotContains(doc, "cpu")
+	s.NotContains(doc, "theme")
+	s.Equal(map[string]any{"cpu": map[string]any{"name": "Intel i7", "cores": float64(8)}, "os": "linux"}, doc["meta"])
+	s.Len(doc["settings"], 2)
+	s.Len(doc["themes"], 1)
+
+	info, err := os.Stat(path)
+	s.Require().NoError(err)
+	s.Equal(os.FileMode(0o600), info.Mode().Perm(), "permissions are kept")
+
+	content, err := os.ReadFile(path)
+	s.Require().NoError(err)
+	s.Contains(string(content), "\n  \"name\": \"legacy\",", "indented input stays indented")
+}
+
+func (s *MigrateSuite) TestOutputLeavesSourceAlone() {
+	path := s.writeFile("old.json", legacyDataset)
+	out := filepath.Join(filepath.Dir(path), "new.json")
+	rootCmd.SetArgs([]string{"migrate", path, "-o", out})
+	s.Require().NoError(rootCmd.Execute())
+
+	source, err := os.ReadFile(path)
+	s.Require().NoError(err)
+	s.Equal(legacyDataset, string(source))
+	s.EqualValues(shared.CurrentSchemaVersion, s.readDataset(out)["schemaVersion"])
+}
+
+func (s *MigrateSuite) TestDryRunPrintsChangesWithoutWriting() {
+	path := s.writeFile("old.json", legacyDataset)
+	rootCmd.SetArgs([]string{"migrate", "--dry-run", path})
+	stdout := testutil.CaptureStdout(func() { s.Require().NoError(rootCmd.Execute()) })
+
+	s.Contains(stdout, "upgraded to schemaVersion 4 (flat cpu/os/arch/pkg fields → meta; "+
+		"v0.12.0 settings object → per-chart settings; theme string → themes; stat labels → unit/per)")
+	s.Contains(stdout, `  - /os: "linux"`)
+	s.Contains(stdout, `  + /schemaVersion: 4`)
+	s.Contains(stdout, `  + /data/0/stats/0/unit: "ns"`)
+	s.Contains(stdout, `  ~ /settings: {"charts":["bar","pie"]`)
+
+	source, err := os.ReadFile(path)
+	s.Require().NoError(err)
+	s.Equal(legacyDataset, string(source))
+}
+
+func (s *MigrateSuite) TestArrayKeepsShapeAndCompactLayout() {
+	path := s.writeFile("many.json",
+		`[{"name":"a","os":"linux","axes":[],"settings":[],"data":[]},{"schemaVersion":4,"name":"b","axes":[],"settings":[],"data":[]}]`)
+	rootCmd.SetArgs([]string{"migrate", path})
+	s.Require().NoError(rootCmd.Execute())
+
+	content, err := os.ReadFile(path)
+	s.Require().NoError(err)
+	s.NotContains(string(content), "\n")
+	var docs []map[string]any
+	s.Require().NoError(json.Unmarshal(content, &docs))
+	s.Require().Len(docs, 2)
+	s.Equal(map[string]any{"os": "linux"}, docs[0]["meta"])
+	s.EqualValues(shared.CurrentSchemaVersion, docs[1]["schemaVersion"])
+}
+
+func (s *MigrateSuite) TestCurrentFileIsLeftUntouched() {
+	const current = `{"schemaVersion":4,"name":"a","axes":[],"settings":[],"data":[]}`
+	path := s.writeFile("current.json", current)
+	rootCmd.SetArgs([]string{"migrate", path})
+	s.Require().NoError(rootCmd.Execute())
+
+	content, err := os.ReadFile(path)
+	s.Require().NoError(err)
+	s.Equal(current, string(content))
+}
+
+func (s *MigrateSuite) TestNewerSchemaFails() {
+	path := s.writeFile("future.json", `{"schemaVersion":99,"name":"a","axes":[],"settings":[],"data":[]}`)
+	rootCmd.SetArgs([]string{"migrate", path})
+	stderr := testutil.CaptureStderr(func() {
+		s.Panics(func() { _ = rootCmd.Execute() })
+	})
+	s.True(*s.exitCalled)
+	s.Contains(stderr, "schemaVersion 99 is newer than this vizb supports (4)")
+}
+
+func (s *MigrateSuite) TestOutputRequiresSingleInput() {
+	a := s.writeFile("a.json", legacyDataset)
+	b := s.writeFile("b.json", legacyDataset)
+	rootCmd.SetArgs([]string{"migrate", a, b, "-o", filepath.Join(s.T().TempDir(), "out.json")})
+	s.Panics(func() { _ = rootCmd.Execute() })
+	s.True(*s.exitCalled)
+}
+
+func TestMigrateSuite(t *testing.T) {
+	suite.Run(t, new(MigrateSuite))
+}
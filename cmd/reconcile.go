@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/goptics/vizb/shared"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// reconcileFlags are merge's rename flags. Entries given on the command line
+// override the same keys from --reconcile.
+type reconcileFlags struct {
+	File       string
+	Aliases    []string
+	AxisLabels []string
+	Stats      []string
+}
+
+func (f *reconcileFlags) register(fs *pflag.FlagSet) {
+	fs.StringVar(&f.File, "reconcile", "", "JSON or YAML file of aliases, axisLabels and stats renames")
+	fs.StringArrayVar(&f.Aliases, "alias", nil,
+		"Rename a benchmark or axis value before merging, old=new (repeatable; old=>new when a name contains '=')")
+	fs.StringArrayVar(&f.AxisLabels, "rename-axis", nil, "Rename an axis label before merging, old=new (repeatable)")
+	fs.StringArrayVar(&f.Stats, "rename-stat", nil, "Rename a stat type before merging, old=new (repeatable)")
+}
+
+func (f *reconcileFlags) reset() {
+	*f = reconcileFlags{}
+}
+
+// reconcile builds the rename rules, exiting on an unreadable file or a
+// malformed entry.
+func (f *reconcileFlags) reconcile() shared.Reconcile {
+	var r shared.Reconcile
+	if f.File != "" {
+		loaded, err := loadReconcileFile(f.File)
+		if err != nil {
+			shared.ExitWithError("--reconcile "+f.File, err)
+		}
+		r = loaded
+	}
+	fromFlags := shared.Reconcile{
+		Aliases:    parseRenameFlags("--alias", f.Aliases),
+		AxisLabels: parseRenameFlags("--rename-axis", f.AxisLabels),
+		Stats:      parseRenameFlags("--rename-stat", f.Stats),
+	}
+	r = r.With(fromFlags)
+	if err := r.Validate(); err != nil {
+		shared.ExitWithError("Invalid reconcile rules", err)
+	}
+	return r
+}
+
+func parseRenameFlags(flag string, values []string) map[string]string {
+	if len(values) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(values))
+	for _, raw := range values {
+		from, to, err := shared.ParseRenameRule(raw)
+		if err != nil {
+			shared.ExitWithError(flag, err)
+		}
+		out[from] = to
+	}
+	return out
+}
+
+// loadReconcileFile reads rename rules from JSON, or YAML for .yaml/.yml
+// files. Unknown keys are rejected so a typo doesn't silently rename nothing.
+func loadReconcileFile(path string) (shared.Reconcile, error) {
+	var r shared.Reconcile
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return r, err
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(content))
+		dec.KnownFields(true)
+		if err := dec.Decode(&r); err != nil {
+			return r, fmt.Errorf("invalid YAML: %w", err)
+		}
+	default:
+		dec := json.NewDecoder(bytes.NewReader(content))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&r); err != nil {
+			return r, fmt.Errorf("invalid JSON: %w", err)
+		}
+	}
+	return r, nil
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"github.com/goptics/vizb/shared"
+	"github.com/spf13/pflag"
+)
+
+// retentionFlags are the tag retention flags shared by merge and history
+// prune. Ages are strings so they can take days and weeks (see
+// shared.ParseAge).
+type retentionFlags struct {
+	KeepLast   int
+	KeepWithin string
+	ThinAfter  string
+	ThinEvery  string
+	Pin        []string
+}
+
+func (f *retentionFlags) register(fs *pflag.FlagSet) {
+	fs.IntVar(&f.KeepLast, "keep-last", 0, "Keep the newest N tags")
+	fs.StringVar(&f.KeepWithin, "keep-within", "", "Keep tags whose run is younger than this age (30d, 2w, 36h)")
+	fs.StringVar(&f.ThinAfter, "thin-after", "", "Keep only the newest tag per --thin-every period once runs are older than this age")
+	fs.StringVar(&f.ThinEvery, "thin-every", string(shared.ThinDay), "Period for --thin-after (day, week)")
+	fs.StringArrayVar(&f.Pin, "pin", nil, "Always keep tags matching this pattern, e.g. 'v*.0.0' (repeatable)")
+}
+
+func (f *retentionFlags) reset() {
+	*f = retentionFlags{ThinEvery: string(shared.ThinDay)}
+}
+
+// retention builds the policy, exiting on malformed ages or values.
+func (f *retentionFlags) retention() shared.Retention {
+	r := shared.Retention{
+		KeepLast:  f.KeepLast,
+		ThinEvery: shared.ThinPeriod(f.ThinEvery),
+		Pinned:    f.Pin,
+	}
+	var err error
+	if f.KeepWithin != "" {
+		if r.KeepWithin, err = shared.ParseAge(f.KeepWithin); err != nil {
+			shared.ExitWithError("--keep-within", err)
+		}
+	}
+	if f.ThinAfter != "" {
+		if r.ThinAfter, err = shared.ParseAge(f.ThinAfter); err != nil {
+			shared.ExitWithError("--thin-after", err)
+		}
+	}
+	if err := r.Validate(); err != nil {
+		shared.ExitWithError("Invalid retention", err)
+	}
+	return r
+}
@@ -11,17 +11,25 @@ import (
 	"github.com/goptics/vizb/internal/flags"
 	"github.com/goptics/vizb/pkg/cliout"
 
-	// Chart configs self-register into the charts registry and cli metadata
-	// via init() in cmd/charts/<c>; blank-importing them makes the registry
-	// (and thus the subcommands and --chart key set) complete.
+	// Chart configs self-register into the charts registry via
+	// internal/charts/all and into the cli metadata via init() in
+	// cmd/charts/<c>; blank-importing them makes the registry (and thus the
+	// subcommands and --chart key set) complete.
 	_ "github.com/goptics/vizb/cmd/charts/bar"
+	_ "github.com/goptics/vizb/cmd/charts/bump"
+	_ "github.com/goptics/vizb/cmd/charts/calendar"
 	_ "github.com/goptics/vizb/cmd/charts/chord"
 	_ "github.com/goptics/vizb/cmd/charts/heatmap"
+	_ "github.com/goptics/vizb/cmd/charts/histogram"
 	_ "github.com/goptics/vizb/cmd/charts/line"
+	_ "github.com/goptics/vizb/cmd/charts/parallel"
 	_ "github.com/goptics/vizb/cmd/charts/pie"
 	_ "github.com/goptics/vizb/cmd/charts/radar"
 	_ "github.com/goptics/vizb/cmd/charts/sankey"
 	_ "github.com/goptics/vizb/cmd/charts/scatter"
+	_ "github.com/goptics/vizb/cmd/charts/sunburst"
+	_ "github.com/goptics/vizb/cmd/charts/treemap"
+	_ "github.com/goptics/vizb/cmd/charts/waterfall"
 
 	// Parsers self-register into pkg/parser via their init().
 	_ "github.com/goptics/vizb/pkg/parser/csv"
@@ -29,6 +37,8 @@ import (
 	_ "github.com/goptics/vizb/pkg/parser/javascript"
 	_ "github.com/goptics/vizb/pkg/parser/json"
 	_ "github.com/goptics/vizb/pkg/parser/rust"
+	_ "github.com/goptics/vizb/pkg/parser/toml"
+	_ "github.com/goptics/vizb/pkg/parser/yaml"
 	"github.com/goptics/vizb/shared"
 	"github.com/goptics/vizb/shared/utils"
 	"github.com/goptics/vizb/version"
@@ -67,7 +77,8 @@ var rootCmd = &cobra.Command{
 without writing chart code. Reads a file or piped stdin, auto-detects the
 format (override with --parser), and writes one self-contained HTML report
 (or JSON). Default charts: bar, line, pie. Add scatter, heatmap, radar,
-sankey, or chord with --charts or a chart subcommand.`,
+sankey, chord, treemap, sunburst, histogram, parallel, calendar, bump, or
+waterfall with --charts or a chart subcommand.`,
 	Version: version.Version,
 	Args:    cobra.ArbitraryArgs,
 	Run:     runBenchmark,
@@ -79,14 +90,23 @@ func Execute() {
 	os.Args = cli.RewriteStatArg(os.Args)
 	os.Args = cli.RewriteObjectArg(os.Args)
 
-	if err := rootCmd.Execute(); err != nil {
-		shared.ExitWithError(err.Error(), nil)
+	if cmd, err := rootCmd.ExecuteC(); err != nil {
+		shared.ExitWithErrorCode(usageExitCode(cmd), err.Error(), nil)
+	}
+}
+
+// usageExitCode is the exit status for an argument or flag error on cmd.
+// diff keeps 1 for "files differ", so its usage errors exit 2 instead.
+func usageExitCode(cmd *cobra.Command) int {
+	if cmd == diffCmd {
+		return diffExitTrouble
 	}
+	return 1
 }
 
 func init() {
 	rootBag.Bind(rootCmd.Flags())
-	rootCmd.Flags().StringSliceVarP(&rootCharts, "charts", "c", defaultChartTypes, "Chart types to embed (bar, line, scatter, pie, heatmap, radar, sankey, chord)")
+	rootCmd.Flags().StringSliceVarP(&rootCharts, "charts", "c", defaultChartTypes, "Chart types to embed (bar, line, scatter, pie, heatmap, radar, sankey, chord, treemap, sunburst, histogram, parallel, calendar, bump, waterfall)")
 	rootCmd.Flags().StringArrayVar(&rootChartSpecs, "chart", nil,
 		"Per-chart override (type:key=val; repeatable; see docs)")
 
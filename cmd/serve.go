@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
 	"strconv"
 	"strings"
 	"syscall"
@@ -20,6 +21,7 @@ import (
 	"github.com/goptics/vizb/internal/flags"
 	"github.com/goptics/vizb/pkg/cliout"
 	"github.com/goptics/vizb/pkg/core"
+	"github.com/goptics/vizb/pkg/export"
 	"github.com/goptics/vizb/shared"
 	"github.com/spf13/cobra"
 )
@@ -70,6 +72,7 @@ func init() {
 // code.
 type restHandlers struct {
 	convert http.Handler
+	export  http.Handler
 	health  http.Handler
 	merge   http.Handler
 	ui      http.Handler
@@ -87,6 +90,7 @@ type restRouter struct {
 func newRESTHandler() http.Handler {
 	return composeRESTRoutes(restHandlers{
 		convert: http.HandlerFunc(handleConvert),
+		export:  http.HandlerFunc(handleExport),
 		health:  http.HandlerFunc(handleHealth),
 		merge:   http.HandlerFunc(handleMerge),
 		ui:      http.HandlerFunc(handleUI),
@@ -96,6 +100,7 @@ func newRESTHandler() http.Handler {
 func composeRESTRoutes(handlers restHandlers) http.Handler {
 	return restRouter{routes: map[string]restRoute{
 		"/":       {method: http.MethodPost, handler: handlers.convert},
+		"/export": {method: http.MethodPost, handler: handlers.export},
 		"/health": {method: http.MethodGet, handler: handlers.health},
 		"/merge":  {method: http.MethodPost, handler: handlers.merge},
 		"/ui":     {method: http.MethodPost, handler: handlers.ui},
@@ -349,6 +354,52 @@ func handleUIWithGenerator(
 	_, _ = io.WriteString(w, html)
 }
 
+func handleExport(w http.ResponseWriter, r *http.Request) {
+	var request exportRequest
+	if !decodeAPIRequest(w, r, &request) {
+		return
+	}
+	if !accepts(r, "application/json") {
+		writeAPIProblem(w, r, http.StatusNotAcceptable, "Not acceptable", "Accept must allow application/json")
+		return
+	}
+	format := export.FormatVegaLite
+	if request.Format != nil {
+		format = *request.Format
+	}
+	if !slices.Contains(export.Formats, format) {
+		writeValidationProblem(w, r, bodyValidationError("/format", "invalid_enum", "format must be vega-lite or echarts"))
+		return
+	}
+	datasets, validationErr := decodeDatasets(request.Datasets)
+	if validationErr != nil {
+		writeValidationProblem(w, r, *validationErr)
+		return
+	}
+	datasets, _, validationErr = applyUIOptions(datasets, request.Charts, nil)
+	if validationErr != nil {
+		writeValidationProblem(w, r, *validationErr)
+		return
+	}
+	ptrs := make([]*shared.Dataset, len(datasets))
+	for i := range datasets {
+		ptrs[i] = &datasets[i]
+	}
+	// Chart types without an exporter are left out, as on the CLI; only a
+	// request with nothing to export fails.
+	doc, _, err := export.Document(format, ptrs)
+	if errors.Is(err, export.ErrNoCharts) {
+		writeAPIProblem(w, r, http.StatusUnprocessableEntity, "Input processing failed", err.Error())
+		return
+	}
+	if err != nil {
+		writeInternalServerError(w, r, "export "+format, err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(doc)
+}
+
 func decodeAPIRequest(w http.ResponseWriter, r *http.Request, target any) bool {
 	contentType := r.Header.Get("Content-Type")
 	mediaType, _, err := mime.ParseMediaType(contentType)
@@ -6,7 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"regexp"
+	"maps"
 	"slices"
 	"sort"
 	"strings"
@@ -66,17 +66,21 @@ type convertRequest struct {
 	Themes []shared.Theme `json:"themes"`
 	// Theme is the legacy single theme name/spec; expanded into Themes when
 	// Themes is empty. Prefer Themes for new clients.
-	Theme       *string          `json:"theme"`
-	Description *string          `json:"description"`
-	Tag         *string          `json:"tag"`
-	Parser      *string          `json:"parser"`
-	Grouping    *groupingOptions `json:"grouping"`
-	Units       *unitOptions     `json:"units"`
-	Round       bool             `json:"round"`
-	Select      []string         `json:"select"`
-	JSONPath    string           `json:"jsonPath"`
-	Charts      chartSelection   `json:"charts"`
-	Output      *convertOutput   `json:"output"`
+	Theme       *string           `json:"theme"`
+	Description *string           `json:"description"`
+	Tag         *string           `json:"tag"`
+	Parser      *string           `json:"parser"`
+	Grouping    *groupingOptions  `json:"grouping"`
+	Units       *unitOptions      `json:"units"`
+	Round       bool              `json:"round"`
+	Select      []string          `json:"select"`
+	Better      map[string]string `json:"better"`
+	JSONPath    string            `json:"jsonPath"`
+	// JSONPathKeys matches --json-path-keys: the keys and indices the
+	// jsonPath wildcards walked become dimension columns.
+	JSONPathKeys bool           `json:"jsonPathKeys"`
+	Charts       chartSelection `json:"charts"`
+	Output       *convertOutput `json:"output"`
 }
 
 type convertOutput struct {
@@ -87,8 +91,8 @@ func (r *convertRequest) UnmarshalJSON(data []byte) error {
 	if err := rejectNullFields(data, "/", map[string]string{
 		"id": "/id", "name": "/name", "title": "/title", "themes": "/themes", "theme": "/theme",
 		"description": "/description", "tag": "/tag", "parser": "/parser", "grouping": "/grouping",
-		"units": "/units", "round": "/round", "select": "/select", "jsonPath": "/jsonPath",
-		"charts": "/charts", "output": "/output",
+		"units": "/units", "round": "/round", "select": "/select", "better": "/better", "jsonPath": "/jsonPath",
+		"jsonPathKeys": "/jsonPathKeys", "charts": "/charts", "output": "/output",
 	}); err != nil {
 		return err
 	}
@@ -246,6 +250,25 @@ func (r *uiRequest) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+type exportRequest struct {
+	Datasets json.RawMessage `json:"datasets"`
+	Format   *string         `json:"format"`
+	Charts   chartSelection  `json:"charts"`
+}
+
+func (r *exportRequest) UnmarshalJSON(data []byte) error {
+	if err := rejectNullFields(data, "/", map[string]string{"format": "/format"}); err != nil {
+		return err
+	}
+	type wire exportRequest
+	var decoded wire
+	if err := strictDecodeRequestObject(data, &decoded, ""); err != nil {
+		return err
+	}
+	*r = exportRequest(decoded)
+	return nil
+}
+
 type apiValidationError struct {
 	Location string `json:"location"`
 	Path     string `json:"path"`
@@ -345,8 +368,8 @@ func buildConvertInput(request convertRequest, input []byte) (core.ConvertInput,
 	if request.Parser != nil {
 		key = *request.Parser
 	}
-	if !slices.Contains([]string{"auto", "csv", "json", "go", "javascript", "rust"}, key) {
-		err := bodyValidationError("/parser", "invalid_enum", "parser must be one of auto, csv, json, go, javascript, or rust")
+	if !slices.Contains([]string{"auto", "csv", "json", "yaml", "toml", "go", "javascript", "rust"}, key) {
+		err := bodyValidationError("/parser", "invalid_enum", "parser must be one of auto, csv, json, yaml, toml, go, javascript, or rust")
 		return core.ConvertInput{}, nil, &err
 	}
 
@@ -484,7 +507,7 @@ func styleThemesToShared(themes []style.Theme) []shared.Theme {
 }
 
 func buildParserConfig(request convertRequest, key string) (parser.Config, *apiValidationError) {
-	cfg := parser.Config{GroupPattern: "x", MemUnit: "B", TimeUnit: "ns", JSONPath: request.JSONPath}
+	cfg := parser.Config{GroupPattern: "x", MemUnit: "B", TimeUnit: "ns", JSONPath: request.JSONPath, JSONPathKeys: request.JSONPathKeys}
 	if request.Grouping != nil {
 		if request.Grouping.Pattern != nil {
 			cfg.GroupPattern = *request.Grouping.Pattern
@@ -493,49 +516,10 @@ func buildParserConfig(request convertRequest, key string) (parser.Config, *apiV
 		cfg.Group = slices.Clone(request.Grouping.Columns)
 		cfg.Filter = request.Grouping.Filter
 		if request.Grouping.ColAxis != nil {
-			if !slices.Contains([]string{"n", "x", "y", "z"}, *request.Grouping.ColAxis) {
-				validationErr := bodyValidationError("/grouping/colAxis", "invalid_enum", "grouping colAxis must be one of n, x, y, or z")
-				return cfg, &validationErr
-			}
 			cfg.ColAxis = *request.Grouping.ColAxis
 		}
 	}
-	if err := parser.ValidateGroupPattern(cfg.GroupPattern); err != nil {
-		validationErr := bodyValidationError("/grouping/pattern", "invalid_value", err.Error())
-		return cfg, &validationErr
-	}
-	for i, column := range cfg.Group {
-		if strings.TrimSpace(column) == "" {
-			validationErr := bodyValidationError(fmt.Sprintf("/grouping/columns/%d", i), "min_length", "grouping columns must not be empty")
-			return cfg, &validationErr
-		}
-	}
-	if cfg.GroupRegex != "" {
-		if _, err := regexp.Compile(cfg.GroupRegex); err != nil {
-			validationErr := bodyValidationError("/grouping/regex", "invalid_regex", err.Error())
-			return cfg, &validationErr
-		}
-	}
-	if cfg.Filter != "" {
-		if _, err := regexp.Compile(cfg.Filter); err != nil {
-			validationErr := bodyValidationError("/grouping/filter", "invalid_regex", err.Error())
-			return cfg, &validationErr
-		}
-	}
-
 	if request.Units != nil {
-		if request.Units.Memory != nil && !slices.Contains([]string{"b", "B", "KB", "MB", "GB"}, *request.Units.Memory) {
-			validationErr := bodyValidationError("/units/memory", "invalid_enum", "memory unit must be one of b, B, KB, MB, or GB")
-			return cfg, &validationErr
-		}
-		if request.Units.Time != nil && !slices.Contains([]string{"ns", "us", "ms", "s"}, *request.Units.Time) {
-			validationErr := bodyValidationError("/units/time", "invalid_enum", "time unit must be one of ns, us, ms, or s")
-			return cfg, &validationErr
-		}
-		if request.Units.Number != nil && !slices.Contains([]string{"K", "M", "B", "T"}, *request.Units.Number) {
-			validationErr := bodyValidationError("/units/number", "invalid_enum", "number unit must be one of K, M, B, or T")
-			return cfg, &validationErr
-		}
 		if request.Units.Memory != nil {
 			cfg.MemUnit = *request.Units.Memory
 		}
@@ -546,6 +530,12 @@ func buildParserConfig(request convertRequest, key string) (parser.Config, *apiV
 			cfg.NumberUnit = *request.Units.Number
 		}
 	}
+	if err := core.ValidateConfig(cfg); err != nil {
+		var optionErr *core.OptionError
+		errors.As(err, &optionErr)
+		validationErr := configValidationError(optionErr)
+		return cfg, &validationErr
+	}
 	cfg.Round = request.Round
 
 	var err error
@@ -557,10 +547,48 @@ func buildParserConfig(request convertRequest, key string) (parser.Config, *apiV
 	if validationErr := applySelectOptions(&cfg, request.Select); validationErr != nil {
 		return cfg, validationErr
 	}
+	for _, name := range slices.Sorted(maps.Keys(request.Better)) {
+		d, err := shared.ParseDirection(request.Better[name])
+		if err != nil {
+			pointer := "/better/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+			validationErr := bodyValidationError(pointer, "invalid_enum",
+				"better direction must be one of lower, higher, or neutral")
+			return cfg, &validationErr
+		}
+		if cfg.Better == nil {
+			cfg.Better = map[string]shared.Direction{}
+		}
+		cfg.Better[name] = d
+	}
 	cfg.Mode = parser.ResolveMode(cfg)
 	return cfg, nil
 }
 
+// configValidationError points a core.ValidateConfig failure at the request
+// field it came from.
+func configValidationError(optionErr *core.OptionError) apiValidationError {
+	switch optionErr.Name {
+	case "groupPattern":
+		return bodyValidationError("/grouping/pattern", "invalid_value", optionErr.Error())
+	case "colAxis":
+		return bodyValidationError("/grouping/colAxis", "invalid_enum", optionErr.Error())
+	case "group":
+		return bodyValidationError(fmt.Sprintf("/grouping/columns/%d", optionErr.Index), "min_length", optionErr.Error())
+	case "groupRegex":
+		return bodyValidationError("/grouping/regex", "invalid_regex", optionErr.Error())
+	case "filter":
+		return bodyValidationError("/grouping/filter", "invalid_regex", optionErr.Error())
+	case "memUnit":
+		return bodyValidationError("/units/memory", "invalid_enum", optionErr.Error())
+	case "timeUnit":
+		return bodyValidationError("/units/time", "invalid_enum", optionErr.Error())
+	case "numberUnit":
+		return bodyValidationError("/units/number", "invalid_enum", optionErr.Error())
+	default:
+		return bodyValidationError("/grouping", "invalid_value", optionErr.Error())
+	}
+}
+
 func applySelectOptions(cfg *parser.Config, rawSelect []string) *apiValidationError {
 	if len(rawSelect) == 0 {
 		return nil
@@ -894,25 +922,29 @@ func applyUIOptions(datasets []shared.Dataset, selection chartSelection, statist
 }
 
 type datasetWire struct {
-	ID           string              `json:"id"`
-	Tag          string              `json:"tag"`
-	Timestamp    string              `json:"timestamp"`
-	Name         *string             `json:"name"`
-	Themes       []shared.Theme      `json:"themes"`
-	Theme        string              `json:"theme"` // legacy; expanded when Themes empty
-	History      []historyWire       `json:"history"`
-	Description  string              `json:"description"`
-	Meta         *shared.Meta        `json:"meta"`
-	Axes         *[]axisWire         `json:"axes"`
-	Settings     *[]json.RawMessage  `json:"settings"`
-	Data         *[]shared.DataPoint `json:"data"`
-	PreserveRows bool                `json:"preserveRows"`
+	SchemaVersion int                 `json:"schemaVersion"`
+	ID            string              `json:"id"`
+	Tag           string              `json:"tag"`
+	Timestamp     string              `json:"timestamp"`
+	Commit        *shared.Commit      `json:"commit"`
+	Name          *string             `json:"name"`
+	Themes        []shared.Theme      `json:"themes"`
+	Theme         string              `json:"theme"` // legacy; expanded when Themes empty
+	History       []historyWire       `json:"history"`
+	Description   string              `json:"description"`
+	Meta          *shared.Meta        `json:"meta"`
+	Axes          *[]axisWire         `json:"axes"`
+	Settings      *[]json.RawMessage  `json:"settings"`
+	Data          *[]shared.DataPoint `json:"data"`
+	PreserveRows  bool                `json:"preserveRows"`
+	Annotations   []shared.Annotation `json:"annotations"`
 }
 
 type historyWire struct {
-	Tag       *string      `json:"tag"`
-	Timestamp *string      `json:"timestamp"`
-	Meta      *shared.Meta `json:"meta"`
+	Tag       *string        `json:"tag"`
+	Timestamp *string        `json:"timestamp"`
+	Commit    *shared.Commit `json:"commit"`
+	Meta      *shared.Meta   `json:"meta"`
 }
 
 type axisWire struct {
@@ -928,6 +960,10 @@ func decodeStrictDataset(raw json.RawMessage, path string) (shared.Dataset, *api
 		return shared.Dataset{}, &validationErr
 	}
 	switch {
+	case wire.SchemaVersion < 0 || wire.SchemaVersion > shared.CurrentSchemaVersion:
+		validationErr := bodyValidationError(path+"/schemaVersion", "invalid_value",
+			fmt.Sprintf("dataset schemaVersion must be between 0 and %d", shared.CurrentSchemaVersion))
+		return shared.Dataset{}, &validationErr
 	case wire.Name == nil:
 		validationErr := bodyValidationError(path+"/name", "required", "dataset name is required")
 		return shared.Dataset{}, &validationErr
@@ -987,7 +1023,7 @@ func decodeStrictDataset(raw json.RawMessage, path string) (shared.Dataset, *api
 			validationErr := bodyValidationError(entryPath+"/timestamp", "required", "history timestamp is required")
 			return shared.Dataset{}, &validationErr
 		}
-		history = append(history, shared.HistoryEntry{Tag: *entry.Tag, Timestamp: *entry.Timestamp, Meta: entry.Meta})
+		history = append(history, shared.HistoryEntry{Tag: *entry.Tag, Timestamp: *entry.Timestamp, Commit: entry.Commit, Meta: entry.Meta})
 	}
 
 	themes, validationErr := resolveDatasetWireThemes(wire.Themes, wire.Theme, path)
@@ -995,19 +1031,27 @@ func decodeStrictDataset(raw json.RawMessage, path string) (shared.Dataset, *api
 		return shared.Dataset{}, validationErr
 	}
 
+	data := slices.Clone(*wire.Data)
+	if wire.SchemaVersion < shared.SchemaVersionStatUnits {
+		shared.InferStatUnits(data)
+	}
+
 	return shared.Dataset{
-		ID:           wire.ID,
-		Tag:          wire.Tag,
-		Timestamp:    wire.Timestamp,
-		Name:         *wire.Name,
-		Themes:       themes,
-		History:      history,
-		Description:  wire.Description,
-		Meta:         wire.Meta,
-		Axes:         axes,
-		Settings:     settings,
-		Data:         slices.Clone(*wire.Data),
-		PreserveRows: wire.PreserveRows,
+		SchemaVersion: shared.CurrentSchemaVersion,
+		ID:            wire.ID,
+		Tag:           wire.Tag,
+		Timestamp:     wire.Timestamp,
+		Commit:        wire.Commit,
+		Name:          *wire.Name,
+		Themes:        themes,
+		History:       history,
+		Description:   wire.Description,
+		Meta:          wire.Meta,
+		Axes:          axes,
+		Settings:      settings,
+		Data:          data,
+		PreserveRows:  wire.PreserveRows,
+		Annotations:   wire.Annotations,
 	}, nil
 }
 
@@ -82,6 +82,10 @@ func (s *ServeSuite) TestRoutesRegisterDocumentedOperations() {
 			called["convert"] = true
 			w.WriteHeader(http.StatusNoContent)
 		}),
+		export: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called["export"] = true
+			w.WriteHeader(http.StatusNoContent)
+		}),
 		health: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			called["health"] = true
 			w.WriteHeader(http.StatusNoContent)
@@ -99,6 +103,7 @@ func (s *ServeSuite) TestRoutesRegisterDocumentedOperations() {
 		name   string
 	}{
 		{method: http.MethodPost, path: "/", name: "convert"},
+		{method: http.MethodPost, path: "/export", name: "export"},
 		{method: http.MethodGet, path: "/health", name: "health"},
 		{method: http.MethodPost, path: "/merge", name: "merge"},
 		{method: http.MethodPost, path: "/ui", name: "ui"},
@@ -489,6 +494,20 @@ func (s *ServeSuite) TestConvertEndpoint() {
 	s.Equal(http.StatusOK, recorder.Code)
 	s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &dataset))
 	s.Equal("Comparisons", dataset["name"])
+
+	keyed := `{
+		"input":{"platforms":{"linux":{"runs":[{"ms":5},{"ms":6}]}}},
+		"jsonPath":".platforms[].runs[]",
+		"jsonPathKeys":true,
+		"output":{"format":"dataset"}
+	}`
+	recorder = s.apiRequest(handler, "/", keyed, "application/json", "application/json")
+	s.Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+	var keyedDataset shared.Dataset
+	s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &keyedDataset))
+	s.Require().Len(keyedDataset.Data, 2)
+	s.Equal([]string{"linux", "1"}, []string{keyedDataset.Data[1].XAxis, keyedDataset.Data[1].YAxis})
+	s.Equal([]string{"ms"}, keyedDataset.StatTypes())
 }
 
 func (s *ServeSuite) TestConvertEndpointEmbedsThemesCatalog() {
@@ -617,6 +636,9 @@ func (s *ServeSuite) TestConvertEndpointRejectsInvalidRequests() {
 		{name: "request not object", body: `"foo"`, contentType: "application/json", wantStatus: http.StatusUnprocessableEntity, wantErrors: true, wantPath: "/"},
 		{name: "grouping not object", body: `{"input":"x,y\na,1\n","grouping":"foo"}`, contentType: "application/json", wantStatus: http.StatusUnprocessableEntity, wantErrors: true, wantPath: "/grouping"},
 		{name: "grouping pattern wrong type", body: `{"input":"x,y\na,1\n","grouping":{"pattern":123}}`, contentType: "application/json", wantStatus: http.StatusUnprocessableEntity, wantErrors: true, wantPath: "/grouping/pattern"},
+		{name: "invalid better direction", body: `{"input":"x,y\na,1\n","better":{"y":"up"}}`, contentType: "application/json", wantStatus: http.StatusUnprocessableEntity, wantErrors: true},
+		{name: "null better", body: `{"input":"x,y\na,1\n","better":null}`, contentType: "application/json", wantStatus: http.StatusUnprocessableEntity, wantErrors: true},
+		{name: "null jsonPathKeys", body: `{"input":"x,y\na,1\n","jsonPathKeys":null}`, contentType: "application/json", wantStatus: http.StatusUnprocessableEntity, wantErrors: true, wantPath: "/jsonPathKeys"},
 		{name: "missing content type", body: `{}`, wantStatus: http.StatusUnsupportedMediaType},
 		{name: "wrong content type", body: `{}`, contentType: "text/plain", wantStatus: http.StatusUnsupportedMediaType},
 		{name: "malformed content type", body: `{}`, contentType: `application/json; charset="`, wantStatus: http.StatusUnsupportedMediaType},
@@ -703,6 +725,30 @@ func (s *ServeSuite) TestConvertEndpointValidatesStructuredOptions() {
 	}
 }
 
+func (s *ServeSuite) TestBuildParserConfigPointsAtTheInvalidField() {
+	pattern, colAxis := "[", "value"
+	memory, time, number := "TB", "minute", "Q"
+	for _, test := range []struct {
+		request convertRequest
+		path    string
+		code    string
+	}{
+		{convertRequest{Grouping: &groupingOptions{Pattern: &pattern}}, "/grouping/pattern", "invalid_value"},
+		{convertRequest{Grouping: &groupingOptions{ColAxis: &colAxis}}, "/grouping/colAxis", "invalid_enum"},
+		{convertRequest{Grouping: &groupingOptions{Columns: []string{"region", " "}}}, "/grouping/columns/1", "min_length"},
+		{convertRequest{Grouping: &groupingOptions{Regex: "["}}, "/grouping/regex", "invalid_regex"},
+		{convertRequest{Grouping: &groupingOptions{Filter: "["}}, "/grouping/filter", "invalid_regex"},
+		{convertRequest{Units: &unitOptions{Memory: &memory}}, "/units/memory", "invalid_enum"},
+		{convertRequest{Units: &unitOptions{Time: &time}}, "/units/time", "invalid_enum"},
+		{convertRequest{Units: &unitOptions{Number: &number}}, "/units/number", "invalid_enum"},
+	} {
+		_, validationErr := buildParserConfig(test.request, "csv")
+		s.Require().NotNil(validationErr, test.path)
+		s.Equal(test.path, validationErr.Path)
+		s.Equal(test.code, validationErr.Code, test.path)
+	}
+}
+
 func (s *ServeSuite) TestConvertEndpointReportsUIGenerationFailure() {
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		handleConvertWithGenerator(w, r, func([]shared.Dataset, []string) (string, error) {
@@ -737,6 +783,21 @@ func (s *ServeSuite) TestConvertEndpointReportsInapplicableChartOptions() {
 	s.Equal("inapplicable_option", problem.Errors[0].Code)
 }
 
+func (s *ServeSuite) TestMergeEndpointConvertsStatUnits() {
+	older := `{"name":"B","tag":"v1","timestamp":"2026-01-01T00:00:00Z","axes":[{"key":"x"}],"settings":[],` +
+		`"data":[{"xAxis":"a","stats":[{"type":"Execution Time (ns/op)","value":1500}]}]}`
+	newer := `{"schemaVersion":4,"name":"B","tag":"v2","timestamp":"2026-01-02T00:00:00Z","axes":[{"key":"x"}],"settings":[],` +
+		`"data":[{"xAxis":"a","stats":[{"type":"Execution Time (us/op)","value":2,"unit":"us","per":"op"}]}]}`
+	recorder := s.apiRequest(newRESTHandler(), "/merge", `{"datasets":[`+older+`,`+newer+`],"tagAxis":"y"}`, "application/json", "")
+	s.Require().Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var merged []shared.Dataset
+	s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &merged))
+	s.Require().Len(merged, 1)
+	s.Equal([]string{"Execution Time (us/op)"}, merged[0].StatTypes(), "the unlabelled v0 stat is inferred and converted")
+	s.Equal(1.5, *merged[0].Data[0].Stats[0].Value)
+}
+
 func (s *ServeSuite) TestMergeEndpoint() {
 	handler := newRESTHandler()
 	recorder := s.apiRequest(handler, "/merge", `{`, "application/json", "")
@@ -753,7 +814,8 @@ func (s *ServeSuite) TestMergeEndpoint() {
 	s.Equal(http.StatusOK, recorder.Code)
 	var merged []map[string]any
 	s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &merged))
-	s.Len(merged, 1)
+	s.Require().Len(merged, 1)
+	s.EqualValues(shared.CurrentSchemaVersion, merged[0]["schemaVersion"])
 
 	recorder = s.apiRequest(handler, "/merge", `{"datasets":[`+firstMergeJSON+`,`+secondMergeJSON+`],"tagAxis":"invalid"}`, "application/json", "")
 	s.Equal(http.StatusUnprocessableEntity, recorder.Code)
@@ -871,6 +933,7 @@ func (s *ServeSuite) TestUIEndpointValidatesDatasetsAndStatistics() {
 		suffix   string
 	}{
 		{name: "missing name", datasets: `{"axes":[],"settings":[],"data":[]}`},
+		{name: "newer schema version", datasets: `{"schemaVersion":99,"name":"Bench","axes":[],"settings":[],"data":[]}`},
 		{name: "missing axes", datasets: `{"name":"Bench","settings":[],"data":[]}`},
 		{name: "missing settings", datasets: `{"name":"Bench","axes":[],"data":[]}`},
 		{name: "missing data", datasets: `{"name":"Bench","axes":[],"settings":[]}`},
@@ -909,6 +972,50 @@ func (s *ServeSuite) TestUIEndpointReportsGenerationFailure() {
 	s.Contains(recorder.Body.String(), "could not generate the response")
 }
 
+func (s *ServeSuite) TestExportEndpoint() {
+	handler := newRESTHandler()
+	dataset := `{"name":"Bench","axes":[{"key":"x"}],"settings":[{"type":"bar"},{"type":"heatmap"}],` +
+		`"data":[{"xAxis":"Sort","stats":[{"type":"Execution Time (ns/op)","value":40}]},{"xAxis":"Map","stats":[{"type":"Execution Time (ns/op)","value":30}]}]}`
+
+	recorder := s.apiRequest(handler, "/export", `{"datasets":`+dataset+`}`, "application/json", "application/json")
+	s.Require().Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+	s.Equal("application/json", recorder.Header().Get("Content-Type"))
+	var spec map[string]any
+	s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &spec))
+	s.Equal("https://vega.github.io/schema/vega-lite/v5.json", spec["$schema"])
+	s.Equal("bar", spec["mark"].(map[string]any)["type"], "heatmap has no exporter and is left out")
+
+	body := `{"datasets":[` + dataset + `],"format":"echarts","charts":{"types":["line","pie"],"configs":[{"type":"line","smooth":true}]}}`
+	recorder = s.apiRequest(handler, "/export", body, "application/json", "application/*")
+	s.Require().Equal(http.StatusOK, recorder.Code, recorder.Body.String())
+	var options []map[string]any
+	s.Require().NoError(json.Unmarshal(recorder.Body.Bytes(), &options))
+	s.Require().Len(options, 2)
+	s.Equal(true, options[0]["series"].([]any)[0].(map[string]any)["smooth"])
+
+	for _, test := range []struct {
+		name       string
+		body       string
+		accept     string
+		wantStatus int
+	}{
+		{name: "invalid request", body: `{`, accept: "application/json", wantStatus: http.StatusBadRequest},
+		{name: "not accepted", body: `{"datasets":` + dataset + `}`, accept: "text/html", wantStatus: http.StatusNotAcceptable},
+		{name: "missing datasets", body: `{}`, accept: "application/json", wantStatus: http.StatusUnprocessableEntity},
+		{name: "unknown format", body: `{"datasets":` + dataset + `,"format":"plotly"}`, accept: "application/json", wantStatus: http.StatusUnprocessableEntity},
+		{name: "null format", body: `{"datasets":` + dataset + `,"format":null}`, accept: "application/json", wantStatus: http.StatusUnprocessableEntity},
+		{name: "unknown field", body: `{"datasets":` + dataset + `,"layout":"grid"}`, accept: "application/json", wantStatus: http.StatusUnprocessableEntity},
+		{name: "nothing to export", body: `{"datasets":` + dataset + `,"charts":{"types":["heatmap"]}}`, accept: "application/json", wantStatus: http.StatusUnprocessableEntity},
+	} {
+		s.Run(test.name, func() {
+			recorder := s.apiRequest(handler, "/export", test.body, "application/json", test.accept)
+			s.Equal(test.wantStatus, recorder.Code, recorder.Body.String())
+			s.Equal("application/problem+json", recorder.Header().Get("Content-Type"))
+			s.Equal(float64(test.wantStatus), s.problemStatus(recorder))
+		})
+	}
+}
+
 func (s *ServeSuite) TestRequestHelpers() {
 	s.Equal([]byte("text"), s.inlineInput(`"text"`))
 	s.Equal([]byte(`{"value":1}`), s.inlineInput(`{"value":1}`))
@@ -1015,6 +1122,14 @@ func (s *ServeSuite) TestRequestHelpers() {
 
 	_, validationErr = decodeStrictDataset(json.RawMessage(`{"name":"Bench","history":[{"tag":"v1","timestamp":"now"}],"axes":[],"settings":[],"data":[]}`), "/datasets/0")
 	s.Nil(validationErr)
+
+	annotated, validationErr := decodeStrictDataset(json.RawMessage(`{"name":"Bench","axes":[],"settings":[],"data":[],"annotations":[{"type":"hline","stat":"ns/op","value":50}]}`), "/datasets/0")
+	s.Nil(validationErr)
+	s.Equal([]shared.Annotation{{Type: "hline", Stat: "ns/op", Value: shared.F64(50)}}, annotated.Annotations)
+
+	_, validationErr = decodeStrictDataset(json.RawMessage(`{"name":"Bench","axes":[],"settings":[],"data":[],"annotations":[{"type":"point","at":"Sort"}]}`), "/datasets/0")
+	s.Require().NotNil(validationErr)
+	s.Contains(validationErr.Message, "point mark requires at and value")
 }
 
 func (s *ServeSuite) TestRequestContractHelpers() {
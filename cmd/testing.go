@@ -1,11 +1,12 @@
 package cmd
 
 import (
+	"github.com/goptics/vizb/pkg/export"
 	"github.com/goptics/vizb/shared"
 	"github.com/spf13/pflag"
 )
 
-// ResetTestState restores root, ui, and merge flag globals to their defaults.
+// ResetTestState restores root, ui, merge, export, migrate, and history flag globals to their defaults.
 // Chart slices get a fresh copy so tests do not alias the package-level defaults.
 // Tests that pass explicit -c should set rootOpts.Charts = nil before Execute so
 // cobra replaces the slice instead of appending to the reset copy.
@@ -25,12 +26,31 @@ func ResetTestState() {
 
 	mergeOpts.OutputFile = ""
 	mergeOpts.TagAxis = "n"
+	mergeOpts.Retention.reset()
+	mergeOpts.Reconcile.reset()
+
+	exportOpts.OutputFile = ""
+	exportOpts.Format = export.FormatVegaLite
+
+	migrateOpts.OutputFile = ""
+	migrateOpts.DryRun = false
+
+	historyOpts = historyOptions{}
+	historyOpts.Retention.reset()
+
+	diffOpts = diffOptions{Format: diffFormatText}
 
 	serveBag.Reset()
 
 	resetChanged(rootCmd.Flags())
 	resetChanged(uiCmd.Flags())
 	resetChanged(mergeCmd.Flags())
+	resetChanged(exportCmd.Flags())
+	resetChanged(migrateCmd.Flags())
+	resetChanged(historyCmd.PersistentFlags())
+	resetChanged(historyPruneCmd.Flags())
+	resetChanged(historyRmCmd.Flags())
+	resetChanged(diffCmd.Flags())
 	resetChanged(serveCmd.Flags())
 	resetChanged(updateCmd.Flags())
 }
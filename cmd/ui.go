@@ -11,13 +11,20 @@ import (
 	"github.com/goptics/vizb/cmd/cli"
 	internal_charts "github.com/goptics/vizb/internal/charts"
 	barchart "github.com/goptics/vizb/internal/charts/bar"
+	bumpchart "github.com/goptics/vizb/internal/charts/bump"
+	calendarchart "github.com/goptics/vizb/internal/charts/calendar"
 	chordchart "github.com/goptics/vizb/internal/charts/chord"
 	heatmapchart "github.com/goptics/vizb/internal/charts/heatmap"
+	histogramchart "github.com/goptics/vizb/internal/charts/histogram"
 	linechart "github.com/goptics/vizb/internal/charts/line"
+	parallelchart "github.com/goptics/vizb/internal/charts/parallel"
 	piechart "github.com/goptics/vizb/internal/charts/pie"
 	radarchart "github.com/goptics/vizb/internal/charts/radar"
 	sankeychart "github.com/goptics/vizb/internal/charts/sankey"
 	scatterchart "github.com/goptics/vizb/internal/charts/scatter"
+	sunburstchart "github.com/goptics/vizb/internal/charts/sunburst"
+	treemapchart "github.com/goptics/vizb/internal/charts/treemap"
+	waterfallchart "github.com/goptics/vizb/internal/charts/waterfall"
 	"github.com/goptics/vizb/pkg/cliout"
 	"github.com/goptics/vizb/pkg/core"
 	"github.com/goptics/vizb/pkg/template"
@@ -59,7 +66,7 @@ func init() {
 	uiCmd.Flags().StringVarP(&uiOpts.DataURL, "data-url", "U", "", "Runtime URL for Dataset JSON or id/name catalog")
 	// --charts lets `vizb ui` prune chart chunks (incl. --data-url, where it's the
 	// only source of the selection since the data is fetched at runtime).
-	uiCmd.Flags().StringSliceVarP(&uiOpts.Charts, "charts", "c", shared.DefaultChartTypes, "Chart types to embed (bar, line, scatter, pie, heatmap, radar, sankey, chord)")
+	uiCmd.Flags().StringSliceVarP(&uiOpts.Charts, "charts", "c", shared.DefaultChartTypes, "Chart types to embed (bar, line, scatter, pie, heatmap, radar, sankey, chord, treemap, sunburst, histogram, parallel, calendar, bump, waterfall)")
 	uiCmd.Flags().StringArrayVar(&uiOpts.ChartSpecs, "chart", nil,
 		"Per-chart override (type:key=val; repeatable; see docs)")
 	uiCmd.Flags().BoolVar(&uiOpts.Enable3D, "3d", false, "Bundle 3D renderer for --data-url")
@@ -291,6 +298,20 @@ func applyStatToSettings(settings []internal_charts.ChartConfig, stat *shared.St
 			c.Stat = stat
 		case *chordchart.Config:
 			c.Stat = stat
+		case *treemapchart.Config:
+			c.Stat = stat
+		case *sunburstchart.Config:
+			c.Stat = stat
+		case *histogramchart.Config:
+			c.Stat = stat
+		case *parallelchart.Config:
+			c.Stat = stat
+		case *calendarchart.Config:
+			c.Stat = stat
+		case *bumpchart.Config:
+			c.Stat = stat
+		case *waterfallchart.Config:
+			c.Stat = stat
 		default:
 			// ponytail: new chart type — add a case above and wire c.Stat = stat
 			panic(fmt.Sprintf("applyStatToSettings: unhandled chart type %T", c))
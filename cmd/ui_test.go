@@ -10,13 +10,20 @@ import (
 
 	internal_charts "github.com/goptics/vizb/internal/charts"
 	barchart "github.com/goptics/vizb/internal/charts/bar"
+	bumpchart "github.com/goptics/vizb/internal/charts/bump"
+	calendarchart "github.com/goptics/vizb/internal/charts/calendar"
 	chordchart "github.com/goptics/vizb/internal/charts/chord"
 	heatmapchart "github.com/goptics/vizb/internal/charts/heatmap"
+	histogramchart "github.com/goptics/vizb/internal/charts/histogram"
 	linechart "github.com/goptics/vizb/internal/charts/line"
+	parallelchart "github.com/goptics/vizb/internal/charts/parallel"
 	piechart "github.com/goptics/vizb/internal/charts/pie"
 	radarchart "github.com/goptics/vizb/internal/charts/radar"
 	sankeychart "github.com/goptics/vizb/internal/charts/sankey"
 	scatterchart "github.com/goptics/vizb/internal/charts/scatter"
+	sunburstchart "github.com/goptics/vizb/internal/charts/sunburst"
+	treemapchart "github.com/goptics/vizb/internal/charts/treemap"
+	waterfallchart "github.com/goptics/vizb/internal/charts/waterfall"
 	"github.com/goptics/vizb/pkg/template"
 	"github.com/goptics/vizb/shared"
 	"github.com/goptics/vizb/testutil"
@@ -519,6 +526,13 @@ func (s *UISuite) TestRunUIAppliesStatToMultipleChartTypes() {
 			&radarchart.Config{Type: "radar"},
 			&sankeychart.Config{Type: "sankey"},
 			&chordchart.Config{Type: "chord"},
+			&treemapchart.Config{Type: "treemap"},
+			&sunburstchart.Config{Type: "sunburst"},
+			&histogramchart.Config{Type: "histogram"},
+			&parallelchart.Config{Type: "parallel"},
+			&calendarchart.Config{Type: "calendar"},
+			&bumpchart.Config{Type: "bump"},
+			&waterfallchart.Config{Type: "waterfall"},
 		},
 		Data: []shared.DataPoint{{Name: "T1", XAxis: "1", YAxis: "100"}},
 	})
@@ -529,7 +543,7 @@ func (s *UISuite) TestRunUIAppliesStatToMultipleChartTypes() {
 
 	datasets := s.extractVIZBDataArray(s.read(out))
 	settings := datasets[0].(map[string]any)["settings"].([]any)
-	s.Require().Len(settings, 7)
+	s.Require().Len(settings, 14)
 	for _, raw := range settings {
 		stat := raw.(map[string]any)["stat"].(map[string]any)
 		s.Equal([]any{"shape"}, stat["math"])
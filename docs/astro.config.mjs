@@ -44,6 +44,13 @@ export default defineConfig({
 					{ label: 'Heatmap', slug: 'charts/heatmap' },
 					{ label: 'Sankey Chart', slug: 'charts/sankey' },
 					{ label: 'Chord Chart', slug: 'charts/chord' },
+					{ label: 'Treemap Chart', slug: 'charts/treemap' },
+					{ label: 'Sunburst Chart', slug: 'charts/sunburst' },
+					{ label: 'Histogram Chart', slug: 'charts/histogram' },
+					{ label: 'Parallel Chart', slug: 'charts/parallel' },
+					{ label: 'Calendar Chart', slug: 'charts/calendar' },
+					{ label: 'Bump Chart', slug: 'charts/bump' },
+					{ label: 'Waterfall Chart', slug: 'charts/waterfall' },
 					{ label: '3D Charts (WebGL)', slug: 'charts/3d' },
 				],
 			},
@@ -56,6 +63,7 @@ export default defineConfig({
 					{ label: 'Select', slug: 'guides/select' },
 					{ label: 'Merging', slug: 'guides/merging' },
 					{ label: 'Parser Guide', slug: 'guides/parsers' },
+					{ label: 'Go API', slug: 'guides/go-api' },
 				],
 			},
 			{
@@ -64,7 +72,11 @@ export default defineConfig({
 					{ label: 'vizb', slug: 'commands/root' },
 					{ label: 'vizb <chart>', slug: 'commands/charts' },
 					{ label: 'vizb merge', slug: 'commands/merge' },
+					{ label: 'vizb history', slug: 'commands/history' },
+					{ label: 'vizb diff', slug: 'commands/diff' },
 					{ label: 'vizb ui', slug: 'commands/ui' },
+					{ label: 'vizb export', slug: 'commands/export' },
+					{ label: 'vizb migrate', slug: 'commands/migrate' },
 					{ label: 'vizb serve', slug: 'commands/serve' },
 					{ label: 'vizb update', slug: 'commands/update' },
 				],
@@ -3,6 +3,7 @@ module github.com/goptics/vizb
 go 1.26.5
 
 require (
+	github.com/BurntSushi/toml v1.6.0
 	github.com/charmbracelet/lipgloss v1.1.0
 	github.com/charmbracelet/log v1.0.0
 	github.com/muesli/termenv v0.16.0
@@ -0,0 +1,5 @@
+// Package all registers every chart type: each file plugs a typed Config
+// factory into the charts registry and stores its flag descriptors. Both the
+// vizb command and the pkg/vizb library import it; the cobra metadata for the
+// chart subcommands stays in cmd/charts.
+package all
@@ -0,0 +1,20 @@
+package all
+
+import (
+	"slices"
+
+	"github.com/goptics/vizb/internal/charts"
+	barchart "github.com/goptics/vizb/internal/charts/bar"
+)
+
+func init() {
+	charts.Register(charts.Spec{Type: "bar", Factory: barchart.New})
+	charts.SetFlags("bar", append(slices.Clone(charts.BaseChartFlags),
+		charts.ScaleFlag, charts.MinFlag, charts.MaxFlag, charts.LogBaseFlag, charts.ZeroBaselineFlag, charts.InverseFlag, charts.ZoomFlag,
+		charts.StackFlag, charts.Y2Flag, charts.ThreeDFlag, charts.ThreeDRotateFlag, charts.ThreeDVisualMapFlag,
+		charts.HorizontalFlag,
+		charts.BorderRadiusFlag,
+		charts.BgFlag,
+		charts.MarkFlag,
+	))
+}
@@ -0,0 +1,17 @@
+package all
+
+import (
+	"github.com/goptics/vizb/internal/charts"
+	bumpchart "github.com/goptics/vizb/internal/charts/bump"
+	"github.com/goptics/vizb/internal/flags"
+)
+
+func init() {
+	charts.Register(charts.Spec{Type: "bump", Factory: bumpchart.New})
+	// No SortFlag or SwapFlag: --rank orders each tag, and the tag dimension
+	// stays where vizb merge put it.
+	charts.SetFlags("bump", []flags.Flag{
+		charts.LabelsFlag, charts.StatFlag,
+		charts.TagAxisFlag, charts.RankFlag,
+	})
+}
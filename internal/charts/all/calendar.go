@@ -0,0 +1,16 @@
+package all
+
+import (
+	"github.com/goptics/vizb/internal/charts"
+	calendarchart "github.com/goptics/vizb/internal/charts/calendar"
+	"github.com/goptics/vizb/internal/flags"
+)
+
+func init() {
+	charts.Register(charts.Spec{Type: "calendar", Factory: calendarchart.New})
+	// No SortFlag: days always keep date order.
+	charts.SetFlags("calendar", []flags.Flag{
+		charts.SwapFlag, charts.LabelsFlag, charts.StatFlag,
+		charts.WeekStartFlag, charts.YearsFlag, charts.CellSizeFlag, charts.CalendarVisualMapFlag,
+	})
+}
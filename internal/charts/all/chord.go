@@ -0,0 +1,13 @@
+package all
+
+import (
+	"slices"
+
+	"github.com/goptics/vizb/internal/charts"
+	chordchart "github.com/goptics/vizb/internal/charts/chord"
+)
+
+func init() {
+	charts.Register(charts.Spec{Type: chordchart.Type, Factory: chordchart.New})
+	charts.SetFlags(chordchart.Type, slices.Clone(charts.BaseChartFlags))
+}
@@ -0,0 +1,13 @@
+package all
+
+import (
+	"slices"
+
+	"github.com/goptics/vizb/internal/charts"
+	heatmapchart "github.com/goptics/vizb/internal/charts/heatmap"
+)
+
+func init() {
+	charts.Register(charts.Spec{Type: "heatmap", Factory: heatmapchart.New})
+	charts.SetFlags("heatmap", slices.Clone(charts.BaseChartFlags))
+}
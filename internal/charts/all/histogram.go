@@ -0,0 +1,15 @@
+package all
+
+import (
+	"slices"
+
+	"github.com/goptics/vizb/internal/charts"
+	histogramchart "github.com/goptics/vizb/internal/charts/histogram"
+)
+
+func init() {
+	charts.Register(charts.Spec{Type: "histogram", Factory: histogramchart.New})
+	charts.SetFlags("histogram", append(slices.Clone(charts.BaseChartFlags),
+		charts.BinsFlag, charts.BinWidthFlag, charts.BinMethodFlag, charts.CumulativeFlag,
+	))
+}
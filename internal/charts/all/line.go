@@ -0,0 +1,18 @@
+package all
+
+import (
+	"slices"
+
+	"github.com/goptics/vizb/internal/charts"
+	linechart "github.com/goptics/vizb/internal/charts/line"
+)
+
+func init() {
+	charts.Register(charts.Spec{Type: "line", Factory: linechart.New})
+	charts.SetFlags("line", append(slices.Clone(charts.BaseChartFlags),
+		charts.ScaleFlag, charts.MinFlag, charts.MaxFlag, charts.LogBaseFlag, charts.ZeroBaselineFlag, charts.InverseFlag, charts.ZoomFlag,
+		charts.StackFlag, charts.Y2Flag, charts.ThreeDFlag, charts.ThreeDRotateFlag, charts.ThreeDVisualMapFlag,
+		charts.SymbolFlag, charts.SymbolSizeFlag, charts.SmoothFlag,
+		charts.MarkFlag,
+	))
+}
@@ -0,0 +1,16 @@
+package all
+
+import (
+	"github.com/goptics/vizb/internal/charts"
+	parallelchart "github.com/goptics/vizb/internal/charts/parallel"
+	"github.com/goptics/vizb/internal/flags"
+)
+
+func init() {
+	charts.Register(charts.Spec{Type: "parallel", Factory: parallelchart.New})
+	// No LabelsFlag: parallel lines carry no per-point labels.
+	charts.SetFlags("parallel", []flags.Flag{
+		charts.SwapFlag, charts.SortFlag, charts.StatFlag,
+		charts.ScaleFlag, charts.AxisScaleFlag, charts.BrushFlag,
+	})
+}
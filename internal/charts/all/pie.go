@@ -0,0 +1,13 @@
+package all
+
+import (
+	"slices"
+
+	"github.com/goptics/vizb/internal/charts"
+	piechart "github.com/goptics/vizb/internal/charts/pie"
+)
+
+func init() {
+	charts.Register(charts.Spec{Type: "pie", Factory: piechart.New})
+	charts.SetFlags("pie", slices.Clone(charts.BaseChartFlags))
+}
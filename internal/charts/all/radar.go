@@ -0,0 +1,13 @@
+package all
+
+import (
+	"slices"
+
+	"github.com/goptics/vizb/internal/charts"
+	radarchart "github.com/goptics/vizb/internal/charts/radar"
+)
+
+func init() {
+	charts.Register(charts.Spec{Type: "radar", Factory: radarchart.New})
+	charts.SetFlags("radar", slices.Clone(charts.BaseChartFlags))
+}
@@ -0,0 +1,13 @@
+package all
+
+import (
+	"slices"
+
+	"github.com/goptics/vizb/internal/charts"
+	sankeychart "github.com/goptics/vizb/internal/charts/sankey"
+)
+
+func init() {
+	charts.Register(charts.Spec{Type: "sankey", Factory: sankeychart.New})
+	charts.SetFlags("sankey", slices.Clone(charts.BaseChartFlags))
+}
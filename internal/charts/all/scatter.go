@@ -0,0 +1,18 @@
+package all
+
+import (
+	"slices"
+
+	"github.com/goptics/vizb/internal/charts"
+	scatterchart "github.com/goptics/vizb/internal/charts/scatter"
+)
+
+func init() {
+	charts.Register(charts.Spec{Type: "scatter", Factory: scatterchart.New})
+	charts.SetFlags("scatter", append(slices.Clone(charts.BaseChartFlags),
+		charts.ScaleFlag, charts.MinFlag, charts.MaxFlag, charts.LogBaseFlag, charts.ZeroBaselineFlag, charts.InverseFlag, charts.ZoomFlag,
+		charts.ThreeDFlag, charts.ThreeDRotateFlag, charts.ThreeDVisualMapFlag,
+		charts.VisualMapFlag, charts.SymbolFlag, charts.SymbolSizeFlag,
+		charts.MarkFlag,
+	))
+}
@@ -0,0 +1,15 @@
+package all
+
+import (
+	"slices"
+
+	"github.com/goptics/vizb/internal/charts"
+	sunburstchart "github.com/goptics/vizb/internal/charts/sunburst"
+)
+
+func init() {
+	charts.Register(charts.Spec{Type: "sunburst", Factory: sunburstchart.New})
+	charts.SetFlags("sunburst", append(slices.Clone(charts.BaseChartFlags),
+		charts.LeafDepthFlag, charts.LabelLevelsFlag, charts.ValueStatFlag,
+	))
+}
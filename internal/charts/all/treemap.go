@@ -0,0 +1,15 @@
+package all
+
+import (
+	"slices"
+
+	"github.com/goptics/vizb/internal/charts"
+	treemapchart "github.com/goptics/vizb/internal/charts/treemap"
+)
+
+func init() {
+	charts.Register(charts.Spec{Type: "treemap", Factory: treemapchart.New})
+	charts.SetFlags("treemap", append(slices.Clone(charts.BaseChartFlags),
+		charts.LeafDepthFlag, charts.LabelLevelsFlag, charts.ValueStatFlag,
+	))
+}
@@ -0,0 +1,16 @@
+package all
+
+import (
+	"github.com/goptics/vizb/internal/charts"
+	waterfallchart "github.com/goptics/vizb/internal/charts/waterfall"
+	"github.com/goptics/vizb/internal/flags"
+)
+
+func init() {
+	charts.Register(charts.Spec{Type: "waterfall", Factory: waterfallchart.New})
+	// No SwapFlag: the tag dimension stays where vizb merge put it.
+	charts.SetFlags("waterfall", []flags.Flag{
+		charts.SortFlag, charts.LabelsFlag, charts.StatFlag,
+		charts.TagAxisFlag, charts.FromTagFlag, charts.ToTagFlag,
+	})
+}
@@ -18,7 +18,14 @@ type Config struct {
 	Swap            string               `json:"swap,omitempty"`
 	Sort            *shared.Sort         `json:"sort,omitempty"`
 	Scale           string               `json:"scale,omitempty"`
+	Min             *shared.AxisBound    `json:"min,omitempty"`
+	Max             *shared.AxisBound    `json:"max,omitempty"`
+	LogBase         *float64             `json:"logBase,omitempty"`
+	ZeroBaseline    *bool                `json:"zeroBaseline,omitempty"`
+	Inverse         *bool                `json:"inverse,omitempty"`
+	Zoom            string               `json:"zoom,omitempty"`
 	Stack           *bool                `json:"stack,omitempty"`
+	Y2              []string             `json:"y2,omitempty"`
 	ShowLabels      *bool                `json:"showLabels,omitempty"`
 	ThreeDRotate    *bool                `json:"threeDRotate,omitempty"`
 	ThreeD          *bool                `json:"threeD,omitempty"`
@@ -26,6 +33,7 @@ type Config struct {
 	Horizontal      *bool                `json:"horizontal,omitempty"`
 	BorderRadius    *shared.BorderRadius `json:"borderRadius,omitempty"`
 	Background      *shared.Background   `json:"background,omitempty"`
+	Mark            []shared.Annotation  `json:"mark,omitempty"`
 	Stat            *shared.StatConfig   `json:"stat,omitempty"`
 }
 
@@ -0,0 +1,29 @@
+// Package bump defines the typed Config for bump charts: one line per
+// benchmark tracing its rank at each tag of a merged history (vizb merge).
+// TagAxis names the tag dimension (detected when unset) and Rank picks which
+// end of the stat ranks first. There is no sort, swap, scale, or 3D.
+package bump
+
+import (
+	"github.com/goptics/vizb/internal/charts"
+	"github.com/goptics/vizb/shared"
+)
+
+const Type = "bump"
+
+type Config struct {
+	Type       string             `json:"type"`
+	ShowLabels *bool              `json:"showLabels,omitempty"`
+	TagAxis    string             `json:"tagAxis,omitempty"`
+	Rank       string             `json:"rank,omitempty"`
+	Stat       *shared.StatConfig `json:"stat,omitempty"`
+}
+
+func (Config) ChartType() string { return Type }
+
+func (c Config) StatEnabled() bool  { return c.Stat.StatEnabled() }
+func (c Config) StatMath() []string { return c.Stat.StatMath() }
+func (Config) SwapString() string   { return "" }
+
+// New returns a fresh zero-value bump chart Config.
+func New() charts.ChartConfig { return &Config{} }
@@ -0,0 +1,62 @@
+package bump_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	_ "github.com/goptics/vizb/cmd/charts/bump"
+	"github.com/goptics/vizb/internal/charts"
+	bumpchart "github.com/goptics/vizb/internal/charts/bump"
+	"github.com/goptics/vizb/shared"
+	"github.com/stretchr/testify/suite"
+)
+
+// BumpSuite covers the bump chart Config: its factory, JSON round-trip, and
+// the "history fields only, no sort, swap or scale" JSON contract.
+type BumpSuite struct {
+	suite.Suite
+}
+
+func (s *BumpSuite) TestNewReturnsZeroConfig() {
+	cfg := bumpchart.New()
+	got, ok := cfg.(*bumpchart.Config)
+	s.Require().True(ok)
+	s.Empty(got.Type)
+	s.Empty(got.TagAxis)
+	s.Empty(got.Rank)
+	s.Nil(got.Stat)
+	s.Empty(got.SwapString())
+}
+
+func (s *BumpSuite) TestDecodeRoundTripAllFields() {
+	labels := true
+	original := bumpchart.Config{
+		Type:       "bump",
+		ShowLabels: &labels,
+		TagAxis:    "x",
+		Rank:       "desc",
+		Stat:       &shared.StatConfig{Enabled: true},
+	}
+	raw, err := json.Marshal(original)
+	s.Require().NoError(err)
+
+	cfg, err := charts.Decode("bump", raw)
+	s.Require().NoError(err)
+	got, ok := cfg.(*bumpchart.Config)
+	s.Require().True(ok)
+	s.Equal(original, *got)
+	s.Equal("bump", got.ChartType())
+}
+
+func (s *BumpSuite) TestJSONOmitsInapplicableChartFields() {
+	raw, err := json.Marshal(bumpchart.Config{Type: "bump"})
+	s.Require().NoError(err)
+
+	var m map[string]any
+	s.Require().NoError(json.Unmarshal(raw, &m))
+	s.Equal(map[string]any{"type": "bump"}, m)
+}
+
+func TestBumpSuite(t *testing.T) {
+	suite.Run(t, new(BumpSuite))
+}
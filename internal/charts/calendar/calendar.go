@@ -0,0 +1,32 @@
+// Package calendar defines the typed Config for calendar heatmaps: one cell
+// per day of a date-like dimension, laid out in year/month grids and colored
+// by the stat. WeekStart, Years, and CellSize shape the grid; VisualMap shows
+// the color scale. There is no sort, scale, stack, or 3D.
+package calendar
+
+import (
+	"github.com/goptics/vizb/internal/charts"
+	"github.com/goptics/vizb/shared"
+)
+
+const Type = "calendar"
+
+type Config struct {
+	Type       string             `json:"type"`
+	Swap       string             `json:"swap,omitempty"`
+	ShowLabels *bool              `json:"showLabels,omitempty"`
+	WeekStart  string             `json:"weekStart,omitempty"`
+	Years      *shared.YearRange  `json:"years,omitempty"`
+	CellSize   *int               `json:"cellSize,omitempty"`
+	VisualMap  *bool              `json:"visualMap,omitempty"`
+	Stat       *shared.StatConfig `json:"stat,omitempty"`
+}
+
+func (Config) ChartType() string { return Type }
+
+func (c Config) StatEnabled() bool  { return c.Stat.StatEnabled() }
+func (c Config) StatMath() []string { return c.Stat.StatMath() }
+func (c Config) SwapString() string { return c.Swap }
+
+// New returns a fresh zero-value calendar chart Config.
+func New() charts.ChartConfig { return &Config{} }
@@ -0,0 +1,72 @@
+package calendar_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	_ "github.com/goptics/vizb/cmd/charts/calendar"
+	"github.com/goptics/vizb/internal/charts"
+	calendarchart "github.com/goptics/vizb/internal/charts/calendar"
+	"github.com/goptics/vizb/shared"
+	"github.com/stretchr/testify/suite"
+)
+
+// CalendarSuite covers the calendar chart Config: its factory, JSON
+// round-trip, and the "grid fields only, no sort or scale" JSON contract.
+type CalendarSuite struct {
+	suite.Suite
+}
+
+func (s *CalendarSuite) TestNewReturnsZeroConfig() {
+	cfg := calendarchart.New()
+	got, ok := cfg.(*calendarchart.Config)
+	s.Require().True(ok)
+	s.Empty(got.Type)
+	s.Empty(got.WeekStart)
+	s.Nil(got.Years)
+	s.Nil(got.CellSize)
+	s.Nil(got.VisualMap)
+	s.Nil(got.Stat)
+}
+
+func (s *CalendarSuite) TestDecodeRoundTripAllFields() {
+	size := 16
+	visual := true
+	labels := false
+	original := calendarchart.Config{
+		Type:       "calendar",
+		Swap:       "yx",
+		ShowLabels: &labels,
+		WeekStart:  "monday",
+		Years:      &shared.YearRange{From: 2023, To: 2024},
+		CellSize:   &size,
+		VisualMap:  &visual,
+		Stat:       &shared.StatConfig{Enabled: true},
+	}
+	raw, err := json.Marshal(original)
+	s.Require().NoError(err)
+
+	cfg, err := charts.Decode("calendar", raw)
+	s.Require().NoError(err)
+	got, ok := cfg.(*calendarchart.Config)
+	s.Require().True(ok)
+	s.Equal(original, *got)
+	s.Equal("calendar", got.ChartType())
+}
+
+func (s *CalendarSuite) TestJSONOmitsInapplicableChartFields() {
+	raw, err := json.Marshal(calendarchart.Config{Type: "calendar", Years: &shared.YearRange{From: 2024, To: 2024}})
+	s.Require().NoError(err)
+
+	var m map[string]any
+	s.Require().NoError(json.Unmarshal(raw, &m))
+	for _, key := range []string{"sort", "scale", "stack", "threeD", "weekStart", "cellSize", "visualMap"} {
+		_, ok := m[key]
+		s.False(ok, "calendar JSON must not carry %q", key)
+	}
+	s.Equal(map[string]any{"from": float64(2024), "to": float64(2024)}, m["years"])
+}
+
+func TestCalendarSuite(t *testing.T) {
+	suite.Run(t, new(CalendarSuite))
+}
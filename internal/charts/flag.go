@@ -3,6 +3,7 @@ package charts
 import (
 	"fmt"
 	"math"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -37,7 +38,10 @@ var (
 
 // BaseChartFlags are the --chart keys valid for every chart type. Each chart's
 // flag list is composed by prepending a clone of BaseChartFlags before the
-// chart's own variable flags (declared in cmd/charts/<c>/<c>.go).
+// chart's own variable flags (declared in cmd/charts/<c>/<c>.go); parallel,
+// which has no data labels, lists the others without LabelsFlag, calendar,
+// whose days keep date order, lists them without SortFlag, and bump and
+// waterfall, whose tag dimension is fixed, list them without SwapFlag.
 var BaseChartFlags = []flags.Flag{SwapFlag, SortFlag, LabelsFlag, StatFlag}
 
 // --- Variable flags: composed by the charts that carry them. ---
@@ -113,6 +117,212 @@ var (
 		Validate:   ValidateBorderRadiusValue,
 		Encode:     EncodeBorderRadius,
 	}
+	// LeafDepthFlag and LabelLevelsFlag count hierarchy levels from the top of
+	// the serial axis order (name → x → y → z, after swap) for treemap and
+	// sunburst. Unset means every level present in the data.
+	LeafDepthFlag = flags.Flag{
+		Name: "leaf-depth", Usage: "Hierarchy levels to draw; deeper levels roll up into their parent (1–4)",
+		Kind: flags.KindInt, JSONKey: "leafDepth",
+		Validate: ValidateHierarchyLevelValue,
+		Rule:     []flags.RuleFn{WithinHierarchy()},
+	}
+	LabelLevelsFlag = flags.Flag{
+		Name: "label-levels", Usage: "Hierarchy levels (from the top) that carry labels (1–4)",
+		Kind: flags.KindInt, JSONKey: "labelLevels",
+		Validate: ValidateHierarchyLevelValue,
+		Rule:     []flags.RuleFn{WithinHierarchy()},
+	}
+	ValueStatFlag = flags.Flag{
+		Name: "value-stat", Usage: "Stat type that sizes hierarchy nodes, e.g. B/op (default: the active stat)",
+		Kind: flags.KindString, JSONKey: "valueStat",
+		Rule: []flags.RuleFn{RequiresStatType()},
+	}
+	// BinsFlag, BinWidthFlag and BinMethodFlag choose histogram bins; which one
+	// takes effect follows ResolveBinMethod, and the rules skip the rest.
+	BinsFlag = flags.Flag{
+		Name: "bins", Usage: fmt.Sprintf("Histogram bin count for the count and log methods (1–%d)", MaxHistogramBins),
+		Kind: flags.KindInt, JSONKey: "bins",
+		Validate: ValidateBinsValue,
+		Rule:     []flags.RuleFn{UsedByBinMethod(BinMethodCount, BinMethodLog)},
+	}
+	BinWidthFlag = flags.Flag{
+		Name: "bin-width", Usage: "Histogram bin width for the width method",
+		Kind: flags.KindFloat, JSONKey: "binWidth",
+		Validate: ValidateBinWidthValue,
+		Rule:     []flags.RuleFn{UsedByBinMethod(BinMethodWidth)},
+	}
+	BinMethodFlag = flags.Flag{
+		Name: "bin-method", Usage: "Histogram binning (count, width, fd, log; default: from --bins/--bin-width, else fd)",
+		Kind: flags.KindString, JSONKey: "binMethod",
+		Validate:   ValidateBinMethodValue,
+		Encode:     func(v any) any { return strings.ToLower(v.(string)) },
+		Label:      "bin method",
+		ValidSet:   []string{BinMethodCount, BinMethodWidth, BinMethodFD, BinMethodLog},
+		Normalizer: strings.ToLower,
+		Rule:       []flags.RuleFn{BinMethodApplies()},
+	}
+	CumulativeFlag = flags.Flag{
+		Name: "cumulative", Usage: "Draw the cumulative distribution (CDF) instead of per-bin counts",
+		Kind: flags.KindBool, JSONKey: "cumulative",
+	}
+	// AxisScaleFlag overrides the parallel chart's --scale for single stat
+	// axes: comma-separated stat=scale pairs (e.g. Allocations/op=log).
+	AxisScaleFlag = flags.Flag{
+		Name: "axis-scale", Usage: "Per-axis scale as stat=scale pairs (e.g. Allocations/op=log); other axes use --scale",
+		Kind: flags.KindString, JSONKey: "axisScale",
+		MultiValue: true,
+		Validate:   ValidateAxisScaleValue,
+		Encode:     EncodeAxisScale,
+		Rule:       []flags.RuleFn{AxisScaleStatTypes()},
+	}
+	// BrushFlag styles parallel-axis brushing (drag along an axis to filter
+	// lines): bare --brush keeps the defaults, or pass semicolon-separated
+	// props (--brush inactiveOpacity=0.02;color=#888).
+	BrushFlag = flags.Flag{
+		Name: "brush",
+		Usage: "Axis brushing style for parallel charts (bare = defaults, or props " +
+			"semicolon-separated: color, width, opacity, activeOpacity, inactiveOpacity)",
+		Kind:         flags.KindObject,
+		JSONKey:      "brush",
+		Encode:       EncodeBrushObject,
+		ObjectFields: brushObjectFields(),
+	}
+	// WeekStartFlag, YearsFlag, CellSizeFlag and CalendarVisualMapFlag lay out
+	// the calendar chart. Each needs a date-like dimension to draw on.
+	WeekStartFlag = flags.Flag{
+		Name: "week-start", Usage: "First day of each calendar week (sunday, monday)",
+		Kind: flags.KindString, JSONKey: "weekStart",
+		Validate:   ValidateWeekStartValue,
+		Encode:     func(v any) any { return strings.ToLower(v.(string)) },
+		Label:      "week start",
+		ValidSet:   []string{"sunday", "monday"},
+		Normalizer: strings.ToLower,
+		Rule:       []flags.RuleFn{RequiresDateAxis()},
+	}
+	YearsFlag = flags.Flag{
+		Name: "years", Usage: "Calendar years to draw: one year (2024) or a range (2022-2024); default: years in the data",
+		Kind: flags.KindString, JSONKey: "years",
+		Validate: ValidateYearsValue,
+		Encode:   EncodeYears,
+		Rule:     []flags.RuleFn{RequiresDateAxis()},
+	}
+	CellSizeFlag = flags.Flag{
+		Name: "cell-size", Usage: "Calendar day cell size in px (4–64)",
+		Kind: flags.KindInt, JSONKey: "cellSize",
+		Validate: ValidateCellSizeValue,
+		Rule:     []flags.RuleFn{RequiresDateAxis()},
+	}
+	// CalendarVisualMapFlag shares --visualmap's name and key with scatter but
+	// not its 2D-only rule: days are always colored by value, and the flag
+	// shows the color scale (drag its handles to filter days).
+	CalendarVisualMapFlag = flags.Flag{
+		Name: "visualmap", Usage: "Show the calendar color scale (drag its handles to filter days by value)",
+		Kind: flags.KindBool, JSONKey: "visualMap",
+		Rule: []flags.RuleFn{RequiresDateAxis()},
+	}
+	// TagAxisFlag, RankFlag, FromTagFlag and ToTagFlag read the history vizb
+	// merge builds (bump, waterfall). Each needs a dimension holding the tags.
+	TagAxisFlag = flags.Flag{
+		Name: "tag-axis", Usage: "Dimension holding the merged tags (n, x, y, z; default: detected)",
+		Kind: flags.KindString, JSONKey: "tagAxis",
+		Validate:   ValidateTagAxisValue,
+		Encode:     func(v any) any { return strings.ToLower(v.(string)) },
+		Label:      "tag axis",
+		ValidSet:   []string{"n", "x", "y", "z"},
+		Normalizer: strings.ToLower,
+		Rule:       []flags.RuleFn{RequiresTagAxis(), MatchesTagAxis()},
+	}
+	RankFlag = flags.Flag{
+		Name: "rank", Usage: "Rank order per tag (asc: lowest value ranks first, desc: highest; default: asc)",
+		Kind: flags.KindString, JSONKey: "rank",
+		Validate:   ValidateSortValue,
+		Encode:     func(v any) any { return strings.ToLower(v.(string)) },
+		Label:      "rank order",
+		ValidSet:   []string{"asc", "desc"},
+		Normalizer: strings.ToLower,
+		Rule:       []flags.RuleFn{RequiresTagAxis()},
+	}
+	FromTagFlag = flags.Flag{
+		Name: "from", Usage: "Tag the waterfall starts from (default: oldest tag)",
+		Kind: flags.KindString, JSONKey: "from",
+		Rule: []flags.RuleFn{RequiresTag()},
+	}
+	ToTagFlag = flags.Flag{
+		Name: "to", Usage: "Tag the waterfall ends at (default: latest tag)",
+		Kind: flags.KindString, JSONKey: "to",
+		Rule: []flags.RuleFn{RequiresTag()},
+	}
+	// MinFlag, MaxFlag, LogBaseFlag, ZeroBaselineFlag, InverseFlag and
+	// ZoomFlag shape the value axis (and the category axis's zoom) of the 2D
+	// cartesian charts. Each is skipped on 3D, where the UI keeps its own
+	// axes.
+	MinFlag = flags.Flag{
+		Name: "min", Usage: "Value-axis minimum: a number, or a percentile of the plotted values (p5)",
+		Kind: flags.KindString, JSONKey: "min",
+		Validate: ValidateAxisBoundValue,
+		Encode:   EncodeAxisBound,
+		Rule:     []flags.RuleFn{Excludes3DMode(), AxisBoundsFit()},
+	}
+	MaxFlag = flags.Flag{
+		Name: "max", Usage: "Value-axis maximum: a number, or a percentile of the plotted values (p95)",
+		Kind: flags.KindString, JSONKey: "max",
+		Validate: ValidateAxisBoundValue,
+		Encode:   EncodeAxisBound,
+		Rule:     []flags.RuleFn{Excludes3DMode(), AxisBoundsFit()},
+	}
+	LogBaseFlag = flags.Flag{
+		Name: "log-base", Usage: "Logarithm base for --scale log (default 10), e.g. 2",
+		Kind: flags.KindFloat, JSONKey: "logBase",
+		Validate: ValidateLogBaseValue,
+		Rule:     []flags.RuleFn{Excludes3DMode(), RequiresLogScale()},
+	}
+	ZeroBaselineFlag = flags.Flag{
+		Name: "zero-baseline", Usage: "Always start the value axis at zero instead of fitting it to the data",
+		Kind: flags.KindBool, JSONKey: "zeroBaseline",
+		Rule: []flags.RuleFn{Excludes3DMode(), ZeroBaselineApplies()},
+	}
+	InverseFlag = flags.Flag{
+		Name: "inverse", Usage: "Invert the value axis (largest values at the bottom, or left for horizontal bars)",
+		Kind: flags.KindBool, JSONKey: "inverse",
+		Rule: []flags.RuleFn{Excludes3DMode()},
+	}
+	ZoomFlag = flags.Flag{
+		Name: "zoom", Usage: "Data zoom on the category axis (slider, inside, both); long axes get both by default",
+		Kind: flags.KindString, JSONKey: "zoom",
+		Validate:   ValidateZoomValue,
+		Encode:     func(v any) any { return strings.ToLower(v.(string)) },
+		Label:      "zoom",
+		ValidSet:   []string{ZoomSlider, ZoomInside, ZoomBoth},
+		Normalizer: strings.ToLower,
+		Rule:       []flags.RuleFn{Excludes3DMode(), RequiresAxes("x")},
+	}
+	// Y2Flag moves bar/line series onto a secondary value axis so stats in
+	// different units (ns vs B) do not flatten each other: "auto" splits by
+	// the unit in each series' stat type, or list series names. 2D, linear and
+	// unstacked only.
+	Y2Flag = flags.Flag{
+		Name: "y2", Usage: "Series on a secondary value axis: auto (by unit) or comma-separated names (e.g. 'Memory Usage')",
+		Kind: flags.KindString, JSONKey: "y2",
+		MultiValue: true,
+		Validate:   ValidateY2Value,
+		Encode:     EncodeY2,
+		Rule:       []flags.RuleFn{RequiresAxes("y"), Excludes3DMode(), Y2Conflicts()},
+	}
+	// MarkFlag draws one reference mark (bar, line, scatter): a threshold or
+	// target line, a value band, or a point callout. Repeat it for several
+	// marks (--mark type=hline;value=50 --mark type=band;from=0;to=10). Each
+	// bag decodes into a shared.Annotation, which checks the fields its type
+	// needs. 2D only.
+	MarkFlag = flags.Flag{
+		Name: "mark",
+		Usage: "Reference mark, repeatable (2D only; props semicolon-separated: type " +
+			"(hline, vline, band, point), stat, value, from, to, at, label, color)",
+		Kind:         flags.KindObject,
+		JSONKey:      "mark",
+		Repeatable:   true,
+		ObjectFields: markObjectFields(),
+		Rule:         []flags.RuleFn{Excludes3DMode(), MarkStatTypes()},
+	}
 	// BgFlag is the bar-only category background: bare --bg turns it on, and a
 	// semicolon bag of style fields (--bg color=…;borderColor=#000) adds typed
 	// props. Encode injects the implicit "active": true on-switch; "active" is
@@ -130,6 +340,42 @@ var (
 	}
 )
 
+// brushObjectFields lists the typed fields accepted inside the --brush object.
+func brushObjectFields() []flags.ObjectField {
+	return []flags.ObjectField{
+		{Name: "color", Kind: flags.KindString},
+		{Name: "width", Kind: flags.KindFloat, Validate: ValidateNonNegativeNumberValue, Encode: EncodeNumber},
+		{Name: "opacity", Kind: flags.KindFloat, Validate: ValidateOpacityValue, Encode: EncodeNumber},
+		{Name: "activeOpacity", Kind: flags.KindFloat, Validate: ValidateOpacityValue, Encode: EncodeNumber},
+		{Name: "inactiveOpacity", Kind: flags.KindFloat, Validate: ValidateOpacityValue, Encode: EncodeNumber},
+	}
+}
+
+// EncodeBrushObject maps the parsed --brush bag to its payload. A bare --brush
+// encodes as an empty object, so the renderer keeps its defaults.
+func EncodeBrushObject(v any) any {
+	bag, _ := v.(map[string]any)
+	if bag == nil {
+		bag = map[string]any{}
+	}
+	return bag
+}
+
+// markObjectFields lists the typed fields accepted inside one --mark object.
+func markObjectFields() []flags.ObjectField {
+	return []flags.ObjectField{
+		{Name: "type", Kind: flags.KindString, Validate: ValidateMarkTypeValue,
+			Encode: func(v any) any { return strings.ToLower(v.(string)) }},
+		{Name: "stat", Kind: flags.KindString},
+		{Name: "value", Kind: flags.KindFloat, Validate: ValidateNumberValue, Encode: EncodeNumber},
+		{Name: "from", Kind: flags.KindFloat, Validate: ValidateNumberValue, Encode: EncodeNumber},
+		{Name: "to", Kind: flags.KindFloat, Validate: ValidateNumberValue, Encode: EncodeNumber},
+		{Name: "at", Kind: flags.KindString},
+		{Name: "label", Kind: flags.KindString},
+		{Name: "color", Kind: flags.KindString},
+	}
+}
+
 // bgObjectFields lists the typed style fields accepted inside the --bg object.
 func bgObjectFields() []flags.ObjectField {
 	return []flags.ObjectField{
@@ -322,6 +568,372 @@ func ValidateBorderTypeValue(s string) error {
 	return fmt.Errorf("border type %q is invalid (must be solid, dashed, or dotted)", s)
 }
 
+// ValidateHierarchyLevelValue reports whether s is a hierarchy level count:
+// an integer from 1 to 4 (name, x, y, z).
+func ValidateHierarchyLevelValue(s string) error {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return fmt.Errorf("hierarchy level %q must be an integer", s)
+	}
+	if n < 1 || n > 4 {
+		return fmt.Errorf("hierarchy level must be between 1 and 4, got %d", n)
+	}
+	return nil
+}
+
+// Histogram bin methods. An empty method defers to the options that are set.
+const (
+	BinMethodCount = "count"
+	BinMethodWidth = "width"
+	BinMethodFD    = "fd" // Freedman–Diaconis: width from the interquartile range
+	BinMethodLog   = "log"
+)
+
+// MaxHistogramBins caps the bins one stat can produce, so a tiny --bin-width
+// over a wide range cannot explode the output.
+const MaxHistogramBins = 500
+
+// ResolveBinMethod returns the bin method a histogram applies: an explicit
+// method wins, then a bin width, then a bin count, then fd. A width or count
+// method missing its value resolves as if no method was given.
+func ResolveBinMethod(method string, bins int, width float64) string {
+	switch method {
+	case BinMethodWidth:
+		if width > 0 {
+			return BinMethodWidth
+		}
+	case BinMethodCount:
+		if bins > 0 {
+			return BinMethodCount
+		}
+	case BinMethodFD, BinMethodLog:
+		return method
+	}
+	if width > 0 {
+		return BinMethodWidth
+	}
+	if bins > 0 {
+		return BinMethodCount
+	}
+	return BinMethodFD
+}
+
+// ValidateBinsValue reports whether s is a histogram bin count: an integer
+// from 1 to MaxHistogramBins.
+func ValidateBinsValue(s string) error {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return fmt.Errorf("bins %q must be an integer", s)
+	}
+	if n < 1 || n > MaxHistogramBins {
+		return fmt.Errorf("bins must be between 1 and %d, got %d", MaxHistogramBins, n)
+	}
+	return nil
+}
+
+// ValidateBinWidthValue reports whether s is a positive, finite bin width.
+func ValidateBinWidthValue(s string) error {
+	n, ok := parseFiniteFloat(s)
+	if !ok {
+		return fmt.Errorf("bin width %q must be a number", s)
+	}
+	if n <= 0 {
+		return fmt.Errorf("bin width must be greater than 0, got %g", n)
+	}
+	return nil
+}
+
+// ValidateBinMethodValue reports whether s is a histogram bin method,
+// case-insensitively.
+func ValidateBinMethodValue(s string) error {
+	switch strings.ToLower(s) {
+	case BinMethodCount, BinMethodWidth, BinMethodFD, BinMethodLog:
+		return nil
+	}
+	return fmt.Errorf("bin method %q is invalid (must be count, width, fd, or log)", s)
+}
+
+// parseAxisScale splits "stat=scale,stat=scale" into a stat → scale map with
+// lowercased scales. Stat types may contain '=' (the last one separates the
+// scale); a repeated stat keeps its last scale.
+func parseAxisScale(s string) (map[string]string, error) {
+	out := map[string]string{}
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		i := strings.LastIndex(pair, "=")
+		if i <= 0 {
+			return nil, fmt.Errorf("axis scale %q must be stat=scale (e.g. Allocations/op=log)", pair)
+		}
+		stat, scale := strings.TrimSpace(pair[:i]), strings.TrimSpace(pair[i+1:])
+		if err := ValidateScaleValue(scale); err != nil {
+			return nil, fmt.Errorf("axis scale for %q: %w", stat, err)
+		}
+		out[stat] = strings.ToLower(scale)
+	}
+	return out, nil
+}
+
+// ValidateAxisScaleValue reports whether s is a comma-separated list of
+// stat=scale pairs with linear or log scales.
+func ValidateAxisScaleValue(s string) error {
+	_, err := parseAxisScale(s)
+	return err
+}
+
+// EncodeAxisScale maps a validated --axis-scale value to its stat → scale
+// object payload.
+func EncodeAxisScale(v any) any {
+	s, ok := v.(string)
+	if !ok {
+		return v
+	}
+	scales, err := parseAxisScale(s)
+	if err != nil {
+		return v
+	}
+	return scales
+}
+
+// ValidateWeekStartValue reports whether s names a calendar week start
+// (sunday or monday), case-insensitively.
+func ValidateWeekStartValue(s string) error {
+	switch strings.ToLower(s) {
+	case "sunday", "monday":
+		return nil
+	}
+	return fmt.Errorf("week start %q is invalid (must be \"sunday\" or \"monday\")", s)
+}
+
+// parseYears reads "2024" or "2022-2024" as an inclusive year range.
+func parseYears(s string) (from, to int, err error) {
+	lo, hi, isRange := strings.Cut(strings.TrimSpace(s), "-")
+	if from, err = strconv.Atoi(strings.TrimSpace(lo)); err != nil {
+		return 0, 0, fmt.Errorf("years %q must be a year (2024) or a range (2022-2024)", s)
+	}
+	to = from
+	if isRange {
+		if to, err = strconv.Atoi(strings.TrimSpace(hi)); err != nil {
+			return 0, 0, fmt.Errorf("years %q must be a year (2024) or a range (2022-2024)", s)
+		}
+	}
+	if from < 1 || to > 9999 {
+		return 0, 0, fmt.Errorf("years %q must be between 1 and 9999", s)
+	}
+	if from > to {
+		return 0, 0, fmt.Errorf("years %q: range start is after its end", s)
+	}
+	return from, to, nil
+}
+
+// ValidateYearsValue reports whether s is a year or an ascending year range.
+func ValidateYearsValue(s string) error {
+	_, _, err := parseYears(s)
+	return err
+}
+
+// EncodeYears maps a validated --years value to its {from, to} object payload.
+func EncodeYears(v any) any {
+	s, ok := v.(string)
+	if !ok {
+		return v
+	}
+	from, to, err := parseYears(s)
+	if err != nil {
+		return v
+	}
+	return map[string]int{"from": from, "to": to}
+}
+
+// ValidateCellSizeValue reports whether s is a calendar cell size: an integer
+// from 4 to 64 px.
+func ValidateCellSizeValue(s string) error {
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return fmt.Errorf("cell size %q must be an integer", s)
+	}
+	if n < 4 || n > 64 {
+		return fmt.Errorf("cell size must be between 4 and 64, got %d", n)
+	}
+	return nil
+}
+
+// ValidateTagAxisValue reports whether s names a dimension (n, x, y, z),
+// case-insensitively.
+func ValidateTagAxisValue(s string) error {
+	switch strings.ToLower(s) {
+	case "n", "x", "y", "z":
+		return nil
+	}
+	return fmt.Errorf("tag axis %q is invalid (must be n, x, y, or z)", s)
+}
+
+// Reference mark types (--mark type=…, Dataset annotations).
+const (
+	MarkHLine = "hline" // horizontal line at a stat value
+	MarkVLine = "vline" // vertical line at an axis value
+	MarkBand  = "band"  // shaded stat range, from..to
+	MarkPoint = "point" // callout at one axis value and stat value
+)
+
+// MarkTypes lists the reference mark types in documentation order.
+var MarkTypes = []string{MarkHLine, MarkVLine, MarkBand, MarkPoint}
+
+// ValidateMarkTypeValue reports whether s is a reference mark type,
+// case-insensitively.
+func ValidateMarkTypeValue(s string) error {
+	if slices.Contains(MarkTypes, strings.ToLower(s)) {
+		return nil
+	}
+	return fmt.Errorf("mark type %q is invalid (must be hline, vline, band, or point)", s)
+}
+
+// MarkStatMatches reports whether a mark's stat key names statType: the
+// whole type or, for typed units such as "Execution Time (ns/op)", the unit in
+// parentheses ("ns/op"). Matching is case-insensitive.
+func MarkStatMatches(statType, stat string) bool {
+	if strings.EqualFold(statType, stat) {
+		return true
+	}
+	_, unit := SplitStatUnit(statType)
+	return unit != "" && strings.EqualFold(unit, stat)
+}
+
+// SplitStatUnit splits a typed stat such as "Execution Time (ns/op)" into its
+// name ("Execution Time") and the unit in trailing parentheses ("ns/op").
+// Types without a unit come back whole with an empty unit.
+func SplitStatUnit(statType string) (name, unit string) {
+	open := strings.LastIndex(statType, "(")
+	if open < 0 || !strings.HasSuffix(statType, ")") {
+		return statType, ""
+	}
+	return strings.TrimSpace(statType[:open]), statType[open+1 : len(statType)-1]
+}
+
+// ParseAxisBound reads an axis bound: a finite number (absolute) or "p<N>"
+// (a percentile from 0 to 100 of the plotted values, case-insensitive).
+func ParseAxisBound(s string) (value float64, percentile bool, err error) {
+	s = strings.TrimSpace(s)
+	if rest, ok := strings.CutPrefix(strings.ToLower(s), "p"); ok {
+		v, ok := parseFiniteFloat(rest)
+		if !ok || v < 0 || v > 100 {
+			return 0, false, fmt.Errorf("axis bound %q: percentile must be p0–p100 (e.g. p95)", s)
+		}
+		return v, true, nil
+	}
+	v, ok := parseFiniteFloat(s)
+	if !ok {
+		return 0, false, fmt.Errorf("axis bound %q must be a number or a percentile (e.g. 0, 1.5e3, p95)", s)
+	}
+	return v, false, nil
+}
+
+// ValidateAxisBoundValue reports whether s is a number or a percentile bound.
+func ValidateAxisBoundValue(s string) error {
+	_, _, err := ParseAxisBound(s)
+	return err
+}
+
+// EncodeAxisBound maps a validated --min/--max value to its payload: a number,
+// or the normalized "p<N>" string for percentiles.
+func EncodeAxisBound(v any) any {
+	s, ok := v.(string)
+	if !ok {
+		return v
+	}
+	n, pct, err := ParseAxisBound(s)
+	if err != nil {
+		return v
+	}
+	if pct {
+		return "p" + strconv.FormatFloat(n, 'f', -1, 64)
+	}
+	return n
+}
+
+// ValidateLogBaseValue reports whether s is a usable logarithm base: a
+// finite number greater than 1.
+func ValidateLogBaseValue(s string) error {
+	v, ok := parseFiniteFloat(s)
+	if !ok {
+		return fmt.Errorf("log base %q must be a number", s)
+	}
+	if v <= 1 {
+		return fmt.Errorf("log base must be greater than 1, got %g", v)
+	}
+	return nil
+}
+
+// Category-axis data zoom kinds (--zoom).
+const (
+	ZoomSlider = "slider" // draggable range bar below (or beside) the axis
+	ZoomInside = "inside" // wheel / pinch zoom inside the plot
+	ZoomBoth   = "both"
+)
+
+// ValidateZoomValue reports whether s is a data zoom kind, case-insensitively.
+func ValidateZoomValue(s string) error {
+	switch strings.ToLower(s) {
+	case ZoomSlider, ZoomInside, ZoomBoth:
+		return nil
+	}
+	return fmt.Errorf("zoom %q is invalid (must be slider, inside, or both)", s)
+}
+
+// Y2Auto is the --y2 value that moves series to the secondary value axis by
+// unit instead of by name.
+const Y2Auto = "auto"
+
+// parseY2 splits a --y2 value into its trimmed, non-empty series names.
+// "auto" must stand alone.
+func parseY2(s string) ([]string, error) {
+	var names []string
+	for _, name := range strings.Split(s, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			return nil, fmt.Errorf("y2 %q has an empty series name", s)
+		}
+		names = append(names, name)
+	}
+	if len(names) > 1 && slices.ContainsFunc(names, func(n string) bool { return strings.EqualFold(n, Y2Auto) }) {
+		return nil, fmt.Errorf("y2 %q: %q cannot be combined with series names", s, Y2Auto)
+	}
+	if len(names) == 1 && strings.EqualFold(names[0], Y2Auto) {
+		names[0] = Y2Auto
+	}
+	return names, nil
+}
+
+// ValidateY2Value reports whether s is "auto" or a comma-separated list of
+// series names.
+func ValidateY2Value(s string) error {
+	_, err := parseY2(s)
+	return err
+}
+
+// EncodeY2 maps a validated --y2 value to its series-name array payload.
+func EncodeY2(v any) any {
+	s, ok := v.(string)
+	if !ok {
+		return v
+	}
+	names, err := parseY2(s)
+	if err != nil {
+		return v
+	}
+	return names
+}
+
+// Y2Matches reports whether a --y2 name selects series: the whole series
+// (stat type) name or, for typed units, the name without its unit ("Memory
+// Usage" selects "Memory Usage (B/op)"). Matching is case-insensitive.
+func Y2Matches(series, name string) bool {
+	if strings.EqualFold(series, name) {
+		return true
+	}
+	base, unit := SplitStatUnit(series)
+	return unit != "" && strings.EqualFold(base, name)
+}
+
 // EncodeNumber maps a validated numeric object-flag field value to a float64
 // payload (non-string input passes through unchanged).
 func EncodeNumber(v any) any {
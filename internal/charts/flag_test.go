@@ -82,6 +82,23 @@ func (s *ChartFlagSuite) TestBgFlagDescriptor() {
 	assert.False(t, known["active"], "active is not a user field")
 }
 
+func (s *ChartFlagSuite) TestMarkFlagDescriptor() {
+	t := s.T()
+	assert.Equal(t, "mark", charts.MarkFlag.EffectiveKey())
+	assert.Equal(t, "mark", charts.MarkFlag.JSONKey)
+	assert.Equal(t, flags.KindObject, charts.MarkFlag.Kind)
+	assert.True(t, charts.MarkFlag.Repeatable, "marks accumulate into an array")
+	assert.Len(t, charts.MarkFlag.Rule, 2, "2D only, and warns on unknown stats")
+
+	known := map[string]bool{}
+	for _, field := range charts.MarkFlag.ObjectFields {
+		known[field.Name] = true
+	}
+	for _, name := range []string{"type", "stat", "value", "from", "to", "at", "label", "color"} {
+		assert.True(t, known[name], "field %s", name)
+	}
+}
+
 func (s *ChartFlagSuite) TestEncodeBgObject() {
 	t := s.T()
 	assert.Equal(t, map[string]any{"active": true}, charts.EncodeBgObject(map[string]any{}))
@@ -93,6 +110,160 @@ func (s *ChartFlagSuite) TestEncodeBgObject() {
 	assert.Equal(t, map[string]any{"active": true}, charts.EncodeBgObject("junk"))
 }
 
+func (s *ChartFlagSuite) TestAxisScaleValue() {
+	t := s.T()
+	require.NoError(t, charts.ValidateAxisScaleValue("B/op=log"))
+	require.NoError(t, charts.ValidateAxisScaleValue("B/op=LOG, allocs/op=linear"))
+	assert.Error(t, charts.ValidateAxisScaleValue("B/op"), "missing scale")
+	assert.Error(t, charts.ValidateAxisScaleValue("=log"), "missing stat")
+	assert.Error(t, charts.ValidateAxisScaleValue("B/op=sqrt"))
+
+	assert.Equal(t,
+		map[string]string{"B/op": "log", "allocs/op": "linear"},
+		charts.EncodeAxisScale("B/op=LOG,allocs/op=log,allocs/op=linear"),
+		"scales are lowercased and a repeated stat keeps its last scale",
+	)
+	assert.Equal(t, map[string]string{"a=b": "log"}, charts.EncodeAxisScale("a=b=log"), "the last '=' separates the scale")
+	assert.Equal(t, "junk", charts.EncodeAxisScale("junk"))
+}
+
+func (s *ChartFlagSuite) TestEncodeBrushObject() {
+	t := s.T()
+	assert.Equal(t, map[string]any{}, charts.EncodeBrushObject(nil))
+	assert.Equal(t,
+		map[string]any{"inactiveOpacity": 0.02},
+		charts.EncodeBrushObject(map[string]any{"inactiveOpacity": 0.02}),
+	)
+}
+
+func (s *ChartFlagSuite) TestCalendarValues() {
+	t := s.T()
+	require.NoError(t, charts.ValidateWeekStartValue("Monday"))
+	require.NoError(t, charts.ValidateWeekStartValue("sunday"))
+	assert.Error(t, charts.ValidateWeekStartValue("mon"))
+
+	require.NoError(t, charts.ValidateYearsValue("2024"))
+	require.NoError(t, charts.ValidateYearsValue("2022-2024"))
+	assert.Error(t, charts.ValidateYearsValue("2024-2022"), "descending range")
+	assert.Error(t, charts.ValidateYearsValue("last year"))
+	assert.Error(t, charts.ValidateYearsValue("2022-"))
+	assert.Error(t, charts.ValidateYearsValue("0"))
+	assert.Equal(t, map[string]int{"from": 2024, "to": 2024}, charts.EncodeYears("2024"))
+	assert.Equal(t, map[string]int{"from": 2022, "to": 2024}, charts.EncodeYears(" 2022 - 2024 "))
+	assert.Equal(t, "junk", charts.EncodeYears("junk"))
+
+	require.NoError(t, charts.ValidateCellSizeValue("4"))
+	require.NoError(t, charts.ValidateCellSizeValue("64"))
+	assert.Error(t, charts.ValidateCellSizeValue("3"))
+	assert.Error(t, charts.ValidateCellSizeValue("65"))
+	assert.Error(t, charts.ValidateCellSizeValue("big"))
+}
+
+func (s *ChartFlagSuite) TestValidateTagAxisValue() {
+	t := s.T()
+	for _, v := range []string{"n", "x", "Y", "z"} {
+		require.NoError(t, charts.ValidateTagAxisValue(v))
+	}
+	assert.Error(t, charts.ValidateTagAxisValue("name"))
+	assert.Error(t, charts.ValidateTagAxisValue(""))
+}
+
+func (s *ChartFlagSuite) TestValidateMarkTypeValue() {
+	t := s.T()
+	for _, v := range []string{"hline", "VLine", "band", "point"} {
+		require.NoError(t, charts.ValidateMarkTypeValue(v))
+	}
+	assert.Error(t, charts.ValidateMarkTypeValue("line"))
+	assert.Error(t, charts.ValidateMarkTypeValue(""))
+}
+
+func (s *ChartFlagSuite) TestMarkStatMatches() {
+	t := s.T()
+	assert.True(t, charts.MarkStatMatches("ns/op", "NS/OP"))
+	assert.True(t, charts.MarkStatMatches("Execution Time (ns/op)", "ns/op"))
+	assert.True(t, charts.MarkStatMatches("Execution Time (ns/op)", "execution time (ns/op)"))
+	assert.False(t, charts.MarkStatMatches("Execution Time (ns/op)", "B/op"))
+	assert.False(t, charts.MarkStatMatches("Allocations/op", "op"))
+}
+
+func (s *ChartFlagSuite) TestSplitStatUnit() {
+	t := s.T()
+	name, unit := charts.SplitStatUnit("Memory Usage (B/op)")
+	assert.Equal(t, "Memory Usage", name)
+	assert.Equal(t, "B/op", unit)
+	name, unit = charts.SplitStatUnit("Allocations/op")
+	assert.Equal(t, "Allocations/op", name)
+	assert.Empty(t, unit)
+}
+
+func (s *ChartFlagSuite) TestValidateY2Value() {
+	t := s.T()
+	for _, v := range []string{"auto", "AUTO", "Memory Usage", "Memory Usage, Allocations/op"} {
+		require.NoError(t, charts.ValidateY2Value(v), v)
+	}
+	assert.ErrorContains(t, charts.ValidateY2Value("auto,Memory Usage"), "cannot be combined")
+	assert.ErrorContains(t, charts.ValidateY2Value("a,,b"), "empty series name")
+	assert.Error(t, charts.ValidateY2Value(""))
+}
+
+func (s *ChartFlagSuite) TestEncodeY2() {
+	t := s.T()
+	assert.Equal(t, []string{"auto"}, charts.EncodeY2("Auto"))
+	assert.Equal(t, []string{"Memory Usage", "Allocations/op"}, charts.EncodeY2(" Memory Usage ,Allocations/op"))
+	assert.Equal(t, 3, charts.EncodeY2(3), "non-string passes through")
+}
+
+func (s *ChartFlagSuite) TestY2Matches() {
+	t := s.T()
+	assert.True(t, charts.Y2Matches("Memory Usage (B/op)", "memory usage"))
+	assert.True(t, charts.Y2Matches("Memory Usage (B/op)", "Memory Usage (B/op)"))
+	assert.True(t, charts.Y2Matches("Allocations/op", "allocations/op"))
+	assert.False(t, charts.Y2Matches("Memory Usage (B/op)", "B/op"))
+	assert.False(t, charts.Y2Matches("Execution Time (ns/op)", "Memory Usage"))
+}
+
+func (s *ChartFlagSuite) TestParseAxisBound() {
+	t := s.T()
+	v, pct, err := charts.ParseAxisBound("1.5e3")
+	require.NoError(t, err)
+	assert.Equal(t, 1500.0, v)
+	assert.False(t, pct)
+
+	v, pct, err = charts.ParseAxisBound("P99.9")
+	require.NoError(t, err)
+	assert.Equal(t, 99.9, v)
+	assert.True(t, pct)
+
+	for _, bad := range []string{"", "p", "p101", "p-1", "NaN", "tall"} {
+		_, _, err := charts.ParseAxisBound(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func (s *ChartFlagSuite) TestEncodeAxisBound() {
+	t := s.T()
+	assert.Equal(t, -2.5, charts.EncodeAxisBound("-2.5"))
+	assert.Equal(t, "p95", charts.EncodeAxisBound("P95.0"))
+	assert.Equal(t, 3, charts.EncodeAxisBound(3), "non-string passes through")
+}
+
+func (s *ChartFlagSuite) TestValidateLogBaseValue() {
+	t := s.T()
+	require.NoError(t, charts.ValidateLogBaseValue("2"))
+	require.NoError(t, charts.ValidateLogBaseValue("2.718"))
+	assert.ErrorContains(t, charts.ValidateLogBaseValue("1"), "greater than 1")
+	assert.ErrorContains(t, charts.ValidateLogBaseValue("0.5"), "greater than 1")
+	assert.Error(t, charts.ValidateLogBaseValue("e"))
+}
+
+func (s *ChartFlagSuite) TestValidateZoomValue() {
+	t := s.T()
+	for _, v := range []string{"slider", "Inside", "BOTH"} {
+		require.NoError(t, charts.ValidateZoomValue(v), v)
+	}
+	assert.Error(t, charts.ValidateZoomValue("scroll"))
+}
+
 func (s *ChartFlagSuite) TestValidateNumberValue() {
 	t := s.T()
 	require.NoError(t, charts.ValidateNumberValue("0"))
@@ -138,6 +309,44 @@ func (s *ChartFlagSuite) TestValidateBorderTypeValue() {
 	assert.Error(t, charts.ValidateBorderTypeValue(""))
 }
 
+func (s *ChartFlagSuite) TestValidateHierarchyLevelValue() {
+	t := s.T()
+	require.NoError(t, charts.ValidateHierarchyLevelValue("1"))
+	require.NoError(t, charts.ValidateHierarchyLevelValue("4"))
+	assert.Error(t, charts.ValidateHierarchyLevelValue("0"))
+	assert.Error(t, charts.ValidateHierarchyLevelValue("5"))
+	assert.Error(t, charts.ValidateHierarchyLevelValue("two"))
+}
+
+func (s *ChartFlagSuite) TestValidateBinValues() {
+	t := s.T()
+	require.NoError(t, charts.ValidateBinsValue("1"))
+	require.NoError(t, charts.ValidateBinsValue("500"))
+	assert.Error(t, charts.ValidateBinsValue("0"))
+	assert.Error(t, charts.ValidateBinsValue("501"))
+	assert.Error(t, charts.ValidateBinsValue("ten"))
+
+	require.NoError(t, charts.ValidateBinWidthValue("0.25"))
+	assert.Error(t, charts.ValidateBinWidthValue("0"))
+	assert.Error(t, charts.ValidateBinWidthValue("-1"))
+	assert.Error(t, charts.ValidateBinWidthValue("Inf"))
+
+	require.NoError(t, charts.ValidateBinMethodValue("FD"))
+	require.NoError(t, charts.ValidateBinMethodValue("log"))
+	assert.Error(t, charts.ValidateBinMethodValue("sturges"))
+}
+
+func (s *ChartFlagSuite) TestResolveBinMethod() {
+	t := s.T()
+	assert.Equal(t, charts.BinMethodFD, charts.ResolveBinMethod("", 0, 0))
+	assert.Equal(t, charts.BinMethodCount, charts.ResolveBinMethod("", 20, 0))
+	assert.Equal(t, charts.BinMethodWidth, charts.ResolveBinMethod("", 20, 5), "a width beats a count")
+	assert.Equal(t, charts.BinMethodLog, charts.ResolveBinMethod("log", 0, 5))
+	assert.Equal(t, charts.BinMethodCount, charts.ResolveBinMethod("count", 10, 5), "explicit method wins")
+	assert.Equal(t, charts.BinMethodFD, charts.ResolveBinMethod("width", 0, 0), "width without --bin-width")
+	assert.Equal(t, charts.BinMethodCount, charts.ResolveBinMethod("width", 10, 0))
+}
+
 func (s *ChartFlagSuite) TestEncodeNumber() {
 	t := s.T()
 	assert.Equal(t, 0.5, charts.EncodeNumber("0.5"))
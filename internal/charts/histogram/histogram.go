@@ -0,0 +1,45 @@
+// Package histogram defines the typed Config for histogram charts. Bins are
+// computed in Go when the Dataset is assembled (one point per bin and series),
+// so the Config carries the binning options and the cumulative toggle; scale,
+// stack, 3D, and visualMap do not apply.
+package histogram
+
+import (
+	"github.com/goptics/vizb/internal/charts"
+	"github.com/goptics/vizb/shared"
+)
+
+const Type = "histogram"
+
+type Config struct {
+	Type       string             `json:"type"`
+	Swap       string             `json:"swap,omitempty"`
+	Sort       *shared.Sort       `json:"sort,omitempty"`
+	ShowLabels *bool              `json:"showLabels,omitempty"`
+	Bins       *int               `json:"bins,omitempty"`
+	BinWidth   *float64           `json:"binWidth,omitempty"`
+	BinMethod  string             `json:"binMethod,omitempty"`
+	Cumulative *bool              `json:"cumulative,omitempty"`
+	Stat       *shared.StatConfig `json:"stat,omitempty"`
+}
+
+func (Config) ChartType() string { return Type }
+
+func (c Config) StatEnabled() bool  { return c.Stat.StatEnabled() }
+func (c Config) StatMath() []string { return c.Stat.StatMath() }
+func (c Config) SwapString() string { return c.Swap }
+
+// BinOptions returns the binning options for shared.BinDataPoints.
+func (c Config) BinOptions() shared.BinOptions {
+	opts := shared.BinOptions{Method: c.BinMethod}
+	if c.Bins != nil {
+		opts.Count = *c.Bins
+	}
+	if c.BinWidth != nil {
+		opts.Width = *c.BinWidth
+	}
+	return opts
+}
+
+// New returns a fresh zero-value histogram chart Config.
+func New() charts.ChartConfig { return &Config{} }
@@ -0,0 +1,82 @@
+package histogram_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	_ "github.com/goptics/vizb/cmd/charts/histogram"
+	"github.com/goptics/vizb/internal/charts"
+	histogramchart "github.com/goptics/vizb/internal/charts/histogram"
+	"github.com/goptics/vizb/shared"
+	"github.com/stretchr/testify/suite"
+)
+
+// HistogramSuite covers the histogram chart Config: its factory, JSON
+// round-trip, the "bin fields only, no 3D" JSON contract, and BinOptions.
+type HistogramSuite struct {
+	suite.Suite
+}
+
+func (s *HistogramSuite) TestNewReturnsZeroConfig() {
+	cfg := histogramchart.New()
+	got, ok := cfg.(*histogramchart.Config)
+	s.Require().True(ok)
+	s.Empty(got.Type)
+	s.Nil(got.Bins)
+	s.Nil(got.BinWidth)
+	s.Empty(got.BinMethod)
+	s.Nil(got.Cumulative)
+	s.Nil(got.Stat)
+}
+
+func (s *HistogramSuite) TestDecodeRoundTripAllFields() {
+	original := histogramchart.Config{
+		Type:       "histogram",
+		Swap:       "xy",
+		Sort:       &shared.Sort{Enabled: true, Order: "desc"},
+		ShowLabels: boolPtr(true),
+		Bins:       intPtr(40),
+		BinWidth:   floatPtr(2.5),
+		BinMethod:  "log",
+		Cumulative: boolPtr(true),
+		Stat:       &shared.StatConfig{Enabled: true, Math: []string{"counts"}},
+	}
+	raw, err := json.Marshal(original)
+	s.Require().NoError(err)
+
+	cfg, err := charts.Decode("histogram", raw)
+	s.Require().NoError(err)
+	got, ok := cfg.(*histogramchart.Config)
+	s.Require().True(ok)
+	s.Equal(original, *got)
+	s.Equal("histogram", got.ChartType())
+}
+
+func (s *HistogramSuite) TestJSONOmitsInapplicableChartFields() {
+	raw, err := json.Marshal(histogramchart.Config{Type: "histogram", Bins: intPtr(12)})
+	s.Require().NoError(err)
+
+	var m map[string]any
+	s.Require().NoError(json.Unmarshal(raw, &m))
+	for _, key := range []string{"scale", "stack", "threeD", "threeDRotate", "visualMap", "binWidth", "binMethod", "cumulative"} {
+		_, ok := m[key]
+		s.False(ok, "histogram JSON must not carry %q", key)
+	}
+	s.Equal(float64(12), m["bins"])
+}
+
+func (s *HistogramSuite) TestBinOptions() {
+	s.Equal(shared.BinOptions{}, histogramchart.Config{}.BinOptions())
+	s.Equal(
+		shared.BinOptions{Method: "width", Count: 8, Width: 0.5},
+		histogramchart.Config{BinMethod: "width", Bins: intPtr(8), BinWidth: floatPtr(0.5)}.BinOptions(),
+	)
+}
+
+func boolPtr(b bool) *bool        { return &b }
+func intPtr(n int) *int           { return &n }
+func floatPtr(f float64) *float64 { return &f }
+
+func TestHistogramSuite(t *testing.T) {
+	suite.Run(t, new(HistogramSuite))
+}
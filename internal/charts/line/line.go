@@ -11,19 +11,27 @@ import (
 const Type = "line"
 
 type Config struct {
-	Type            string             `json:"type"`
-	Swap            string             `json:"swap,omitempty"`
-	Sort            *shared.Sort       `json:"sort,omitempty"`
-	Scale           string             `json:"scale,omitempty"`
-	Stack           *bool              `json:"stack,omitempty"`
-	ShowLabels      *bool              `json:"showLabels,omitempty"`
-	Symbol          string             `json:"symbol,omitempty"`
-	SymbolSize      *float64           `json:"symbolSize,omitempty"`
-	Smooth          *bool              `json:"smooth,omitempty"`
-	ThreeDRotate    *bool              `json:"threeDRotate,omitempty"`
-	ThreeD          *bool              `json:"threeD,omitempty"`
-	ThreeDVisualMap *bool              `json:"threeDVisualMap,omitempty"`
-	Stat            *shared.StatConfig `json:"stat,omitempty"`
+	Type            string              `json:"type"`
+	Swap            string              `json:"swap,omitempty"`
+	Sort            *shared.Sort        `json:"sort,omitempty"`
+	Scale           string              `json:"scale,omitempty"`
+	Min             *shared.AxisBound   `json:"min,omitempty"`
+	Max             *shared.AxisBound   `json:"max,omitempty"`
+	LogBase         *float64            `json:"logBase,omitempty"`
+	ZeroBaseline    *bool               `json:"zeroBaseline,omitempty"`
+	Inverse         *bool               `json:"inverse,omitempty"`
+	Zoom            string              `json:"zoom,omitempty"`
+	Stack           *bool               `json:"stack,omitempty"`
+	Y2              []string            `json:"y2,omitempty"`
+	ShowLabels      *bool               `json:"showLabels,omitempty"`
+	Symbol          string              `json:"symbol,omitempty"`
+	SymbolSize      *float64            `json:"symbolSize,omitempty"`
+	Smooth          *bool               `json:"smooth,omitempty"`
+	ThreeDRotate    *bool               `json:"threeDRotate,omitempty"`
+	ThreeD          *bool               `json:"threeD,omitempty"`
+	ThreeDVisualMap *bool               `json:"threeDVisualMap,omitempty"`
+	Mark            []shared.Annotation `json:"mark,omitempty"`
+	Stat            *shared.StatConfig  `json:"stat,omitempty"`
 }
 
 func (Config) ChartType() string { return Type }
@@ -0,0 +1,31 @@
+// Package parallel defines the typed Config for parallel-coordinates charts:
+// one axis per stat type and one polyline per row. Scale is the default for
+// every axis and AxisScale overrides it per stat type; there are no per-point
+// labels, stack, or 3D.
+package parallel
+
+import (
+	"github.com/goptics/vizb/internal/charts"
+	"github.com/goptics/vizb/shared"
+)
+
+const Type = "parallel"
+
+type Config struct {
+	Type      string             `json:"type"`
+	Swap      string             `json:"swap,omitempty"`
+	Sort      *shared.Sort       `json:"sort,omitempty"`
+	Scale     string             `json:"scale,omitempty"`
+	AxisScale map[string]string  `json:"axisScale,omitempty"`
+	Brush     *shared.Brush      `json:"brush,omitempty"`
+	Stat      *shared.StatConfig `json:"stat,omitempty"`
+}
+
+func (Config) ChartType() string { return Type }
+
+func (c Config) StatEnabled() bool  { return c.Stat.StatEnabled() }
+func (c Config) StatMath() []string { return c.Stat.StatMath() }
+func (c Config) SwapString() string { return c.Swap }
+
+// New returns a fresh zero-value parallel chart Config.
+func New() charts.ChartConfig { return &Config{} }
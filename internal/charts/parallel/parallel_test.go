@@ -0,0 +1,69 @@
+package parallel_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	_ "github.com/goptics/vizb/cmd/charts/parallel"
+	"github.com/goptics/vizb/internal/charts"
+	parallelchart "github.com/goptics/vizb/internal/charts/parallel"
+	"github.com/goptics/vizb/shared"
+	"github.com/stretchr/testify/suite"
+)
+
+// ParallelSuite covers the parallel chart Config: its factory, JSON
+// round-trip, and the "axis fields only, no labels or 3D" JSON contract.
+type ParallelSuite struct {
+	suite.Suite
+}
+
+func (s *ParallelSuite) TestNewReturnsZeroConfig() {
+	cfg := parallelchart.New()
+	got, ok := cfg.(*parallelchart.Config)
+	s.Require().True(ok)
+	s.Empty(got.Type)
+	s.Empty(got.Scale)
+	s.Nil(got.AxisScale)
+	s.Nil(got.Brush)
+	s.Nil(got.Stat)
+}
+
+func (s *ParallelSuite) TestDecodeRoundTripAllFields() {
+	original := parallelchart.Config{
+		Type:      "parallel",
+		Swap:      "xy",
+		Sort:      &shared.Sort{Enabled: true, Order: "asc"},
+		Scale:     "log",
+		AxisScale: map[string]string{"ns/op": "linear", "allocs/op": "log"},
+		Brush:     &shared.Brush{Color: "#888", Width: floatPtr(2), InactiveOpacity: floatPtr(0.02)},
+		Stat:      &shared.StatConfig{Enabled: true},
+	}
+	raw, err := json.Marshal(original)
+	s.Require().NoError(err)
+
+	cfg, err := charts.Decode("parallel", raw)
+	s.Require().NoError(err)
+	got, ok := cfg.(*parallelchart.Config)
+	s.Require().True(ok)
+	s.Equal(original, *got)
+	s.Equal("parallel", got.ChartType())
+}
+
+func (s *ParallelSuite) TestJSONOmitsInapplicableChartFields() {
+	raw, err := json.Marshal(parallelchart.Config{Type: "parallel", Brush: &shared.Brush{}})
+	s.Require().NoError(err)
+
+	var m map[string]any
+	s.Require().NoError(json.Unmarshal(raw, &m))
+	for _, key := range []string{"showLabels", "stack", "threeD", "threeDRotate", "visualMap", "scale", "axisScale"} {
+		_, ok := m[key]
+		s.False(ok, "parallel JSON must not carry %q", key)
+	}
+	s.Equal(map[string]any{}, m["brush"], "a bare --brush keeps an empty object")
+}
+
+func floatPtr(f float64) *float64 { return &f }
+
+func TestParallelSuite(t *testing.T) {
+	suite.Run(t, new(ParallelSuite))
+}
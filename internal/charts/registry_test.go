@@ -6,13 +6,20 @@ import (
 	"testing"
 
 	_ "github.com/goptics/vizb/cmd/charts/bar"
+	_ "github.com/goptics/vizb/cmd/charts/bump"
+	_ "github.com/goptics/vizb/cmd/charts/calendar"
 	_ "github.com/goptics/vizb/cmd/charts/chord"
 	_ "github.com/goptics/vizb/cmd/charts/heatmap"
+	_ "github.com/goptics/vizb/cmd/charts/histogram"
 	_ "github.com/goptics/vizb/cmd/charts/line"
+	_ "github.com/goptics/vizb/cmd/charts/parallel"
 	_ "github.com/goptics/vizb/cmd/charts/pie"
 	_ "github.com/goptics/vizb/cmd/charts/radar"
 	_ "github.com/goptics/vizb/cmd/charts/sankey"
 	_ "github.com/goptics/vizb/cmd/charts/scatter"
+	_ "github.com/goptics/vizb/cmd/charts/sunburst"
+	_ "github.com/goptics/vizb/cmd/charts/treemap"
+	_ "github.com/goptics/vizb/cmd/charts/waterfall"
 	"github.com/goptics/vizb/internal/charts"
 	barchart "github.com/goptics/vizb/internal/charts/bar"
 	chordchart "github.com/goptics/vizb/internal/charts/chord"
@@ -34,7 +41,7 @@ type RegistrySuite struct {
 func (s *RegistrySuite) TestRegistryListsChartTypes() {
 	got := charts.Registered()
 	sort.Strings(got)
-	want := []string{"bar", "chord", "heatmap", "line", "pie", "radar", "sankey", "scatter"}
+	want := []string{"bar", "bump", "calendar", "chord", "heatmap", "histogram", "line", "parallel", "pie", "radar", "sankey", "scatter", "sunburst", "treemap", "waterfall"}
 	s.Equal(want, got)
 }
 
@@ -69,7 +76,7 @@ func (s *RegistrySuite) TestSmoothFlagIsLineOnly() {
 	}
 
 	s.True(flagNames("line")["smooth"])
-	for _, chartType := range []string{"bar", "scatter", "pie", "heatmap", "radar", "sankey", "chord"} {
+	for _, chartType := range []string{"bar", "scatter", "pie", "heatmap", "radar", "sankey", "chord", "treemap", "sunburst", "histogram", "parallel", "calendar", "bump", "waterfall"} {
 		s.False(flagNames(chartType)["smooth"], "%s should not register smooth", chartType)
 	}
 }
@@ -84,11 +91,154 @@ func (s *RegistrySuite) TestHorizontalFlagIsBarOnly() {
 	}
 
 	s.True(flagNames("bar")["horizontal"])
-	for _, chartType := range []string{"line", "scatter", "pie", "heatmap", "radar", "sankey", "chord"} {
+	for _, chartType := range []string{"line", "scatter", "pie", "heatmap", "radar", "sankey", "chord", "treemap", "sunburst", "histogram", "parallel", "calendar", "bump", "waterfall"} {
 		s.False(flagNames(chartType)["horizontal"], "%s should not register horizontal", chartType)
 	}
 }
 
+func (s *RegistrySuite) TestMarkFlagIsCartesian2DOnly() {
+	flagNames := func(chartType string) map[string]bool {
+		out := map[string]bool{}
+		for _, f := range charts.FlagsFor(chartType) {
+			out[f.EffectiveKey()] = true
+		}
+		return out
+	}
+
+	for _, chartType := range []string{"bar", "line", "scatter"} {
+		s.True(flagNames(chartType)["mark"], "%s should register mark", chartType)
+	}
+	for _, chartType := range []string{"pie", "heatmap", "radar", "sankey", "chord", "treemap", "sunburst", "histogram", "parallel", "calendar", "bump", "waterfall"} {
+		s.False(flagNames(chartType)["mark"], "%s should not register mark", chartType)
+	}
+}
+
+func (s *RegistrySuite) TestY2FlagIsBarAndLineOnly() {
+	for _, chartType := range charts.Registered() {
+		has := false
+		for _, f := range charts.FlagsFor(chartType) {
+			has = has || f.JSONKey == "y2"
+		}
+		s.Equal(chartType == "bar" || chartType == "line", has, "%s y2 registration", chartType)
+	}
+}
+
+func (s *RegistrySuite) TestAxisRangeFlagsAreCartesianOnly() {
+	cartesian := map[string]bool{"bar": true, "line": true, "scatter": true}
+	for _, chartType := range charts.Registered() {
+		keys := map[string]bool{}
+		for _, f := range charts.FlagsFor(chartType) {
+			keys[f.JSONKey] = true
+		}
+		for _, key := range []string{"min", "max", "logBase", "zeroBaseline", "inverse", "zoom"} {
+			s.Equal(cartesian[chartType], keys[key], "%s %s registration", chartType, key)
+		}
+	}
+}
+
+func (s *RegistrySuite) TestHierarchyFlagsAreTreemapAndSunburstOnly() {
+	flagNames := func(chartType string) map[string]bool {
+		out := map[string]bool{}
+		for _, f := range charts.FlagsFor(chartType) {
+			out[f.EffectiveKey()] = true
+		}
+		return out
+	}
+
+	for _, chartType := range []string{"treemap", "sunburst"} {
+		for _, key := range []string{"leaf-depth", "label-levels", "value-stat"} {
+			s.True(flagNames(chartType)[key], "%s should register %s", chartType, key)
+		}
+		s.False(flagNames(chartType)["scale"], "%s should not register scale", chartType)
+	}
+	for _, chartType := range []string{"bar", "line", "scatter", "pie", "heatmap", "radar", "sankey", "chord", "histogram", "parallel", "calendar", "bump", "waterfall"} {
+		s.False(flagNames(chartType)["leaf-depth"], "%s should not register leaf-depth", chartType)
+	}
+}
+
+func (s *RegistrySuite) TestBinFlagsAreHistogramOnly() {
+	flagNames := func(chartType string) map[string]bool {
+		out := map[string]bool{}
+		for _, f := range charts.FlagsFor(chartType) {
+			out[f.EffectiveKey()] = true
+		}
+		return out
+	}
+
+	for _, key := range []string{"bins", "bin-width", "bin-method", "cumulative"} {
+		s.True(flagNames("histogram")[key], "histogram should register %s", key)
+	}
+	s.False(flagNames("histogram")["scale"], "histogram should not register scale")
+	for _, chartType := range []string{"bar", "line", "scatter", "pie", "heatmap", "radar", "sankey", "chord", "treemap", "sunburst", "parallel", "calendar", "bump", "waterfall"} {
+		s.False(flagNames(chartType)["bins"], "%s should not register bins", chartType)
+	}
+}
+
+func (s *RegistrySuite) TestParallelFlags() {
+	flagNames := func(chartType string) map[string]bool {
+		out := map[string]bool{}
+		for _, f := range charts.FlagsFor(chartType) {
+			out[f.EffectiveKey()] = true
+		}
+		return out
+	}
+
+	for _, key := range []string{"scale", "axis-scale", "brush", "sort", "swap"} {
+		s.True(flagNames("parallel")[key], "parallel should register %s", key)
+	}
+	s.False(flagNames("parallel")["labels"], "parallel should not register labels")
+	for _, chartType := range []string{"bar", "line", "scatter", "pie", "heatmap", "radar", "sankey", "chord", "treemap", "sunburst", "histogram", "calendar", "bump", "waterfall"} {
+		s.False(flagNames(chartType)["axis-scale"], "%s should not register axis-scale", chartType)
+		s.False(flagNames(chartType)["brush"], "%s should not register brush", chartType)
+	}
+}
+
+func (s *RegistrySuite) TestCalendarFlags() {
+	flagNames := func(chartType string) map[string]bool {
+		out := map[string]bool{}
+		for _, f := range charts.FlagsFor(chartType) {
+			out[f.EffectiveKey()] = true
+		}
+		return out
+	}
+
+	for _, key := range []string{"week-start", "years", "cell-size", "visualmap", "labels", "swap"} {
+		s.True(flagNames("calendar")[key], "calendar should register %s", key)
+	}
+	for _, key := range []string{"sort", "scale"} {
+		s.False(flagNames("calendar")[key], "calendar should not register %s", key)
+	}
+	for _, chartType := range []string{"bar", "line", "scatter", "pie", "heatmap", "radar", "sankey", "chord", "treemap", "sunburst", "histogram", "parallel", "bump", "waterfall"} {
+		s.False(flagNames(chartType)["week-start"], "%s should not register week-start", chartType)
+	}
+}
+
+func (s *RegistrySuite) TestHistoryFlags() {
+	flagNames := func(chartType string) map[string]bool {
+		out := map[string]bool{}
+		for _, f := range charts.FlagsFor(chartType) {
+			out[f.EffectiveKey()] = true
+		}
+		return out
+	}
+
+	for _, key := range []string{"tag-axis", "rank", "labels", "stat"} {
+		s.True(flagNames("bump")[key], "bump should register %s", key)
+	}
+	for _, key := range []string{"tag-axis", "from", "to", "sort", "labels", "stat"} {
+		s.True(flagNames("waterfall")[key], "waterfall should register %s", key)
+	}
+	for _, key := range []string{"swap", "scale", "from"} {
+		s.False(flagNames("bump")[key], "bump should not register %s", key)
+	}
+	for _, key := range []string{"swap", "scale", "rank"} {
+		s.False(flagNames("waterfall")[key], "waterfall should not register %s", key)
+	}
+	for _, chartType := range []string{"bar", "line", "scatter", "pie", "heatmap", "radar", "sankey", "chord", "treemap", "sunburst", "histogram", "parallel", "calendar"} {
+		s.False(flagNames(chartType)["tag-axis"], "%s should not register tag-axis", chartType)
+	}
+}
+
 func (s *RegistrySuite) TestNewScatterKnownType() {
 	cfg, err := charts.New("scatter")
 	s.NoError(err)
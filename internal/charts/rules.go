@@ -21,6 +21,10 @@ type AxisInfo struct {
 type RuleContext struct {
 	ChartType string     // e.g. "bar", "line"
 	Axes      []AxisInfo // data-derived axes (post-parse, includes AutoGroup cases)
+	StatTypes []string   // distinct stat types in the data (e.g. "ns/op", "B/op")
+	DateAxes  []string   // keys of dimensions whose values are all dates
+	Tags      []string   // merged history tags, oldest first (vizb merge)
+	TagAxes   []string   // keys of dimensions whose values are all tags
 	Value     any        // this flag's current value from the marshalled Config
 	Config    map[string]any
 }
@@ -94,6 +98,148 @@ func StackRequiresLinearScale() flags.RuleFn {
 	}
 }
 
+// Y2Conflicts skips --y2 when the same chart config stacks its series or uses
+// a log scale: a stack sums series on one value axis, and the secondary axis
+// would not share the primary's log range.
+func Y2Conflicts() flags.RuleFn {
+	return func(ctx any) (flags.Outcome, string) {
+		rc, ok := ctx.(RuleContext)
+		if !ok {
+			return flags.Fatal, "internal: expected charts.RuleContext"
+		}
+		if stacked, _ := rc.Config["stack"].(bool); stacked {
+			return flags.Skip, "dual value axes cannot stack series; ignoring"
+		}
+		scale, _ := rc.Config["scale"].(string)
+		if strings.EqualFold(scale, "log") {
+			return flags.Skip, "dual value axes require linear scale; ignoring"
+		}
+		return flags.Keep, ""
+	}
+}
+
+// axisBound reads a --min/--max payload from a marshalled Config: a number
+// (absolute) or a "p<N>" string (percentile). ok is false when unset.
+func axisBound(v any) (value float64, percentile, ok bool) {
+	switch b := v.(type) {
+	case float64:
+		return b, false, true
+	case string:
+		n, pct, err := ParseAxisBound(b)
+		return n, pct, err == nil
+	}
+	return 0, false, false
+}
+
+// AxisBoundsFit returns the rule for --min/--max. A range that is empty
+// (min ≥ max, both absolute or both percentiles) is Fatal; an absolute bound
+// at or below zero is Skipped on a log scale, which cannot show it.
+func AxisBoundsFit() flags.RuleFn {
+	return func(ctx any) (flags.Outcome, string) {
+		rc, ok := ctx.(RuleContext)
+		if !ok {
+			return flags.Fatal, "internal: expected charts.RuleContext"
+		}
+		lo, loPct, hasLo := axisBound(rc.Config["min"])
+		hi, hiPct, hasHi := axisBound(rc.Config["max"])
+		if hasLo && hasHi && loPct == hiPct && lo >= hi {
+			return flags.Fatal, fmt.Sprintf("min (%v) must be less than max (%v)", rc.Config["min"], rc.Config["max"])
+		}
+		v, pct, _ := axisBound(rc.Value)
+		scale, _ := rc.Config["scale"].(string)
+		if strings.EqualFold(scale, "log") && !pct && v <= 0 {
+			return flags.Skip, fmt.Sprintf("log scale cannot reach %v; ignoring", v)
+		}
+		return flags.Keep, ""
+	}
+}
+
+// RequiresLogScale returns a rule that Skips the flag unless the same chart
+// config uses --scale log.
+func RequiresLogScale() flags.RuleFn {
+	return func(ctx any) (flags.Outcome, string) {
+		rc, ok := ctx.(RuleContext)
+		if !ok {
+			return flags.Fatal, "internal: expected charts.RuleContext"
+		}
+		scale, _ := rc.Config["scale"].(string)
+		if !strings.EqualFold(scale, "log") {
+			return flags.Skip, "requires --scale log; ignoring"
+		}
+		return flags.Keep, ""
+	}
+}
+
+// ZeroBaselineApplies skips --zero-baseline on a log scale (which has no zero)
+// and when --min already sets where the axis starts.
+func ZeroBaselineApplies() flags.RuleFn {
+	return func(ctx any) (flags.Outcome, string) {
+		rc, ok := ctx.(RuleContext)
+		if !ok {
+			return flags.Fatal, "internal: expected charts.RuleContext"
+		}
+		if enabled, ok := rc.Value.(bool); ok && !enabled {
+			return flags.Keep, ""
+		}
+		scale, _ := rc.Config["scale"].(string)
+		if strings.EqualFold(scale, "log") {
+			return flags.Skip, "log scale has no zero; ignoring"
+		}
+		if _, _, ok := axisBound(rc.Config["min"]); ok {
+			return flags.Skip, "--min sets where the axis starts; ignoring"
+		}
+		return flags.Keep, ""
+	}
+}
+
+// binSettings reads the histogram bin fields from a marshalled Config.
+// JSON numbers arrive as float64.
+func binSettings(config map[string]any) (method string, bins int, width float64) {
+	method, _ = config["binMethod"].(string)
+	if n, ok := config["bins"].(float64); ok {
+		bins = int(n)
+	}
+	width, _ = config["binWidth"].(float64)
+	return method, bins, width
+}
+
+// UsedByBinMethod returns a rule that Skips a histogram bin option unless the
+// chart's resolved bin method is one of methods.
+func UsedByBinMethod(methods ...string) flags.RuleFn {
+	return func(ctx any) (flags.Outcome, string) {
+		rc, ok := ctx.(RuleContext)
+		if !ok {
+			return flags.Fatal, "internal: expected charts.RuleContext"
+		}
+		resolved := ResolveBinMethod(binSettings(rc.Config))
+		if slices.Contains(methods, resolved) {
+			return flags.Keep, ""
+		}
+		return flags.Skip, fmt.Sprintf("not used by the %q bin method; ignoring", resolved)
+	}
+}
+
+// BinMethodApplies skips --bin-method when the method is missing the option it
+// needs (count without --bins, width without --bin-width).
+func BinMethodApplies() flags.RuleFn {
+	return func(ctx any) (flags.Outcome, string) {
+		rc, ok := ctx.(RuleContext)
+		if !ok {
+			return flags.Fatal, "internal: expected charts.RuleContext"
+		}
+		method, bins, width := binSettings(rc.Config)
+		resolved := ResolveBinMethod(method, bins, width)
+		if resolved == method {
+			return flags.Keep, ""
+		}
+		needs := "--bins"
+		if method == BinMethodWidth {
+			needs = "--bin-width"
+		}
+		return flags.Skip, fmt.Sprintf("bin method %q needs %s; using %q", method, needs, resolved)
+	}
+}
+
 // Requires3DMode returns a rule that Skips the flag when no z-axis is present.
 // Both explicit z-axis data and auto-enabled value-mode xyz add a z axis to
 // the runtime axes, so a single z-axis check covers both cases.
@@ -124,11 +270,11 @@ func Excludes3DMode() flags.RuleFn {
 		}
 		for _, a := range rc.Axes {
 			if a.Key == "z" {
-				return flags.Skip, "background is 2D only; ignoring on 3D chart"
+				return flags.Skip, "2D only; ignoring on 3D chart"
 			}
 		}
 		if enabled, ok := rc.Config["threeD"].(bool); ok && enabled {
-			return flags.Skip, "background is 2D only; ignoring on 3D chart"
+			return flags.Skip, "2D only; ignoring on 3D chart"
 		}
 		return flags.Keep, ""
 	}
@@ -171,6 +317,178 @@ func OnlyScatter2D() flags.RuleFn {
 	}
 }
 
+// WithinHierarchy returns a rule for treemap/sunburst level counts: it keeps
+// the value but warns when it asks for more levels than the data has, since
+// the renderer clamps to the levels present.
+func WithinHierarchy() flags.RuleFn {
+	return func(ctx any) (flags.Outcome, string) {
+		rc, ok := ctx.(RuleContext)
+		if !ok {
+			return flags.Fatal, "internal: expected charts.RuleContext"
+		}
+		n, ok := rc.Value.(float64)
+		if !ok {
+			return flags.Keep, ""
+		}
+		levels := 0
+		for _, a := range rc.Axes {
+			switch a.Key {
+			case "name", "x", "y", "z":
+				levels++
+			}
+		}
+		if int(n) > levels {
+			return flags.WarnKeep, fmt.Sprintf("%d levels requested but the data has %d (%v); using %d", int(n), levels, axisKeys(rc.Axes), levels)
+		}
+		return flags.Keep, ""
+	}
+}
+
+// RequiresStatType returns a rule that Skips --value-stat when the named stat
+// type (case-insensitive) is not in the data, so the chart falls back to the
+// active stat instead of sizing every node at zero.
+func RequiresStatType() flags.RuleFn {
+	return func(ctx any) (flags.Outcome, string) {
+		rc, ok := ctx.(RuleContext)
+		if !ok {
+			return flags.Fatal, "internal: expected charts.RuleContext"
+		}
+		want, _ := rc.Value.(string)
+		if want == "" {
+			return flags.Keep, ""
+		}
+		for _, t := range rc.StatTypes {
+			if strings.EqualFold(t, want) {
+				return flags.Keep, ""
+			}
+		}
+		return flags.Skip, fmt.Sprintf("stat type %q not in data (present: %v); using the active stat", want, rc.StatTypes)
+	}
+}
+
+// AxisScaleStatTypes returns a rule that warns when --axis-scale names stat
+// types missing from the data (case-insensitive). The value is kept: the
+// renderer ignores entries without an axis.
+func AxisScaleStatTypes() flags.RuleFn {
+	return func(ctx any) (flags.Outcome, string) {
+		rc, ok := ctx.(RuleContext)
+		if !ok {
+			return flags.Fatal, "internal: expected charts.RuleContext"
+		}
+		scales, _ := rc.Value.(map[string]any)
+		var missing []string
+		for stat := range scales {
+			if !slices.ContainsFunc(rc.StatTypes, func(t string) bool { return MarkStatMatches(t, stat) }) {
+				missing = append(missing, stat)
+			}
+		}
+		if len(missing) == 0 {
+			return flags.Keep, ""
+		}
+		slices.Sort(missing)
+		return flags.WarnKeep, fmt.Sprintf("stat types %v not in data (present: %v); those axes do not exist", missing, rc.StatTypes)
+	}
+}
+
+// MarkStatTypes returns a rule that warns when a --mark names a stat type
+// missing from the data (see MarkStatMatches). The value is kept: the mark
+// only draws on charts of its stat, so it never shows.
+func MarkStatTypes() flags.RuleFn {
+	return func(ctx any) (flags.Outcome, string) {
+		rc, ok := ctx.(RuleContext)
+		if !ok {
+			return flags.Fatal, "internal: expected charts.RuleContext"
+		}
+		marks, _ := rc.Value.([]any)
+		var missing []string
+		for _, m := range marks {
+			bag, _ := m.(map[string]any)
+			stat, _ := bag["stat"].(string)
+			if stat == "" || slices.Contains(missing, stat) {
+				continue
+			}
+			if !slices.ContainsFunc(rc.StatTypes, func(t string) bool { return MarkStatMatches(t, stat) }) {
+				missing = append(missing, stat)
+			}
+		}
+		if len(missing) == 0 {
+			return flags.Keep, ""
+		}
+		return flags.WarnKeep, fmt.Sprintf("mark stat types %v not in data (present: %v); those marks do not show", missing, rc.StatTypes)
+	}
+}
+
+// RequiresDateAxis returns a rule for calendar options: it Skips the flag
+// unless some dimension holds dates, since there is no calendar to lay out.
+func RequiresDateAxis() flags.RuleFn {
+	return func(ctx any) (flags.Outcome, string) {
+		rc, ok := ctx.(RuleContext)
+		if !ok {
+			return flags.Fatal, "internal: expected charts.RuleContext"
+		}
+		if len(rc.DateAxes) == 0 {
+			return flags.Skip, fmt.Sprintf("requires a date dimension such as 2024-01-31 (axes: %v)", axisKeys(rc.Axes))
+		}
+		return flags.Keep, ""
+	}
+}
+
+// RequiresTagAxis returns a rule for history options (bump, waterfall): it
+// Skips the flag unless some dimension holds the tags vizb merge injected,
+// since there is nothing to rank or step across.
+func RequiresTagAxis() flags.RuleFn {
+	return func(ctx any) (flags.Outcome, string) {
+		rc, ok := ctx.(RuleContext)
+		if !ok {
+			return flags.Fatal, "internal: expected charts.RuleContext"
+		}
+		if len(rc.TagAxes) == 0 {
+			return flags.Skip, fmt.Sprintf("requires a tag dimension from vizb merge (axes: %v)", axisKeys(rc.Axes))
+		}
+		return flags.Keep, ""
+	}
+}
+
+// MatchesTagAxis returns a rule for --tag-axis: it Skips the flag when the
+// named dimension (n, x, y, z) does not hold the merged tags, so the chart
+// falls back to detecting the tag dimension itself.
+func MatchesTagAxis() flags.RuleFn {
+	return func(ctx any) (flags.Outcome, string) {
+		rc, ok := ctx.(RuleContext)
+		if !ok {
+			return flags.Fatal, "internal: expected charts.RuleContext"
+		}
+		s, _ := rc.Value.(string)
+		key := strings.ToLower(s)
+		if key == "n" {
+			key = "name"
+		}
+		if len(rc.TagAxes) == 0 || slices.Contains(rc.TagAxes, key) {
+			return flags.Keep, ""
+		}
+		return flags.Skip, fmt.Sprintf("dimension %q does not hold the merged tags (tag dimensions: %v)", s, rc.TagAxes)
+	}
+}
+
+// RequiresTag returns a rule for --from/--to: it Skips the flag unless the
+// data has a tag dimension and the value names one of its tags.
+func RequiresTag() flags.RuleFn {
+	return func(ctx any) (flags.Outcome, string) {
+		rc, ok := ctx.(RuleContext)
+		if !ok {
+			return flags.Fatal, "internal: expected charts.RuleContext"
+		}
+		if len(rc.TagAxes) == 0 {
+			return flags.Skip, fmt.Sprintf("requires a tag dimension from vizb merge (axes: %v)", axisKeys(rc.Axes))
+		}
+		s, _ := rc.Value.(string)
+		if !slices.Contains(rc.Tags, s) {
+			return flags.Skip, fmt.Sprintf("tag %q not in the merged history (tags: %v)", s, rc.Tags)
+		}
+		return flags.Keep, ""
+	}
+}
+
 // ApplyRules is the central pipeline pass. It evaluates every chart-flag
 // descriptor's Rule list against each materialised Config, post-parse, with
 // full data-derived axes.
@@ -216,6 +534,10 @@ func ApplyRules(ctx RuleContext, configs []ChartConfig) (warnings []string, fata
 			perFlagCtx := RuleContext{
 				ChartType: chartType,
 				Axes:      ctx.Axes,
+				StatTypes: ctx.StatTypes,
+				DateAxes:  ctx.DateAxes,
+				Tags:      ctx.Tags,
+				TagAxes:   ctx.TagAxes,
 				Value:     val,
 				Config:    m,
 			}
@@ -2,13 +2,27 @@ package charts_test
 
 import (
 	"encoding/json"
+	"slices"
+	"strings"
 	"testing"
 
 	_ "github.com/goptics/vizb/cmd/charts/bar"
+	_ "github.com/goptics/vizb/cmd/charts/bump"
+	_ "github.com/goptics/vizb/cmd/charts/calendar"
+	_ "github.com/goptics/vizb/cmd/charts/histogram"
 	_ "github.com/goptics/vizb/cmd/charts/line"
+	_ "github.com/goptics/vizb/cmd/charts/parallel"
 	_ "github.com/goptics/vizb/cmd/charts/scatter"
+	_ "github.com/goptics/vizb/cmd/charts/treemap"
+	_ "github.com/goptics/vizb/cmd/charts/waterfall"
 	"github.com/goptics/vizb/internal/charts"
 	barchart "github.com/goptics/vizb/internal/charts/bar"
+	bumpchart "github.com/goptics/vizb/internal/charts/bump"
+	calendarchart "github.com/goptics/vizb/internal/charts/calendar"
+	histogramchart "github.com/goptics/vizb/internal/charts/histogram"
+	parallelchart "github.com/goptics/vizb/internal/charts/parallel"
+	treemapchart "github.com/goptics/vizb/internal/charts/treemap"
+	waterfallchart "github.com/goptics/vizb/internal/charts/waterfall"
 	"github.com/goptics/vizb/internal/flags"
 	"github.com/goptics/vizb/shared"
 	"github.com/stretchr/testify/suite"
@@ -469,6 +483,400 @@ func (s *RulesSuite) TestApplyRules_BackgroundSkippedOnThreeDOption() {
 	s.True(*got.ThreeD)
 }
 
+// --- WithinHierarchy / RequiresStatType (treemap, sunburst) ---
+
+func (s *RulesSuite) TestWithinHierarchy_KeepWhenLevelsPresent() {
+	rule := charts.WithinHierarchy()
+	out, msg := rule(charts.RuleContext{
+		Axes:  []charts.AxisInfo{{Key: "name"}, {Key: "x"}, {Key: "y"}},
+		Value: float64(3),
+	})
+	s.Equal(flags.Keep, out)
+	s.Empty(msg)
+}
+
+func (s *RulesSuite) TestWithinHierarchy_WarnKeepWhenDeeperThanData() {
+	rule := charts.WithinHierarchy()
+	out, msg := rule(charts.RuleContext{
+		Axes:  []charts.AxisInfo{{Key: "name"}, {Key: "x"}},
+		Value: float64(4),
+	})
+	s.Equal(flags.WarnKeep, out)
+	s.Contains(msg, "4 levels requested but the data has 2")
+}
+
+func (s *RulesSuite) TestRequiresStatType_KeepCaseInsensitive() {
+	rule := charts.RequiresStatType()
+	out, _ := rule(charts.RuleContext{StatTypes: []string{"ns/op", "B/op"}, Value: "b/op"})
+	s.Equal(flags.Keep, out)
+}
+
+func (s *RulesSuite) TestRequiresStatType_SkipWhenAbsent() {
+	rule := charts.RequiresStatType()
+	out, msg := rule(charts.RuleContext{StatTypes: []string{"ns/op"}, Value: "allocs/op"})
+	s.Equal(flags.Skip, out)
+	s.Contains(msg, `"allocs/op" not in data`)
+}
+
+func (s *RulesSuite) TestApplyRules_TreemapHierarchyFlags() {
+	depth := 4
+	configs := []charts.ChartConfig{
+		&treemapchart.Config{Type: "treemap", LeafDepth: &depth, ValueStat: "allocs/op"},
+	}
+	ctx := charts.RuleContext{
+		Axes:      []charts.AxisInfo{{Key: "name"}, {Key: "x"}, {Key: "y"}},
+		StatTypes: []string{"ns/op", "B/op"},
+	}
+
+	warnings, fatal := charts.ApplyRules(ctx, configs)
+	s.Nil(fatal)
+	s.Len(warnings, 2)
+	got := configs[0].(*treemapchart.Config)
+	s.Require().NotNil(got.LeafDepth, "an over-deep leaf depth is clamped by the renderer, not dropped")
+	s.Equal(4, *got.LeafDepth)
+	s.Empty(got.ValueStat)
+}
+
+// --- UsedByBinMethod / BinMethodApplies (histogram) ---
+
+func (s *RulesSuite) TestUsedByBinMethod_KeepForResolvedMethod() {
+	rule := charts.UsedByBinMethod(charts.BinMethodCount, charts.BinMethodLog)
+	out, msg := rule(charts.RuleContext{Config: map[string]any{"bins": float64(20), "binMethod": "log"}})
+	s.Equal(flags.Keep, out)
+	s.Empty(msg)
+}
+
+func (s *RulesSuite) TestUsedByBinMethod_SkipWhenWidthWins() {
+	rule := charts.UsedByBinMethod(charts.BinMethodCount, charts.BinMethodLog)
+	out, msg := rule(charts.RuleContext{Config: map[string]any{"bins": float64(20), "binWidth": float64(5)}})
+	s.Equal(flags.Skip, out)
+	s.Contains(msg, `not used by the "width" bin method`)
+}
+
+func (s *RulesSuite) TestBinMethodApplies_SkipWhenValueMissing() {
+	rule := charts.BinMethodApplies()
+	out, msg := rule(charts.RuleContext{Config: map[string]any{"binMethod": "width"}})
+	s.Equal(flags.Skip, out)
+	s.Contains(msg, `bin method "width" needs --bin-width; using "fd"`)
+
+	out, _ = rule(charts.RuleContext{Config: map[string]any{"binMethod": "fd"}})
+	s.Equal(flags.Keep, out)
+}
+
+func (s *RulesSuite) TestApplyRules_HistogramBinFlags() {
+	bins, width := 30, 2.5
+	configs := []charts.ChartConfig{
+		&histogramchart.Config{Type: "histogram", Bins: &bins, BinWidth: &width, BinMethod: "count"},
+	}
+
+	warnings, fatal := charts.ApplyRules(charts.RuleContext{}, configs)
+	s.Nil(fatal)
+	s.Len(warnings, 1)
+	got := configs[0].(*histogramchart.Config)
+	s.Require().NotNil(got.Bins)
+	s.Equal(30, *got.Bins)
+	s.Nil(got.BinWidth, "the width is unused once count is chosen")
+	s.Equal("count", got.BinMethod)
+}
+
+// --- AxisScaleStatTypes (parallel) ---
+
+func (s *RulesSuite) TestAxisScaleStatTypes_KeepCaseInsensitive() {
+	rule := charts.AxisScaleStatTypes()
+	out, msg := rule(charts.RuleContext{
+		StatTypes: []string{"ns/op", "B/op"},
+		Value:     map[string]any{"b/op": "log"},
+	})
+	s.Equal(flags.Keep, out)
+	s.Empty(msg)
+}
+
+func (s *RulesSuite) TestAxisScaleStatTypes_WarnForMissingAxes() {
+	rule := charts.AxisScaleStatTypes()
+	out, msg := rule(charts.RuleContext{
+		StatTypes: []string{"ns/op"},
+		Value:     map[string]any{"MB/s": "log", "allocs/op": "log", "ns/op": "linear"},
+	})
+	s.Equal(flags.WarnKeep, out)
+	s.Contains(msg, "stat types [MB/s allocs/op] not in data")
+}
+
+func (s *RulesSuite) TestApplyRules_ParallelAxisScaleKept() {
+	configs := []charts.ChartConfig{
+		&parallelchart.Config{Type: "parallel", AxisScale: map[string]string{"allocs/op": "log"}},
+	}
+
+	warnings, fatal := charts.ApplyRules(charts.RuleContext{StatTypes: []string{"ns/op"}}, configs)
+	s.Nil(fatal)
+	s.Len(warnings, 1)
+	got := configs[0].(*parallelchart.Config)
+	s.Equal(map[string]string{"allocs/op": "log"}, got.AxisScale, "unknown axes warn but are kept")
+}
+
+// --- MarkStatTypes (bar, line, scatter) ---
+
+func (s *RulesSuite) TestMarkStatTypes() {
+	rule := charts.MarkStatTypes()
+	marks := []any{
+		map[string]any{"type": "hline", "stat": "NS/op", "value": 50.0},
+		map[string]any{"type": "vline", "at": "1024"},
+		map[string]any{"type": "hline", "stat": "allocs/op", "value": 0.0},
+		map[string]any{"type": "band", "stat": "allocs/op", "from": 0.0, "to": 1.0},
+	}
+
+	out, msg := rule(charts.RuleContext{StatTypes: []string{"ns/op", "allocs/op"}, Value: marks})
+	s.Equal(flags.Keep, out)
+	s.Empty(msg)
+
+	out, msg = rule(charts.RuleContext{StatTypes: []string{"ns/op"}, Value: marks})
+	s.Equal(flags.WarnKeep, out)
+	s.Contains(msg, "mark stat types [allocs/op] not in data")
+
+	// A mark keyed by unit matches the Go-bench stat type that carries it.
+	out, _ = rule(charts.RuleContext{StatTypes: []string{"Execution Time (ns/op)", "allocs/op"}, Value: marks})
+	s.Equal(flags.Keep, out)
+}
+
+func (s *RulesSuite) TestApplyRules_MarkSkippedOn3D() {
+	configs := []charts.ChartConfig{
+		&barchart.Config{Type: "bar", Mark: []shared.Annotation{{Type: "hline", Value: shared.F64(50)}}},
+	}
+	ctx := charts.RuleContext{Axes: []charts.AxisInfo{{Key: "x"}, {Key: "y"}, {Key: "z"}}}
+
+	warnings, fatal := charts.ApplyRules(ctx, configs)
+	s.Nil(fatal)
+	s.Len(warnings, 1)
+	s.Contains(warnings[0], `"mark" skipped: 2D only`)
+	s.Nil(configs[0].(*barchart.Config).Mark)
+}
+
+// --- Y2Conflicts (bar, line) ---
+
+func (s *RulesSuite) TestY2Conflicts() {
+	rule := charts.Y2Conflicts()
+	out, _ := rule(charts.RuleContext{Config: map[string]any{"y2": []any{"auto"}}})
+	s.Equal(flags.Keep, out)
+
+	out, msg := rule(charts.RuleContext{Config: map[string]any{"stack": true}})
+	s.Equal(flags.Skip, out)
+	s.Contains(msg, "cannot stack")
+
+	out, msg = rule(charts.RuleContext{Config: map[string]any{"scale": "LOG"}})
+	s.Equal(flags.Skip, out)
+	s.Contains(msg, "linear scale")
+}
+
+func (s *RulesSuite) TestApplyRules_Y2() {
+	xy := []charts.AxisInfo{{Key: "x"}, {Key: "y"}}
+	stack := true
+	cases := []struct {
+		name string
+		cfg  *barchart.Config
+		axes []charts.AxisInfo
+		kept bool
+		warn string
+	}{
+		{"grouped 2D keeps y2", &barchart.Config{Type: "bar", Y2: []string{"auto"}}, xy, true, ""},
+		{"no series dimension", &barchart.Config{Type: "bar", Y2: []string{"auto"}}, []charts.AxisInfo{{Key: "x"}}, false, `requires axis "y"`},
+		{"3D", &barchart.Config{Type: "bar", Y2: []string{"auto"}}, append(slices.Clone(xy), charts.AxisInfo{Key: "z"}), false, "2D only"},
+		{"stacked", &barchart.Config{Type: "bar", Stack: &stack, Y2: []string{"Memory Usage"}}, xy, false, "cannot stack"},
+		{"log scale", &barchart.Config{Type: "bar", Scale: "log", Y2: []string{"auto"}}, xy, false, "linear scale"},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			configs := []charts.ChartConfig{tc.cfg}
+			warnings, fatal := charts.ApplyRules(charts.RuleContext{Axes: tc.axes}, configs)
+			s.Nil(fatal)
+			got := configs[0].(*barchart.Config)
+			if tc.kept {
+				s.Empty(warnings)
+				s.Equal([]string{"auto"}, got.Y2)
+				return
+			}
+			s.Nil(got.Y2)
+			s.True(slices.ContainsFunc(warnings, func(w string) bool {
+				return strings.Contains(w, `"y2" skipped`) && strings.Contains(w, tc.warn)
+			}), "warnings: %v", warnings)
+		})
+	}
+}
+
+// --- RequiresDateAxis (calendar) ---
+
+func (s *RulesSuite) TestRequiresDateAxis() {
+	rule := charts.RequiresDateAxis()
+	out, _ := rule(charts.RuleContext{Axes: []charts.AxisInfo{{Key: "x"}}, DateAxes: []string{"x"}})
+	s.Equal(flags.Keep, out)
+
+	out, msg := rule(charts.RuleContext{Axes: []charts.AxisInfo{{Key: "x"}, {Key: "y"}}})
+	s.Equal(flags.Skip, out)
+	s.Contains(msg, "requires a date dimension")
+	s.Contains(msg, "[x y]")
+}
+
+func (s *RulesSuite) TestApplyRules_CalendarWithoutDatesDropsOptions() {
+	size := 14
+	configs := []charts.ChartConfig{
+		&calendarchart.Config{Type: "calendar", WeekStart: "monday", CellSize: &size, Years: &shared.YearRange{From: 2024, To: 2024}},
+	}
+
+	warnings, fatal := charts.ApplyRules(charts.RuleContext{Axes: []charts.AxisInfo{{Key: "x"}}}, configs)
+	s.Nil(fatal)
+	s.Len(warnings, 3)
+	got := configs[0].(*calendarchart.Config)
+	s.Empty(got.WeekStart)
+	s.Nil(got.CellSize)
+	s.Nil(got.Years)
+
+	configs[0] = &calendarchart.Config{Type: "calendar", WeekStart: "monday"}
+	warnings, fatal = charts.ApplyRules(charts.RuleContext{Axes: []charts.AxisInfo{{Key: "x"}}, DateAxes: []string{"x"}}, configs)
+	s.Nil(fatal)
+	s.Empty(warnings)
+	s.Equal("monday", configs[0].(*calendarchart.Config).WeekStart)
+}
+
+// --- RequiresTagAxis / MatchesTagAxis / RequiresTag (bump, waterfall) ---
+
+func (s *RulesSuite) TestRequiresTagAxis() {
+	rule := charts.RequiresTagAxis()
+	out, _ := rule(charts.RuleContext{TagAxes: []string{"name"}})
+	s.Equal(flags.Keep, out)
+
+	out, msg := rule(charts.RuleContext{Axes: []charts.AxisInfo{{Key: "name"}, {Key: "x"}}})
+	s.Equal(flags.Skip, out)
+	s.Contains(msg, "requires a tag dimension")
+	s.Contains(msg, "[name x]")
+}
+
+func (s *RulesSuite) TestMatchesTagAxis() {
+	rule := charts.MatchesTagAxis()
+	out, _ := rule(charts.RuleContext{TagAxes: []string{"name"}, Value: "N"})
+	s.Equal(flags.Keep, out, "n names the name dimension")
+
+	out, msg := rule(charts.RuleContext{TagAxes: []string{"name"}, Value: "x"})
+	s.Equal(flags.Skip, out)
+	s.Contains(msg, "[name]")
+}
+
+func (s *RulesSuite) TestRequiresTag() {
+	rule := charts.RequiresTag()
+	ctx := charts.RuleContext{Tags: []string{"v1", "v2"}, TagAxes: []string{"x"}, Value: "v1"}
+	out, _ := rule(ctx)
+	s.Equal(flags.Keep, out)
+
+	ctx.Value = "v3"
+	out, msg := rule(ctx)
+	s.Equal(flags.Skip, out)
+	s.Contains(msg, `tag "v3" not in the merged history`)
+
+	out, msg = rule(charts.RuleContext{Tags: []string{"v1"}, Value: "v1"})
+	s.Equal(flags.Skip, out, "tags without a tag dimension cannot be charted")
+	s.Contains(msg, "requires a tag dimension")
+}
+
+func (s *RulesSuite) TestApplyRules_HistoryWithoutTagsDropsOptions() {
+	configs := []charts.ChartConfig{
+		&bumpchart.Config{Type: "bump", TagAxis: "n", Rank: "desc"},
+		&waterfallchart.Config{Type: "waterfall", TagAxis: "n", From: "v1", To: "v2"},
+	}
+
+	warnings, fatal := charts.ApplyRules(charts.RuleContext{Axes: []charts.AxisInfo{{Key: "name"}}}, configs)
+	s.Nil(fatal)
+	s.Len(warnings, 5)
+	s.Equal(&bumpchart.Config{Type: "bump"}, configs[0])
+	s.Equal(&waterfallchart.Config{Type: "waterfall"}, configs[1])
+
+	ctx := charts.RuleContext{Axes: []charts.AxisInfo{{Key: "name"}}, Tags: []string{"v1", "v2"}, TagAxes: []string{"name"}}
+	configs[1] = &waterfallchart.Config{Type: "waterfall", TagAxis: "n", From: "v1", To: "v9"}
+	warnings, fatal = charts.ApplyRules(ctx, configs[1:])
+	s.Nil(fatal)
+	s.Len(warnings, 1)
+	s.Equal(&waterfallchart.Config{Type: "waterfall", TagAxis: "n", From: "v1"}, configs[1])
+}
+
 func TestRulesSuite(t *testing.T) {
 	suite.Run(t, new(RulesSuite))
 }
+
+func (s *RulesSuite) TestAxisBoundsFit() {
+	rule := charts.AxisBoundsFit()
+	out, _ := rule(charts.RuleContext{Value: 10.0, Config: map[string]any{"min": 10.0, "max": "p95"}})
+	s.Equal(flags.Keep, out, "absolute and percentile bounds are not compared")
+
+	out, msg := rule(charts.RuleContext{Value: 10.0, Config: map[string]any{"min": 10.0, "max": 10.0}})
+	s.Equal(flags.Fatal, out)
+	s.Contains(msg, "less than max")
+
+	out, _ = rule(charts.RuleContext{Value: "p90", Config: map[string]any{"min": "p90", "max": "p10"}})
+	s.Equal(flags.Fatal, out)
+
+	out, msg = rule(charts.RuleContext{Value: 0.0, Config: map[string]any{"min": 0.0, "scale": "log"}})
+	s.Equal(flags.Skip, out)
+	s.Contains(msg, "log scale")
+
+	out, _ = rule(charts.RuleContext{Value: "p0", Config: map[string]any{"min": "p0", "scale": "log"}})
+	s.Equal(flags.Keep, out)
+}
+
+func (s *RulesSuite) TestRequiresLogScale() {
+	rule := charts.RequiresLogScale()
+	out, _ := rule(charts.RuleContext{Config: map[string]any{"scale": "log"}})
+	s.Equal(flags.Keep, out)
+	out, msg := rule(charts.RuleContext{Config: map[string]any{}})
+	s.Equal(flags.Skip, out)
+	s.Contains(msg, "--scale log")
+}
+
+func (s *RulesSuite) TestZeroBaselineApplies() {
+	rule := charts.ZeroBaselineApplies()
+	out, _ := rule(charts.RuleContext{Value: true, Config: map[string]any{"max": 10.0}})
+	s.Equal(flags.Keep, out)
+	out, msg := rule(charts.RuleContext{Value: true, Config: map[string]any{"scale": "log"}})
+	s.Equal(flags.Skip, out)
+	s.Contains(msg, "no zero")
+	out, msg = rule(charts.RuleContext{Value: true, Config: map[string]any{"min": "p5"}})
+	s.Equal(flags.Skip, out)
+	s.Contains(msg, "--min")
+}
+
+func (s *RulesSuite) TestApplyRules_AxisRange() {
+	xy := []charts.AxisInfo{{Key: "x"}, {Key: "y"}}
+	yes := true
+	base := 2.0
+	cases := []struct {
+		name    string
+		cfg     *barchart.Config
+		axes    []charts.AxisInfo
+		check   func(*barchart.Config)
+		warn    string
+		fatalOK bool
+	}{
+		{"linear range kept", &barchart.Config{Type: "bar", Min: &shared.AxisBound{Value: 5}, Max: &shared.AxisBound{Value: 95, Percentile: true}}, xy,
+			func(c *barchart.Config) { s.NotNil(c.Min); s.NotNil(c.Max) }, "", false},
+		{"log base without log scale", &barchart.Config{Type: "bar", LogBase: &base}, xy,
+			func(c *barchart.Config) { s.Nil(c.LogBase) }, "--scale log", false},
+		{"zero baseline with min", &barchart.Config{Type: "bar", Min: &shared.AxisBound{Value: 5}, ZeroBaseline: &yes}, xy,
+			func(c *barchart.Config) { s.Nil(c.ZeroBaseline); s.NotNil(c.Min) }, "--min", false},
+		{"3D drops inverse and zoom", &barchart.Config{Type: "bar", Inverse: &yes, Zoom: "both"}, append(slices.Clone(xy), charts.AxisInfo{Key: "z"}),
+			func(c *barchart.Config) { s.Nil(c.Inverse); s.Empty(c.Zoom) }, "2D only", false},
+		{"empty range", &barchart.Config{Type: "bar", Min: &shared.AxisBound{Value: 9}, Max: &shared.AxisBound{Value: 1}}, xy,
+			nil, "", true},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			configs := []charts.ChartConfig{tc.cfg}
+			warnings, fatal := charts.ApplyRules(charts.RuleContext{Axes: tc.axes}, configs)
+			if tc.fatalOK {
+				s.NotNil(fatal)
+				return
+			}
+			s.Nil(fatal)
+			tc.check(configs[0].(*barchart.Config))
+			if tc.warn == "" {
+				s.Empty(warnings)
+				return
+			}
+			s.True(slices.ContainsFunc(warnings, func(w string) bool { return strings.Contains(w, tc.warn) }), "warnings: %v", warnings)
+		})
+	}
+}
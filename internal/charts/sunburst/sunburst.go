@@ -0,0 +1,32 @@
+// Package sunburst defines the typed Config for sunburst charts. Sunburst is
+// the radial form of the treemap hierarchy (name → x → y → z, one ring per
+// level), so Config carries the same fields and omits Scale, stack, 3D, and
+// visualMap.
+package sunburst
+
+import (
+	"github.com/goptics/vizb/internal/charts"
+	"github.com/goptics/vizb/shared"
+)
+
+const Type = "sunburst"
+
+type Config struct {
+	Type        string             `json:"type"`
+	Swap        string             `json:"swap,omitempty"`
+	Sort        *shared.Sort       `json:"sort,omitempty"`
+	ShowLabels  *bool              `json:"showLabels,omitempty"`
+	LeafDepth   *int               `json:"leafDepth,omitempty"`
+	LabelLevels *int               `json:"labelLevels,omitempty"`
+	ValueStat   string             `json:"valueStat,omitempty"`
+	Stat        *shared.StatConfig `json:"stat,omitempty"`
+}
+
+func (Config) ChartType() string { return Type }
+
+func (c Config) StatEnabled() bool  { return c.Stat.StatEnabled() }
+func (c Config) StatMath() []string { return c.Stat.StatMath() }
+func (c Config) SwapString() string { return c.Swap }
+
+// New returns a fresh zero-value sunburst chart Config.
+func New() charts.ChartConfig { return &Config{} }
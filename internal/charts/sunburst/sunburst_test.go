@@ -0,0 +1,71 @@
+package sunburst_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	_ "github.com/goptics/vizb/cmd/charts/sunburst"
+	"github.com/goptics/vizb/internal/charts"
+	sunburstchart "github.com/goptics/vizb/internal/charts/sunburst"
+	"github.com/goptics/vizb/shared"
+	"github.com/stretchr/testify/suite"
+)
+
+// SunburstSuite covers the sunburst chart Config: its factory, JSON round-trip,
+// and the "hierarchy fields only, no 3D" JSON contract.
+type SunburstSuite struct {
+	suite.Suite
+}
+
+func (s *SunburstSuite) TestNewReturnsZeroConfig() {
+	cfg := sunburstchart.New()
+	got, ok := cfg.(*sunburstchart.Config)
+	s.Require().True(ok)
+	s.Empty(got.Type)
+	s.Nil(got.LeafDepth)
+	s.Nil(got.LabelLevels)
+	s.Empty(got.ValueStat)
+	s.Nil(got.Stat)
+}
+
+func (s *SunburstSuite) TestDecodeRoundTripAllFields() {
+	original := sunburstchart.Config{
+		Type:        "sunburst",
+		Swap:        "xyn",
+		Sort:        &shared.Sort{Enabled: true, Order: "desc"},
+		ShowLabels:  boolPtr(true),
+		LeafDepth:   intPtr(2),
+		LabelLevels: intPtr(1),
+		ValueStat:   "allocs/op",
+		Stat:        &shared.StatConfig{Enabled: true, Math: []string{"counts"}},
+	}
+	raw, err := json.Marshal(original)
+	s.Require().NoError(err)
+
+	cfg, err := charts.Decode("sunburst", raw)
+	s.Require().NoError(err)
+	got, ok := cfg.(*sunburstchart.Config)
+	s.Require().True(ok)
+	s.Equal(original, *got)
+	s.Equal("sunburst", got.ChartType())
+}
+
+func (s *SunburstSuite) TestJSONOmitsInapplicableChartFields() {
+	raw, err := json.Marshal(sunburstchart.Config{Type: "sunburst", LeafDepth: intPtr(3)})
+	s.Require().NoError(err)
+
+	var m map[string]any
+	s.Require().NoError(json.Unmarshal(raw, &m))
+	for _, key := range []string{"scale", "stack", "threeD", "threeDRotate", "threeDVisualMap", "visualMap", "labelLevels", "valueStat"} {
+		_, ok := m[key]
+		s.False(ok, "sunburst JSON must not carry %q", key)
+	}
+	s.Equal(float64(3), m["leafDepth"])
+}
+
+func boolPtr(b bool) *bool { return &b }
+func intPtr(n int) *int    { return &n }
+
+func TestSunburstSuite(t *testing.T) {
+	suite.Run(t, new(SunburstSuite))
+}
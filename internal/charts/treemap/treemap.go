@@ -0,0 +1,32 @@
+// Package treemap defines the typed Config for treemap charts. The name → x →
+// y → z axes nest as a hierarchy and the active (or --value-stat) stat sizes
+// the leaves, so Config omits Scale, stack, 3D, and visualMap — those do not
+// apply.
+package treemap
+
+import (
+	"github.com/goptics/vizb/internal/charts"
+	"github.com/goptics/vizb/shared"
+)
+
+const Type = "treemap"
+
+type Config struct {
+	Type        string             `json:"type"`
+	Swap        string             `json:"swap,omitempty"`
+	Sort        *shared.Sort       `json:"sort,omitempty"`
+	ShowLabels  *bool              `json:"showLabels,omitempty"`
+	LeafDepth   *int               `json:"leafDepth,omitempty"`
+	LabelLevels *int               `json:"labelLevels,omitempty"`
+	ValueStat   string             `json:"valueStat,omitempty"`
+	Stat        *shared.StatConfig `json:"stat,omitempty"`
+}
+
+func (Config) ChartType() string { return Type }
+
+func (c Config) StatEnabled() bool  { return c.Stat.StatEnabled() }
+func (c Config) StatMath() []string { return c.Stat.StatMath() }
+func (c Config) SwapString() string { return c.Swap }
+
+// New returns a fresh zero-value treemap chart Config.
+func New() charts.ChartConfig { return &Config{} }
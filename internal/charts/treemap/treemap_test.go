@@ -0,0 +1,71 @@
+package treemap_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	_ "github.com/goptics/vizb/cmd/charts/treemap"
+	"github.com/goptics/vizb/internal/charts"
+	treemapchart "github.com/goptics/vizb/internal/charts/treemap"
+	"github.com/goptics/vizb/shared"
+	"github.com/stretchr/testify/suite"
+)
+
+// TreemapSuite covers the treemap chart Config: its factory, JSON round-trip,
+// and the "hierarchy fields only, no 3D" JSON contract.
+type TreemapSuite struct {
+	suite.Suite
+}
+
+func (s *TreemapSuite) TestNewReturnsZeroConfig() {
+	cfg := treemapchart.New()
+	got, ok := cfg.(*treemapchart.Config)
+	s.Require().True(ok)
+	s.Empty(got.Type)
+	s.Nil(got.LeafDepth)
+	s.Nil(got.LabelLevels)
+	s.Empty(got.ValueStat)
+	s.Nil(got.Stat)
+}
+
+func (s *TreemapSuite) TestDecodeRoundTripAllFields() {
+	original := treemapchart.Config{
+		Type:        "treemap",
+		Swap:        "xyn",
+		Sort:        &shared.Sort{Enabled: true, Order: "desc"},
+		ShowLabels:  boolPtr(true),
+		LeafDepth:   intPtr(2),
+		LabelLevels: intPtr(1),
+		ValueStat:   "allocs/op",
+		Stat:        &shared.StatConfig{Enabled: true, Math: []string{"counts"}},
+	}
+	raw, err := json.Marshal(original)
+	s.Require().NoError(err)
+
+	cfg, err := charts.Decode("treemap", raw)
+	s.Require().NoError(err)
+	got, ok := cfg.(*treemapchart.Config)
+	s.Require().True(ok)
+	s.Equal(original, *got)
+	s.Equal("treemap", got.ChartType())
+}
+
+func (s *TreemapSuite) TestJSONOmitsInapplicableChartFields() {
+	raw, err := json.Marshal(treemapchart.Config{Type: "treemap", LeafDepth: intPtr(3)})
+	s.Require().NoError(err)
+
+	var m map[string]any
+	s.Require().NoError(json.Unmarshal(raw, &m))
+	for _, key := range []string{"scale", "stack", "threeD", "threeDRotate", "threeDVisualMap", "visualMap", "labelLevels", "valueStat"} {
+		_, ok := m[key]
+		s.False(ok, "treemap JSON must not carry %q", key)
+	}
+	s.Equal(float64(3), m["leafDepth"])
+}
+
+func boolPtr(b bool) *bool { return &b }
+func intPtr(n int) *int    { return &n }
+
+func TestTreemapSuite(t *testing.T) {
+	suite.Run(t, new(TreemapSuite))
+}
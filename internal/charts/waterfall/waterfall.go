@@ -0,0 +1,32 @@
+// Package waterfall defines the typed Config for waterfall charts: the stat
+// total at one tag of a merged history (vizb merge), one floating step per
+// benchmark for its change up to a later tag, and the resulting total. From
+// and To pick the two tags (oldest and latest when unset); Sort orders the
+// steps by change. There is no swap, scale, or 3D.
+package waterfall
+
+import (
+	"github.com/goptics/vizb/internal/charts"
+	"github.com/goptics/vizb/shared"
+)
+
+const Type = "waterfall"
+
+type Config struct {
+	Type       string             `json:"type"`
+	Sort       *shared.Sort       `json:"sort,omitempty"`
+	ShowLabels *bool              `json:"showLabels,omitempty"`
+	TagAxis    string             `json:"tagAxis,omitempty"`
+	From       string             `json:"from,omitempty"`
+	To         string             `json:"to,omitempty"`
+	Stat       *shared.StatConfig `json:"stat,omitempty"`
+}
+
+func (Config) ChartType() string { return Type }
+
+func (c Config) StatEnabled() bool  { return c.Stat.StatEnabled() }
+func (c Config) StatMath() []string { return c.Stat.StatMath() }
+func (Config) SwapString() string   { return "" }
+
+// New returns a fresh zero-value waterfall chart Config.
+func New() charts.ChartConfig { return &Config{} }
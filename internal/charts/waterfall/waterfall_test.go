@@ -0,0 +1,69 @@
+package waterfall_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	_ "github.com/goptics/vizb/cmd/charts/waterfall"
+	"github.com/goptics/vizb/internal/charts"
+	waterfallchart "github.com/goptics/vizb/internal/charts/waterfall"
+	"github.com/goptics/vizb/shared"
+	"github.com/stretchr/testify/suite"
+)
+
+// WaterfallSuite covers the waterfall chart Config: its factory, JSON
+// round-trip, and the "sort and tag range only, no swap or scale" contract.
+type WaterfallSuite struct {
+	suite.Suite
+}
+
+func (s *WaterfallSuite) TestNewReturnsZeroConfig() {
+	cfg := waterfallchart.New()
+	got, ok := cfg.(*waterfallchart.Config)
+	s.Require().True(ok)
+	s.Empty(got.Type)
+	s.Nil(got.Sort)
+	s.Empty(got.From)
+	s.Empty(got.To)
+	s.Nil(got.Stat)
+	s.Empty(got.SwapString())
+}
+
+func (s *WaterfallSuite) TestDecodeRoundTripAllFields() {
+	labels := false
+	original := waterfallchart.Config{
+		Type:       "waterfall",
+		Sort:       &shared.Sort{Enabled: true, Order: "desc"},
+		ShowLabels: &labels,
+		TagAxis:    "n",
+		From:       "v1.0",
+		To:         "v1.2",
+		Stat:       &shared.StatConfig{Enabled: true},
+	}
+	raw, err := json.Marshal(original)
+	s.Require().NoError(err)
+
+	cfg, err := charts.Decode("waterfall", raw)
+	s.Require().NoError(err)
+	got, ok := cfg.(*waterfallchart.Config)
+	s.Require().True(ok)
+	s.Equal(original, *got)
+	s.Equal("waterfall", got.ChartType())
+}
+
+func (s *WaterfallSuite) TestJSONOmitsInapplicableChartFields() {
+	raw, err := json.Marshal(waterfallchart.Config{Type: "waterfall", From: "v1"})
+	s.Require().NoError(err)
+
+	var m map[string]any
+	s.Require().NoError(json.Unmarshal(raw, &m))
+	for _, key := range []string{"swap", "scale", "stack", "threeD", "to", "rank"} {
+		_, ok := m[key]
+		s.False(ok, "waterfall JSON must not carry %q", key)
+	}
+	s.Equal("v1", m["from"])
+}
+
+func TestWaterfallSuite(t *testing.T) {
+	suite.Run(t, new(WaterfallSuite))
+}
@@ -0,0 +1,192 @@
+// Package envinfo captures the machine and source state a benchmark ran in —
+// host, kernel, CPU, memory, toolchain versions and git checkout — as a flat
+// key/value map for shared.Meta.Env. Every probe is best effort: a key whose
+// source is missing or unreadable is left out rather than failing the run.
+package envinfo
+
+import (
+	"bufio"
+	"bytes"
+	"cmp"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/goptics/vizb/internal/gitrepo"
+)
+
+// Keys written by Capture. Toolchain keys only appear when the input names a
+// version.
+const (
+	KeyHostname  = "hostname"
+	KeyKernel    = "kernel"
+	KeyCPUModel  = "cpu.model"
+	KeyCPUCores  = "cpu.cores"
+	KeyMemory    = "memory"
+	KeyGo        = "go"
+	KeyRust      = "rust"
+	KeyNode      = "node"
+	KeyGitCommit = "git.commit"
+	KeyGitBranch = "git.branch"
+	KeyGitDirty  = "git.dirty"
+)
+
+// SampleSize is how much of the input Capture scans for toolchain versions;
+// they sit in the run's header, not among the results.
+const SampleSize = 1 << 20
+
+// procRoot and runCommand are swapped by tests.
+var (
+	procRoot   = "/proc"
+	runCommand = func(dir, name string, args ...string) (string, error) {
+		cmd := exec.Command(name, args...)
+		cmd.Dir = dir
+		out, err := cmd.Output()
+		return strings.TrimSpace(string(out)), err
+	}
+)
+
+// toolchainPatterns find versions in benchmark output: `go version` and
+// runtime headers ("go1.22.3"), `rustc --version` ("rustc 1.78.0") and
+// vitest/tinybench or `node --version` banners ("node v20.11.1").
+var toolchainPatterns = []struct {
+	key string
+	re  *regexp.Regexp
+}{
+	{KeyGo, regexp.MustCompile(`\bgo(1\.\d+(?:\.\d+)?(?:rc\d+)?)\b`)},
+	{KeyRust, regexp.MustCompile(`\brustc (\d+\.\d+\.\d+(?:-[\w.]+)?)`)},
+	{KeyNode, regexp.MustCompile(`(?i)\bnode(?:\.js)?[ /:]+v?(\d+\.\d+\.\d+)`)},
+}
+
+// Capture probes the current machine, the git working tree at dir and the
+// input sample (see SampleSize) and returns what it found.
+func Capture(sample []byte, dir string) map[string]string {
+	env := map[string]string{}
+	if host, err := os.Hostname(); err == nil && host != "" {
+		env[KeyHostname] = host
+	}
+	if kernel := kernelRelease(); kernel != "" {
+		env[KeyKernel] = kernel
+	}
+	maps.Copy(env, cpuInfo())
+	if mem := memTotal(); mem != "" {
+		env[KeyMemory] = mem
+	}
+	maps.Copy(env, Toolchains(sample))
+	maps.Copy(env, gitState(dir))
+	return env
+}
+
+// Toolchains returns the first Go, Rust and Node version named in sample.
+func Toolchains(sample []byte) map[string]string {
+	found := map[string]string{}
+	for _, p := range toolchainPatterns {
+		if m := p.re.FindSubmatch(sample); m != nil {
+			found[p.key] = string(m[1])
+		}
+	}
+	return found
+}
+
+// ReadSample returns up to SampleSize bytes from the start of path.
+func ReadSample(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(io.LimitReader(f, SampleSize))
+}
+
+func kernelRelease() string {
+	if b, err := os.ReadFile(filepath.Join(procRoot, "sys", "kernel", "osrelease")); err == nil {
+		return strings.TrimSpace(string(b))
+	}
+	if runtime.GOOS == "windows" {
+		return ""
+	}
+	out, _ := runCommand("", "uname", "-r")
+	return out
+}
+
+// cpuInfo reads the model and logical core count from /proc/cpuinfo, falling
+// back to the Go runtime's count where it is missing.
+func cpuInfo() map[string]string {
+	info := map[string]string{}
+	cores := 0
+	if f, err := os.Open(filepath.Join(procRoot, "cpuinfo")); err == nil {
+		defer f.Close()
+		sc := bufio.NewScanner(f)
+		for sc.Scan() {
+			key, value, ok := strings.Cut(sc.Text(), ":")
+			if !ok {
+				continue
+			}
+			switch strings.TrimSpace(key) {
+			case "processor":
+				cores++
+			case "model name", "Hardware":
+				if info[KeyCPUModel] == "" {
+					info[KeyCPUModel] = strings.TrimSpace(value)
+				}
+			}
+		}
+	}
+	if cores == 0 {
+		cores = runtime.NumCPU()
+	}
+	info[KeyCPUCores] = strconv.Itoa(cores)
+	return info
+}
+
+// memTotal reads MemTotal from /proc/meminfo as GiB.
+func memTotal() string {
+	b, err := os.ReadFile(filepath.Join(procRoot, "meminfo"))
+	if err != nil {
+		return ""
+	}
+	for line := range bytes.Lines(b) {
+		rest, ok := bytes.CutPrefix(line, []byte("MemTotal:"))
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(string(rest))
+		if len(fields) == 0 {
+			return ""
+		}
+		kb, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			return ""
+		}
+		return fmt.Sprintf("%.1f GiB", kb/(1<<20))
+	}
+	return ""
+}
+
+// gitState records the checkout at dir: full commit hash, branch ("HEAD" when
+// detached) and whether tracked or untracked files differ from it. Commit and
+// branch are read from .git directly; only the dirty check needs git on PATH
+// and is left out without it. Outside a repository it returns nothing.
+func gitState(dir string) map[string]string {
+	repo, err := gitrepo.Open(dir)
+	if err != nil {
+		return nil
+	}
+	defer repo.Close()
+	branch, commit, err := repo.Head()
+	if err != nil || commit == "" {
+		return nil
+	}
+	state := map[string]string{KeyGitCommit: commit, KeyGitBranch: cmp.Or(branch, "HEAD")}
+	if status, err := runCommand(dir, "git", "status", "--porcelain"); err == nil {
+		state[KeyGitDirty] = strconv.FormatBool(status != "")
+	}
+	return state
+}
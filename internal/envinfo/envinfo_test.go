@@ -0,0 +1,98 @@
+package envinfo
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type EnvInfoSuite struct {
+	suite.Suite
+	restoreProc string
+	restoreRun  func(dir, name string, args ...string) (string, error)
+}
+
+func (s *EnvInfoSuite) SetupTest() {
+	s.restoreProc, s.restoreRun = procRoot, runCommand
+	procRoot = s.T().TempDir()
+	runCommand = func(string, string, ...string) (string, error) { return "", errors.New("not found") }
+}
+
+func (s *EnvInfoSuite) TearDownTest() {
+	procRoot, runCommand = s.restoreProc, s.restoreRun
+}
+
+func (s *EnvInfoSuite) writeProc(name, content string) {
+	path := filepath.Join(procRoot, name)
+	s.Require().NoError(os.MkdirAll(filepath.Dir(path), 0o755))
+	s.Require().NoError(os.WriteFile(path, []byte(content), 0o644))
+}
+
+func (s *EnvInfoSuite) TestToolchains() {
+	sample := "go version go1.22.3 linux/amd64\nrustc 1.78.0 (9b00956e5 2024-04-29)\nNode.js v20.11.1\n"
+	s.Equal(map[string]string{KeyGo: "1.22.3", KeyRust: "1.78.0", KeyNode: "20.11.1"}, Toolchains([]byte(sample)))
+	s.Empty(Toolchains([]byte("BenchmarkGoodbye-8 100 12 ns/op\ngoos: linux\n")))
+}
+
+func (s *EnvInfoSuite) TestCaptureReadsProc() {
+	s.writeProc("cpuinfo", "processor\t: 0\nmodel name\t: Test CPU @ 3GHz\n\nprocessor\t: 1\nmodel name\t: Test CPU @ 3GHz\n")
+	s.writeProc("meminfo", "MemTotal:       16777216 kB\nMemFree:         1024 kB\n")
+	s.writeProc("sys/kernel/osrelease", "6.8.0-test\n")
+
+	env := Capture(nil, s.T().TempDir())
+	s.Equal("Test CPU @ 3GHz", env[KeyCPUModel])
+	s.Equal("2", env[KeyCPUCores])
+	s.Equal("16.0 GiB", env[KeyMemory])
+	s.Equal("6.8.0-test", env[KeyKernel])
+	s.NotContains(env, KeyGitCommit)
+}
+
+// writeRepo lays out the .git files gitrepo reads for HEAD: no git binary
+// or objects are needed.
+func (s *EnvInfoSuite) writeRepo(head string) string {
+	dir := s.T().TempDir()
+	s.Require().NoError(os.MkdirAll(filepath.Join(dir, ".git", "refs", "heads"), 0o755))
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, ".git", "HEAD"), []byte(head+"\n"), 0o644))
+	s.Require().NoError(os.WriteFile(filepath.Join(dir, ".git", "refs", "heads", "main"), []byte(testCommit+"\n"), 0o644))
+	return dir
+}
+
+const testCommit = "0123456789abcdef0123456789abcdef01234567"
+
+func (s *EnvInfoSuite) TestCaptureRecordsGitState() {
+	dir := s.writeRepo("ref: refs/heads/main")
+	runCommand = func(cmdDir, name string, args ...string) (string, error) {
+		if name == "git" && strings.Join(args, " ") == "status --porcelain" {
+			s.Equal(dir, cmdDir)
+			return " M main.go", nil
+		}
+		return "", errors.New("unexpected command")
+	}
+	env := Capture(nil, dir)
+	s.Equal(testCommit, env[KeyGitCommit])
+	s.Equal("main", env[KeyGitBranch])
+	s.Equal("true", env[KeyGitDirty])
+}
+
+func (s *EnvInfoSuite) TestGitStateWithoutGitBinary() {
+	env := Capture(nil, s.writeRepo(testCommit))
+	s.Equal(testCommit, env[KeyGitCommit])
+	s.Equal("HEAD", env[KeyGitBranch], "detached")
+	s.NotContains(env, KeyGitDirty)
+}
+
+func (s *EnvInfoSuite) TestReadSampleCapsInput() {
+	path := filepath.Join(s.T().TempDir(), "big.txt")
+	s.Require().NoError(os.WriteFile(path, make([]byte, SampleSize+10), 0o644))
+	sample, err := ReadSample(path)
+	s.Require().NoError(err)
+	s.Len(sample, SampleSize)
+}
+
+func TestEnvInfoSuite(t *testing.T) {
+	suite.Run(t, new(EnvInfoSuite))
+}
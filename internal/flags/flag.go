@@ -97,6 +97,11 @@ type Flag struct {
 	// object bag (semicolon-separated key=value pairs). Unknown keys are fatal.
 	ObjectFields []ObjectField
 
+	// Repeatable: for KindObject flags, each occurrence (repeated --mark flags,
+	// or repeated mark={…} keys across --chart specs) appends one bag to an
+	// array payload instead of replacing the previous value.
+	Repeatable bool
+
 	// --- fatal validation (chart flags): invalid input ⇒ error ---
 	Validate func(string) error // context-free; nil = none (swap is validated against axes by the caller)
 
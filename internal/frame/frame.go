@@ -0,0 +1,217 @@
+// Package frame flattens a Dataset into per-stat category × series tables,
+// the shape the static renderers and exporters draw from.
+package frame
+
+import (
+	"math"
+	"slices"
+	"strings"
+
+	internal_charts "github.com/goptics/vizb/internal/charts"
+	"github.com/goptics/vizb/pkg/style"
+	"github.com/goptics/vizb/shared"
+)
+
+// Frame is one chart's data: the averaged value of every (series, category)
+// cell for a single stat type, with categories and series in first-seen order.
+// Missing cells are NaN.
+type Frame struct {
+	Title      string
+	StatType   string
+	StatUnit   string // "ns/op": Stat.Unit and Stat.Per of the stat, when set
+	XLabel     string // category axis name (Axis.Label of x, or name)
+	Categories []string
+	Series     []Series
+}
+
+type Series struct {
+	Name   string
+	Values []float64 // one per category
+}
+
+// Build groups ds.Data into one frame per stat type. Categories come
+// from the x dimension (falling back to name); series from y, joined with z
+// when the data has one — frames are always 2D. Duplicate cells average,
+// as the UI does for aggregated data.
+func Build(ds *shared.Dataset) []Frame {
+	statTypes := ds.StatTypes()
+	if len(statTypes) == 0 {
+		statTypes = []string{""} // untyped stats (e.g. --col-axis y): one chart
+	}
+	xLabel := axisLabel(ds.Axes, "x")
+	if xLabel == "" {
+		xLabel = axisLabel(ds.Axes, "name")
+	}
+
+	frames := make([]Frame, 0, len(statTypes))
+	for _, statType := range statTypes {
+		fr := Frame{StatType: statType, XLabel: xLabel, Title: statType}
+		if fr.Title == "" {
+			fr.Title = ds.Name
+		}
+		catIndex := map[string]int{}
+		seriesIndex := map[string]int{}
+		type cell struct{ sum, n float64 }
+		cells := map[[2]int]*cell{}
+
+		for _, p := range ds.Data {
+			category := p.XAxis
+			if category == "" {
+				category = p.Name
+			}
+			if category == "" {
+				continue
+			}
+			for _, st := range p.Stats {
+				if st.Type != statType || st.Value == nil || math.IsNaN(*st.Value) {
+					continue
+				}
+				ci, ok := catIndex[category]
+				if !ok {
+					ci = len(fr.Categories)
+					catIndex[category] = ci
+					fr.Categories = append(fr.Categories, category)
+				}
+				name := seriesName(p, statType, ds.Name)
+				si, ok := seriesIndex[name]
+				if !ok {
+					si = len(fr.Series)
+					seriesIndex[name] = si
+					fr.Series = append(fr.Series, Series{Name: name})
+				}
+				if fr.StatUnit == "" {
+					fr.StatUnit = statUnit(st)
+				}
+				c := cells[[2]int{si, ci}]
+				if c == nil {
+					c = &cell{}
+					cells[[2]int{si, ci}] = c
+				}
+				c.sum += *st.Value
+				c.n++
+			}
+		}
+		if len(fr.Categories) == 0 {
+			continue
+		}
+		for si := range fr.Series {
+			values := make([]float64, len(fr.Categories))
+			for ci := range values {
+				values[ci] = math.NaN()
+				if c := cells[[2]int{si, ci}]; c != nil {
+					values[ci] = c.sum / c.n
+				}
+			}
+			fr.Series[si].Values = values
+		}
+		frames = append(frames, fr)
+	}
+	return frames
+}
+
+func seriesName(p shared.DataPoint, statType, datasetName string) string {
+	parts := make([]string, 0, 2)
+	for _, v := range []string{p.YAxis, p.ZAxis} {
+		if v != "" {
+			parts = append(parts, v)
+		}
+	}
+	if len(parts) > 0 {
+		return strings.Join(parts, " / ")
+	}
+	if statType != "" {
+		return statType
+	}
+	return datasetName
+}
+
+func axisLabel(axes []shared.Axis, key string) string {
+	for _, a := range axes {
+		if a.Key == key {
+			return a.Label
+		}
+	}
+	return ""
+}
+
+// Unit is the value-axis name: the stat's structured unit, else the stat
+// type's trailing "(unit)", if any.
+func (fr Frame) Unit() string {
+	if fr.StatUnit != "" {
+		return fr.StatUnit
+	}
+	_, unit := internal_charts.SplitStatUnit(fr.StatType)
+	return unit
+}
+
+// statUnit renders a stat's unit and per value as "ns/op" or "ms". A per value
+// alone ("Allocations/op") is part of the name, not a unit.
+func statUnit(st shared.Stat) string {
+	if st.Unit == "" || st.Per == "" {
+		return st.Unit
+	}
+	return st.Unit + "/" + st.Per
+}
+
+// Totals sums every series per category, skipping missing cells.
+func (fr Frame) Totals() []float64 {
+	out := make([]float64, len(fr.Categories))
+	for _, s := range fr.Series {
+		for i, v := range s.Values {
+			if !math.IsNaN(v) {
+				out[i] += v
+			}
+		}
+	}
+	return out
+}
+
+// Sort reorders categories by their total across series (stable, so ties
+// keep first-seen order). A nil or disabled sort is a no-op.
+func (fr *Frame) Sort(s *shared.Sort) {
+	if s == nil || !s.Enabled {
+		return
+	}
+	totals := fr.Totals()
+	order := make([]int, len(fr.Categories))
+	for i := range order {
+		order[i] = i
+	}
+	desc := strings.EqualFold(s.Order, "desc")
+	slices.SortStableFunc(order, func(a, b int) int {
+		switch {
+		case totals[a] == totals[b]:
+			return 0
+		case (totals[a] < totals[b]) != desc:
+			return -1
+		default:
+			return 1
+		}
+	})
+	fr.Categories = permute(fr.Categories, order)
+	fr.Series = slices.Clone(fr.Series) // frames are shared across chart types
+	for i := range fr.Series {
+		fr.Series[i].Values = permute(fr.Series[i].Values, order)
+	}
+}
+
+func permute[T any](in []T, order []int) []T {
+	out := make([]T, len(order))
+	for i, j := range order {
+		out[i] = in[j]
+	}
+	return out
+}
+
+// Palette returns the dataset's series colors: Themes[0] when it embeds one,
+// else the built-in default the UI falls back to.
+func Palette(ds *shared.Dataset) []string {
+	if len(ds.Themes) > 0 && len(ds.Themes[0].Colors) > 0 {
+		return ds.Themes[0].Colors
+	}
+	theme, err := style.ParseThemeSpec("default")
+	if err != nil || len(theme.Colors) == 0 {
+		return []string{"#5470C6"}
+	}
+	return theme.Colors
+}
@@ -0,0 +1,90 @@
+package frame
+
+import (
+	"math"
+	"testing"
+
+	"github.com/goptics/vizb/shared"
+	"github.com/stretchr/testify/suite"
+)
+
+type FrameSuite struct {
+	suite.Suite
+}
+
+func (s *FrameSuite) TestBuildFramesGroupsAndAverages() {
+	ds := &shared.Dataset{
+		Name: "d",
+		Axes: []shared.Axis{{Key: "x", Label: "bench"}, {Key: "y"}},
+		Data: []shared.DataPoint{
+			{XAxis: "a", YAxis: "s1", Stats: []shared.Stat{{Type: "t (ns)", Value: shared.F64(2)}}},
+			{XAxis: "a", YAxis: "s1", Stats: []shared.Stat{{Type: "t (ns)", Value: shared.F64(4)}}},
+			{XAxis: "b", YAxis: "s2", Stats: []shared.Stat{{Type: "t (ns)", Value: shared.F64(5)}}},
+		},
+	}
+	frames := Build(ds)
+	s.Require().Len(frames, 1)
+	fr := frames[0]
+	s.Equal("t (ns)", fr.Title)
+	s.Equal("ns", fr.Unit())
+	s.Equal("bench", fr.XLabel)
+	s.Equal([]string{"a", "b"}, fr.Categories)
+	s.Require().Len(fr.Series, 2)
+	s.Equal("s1", fr.Series[0].Name)
+	s.Equal(3.0, fr.Series[0].Values[0])
+	s.True(math.IsNaN(fr.Series[0].Values[1]))
+	s.Equal(5.0, fr.Series[1].Values[1])
+}
+
+func (s *FrameSuite) TestUnitPrefersStructuredUnit() {
+	ds := &shared.Dataset{Data: []shared.DataPoint{{XAxis: "a", Stats: []shared.Stat{
+		{Type: "Wall clock", Value: shared.F64(1), Unit: "ms"},
+		{Type: "Execution Time (ns/op)", Value: shared.F64(1), Unit: "ns", Per: "op"},
+		{Type: "Allocations/op", Value: shared.F64(1), Per: "op"},
+	}}}}
+	var units []string
+	for _, fr := range Build(ds) {
+		units = append(units, fr.Unit())
+	}
+	s.Equal([]string{"ms", "ns/op", ""}, units)
+}
+
+func (s *FrameSuite) TestBuildFramesUntypedStatsUseDatasetName() {
+	ds := &shared.Dataset{
+		Name: "Comparisons",
+		Data: []shared.DataPoint{{Name: "only", Stats: []shared.Stat{{Value: shared.F64(1)}}}},
+	}
+	frames := Build(ds)
+	s.Require().Len(frames, 1)
+	s.Equal("Comparisons", frames[0].Title)
+	s.Equal([]string{"only"}, frames[0].Categories)
+	s.Equal("Comparisons", frames[0].Series[0].Name)
+}
+
+func (s *FrameSuite) TestSortByTotal() {
+	fr := Frame{
+		Categories: []string{"a", "b", "c"},
+		Series: []Series{
+			{Name: "s1", Values: []float64{1, 5, 3}},
+			{Name: "s2", Values: []float64{1, math.NaN(), 0}},
+		},
+	}
+	asc := fr
+	asc.Sort(&shared.Sort{Enabled: true, Order: "asc"})
+	s.Equal([]string{"a", "c", "b"}, asc.Categories)
+	s.Equal([]float64{1, 3, 5}, asc.Series[0].Values)
+
+	desc := fr
+	desc.Sort(&shared.Sort{Enabled: true, Order: "desc"})
+	s.Equal([]string{"b", "c", "a"}, desc.Categories)
+	s.Equal([]string{"a", "b", "c"}, fr.Categories, "sorting a copy leaves the source frame alone")
+	s.Equal([]float64{1, 5, 3}, fr.Series[0].Values)
+
+	off := fr
+	off.Sort(&shared.Sort{Order: "desc"})
+	s.Equal(fr.Categories, off.Categories)
+}
+
+func TestFrameSuite(t *testing.T) {
+	suite.Run(t, new(FrameSuite))
+}
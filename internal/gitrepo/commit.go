@@ -0,0 +1,209 @@
+package gitrepo
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxWalk bounds history walks (describe, ancestry) on very large repositories.
+const maxWalk = 200_000
+
+// Commit is the parsed header and subject of a commit object.
+type Commit struct {
+	SHA        string
+	Parents    []string
+	Author     string // name only, without the email
+	AuthorTime time.Time
+	CommitTime time.Time
+	Subject    string
+}
+
+// Commit reads and parses the commit with the given hash.
+func (r *Repo) Commit(sha string) (*Commit, error) {
+	if c, ok := r.commits[sha]; ok {
+		return c, nil
+	}
+	typ, data, err := r.object(sha)
+	if err != nil {
+		return nil, err
+	}
+	if typ != objCommit {
+		return nil, fmt.Errorf("object %s is not a commit", sha)
+	}
+	c := parseCommit(sha, data)
+	r.commits[sha] = c
+	return c, nil
+}
+
+func parseCommit(sha string, data []byte) *Commit {
+	c := &Commit{SHA: sha}
+	header, message, _ := bytes.Cut(data, []byte("\n\n"))
+	for line := range strings.SplitSeq(string(header), "\n") {
+		key, value, _ := strings.Cut(line, " ")
+		switch key {
+		case "parent":
+			c.Parents = append(c.Parents, value)
+		case "author":
+			c.Author, c.AuthorTime = parseSignature(value)
+		case "committer":
+			_, c.CommitTime = parseSignature(value)
+		}
+	}
+	subject, _, _ := strings.Cut(strings.TrimLeft(string(message), "\n"), "\n")
+	c.Subject = strings.TrimSpace(subject)
+	return c
+}
+
+// parseSignature splits "Name <email> 1700000000 +0200" into the name and
+// the time in its recorded zone.
+func parseSignature(s string) (string, time.Time) {
+	open := strings.LastIndexByte(s, '<')
+	closing := strings.LastIndexByte(s, '>')
+	if open < 0 || closing < open {
+		return strings.TrimSpace(s), time.Time{}
+	}
+	name := strings.TrimSpace(s[:open])
+	fields := strings.Fields(s[closing+1:])
+	if len(fields) < 1 {
+		return name, time.Time{}
+	}
+	secs, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return name, time.Time{}
+	}
+	t := time.Unix(secs, 0).UTC()
+	if len(fields) > 1 && len(fields[1]) == 5 {
+		hours, errH := strconv.Atoi(fields[1][1:3])
+		mins, errM := strconv.Atoi(fields[1][3:5])
+		if errH == nil && errM == nil {
+			offset := hours*3600 + mins*60
+			if fields[1][0] == '-' {
+				offset = -offset
+			}
+			t = t.In(time.FixedZone(fields[1], offset))
+		}
+	}
+	return name, t
+}
+
+// ShortSHA abbreviates a hash to git's default seven characters.
+func ShortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
+// Describe names sha after its nearest tag like `git describe --tags`: the tag
+// itself when it points at sha, else "<tag>-<n>-g<short sha>" where n counts
+// the commits walked from sha to the tag. ok is false when no tag is reachable.
+// Of several tags on one commit the shortest name wins, so "v1.2.0" beats
+// "v1.2.0-rc1"; equal lengths go to the greatest name.
+func (r *Repo) Describe(sha string) (name string, ok bool, err error) {
+	tags, err := r.tags()
+	if err != nil {
+		return "", false, err
+	}
+	if len(tags) == 0 {
+		return "", false, nil
+	}
+	type step struct {
+		sha   string
+		depth int
+	}
+	queue := []step{{sha, 0}}
+	seen := map[string]bool{sha: true}
+	for len(queue) > 0 && len(seen) <= maxWalk {
+		cur := queue[0]
+		queue = queue[1:]
+		if names := tags[cur.sha]; len(names) > 0 {
+			tag := slices.MaxFunc(names, func(a, b string) int {
+				if len(a) != len(b) {
+					return len(b) - len(a)
+				}
+				return strings.Compare(a, b)
+			})
+			if cur.depth == 0 {
+				return tag, true, nil
+			}
+			return fmt.Sprintf("%s-%d-g%s", tag, cur.depth, ShortSHA(sha)), true, nil
+		}
+		c, err := r.Commit(cur.sha)
+		if err != nil {
+			return "", false, err
+		}
+		for _, p := range c.Parents {
+			if !seen[p] {
+				seen[p] = true
+				queue = append(queue, step{p, cur.depth + 1})
+			}
+		}
+	}
+	return "", false, nil
+}
+
+// IsAncestor reports whether ancestor is reachable from descendant by
+// following parents. Unknown commits are never ancestors.
+func (r *Repo) IsAncestor(ancestor, descendant string) bool {
+	if ancestor == descendant {
+		return false
+	}
+	target, err := r.Commit(ancestor)
+	if err != nil {
+		return false
+	}
+	queue := []string{descendant}
+	seen := map[string]bool{descendant: true}
+	for len(queue) > 0 && len(seen) <= maxWalk {
+		cur := queue[0]
+		queue = queue[1:]
+		c, err := r.Commit(cur)
+		if err != nil {
+			return false
+		}
+		for _, p := range c.Parents {
+			if p == ancestor {
+				return true
+			}
+			if seen[p] {
+				continue
+			}
+			seen[p] = true
+			// A parent committed well before the target can't lead back to it,
+			// bar clock skew, which a day of slack absorbs.
+			if pc, err := r.Commit(p); err == nil && pc.CommitTime.Before(target.CommitTime.Add(-24*time.Hour)) {
+				continue
+			}
+			queue = append(queue, p)
+		}
+	}
+	return false
+}
+
+// Close releases the repository's open pack files.
+func (r *Repo) Close() error {
+	var first error
+	for _, p := range r.packs {
+		if err := p.file.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+	r.packs, r.loaded = nil, false
+	return first
+}
+
+// headerValue returns the first header line named key in a commit or tag
+// object.
+func headerValue(data []byte, key string) (string, bool) {
+	header, _, _ := bytes.Cut(data, []byte("\n\n"))
+	for line := range strings.SplitSeq(string(header), "\n") {
+		if k, v, ok := strings.Cut(line, " "); ok && k == key {
+			return v, true
+		}
+	}
+	return "", false
+}
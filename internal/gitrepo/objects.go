@@ -0,0 +1,307 @@
+package gitrepo
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// Object types, numbered as in pack files.
+const (
+	objCommit   = 1
+	objTree     = 2
+	objBlob     = 3
+	objTag      = 4
+	objOfsDelta = 6
+	objRefDelta = 7
+)
+
+var objTypeNames = map[string]int{"commit": objCommit, "tree": objTree, "blob": objBlob, "tag": objTag}
+
+// maxDeltaChain bounds delta resolution; git itself caps chains at 50 by default.
+const maxDeltaChain = 1000
+
+// errObjectNotFound is returned for hashes in neither loose storage nor a pack.
+var errObjectNotFound = errors.New("object not found")
+
+// object reads and inflates the object with the given hash.
+func (r *Repo) object(sha string) (int, []byte, error) {
+	typ, data, err := r.looseObject(sha)
+	if !errors.Is(err, os.ErrNotExist) {
+		return typ, data, err
+	}
+	if err := r.loadPacks(); err != nil {
+		return 0, nil, err
+	}
+	raw, err := hex.DecodeString(sha)
+	if err != nil {
+		return 0, nil, fmt.Errorf("object %s: %w", sha, err)
+	}
+	for _, p := range r.packs {
+		if offset, ok := p.find(raw); ok {
+			return r.packObject(p, offset, 0)
+		}
+	}
+	return 0, nil, fmt.Errorf("object %s: %w", sha, errObjectNotFound)
+}
+
+func (r *Repo) looseObject(sha string) (int, []byte, error) {
+	f, err := os.Open(filepath.Join(r.commonDir, "objects", sha[:2], sha[2:]))
+	if err != nil {
+		return 0, nil, err
+	}
+	defer f.Close()
+	zr, err := zlib.NewReader(f)
+	if err != nil {
+		return 0, nil, fmt.Errorf("object %s: %w", sha, err)
+	}
+	defer zr.Close()
+	b, err := io.ReadAll(zr)
+	if err != nil {
+		return 0, nil, fmt.Errorf("object %s: %w", sha, err)
+	}
+	header, body, ok := bytes.Cut(b, []byte{0})
+	if !ok {
+		return 0, nil, fmt.Errorf("object %s: missing header", sha)
+	}
+	name, size, _ := bytes.Cut(header, []byte(" "))
+	typ, known := objTypeNames[string(name)]
+	if n, err := strconv.Atoi(string(size)); !known || err != nil || n != len(body) {
+		return 0, nil, fmt.Errorf("object %s: malformed header %q", sha, header)
+	}
+	return typ, body, nil
+}
+
+// pack is one objects/pack/*.pack file and its version 2 index.
+type pack struct {
+	file    *os.File
+	fanout  [256]uint32
+	hashes  []byte // sorted 20-byte hashes
+	offsets []byte // 4-byte offsets, MSB set for the large offset table
+	large   []byte // 8-byte offsets
+}
+
+func (r *Repo) loadPacks() error {
+	if r.loaded {
+		return nil
+	}
+	r.loaded = true
+	idxs, err := filepath.Glob(filepath.Join(r.commonDir, "objects", "pack", "*.idx"))
+	if err != nil {
+		return err
+	}
+	for _, idx := range idxs {
+		p, err := openPack(idx)
+		if err != nil {
+			return err
+		}
+		r.packs = append(r.packs, p)
+	}
+	return nil
+}
+
+func openPack(idxPath string) (*pack, error) {
+	idx, err := os.ReadFile(idxPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(idx) < 8+256*4 || !bytes.Equal(idx[:4], []byte{0xff, 't', 'O', 'c'}) || binary.BigEndian.Uint32(idx[4:8]) != 2 {
+		return nil, fmt.Errorf("%s: only version 2 pack indexes are supported", idxPath)
+	}
+	p := &pack{}
+	for i := range p.fanout {
+		p.fanout[i] = binary.BigEndian.Uint32(idx[8+i*4:])
+	}
+	n := int(p.fanout[255])
+	start := 8 + 256*4
+	end := start + n*20 + n*4 + n*4
+	if len(idx) < end {
+		return nil, fmt.Errorf("%s: truncated index", idxPath)
+	}
+	p.hashes = idx[start : start+n*20]
+	p.offsets = idx[start+n*24 : end]
+	p.large = idx[end:]
+	file, err := os.Open(idxPath[:len(idxPath)-len(".idx")] + ".pack")
+	if err != nil {
+		return nil, err
+	}
+	p.file = file
+	return p, nil
+}
+
+// find returns the pack offset of the object with the raw 20-byte hash.
+func (p *pack) find(raw []byte) (int64, bool) {
+	lo := 0
+	if raw[0] > 0 {
+		lo = int(p.fanout[raw[0]-1])
+	}
+	hi := int(p.fanout[raw[0]])
+	for lo < hi {
+		mid := (lo + hi) / 2
+		switch c := bytes.Compare(p.hashes[mid*20:mid*20+20], raw); {
+		case c == 0:
+			return p.offset(mid), true
+		case c < 0:
+			lo = mid + 1
+		default:
+			hi = mid
+		}
+	}
+	return 0, false
+}
+
+func (p *pack) offset(i int) int64 {
+	off := binary.BigEndian.Uint32(p.offsets[i*4:])
+	if off&0x80000000 == 0 {
+		return int64(off)
+	}
+	j := int(off & 0x7fffffff)
+	return int64(binary.BigEndian.Uint64(p.large[j*8:]))
+}
+
+// packObject reads the entry at offset, resolving delta chains against their
+// base objects.
+func (r *Repo) packObject(p *pack, offset int64, depth int) (int, []byte, error) {
+	if depth > maxDeltaChain {
+		return 0, nil, fmt.Errorf("pack offset %d: delta chain too long", offset)
+	}
+	br := bufio.NewReader(io.NewSectionReader(p.file, offset, 1<<62))
+	c, err := br.ReadByte()
+	if err != nil {
+		return 0, nil, err
+	}
+	typ := int(c>>4) & 7
+	size := int64(c & 0x0f)
+	for shift := 4; c&0x80 != 0; shift += 7 {
+		if c, err = br.ReadByte(); err != nil {
+			return 0, nil, err
+		}
+		size |= int64(c&0x7f) << shift
+	}
+
+	var baseType int
+	var base []byte
+	switch typ {
+	case objOfsDelta:
+		if c, err = br.ReadByte(); err != nil {
+			return 0, nil, err
+		}
+		back := int64(c & 0x7f)
+		for c&0x80 != 0 {
+			if c, err = br.ReadByte(); err != nil {
+				return 0, nil, err
+			}
+			back = (back+1)<<7 | int64(c&0x7f)
+		}
+		if baseType, base, err = r.packObject(p, offset-back, depth+1); err != nil {
+			return 0, nil, err
+		}
+	case objRefDelta:
+		raw := make([]byte, 20)
+		if _, err = io.ReadFull(br, raw); err != nil {
+			return 0, nil, err
+		}
+		if baseType, base, err = r.object(hex.EncodeToString(raw)); err != nil {
+			return 0, nil, err
+		}
+	case objCommit, objTree, objBlob, objTag:
+	default:
+		return 0, nil, fmt.Errorf("pack offset %d: unknown object type %d", offset, typ)
+	}
+
+	zr, err := zlib.NewReader(br)
+	if err != nil {
+		return 0, nil, fmt.Errorf("pack offset %d: %w", offset, err)
+	}
+	defer zr.Close()
+	data := make([]byte, size)
+	if _, err := io.ReadFull(zr, data); err != nil {
+		return 0, nil, fmt.Errorf("pack offset %d: %w", offset, err)
+	}
+	if base == nil {
+		return typ, data, nil
+	}
+	out, err := applyDelta(base, data)
+	if err != nil {
+		return 0, nil, fmt.Errorf("pack offset %d: %w", offset, err)
+	}
+	return baseType, out, nil
+}
+
+// applyDelta rebuilds an object from its base and a git delta: two varint
+// sizes, then copy-from-base and insert-literal instructions.
+func applyDelta(base, delta []byte) ([]byte, error) {
+	errCorrupt := errors.New("corrupt delta")
+	varint := func() (int, bool) {
+		n, shift := 0, 0
+		for len(delta) > 0 {
+			c := delta[0]
+			delta = delta[1:]
+			n |= int(c&0x7f) << shift
+			shift += 7
+			if c&0x80 == 0 {
+				return n, true
+			}
+		}
+		return 0, false
+	}
+	srcSize, ok1 := varint()
+	dstSize, ok2 := varint()
+	if !ok1 || !ok2 || srcSize != len(base) {
+		return nil, errCorrupt
+	}
+	out := make([]byte, 0, dstSize)
+	for len(delta) > 0 {
+		op := delta[0]
+		delta = delta[1:]
+		switch {
+		case op&0x80 != 0:
+			var off, n int
+			for i := range 4 {
+				if op&(1<<i) != 0 {
+					if len(delta) == 0 {
+						return nil, errCorrupt
+					}
+					off |= int(delta[0]) << (8 * i)
+					delta = delta[1:]
+				}
+			}
+			for i := range 3 {
+				if op&(0x10<<i) != 0 {
+					if len(delta) == 0 {
+						return nil, errCorrupt
+					}
+					n |= int(delta[0]) << (8 * i)
+					delta = delta[1:]
+				}
+			}
+			if n == 0 {
+				n = 0x10000
+			}
+			if off+n > len(base) {
+				return nil, errCorrupt
+			}
+			out = append(out, base[off:off+n]...)
+		case op != 0:
+			if int(op) > len(delta) {
+				return nil, errCorrupt
+			}
+			out = append(out, delta[:op]...)
+			delta = delta[op:]
+		default:
+			return nil, errCorrupt
+		}
+	}
+	if len(out) != dstSize {
+		return nil, errCorrupt
+	}
+	return out, nil
+}
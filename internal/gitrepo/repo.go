@@ -0,0 +1,273 @@
+// Package gitrepo reads the little of a git repository vizb needs — HEAD,
+// refs, tags and commit objects — straight from .git, so tagging a run works
+// without a git binary. Loose objects and version 2 pack indexes are
+// supported, including delta-compressed pack entries; SHA-256 repositories
+// are not.
+package gitrepo
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrNotRepository is returned by Open when no enclosing .git is found.
+var ErrNotRepository = errors.New("not a git repository")
+
+// Repo is an open repository. It caches parsed commits and pack indexes and is
+// not safe for concurrent use.
+type Repo struct {
+	// gitDir holds HEAD; commonDir holds objects and refs. They differ only in
+	// linked worktrees.
+	gitDir    string
+	commonDir string
+
+	packs   []*pack
+	loaded  bool
+	commits map[string]*Commit
+}
+
+// Open finds the repository containing dir, walking up parent directories.
+func Open(dir string) (*Repo, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	for {
+		gitDir, err := gitDirAt(abs)
+		if err != nil {
+			return nil, err
+		}
+		if gitDir != "" {
+			return newRepo(gitDir)
+		}
+		parent := filepath.Dir(abs)
+		if parent == abs {
+			return nil, ErrNotRepository
+		}
+		abs = parent
+	}
+}
+
+// gitDirAt returns dir's .git directory, following the "gitdir:" file a
+// linked worktree or submodule leaves in its place, or "" when there is none.
+func gitDirAt(dir string) (string, error) {
+	path := filepath.Join(dir, ".git")
+	info, err := os.Stat(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return "", nil
+	case err != nil:
+		return "", err
+	case info.IsDir():
+		return path, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	target, ok := strings.CutPrefix(strings.TrimSpace(string(b)), "gitdir:")
+	if !ok {
+		return "", fmt.Errorf("%s: malformed gitdir file", path)
+	}
+	target = strings.TrimSpace(target)
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(dir, target)
+	}
+	return target, nil
+}
+
+func newRepo(gitDir string) (*Repo, error) {
+	if _, err := os.Stat(filepath.Join(gitDir, "HEAD")); err != nil {
+		return nil, fmt.Errorf("%s: %w", gitDir, ErrNotRepository)
+	}
+	common := gitDir
+	if b, err := os.ReadFile(filepath.Join(gitDir, "commondir")); err == nil {
+		common = strings.TrimSpace(string(b))
+		if !filepath.IsAbs(common) {
+			common = filepath.Join(gitDir, common)
+		}
+	}
+	return &Repo{gitDir: gitDir, commonDir: common, commits: map[string]*Commit{}}, nil
+}
+
+// Head returns the checked-out branch ("" when HEAD is detached) and the
+// commit it points at ("" on a branch with no commits yet).
+func (r *Repo) Head() (branch, sha string, err error) {
+	b, err := os.ReadFile(filepath.Join(r.gitDir, "HEAD"))
+	if err != nil {
+		return "", "", err
+	}
+	head := strings.TrimSpace(string(b))
+	if ref, ok := strings.CutPrefix(head, "ref:"); ok {
+		ref = strings.TrimSpace(ref)
+		branch = strings.TrimPrefix(ref, "refs/heads/")
+		sha, err = r.resolveRef(ref, 0)
+		if errors.Is(err, os.ErrNotExist) {
+			return branch, "", nil
+		}
+		return branch, sha, err
+	}
+	if !isHash(head) {
+		return "", "", fmt.Errorf("HEAD: unexpected content %q", head)
+	}
+	return "", head, nil
+}
+
+// resolveRef follows a ref, symbolic or not, to a hash; loose refs shadow
+// packed ones. It returns os.ErrNotExist for unknown refs.
+func (r *Repo) resolveRef(name string, depth int) (string, error) {
+	if depth > 5 {
+		return "", fmt.Errorf("ref %s: too many levels of symbolic refs", name)
+	}
+	for _, dir := range []string{r.gitDir, r.commonDir} {
+		b, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			continue
+		}
+		value := strings.TrimSpace(string(b))
+		if target, ok := strings.CutPrefix(value, "ref:"); ok {
+			return r.resolveRef(strings.TrimSpace(target), depth+1)
+		}
+		if !isHash(value) {
+			return "", fmt.Errorf("ref %s: unexpected content %q", name, value)
+		}
+		return value, nil
+	}
+	packed, err := r.packedRefs()
+	if err != nil {
+		return "", err
+	}
+	if ref, ok := packed[name]; ok {
+		return ref.sha, nil
+	}
+	return "", fmt.Errorf("ref %s: %w", name, os.ErrNotExist)
+}
+
+type packedRef struct{ sha, peeled string }
+
+// packedRefs reads .git/packed-refs, including the "^<hash>" peel lines git
+// writes under annotated tags.
+func (r *Repo) packedRefs() (map[string]packedRef, error) {
+	refs := map[string]packedRef{}
+	f, err := os.Open(filepath.Join(r.commonDir, "packed-refs"))
+	if errors.Is(err, os.ErrNotExist) {
+		return refs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	last := ""
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := sc.Text()
+		switch {
+		case line == "" || line[0] == '#':
+		case line[0] == '^':
+			if ref, ok := refs[last]; ok && isHash(line[1:]) {
+				ref.peeled = line[1:]
+				refs[last] = ref
+			}
+		default:
+			sha, name, ok := strings.Cut(line, " ")
+			if ok && isHash(sha) {
+				refs[name] = packedRef{sha: sha}
+				last = name
+			}
+		}
+	}
+	return refs, sc.Err()
+}
+
+// tags maps each tagged commit to its tag names, peeling annotated tags.
+func (r *Repo) tags() (map[string][]string, error) {
+	byCommit := map[string][]string{}
+	packed, err := r.packedRefs()
+	if err != nil {
+		return nil, err
+	}
+	names := map[string]string{}
+	for name, ref := range packed {
+		if tag, ok := strings.CutPrefix(name, "refs/tags/"); ok {
+			sha := ref.sha
+			if ref.peeled != "" {
+				sha = ref.peeled
+			}
+			names[tag] = sha
+		}
+	}
+	root := filepath.Join(r.commonDir, "refs", "tags")
+	err = filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if sha := strings.TrimSpace(string(b)); isHash(sha) {
+			names[filepath.ToSlash(rel)] = sha
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for name, sha := range names {
+		commit, err := r.peel(sha)
+		if err != nil {
+			continue // tags of trees, blobs or missing objects can't describe a commit
+		}
+		byCommit[commit] = append(byCommit[commit], name)
+	}
+	return byCommit, nil
+}
+
+// peel follows annotated tag objects down to the commit they tag.
+func (r *Repo) peel(sha string) (string, error) {
+	for range 10 {
+		typ, data, err := r.object(sha)
+		if err != nil {
+			return "", err
+		}
+		switch typ {
+		case objCommit:
+			return sha, nil
+		case objTag:
+			target, ok := headerValue(data, "object")
+			if !ok {
+				return "", fmt.Errorf("tag %s: missing object", sha)
+			}
+			sha = target
+		default:
+			return "", fmt.Errorf("object %s is not a commit", sha)
+		}
+	}
+	return "", fmt.Errorf("tag %s: too deeply nested", sha)
+}
+
+func isHash(s string) bool {
+	if len(s) != 40 {
+		return false
+	}
+	for _, c := range s {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+	return true
+}
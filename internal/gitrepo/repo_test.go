@@ -0,0 +1,221 @@
+package gitrepo
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// RepoSuite builds throwaway repositories with the git binary and checks the
+// reader against what git itself reports.
+type RepoSuite struct {
+	suite.Suite
+	dir string
+}
+
+func (s *RepoSuite) SetupTest() {
+	if _, err := exec.LookPath("git"); err != nil {
+		s.T().Skip("git not installed")
+	}
+	s.dir = s.T().TempDir()
+	s.git("init", "-q", "-b", "main")
+}
+
+// gitEnv pins identity and ignores the host's git config.
+var gitEnv = []string{
+	"GIT_AUTHOR_NAME=Ada Lovelace", "GIT_AUTHOR_EMAIL=ada@example.com",
+	"GIT_COMMITTER_NAME=Ada Lovelace", "GIT_COMMITTER_EMAIL=ada@example.com",
+	"GIT_CONFIG_GLOBAL=/dev/null", "GIT_CONFIG_NOSYSTEM=1",
+}
+
+func (s *RepoSuite) git(args ...string) string {
+	return s.gitWithEnv(nil, args...)
+}
+
+func (s *RepoSuite) gitWithEnv(env []string, args ...string) string {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = s.dir
+	cmd.Env = append(append(os.Environ(), gitEnv...), env...)
+	out, err := cmd.CombinedOutput()
+	s.Require().NoError(err, "git %v: %s", args, out)
+	return strings.TrimSpace(string(out))
+}
+
+// commit writes a file large enough for git gc to store later versions as
+// deltas, then commits it at a fixed time.
+func (s *RepoSuite) commit(subject string, at int) string {
+	content := strings.Repeat("line of benchmark fixture text\n", 200) + subject + "\n"
+	s.Require().NoError(os.WriteFile(filepath.Join(s.dir, "file.txt"), []byte(content), 0o644))
+	s.git("add", "file.txt")
+	date := fmt.Sprintf("%d +0200", 1_700_000_000+at)
+	s.gitWithEnv([]string{"GIT_AUTHOR_DATE=" + date, "GIT_COMMITTER_DATE=" + date},
+		"commit", "-q", "-m", subject+"\n\nBody text.")
+	return s.git("rev-parse", "HEAD")
+}
+
+func (s *RepoSuite) open(dir string) *Repo {
+	r, err := Open(dir)
+	s.Require().NoError(err)
+	s.T().Cleanup(func() { _ = r.Close() })
+	return r
+}
+
+func (s *RepoSuite) TestHeadAndCommitFromLooseObjects() {
+	first := s.commit("first", 0)
+	second := s.commit("second", 60)
+
+	sub := filepath.Join(s.dir, "nested", "dir")
+	s.Require().NoError(os.MkdirAll(sub, 0o755))
+	r := s.open(sub)
+
+	branch, sha, err := r.Head()
+	s.Require().NoError(err)
+	s.Equal("main", branch)
+	s.Equal(second, sha)
+
+	c, err := r.Commit(sha)
+	s.Require().NoError(err)
+	s.Equal([]string{first}, c.Parents)
+	s.Equal("Ada Lovelace", c.Author)
+	s.Equal("second", c.Subject)
+	s.Equal(time.Unix(1_700_000_060, 0).Unix(), c.CommitTime.Unix())
+	_, offset := c.CommitTime.Zone()
+	s.Equal(2*3600, offset)
+}
+
+func (s *RepoSuite) TestReadsPackedObjectsAndRefs() {
+	var shas []string
+	for i := range 5 {
+		shas = append(shas, s.commit(fmt.Sprintf("commit %d", i), i*60))
+	}
+	s.git("tag", "-a", "v1.0.0", "-m", "release", shas[1])
+	s.git("gc", "-q", "--aggressive")
+	s.Require().NoFileExists(filepath.Join(s.dir, ".git", "refs", "heads", "main"))
+
+	r := s.open(s.dir)
+	_, head, err := r.Head()
+	s.Require().NoError(err)
+	s.Equal(shas[4], head)
+	for i, sha := range shas {
+		c, err := r.Commit(sha)
+		s.Require().NoError(err)
+		s.Equal(fmt.Sprintf("commit %d", i), c.Subject)
+	}
+
+	// Later file versions are stored as deltas against each other.
+	for i, sha := range shas {
+		typ, blob, err := r.object(s.git("rev-parse", sha+":file.txt"))
+		s.Require().NoError(err)
+		s.Equal(objBlob, typ)
+		s.True(strings.HasSuffix(string(blob), fmt.Sprintf("commit %d\n", i)))
+	}
+	s.Contains(s.git("verify-pack", "-v", s.packIndex()), "chain length")
+
+	name, ok, err := r.Describe(head)
+	s.Require().NoError(err)
+	s.True(ok)
+	s.Equal(s.git("describe", "--tags", head), name)
+}
+
+func (s *RepoSuite) packIndex() string {
+	idxs, err := filepath.Glob(filepath.Join(s.dir, ".git", "objects", "pack", "*.idx"))
+	s.Require().NoError(err)
+	s.Require().Len(idxs, 1)
+	return idxs[0]
+}
+
+func (s *RepoSuite) TestDescribe() {
+	first := s.commit("first", 0)
+	r := s.open(s.dir)
+	_, ok, err := r.Describe(first)
+	s.Require().NoError(err)
+	s.False(ok, "no tags yet")
+
+	s.git("tag", "v0.1.0")
+	s.git("tag", "v0.1.0-rc1")
+	name, ok, err := r.Describe(first)
+	s.Require().NoError(err)
+	s.True(ok)
+	s.Equal("v0.1.0", name)
+
+	s.commit("second", 60)
+	third := s.commit("third", 120)
+	name, _, err = s.open(s.dir).Describe(third)
+	s.Require().NoError(err)
+	s.Equal("v0.1.0-2-g"+ShortSHA(third), name)
+	s.Equal(s.git("describe", "--tags", third), name)
+}
+
+func (s *RepoSuite) TestIsAncestor() {
+	base := s.commit("base", 0)
+	s.git("checkout", "-q", "-b", "side")
+	side := s.commit("side", 60)
+	s.git("checkout", "-q", "main")
+	mainTip := s.commit("main", 120)
+
+	r := s.open(s.dir)
+	s.True(r.IsAncestor(base, side))
+	s.True(r.IsAncestor(base, mainTip))
+	s.False(r.IsAncestor(side, mainTip))
+	s.False(r.IsAncestor(mainTip, base))
+	s.False(r.IsAncestor(base, base))
+	s.False(r.IsAncestor(strings.Repeat("0", 40), mainTip))
+}
+
+func (s *RepoSuite) TestDetachedHeadAndUnbornBranch() {
+	r := s.open(s.dir)
+	branch, sha, err := r.Head()
+	s.Require().NoError(err)
+	s.Equal("main", branch)
+	s.Empty(sha)
+
+	first := s.commit("first", 0)
+	s.git("checkout", "-q", "--detach", first)
+	branch, sha, err = s.open(s.dir).Head()
+	s.Require().NoError(err)
+	s.Empty(branch)
+	s.Equal(first, sha)
+}
+
+func (s *RepoSuite) TestLinkedWorktree() {
+	s.commit("first", 0)
+	wt := filepath.Join(s.T().TempDir(), "wt")
+	s.git("worktree", "add", "-q", "-b", "feature", wt)
+
+	branch, sha, err := s.open(wt).Head()
+	s.Require().NoError(err)
+	s.Equal("feature", branch)
+	s.Equal(s.git("rev-parse", "HEAD"), sha)
+}
+
+func (s *RepoSuite) TestOpenOutsideRepository() {
+	_, err := Open(s.T().TempDir())
+	// TempDir may itself sit inside a checkout; only assert the error kind
+	// when the walk reached the filesystem root.
+	if err != nil {
+		s.True(errors.Is(err, ErrNotRepository))
+	}
+}
+
+func (s *RepoSuite) TestApplyDelta() {
+	base := []byte("hello, world")
+	// src 12, dst 11: copy "hello" (offset 0, size 5), insert " gits!".
+	delta := []byte{12, 11, 0x90, 5, 6, ' ', 'g', 'i', 't', 's', '!'}
+	out, err := applyDelta(base, delta)
+	s.Require().NoError(err)
+	s.Equal("hello gits!", string(out))
+
+	_, err = applyDelta(base, []byte{3, 1, 1, 'x'})
+	s.Error(err, "source size mismatch")
+}
+
+func TestRepoSuite(t *testing.T) {
+	suite.Run(t, new(RepoSuite))
+}
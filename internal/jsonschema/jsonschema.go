@@ -0,0 +1,181 @@
+// Package jsonschema derives a JSON Schema (draft 2020-12) from Go types by
+// reflection, following encoding/json's field rules: exported fields under
+// their json tag name, "-" skipped, embedded structs inlined. A field without
+// omitempty is required. Named struct types become $defs entries referenced by
+// $ref; everything else is inlined.
+//
+// Types with a custom JSON shape (a MarshalJSON or UnmarshalJSON that does not
+// mirror the struct) need an Override, and interface-typed fields a Union
+// listing their concrete variants. Output is deterministic: encoding/json sorts
+// map keys and variants are ordered by discriminator value.
+package jsonschema
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Schema is one JSON Schema object.
+type Schema = map[string]any
+
+// Union describes an interface type on the wire: Discriminator names the
+// property that tells the variants apart and Variants maps each of its values
+// to the concrete type. Each variant's discriminator property is pinned to its
+// value with const.
+type Union struct {
+	Discriminator string
+	Variants      map[string]reflect.Type
+}
+
+// Generator turns Go types into schemas, collecting named types in Defs.
+type Generator struct {
+	// Overrides replaces the reflected schema of a type. The type is still
+	// added to Defs under its name.
+	Overrides map[reflect.Type]Schema
+	// Unions resolves interface types to a oneOf over their variants.
+	Unions map[reflect.Type]Union
+	// Name picks the $defs key of a named type; nil uses the Go type name.
+	Name func(reflect.Type) string
+	// Defs holds the schema of every named type reached so far.
+	Defs map[string]Schema
+
+	owners map[string]reflect.Type
+}
+
+// Schema returns the schema for t: a $ref for named structs, overridden types
+// and union variants, an inline schema otherwise. It panics on a type it has
+// no JSON mapping for (channels, funcs, interfaces without a Union) and on two
+// types sharing a $defs name — both are programming errors.
+func (g *Generator) Schema(t reflect.Type) Schema {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if override, ok := g.Overrides[t]; ok {
+		return g.define(t, func() Schema { return override })
+	}
+	if union, ok := g.Unions[t]; ok {
+		return g.union(union)
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if t.Name() == "" {
+			return g.object(t, "", "")
+		}
+		return g.define(t, func() Schema { return g.object(t, "", "") })
+	case reflect.Slice, reflect.Array:
+		return Schema{"type": "array", "items": g.Schema(t.Elem())}
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			panic(fmt.Sprintf("jsonschema: map key %s is not a string", t.Key()))
+		}
+		return Schema{"type": "object", "additionalProperties": g.Schema(t.Elem())}
+	case reflect.String:
+		return Schema{"type": "string"}
+	case reflect.Bool:
+		return Schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Schema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}
+	case reflect.Interface:
+		if t.NumMethod() == 0 {
+			return Schema{}
+		}
+	}
+	panic(fmt.Sprintf("jsonschema: no JSON mapping for %s", t))
+}
+
+// define adds t to Defs (once) and returns a $ref to it. The placeholder
+// entry stops recursive types from looping.
+func (g *Generator) define(t reflect.Type, build func() Schema) Schema {
+	name := t.Name()
+	if g.Name != nil {
+		name = g.Name(t)
+	}
+	if g.Defs == nil {
+		g.Defs = map[string]Schema{}
+		g.owners = map[string]reflect.Type{}
+	}
+	if owner, ok := g.owners[name]; ok {
+		if owner != t {
+			panic(fmt.Sprintf("jsonschema: %s and %s both named %q", owner, t, name))
+		}
+	} else {
+		g.owners[name] = t
+		g.Defs[name] = Schema{}
+		g.Defs[name] = build()
+	}
+	return Schema{"$ref": "#/$defs/" + name}
+}
+
+func (g *Generator) union(u Union) Schema {
+	keys := make([]string, 0, len(u.Variants))
+	for key := range u.Variants {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	variants := make([]any, len(keys))
+	for i, key := range keys {
+		t := u.Variants[key]
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		variants[i] = g.define(t, func() Schema { return g.object(t, u.Discriminator, key) })
+	}
+	return Schema{"oneOf": variants}
+}
+
+// object maps a struct to a closed object schema. When discriminator is set,
+// that property is required and fixed to value.
+func (g *Generator) object(t reflect.Type, discriminator, value string) Schema {
+	properties := Schema{}
+	var required []string
+	g.fields(t, properties, &required)
+	if discriminator != "" {
+		properties[discriminator] = Schema{"const": value}
+		if !slices.Contains(required, discriminator) {
+			required = append(required, discriminator)
+		}
+	}
+	schema := Schema{"type": "object", "properties": properties, "additionalProperties": false}
+	if len(required) > 0 {
+		slices.Sort(required)
+		schema["required"] = required
+	}
+	return schema
+}
+
+func (g *Generator) fields(t reflect.Type, properties Schema, required *[]string) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			for embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				g.fields(embedded, properties, required)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = g.Schema(field.Type)
+		if !slices.Contains(strings.Split(opts, ","), "omitempty") {
+			*required = append(*required, name)
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package jsonschema
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type JSONSchemaSuite struct {
+	suite.Suite
+}
+
+type point struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y,omitempty"`
+}
+
+type base struct {
+	ID string `json:"id"`
+}
+
+type shape interface{ kind() string }
+
+type circle struct {
+	Kind   string  `json:"kind"`
+	Radius float64 `json:"radius"`
+}
+
+func (circle) kind() string { return "circle" }
+
+type square struct {
+	Kind string `json:"kind"`
+	Side *int   `json:"side,omitempty"`
+}
+
+func (square) kind() string { return "square" }
+
+type drawing struct {
+	base
+	Title   string            `json:"title,omitempty"`
+	Points  []point           `json:"points"`
+	Origin  *point            `json:"origin,omitempty"`
+	Tags    map[string]bool   `json:"tags,omitempty"`
+	Extra   any               `json:"extra,omitempty"`
+	Skipped string            `json:"-"`
+	hidden  string            // unexported: ignored
+	Labels  map[string]string `json:"labels,omitempty"`
+	Raw     string
+}
+
+func (s *JSONSchemaSuite) TestStructsFollowEncodingJSON() {
+	var g Generator
+	s.Equal(Schema{"$ref": "#/$defs/drawing"}, g.Schema(reflect.TypeFor[*drawing]()))
+
+	d := g.Defs["drawing"]
+	s.Equal(false, d["additionalProperties"])
+	s.Equal([]string{"Raw", "id", "points"}, d["required"], "embedded fields inline; omitempty is optional")
+	props := d["properties"].(Schema)
+	s.ElementsMatch([]string{"id", "title", "points", "origin", "tags", "extra", "labels", "Raw"}, keys(props))
+	s.Equal(Schema{"type": "array", "items": Schema{"$ref": "#/$defs/point"}}, props["points"])
+	s.Equal(Schema{"$ref": "#/$defs/point"}, props["origin"], "pointers are dereferenced")
+	s.Equal(Schema{"type": "object", "additionalProperties": Schema{"type": "boolean"}}, props["tags"])
+	s.Equal(Schema{}, props["extra"])
+
+	s.Equal(Schema{
+		"type":                 "object",
+		"additionalProperties": false,
+		"properties":           Schema{"x": Schema{"type": "number"}, "y": Schema{"type": "number"}},
+		"required":             []string{"x"},
+	}, g.Defs["point"])
+}
+
+func (s *JSONSchemaSuite) TestUnionPinsDiscriminator() {
+	g := Generator{Unions: map[reflect.Type]Union{
+		reflect.TypeFor[shape](): {Discriminator: "kind", Variants: map[string]reflect.Type{
+			"square": reflect.TypeFor[*square](),
+			"circle": reflect.TypeFor[circle](),
+		}},
+	}}
+	s.Equal(Schema{"oneOf": []any{
+		Schema{"$ref": "#/$defs/circle"},
+		Schema{"$ref": "#/$defs/square"},
+	}}, g.Schema(reflect.TypeFor[shape]()), "variants ordered by discriminator value")
+
+	sq := g.Defs["square"]
+	s.Equal(Schema{"const": "square"}, sq["properties"].(Schema)["kind"])
+	s.Equal([]string{"kind"}, sq["required"], "discriminator is required")
+	s.Equal(Schema{"type": "integer"}, sq["properties"].(Schema)["side"])
+}
+
+func (s *JSONSchemaSuite) TestOverridesAndNames() {
+	g := Generator{
+		Overrides: map[reflect.Type]Schema{reflect.TypeFor[point](): {"type": "string"}},
+		Name:      func(t reflect.Type) string { return "My" + t.Name() },
+	}
+	s.Equal(Schema{"$ref": "#/$defs/Mypoint"}, g.Schema(reflect.TypeFor[point]()))
+	s.Equal(Schema{"type": "string"}, g.Defs["Mypoint"])
+}
+
+func (s *JSONSchemaSuite) TestPanicsOnUnmappedTypes() {
+	var g Generator
+	s.Panics(func() { g.Schema(reflect.TypeFor[shape]()) }, "interface without a Union")
+	s.Panics(func() { g.Schema(reflect.TypeFor[chan int]()) })
+	s.Panics(func() { g.Schema(reflect.TypeFor[map[int]string]()) })
+
+	clash := Generator{Name: func(reflect.Type) string { return "Same" }}
+	clash.Schema(reflect.TypeFor[point]())
+	s.Panics(func() { clash.Schema(reflect.TypeFor[circle]()) }, "two types under one $defs name")
+}
+
+func keys(m Schema) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
+
+func TestJSONSchemaSuite(t *testing.T) {
+	suite.Run(t, new(JSONSchemaSuite))
+}
@@ -159,6 +159,8 @@ func PairAccent(key, value, accent string) string {
 //	rs:*        → Rust orange
 //	csv         → spreadsheet green
 //	json        → JSON amber
+//	yaml        → YAML red
+//	toml        → TOML brown
 func ParserAccent(parser string) string {
 	switch {
 	case parser == "go":
@@ -167,6 +169,10 @@ func ParserAccent(parser string) string {
 		return "#217346"
 	case parser == "json":
 		return accentJSON
+	case parser == "yaml":
+		return "#CB171E"
+	case parser == "toml":
+		return "#9C4121"
 	case parser == "js" || strings.HasPrefix(parser, "js:"):
 		return "#F0DB4F"
 	case parser == "rs" || strings.HasPrefix(parser, "rs:"):
@@ -13,6 +13,7 @@ import (
 
 	internalcharts "github.com/goptics/vizb/internal/charts"
 	barchart "github.com/goptics/vizb/internal/charts/bar"
+	histogramchart "github.com/goptics/vizb/internal/charts/histogram"
 	linechart "github.com/goptics/vizb/internal/charts/line"
 	scatterchart "github.com/goptics/vizb/internal/charts/scatter"
 	"github.com/goptics/vizb/pkg/parser"
@@ -21,6 +22,8 @@ import (
 	_ "github.com/goptics/vizb/pkg/parser/javascript"
 	jsonparser "github.com/goptics/vizb/pkg/parser/json"
 	_ "github.com/goptics/vizb/pkg/parser/rust"
+	_ "github.com/goptics/vizb/pkg/parser/toml"
+	_ "github.com/goptics/vizb/pkg/parser/yaml"
 	"github.com/goptics/vizb/pkg/template"
 	"github.com/goptics/vizb/shared"
 )
@@ -35,8 +38,10 @@ type Metadata struct {
 	Themes      []shared.Theme
 	Description string
 	Tag         string
-	System      *shared.Meta
-	Timestamp   string
+	// Commit is the source revision the run measured, if known.
+	Commit    *shared.Commit
+	System    *shared.Meta
+	Timestamp string
 }
 
 // AssembleInput contains parsed, request-local data and its resolved options.
@@ -73,6 +78,8 @@ type OptionError struct {
 	Name    string
 	Err     error
 	Ignored bool
+	// Index is the offending element when the option is a list.
+	Index int
 }
 
 func (e *OptionError) Error() string {
@@ -83,6 +90,46 @@ func (e *OptionError) Unwrap() error {
 	return e.Err
 }
 
+// ValidateConfig checks the caller-supplied parser options — group pattern,
+// col axis, group columns, group regex, filter and units — before grouping is
+// resolved. Every front end converting on a caller's behalf runs it, so the
+// same input fails the same way everywhere. A failure is an *OptionError named
+// after the field: groupPattern, colAxis, group, groupRegex, filter, memUnit,
+// timeUnit or numberUnit.
+func ValidateConfig(cfg parser.Config) error {
+	if err := parser.ValidateGroupPattern(cfg.GroupPattern); err != nil {
+		return &OptionError{Name: "groupPattern", Err: err}
+	}
+	if cfg.ColAxis != "" && !slices.Contains([]string{"n", "x", "y", "z"}, cfg.ColAxis) {
+		return &OptionError{Name: "colAxis", Err: fmt.Errorf("invalid col axis %q; expected n, x, y, or z", cfg.ColAxis)}
+	}
+	for i, column := range cfg.Group {
+		if strings.TrimSpace(column) == "" {
+			return &OptionError{Name: "group", Index: i, Err: fmt.Errorf("group columns must not be empty")}
+		}
+	}
+	if cfg.GroupRegex != "" {
+		if _, err := regexp.Compile(cfg.GroupRegex); err != nil {
+			return &OptionError{Name: "groupRegex", Err: fmt.Errorf("invalid group regex: %w", err)}
+		}
+	}
+	if cfg.Filter != "" {
+		if _, err := regexp.Compile(cfg.Filter); err != nil {
+			return &OptionError{Name: "filter", Err: fmt.Errorf("invalid filter regex: %w", err)}
+		}
+	}
+	if !slices.Contains([]string{"b", "B", "KB", "MB", "GB"}, cfg.MemUnit) {
+		return &OptionError{Name: "memUnit", Err: fmt.Errorf("invalid memory unit %q; expected b, B, KB, MB, or GB", cfg.MemUnit)}
+	}
+	if !slices.Contains([]string{"ns", "us", "ms", "s"}, cfg.TimeUnit) {
+		return &OptionError{Name: "timeUnit", Err: fmt.Errorf("invalid time unit %q; expected ns, us, ms, or s", cfg.TimeUnit)}
+	}
+	if cfg.NumberUnit != "" && !slices.Contains([]string{"K", "M", "B", "T"}, cfg.NumberUnit) {
+		return &OptionError{Name: "numberUnit", Err: fmt.Errorf("invalid number unit %q; expected K, M, B, or T", cfg.NumberUnit)}
+	}
+	return nil
+}
+
 // Convert parses inline supported data, aggregates tabular rows, applies chart
 // rules, and builds a Dataset. Every dependency receives request-local values
 // and every failure is returned to the caller.
@@ -98,14 +145,13 @@ func Convert(in ConvertInput) (ConvertResult, error) {
 	if key == "" {
 		key = "auto"
 	}
-	if key == "auto" && in.Config.JSONPath != "" {
-		key = "json"
-	} else {
-		var err error
-		key, err = resolveParserKey(key, in.Input)
-		if err != nil {
-			return ConvertResult{}, err
-		}
+	auto := key == "auto"
+	key, err := resolveParserKey(key, in.Input)
+	if err != nil {
+		return ConvertResult{}, err
+	}
+	if auto && in.Config.JSONPath != "" && !parser.SupportsJSONPath(key) {
+		key = "json" // an auto-detected envelope object reads as the go fallback
 	}
 	if in.Config.Filter != "" {
 		if _, err := regexp.Compile(in.Config.Filter); err != nil {
@@ -123,7 +169,7 @@ func Convert(in ConvertInput) (ConvertResult, error) {
 	if err := parser.ValidateSelectViewsForCharts(cfg); err != nil {
 		return ConvertResult{}, &OptionError{Name: "select", Err: err}
 	}
-	tabular := key == "csv" || key == "json"
+	tabular := parser.IsTabular(key)
 	if tabular && len(cfg.Group) > 0 && cfg.GroupRegex == "" {
 		var err error
 		cfg, err = parser.FinalizeGroupConfig(cfg)
@@ -134,7 +180,7 @@ func Convert(in ConvertInput) (ConvertResult, error) {
 	if !tabular && parser.HasSelect(cfg) {
 		return ConvertResult{}, &OptionError{
 			Name: "select",
-			Err:  fmt.Errorf("select is only supported by csv and json input"),
+			Err:  fmt.Errorf("select is only supported by csv, json, yaml and toml input"),
 		}
 	}
 	if tabular && parser.NoExplicitGrouping(cfg) && !parser.HasSelect(cfg) {
@@ -143,16 +189,19 @@ func Convert(in ConvertInput) (ConvertResult, error) {
 
 	data := in.Input
 	if cfg.JSONPath != "" {
-		if key != "json" {
+		if !parser.SupportsJSONPath(key) {
 			return ConvertResult{}, &OptionError{
 				Name: "jsonPath",
-				Err:  fmt.Errorf("json path is only supported by the json parser"),
+				Err:  fmt.Errorf("json path is only supported by the json, yaml and toml parsers"),
 			}
 		}
-		var err error
-		data, err = jsonparser.SelectBytes(data, cfg.JSONPath)
-		if err != nil {
-			return ConvertResult{}, err
+		// yaml/toml navigate the path while decoding.
+		if key == "json" {
+			var err error
+			data, err = jsonparser.SelectBytes(data, cfg.JSONPath, cfg.JSONPathKeys)
+			if err != nil {
+				return ConvertResult{}, err
+			}
 		}
 	}
 
@@ -167,7 +216,7 @@ func Convert(in ConvertInput) (ConvertResult, error) {
 	if tabular {
 		if len(effectiveCfg.Group) == 0 {
 			points = shared.CollapseDataPointsByKey(points)
-		} else {
+		} else if !parser.HasDistributionChart(effectiveCfg) {
 			points = shared.AggregateDataPoints(points)
 			// Sum reintroduces float residue; re-apply 2dp when requested.
 			if effectiveCfg.Round {
@@ -188,10 +237,24 @@ func Convert(in ConvertInput) (ConvertResult, error) {
 		metadata.System = system
 	}
 	dataset := Assemble(AssembleInput{Points: points, Parser: key, Config: effectiveCfg, Metadata: metadata, Charts: in.Charts})
-	for _, chart := range in.Charts {
+	warnings, err := CheckCharts(dataset)
+	if err != nil {
+		return ConvertResult{}, err
+	}
+	for _, name := range shared.UnmatchedDirectionRules(dataset.Data, effectiveCfg.Better) {
+		warnings = append(warnings, fmt.Sprintf("better: no stat named %q", name))
+	}
+	return ConvertResult{Dataset: dataset, Warnings: warnings}, nil
+}
+
+// CheckCharts validates each chart's swap against the dataset's axes and
+// applies the chart rules to its settings, returning the notices for options
+// the data can't use.
+func CheckCharts(dataset *shared.Dataset) ([]string, error) {
+	for _, chart := range dataset.Settings {
 		if swap := chart.SwapString(); swap != "" {
 			if err := shared.ValidateSwap(swap, dataset.Axes); err != nil {
-				return ConvertResult{}, &OptionError{Name: "swap", Err: err}
+				return nil, &OptionError{Name: "swap", Err: err}
 			}
 		}
 	}
@@ -199,11 +262,8 @@ func Convert(in ConvertInput) (ConvertResult, error) {
 	for _, axis := range dataset.Axes {
 		ruleAxes = append(ruleAxes, internalcharts.AxisInfo{Key: axis.Key, Type: axis.Type})
 	}
-	warnings, err := internalcharts.ApplyRules(internalcharts.RuleContext{Axes: ruleAxes}, dataset.Settings)
-	if err != nil {
-		return ConvertResult{}, err
-	}
-	return ConvertResult{Dataset: dataset, Warnings: warnings}, nil
+	ruleCtx := internalcharts.RuleContext{Axes: ruleAxes, StatTypes: dataset.StatTypes(), DateAxes: dataset.DateAxes(), Tags: dataset.Tags(), TagAxes: dataset.TagAxes()}
+	return internalcharts.ApplyRules(ruleCtx, dataset.Settings)
 }
 
 // ApplyColAxis expands grouped multi-column stats onto a free axis and applies
@@ -227,8 +287,8 @@ func ApplyColAxis(data []shared.DataPoint, cfg parser.Config, parserKey, title s
 	if !slices.Contains([]string{"n", "x", "y", "z"}, cfg.ColAxis) {
 		return data, cfg, &OptionError{Name: "colAxis", Err: fmt.Errorf("invalid col axis %q; expected n, x, y, or z", cfg.ColAxis)}
 	}
-	if parserKey != "csv" && parserKey != "json" {
-		return ignored("colAxis", "--col-axis is only supported for csv/json parsers; ignoring")
+	if !parser.IsTabular(parserKey) {
+		return ignored("colAxis", "--col-axis is only supported for csv/json/yaml/toml parsers; ignoring")
 	}
 	if cfg.Mode.IsSelectAxis() {
 		return ignored("colAxis", "--col-axis requires grouped multi-column stats; ignoring")
@@ -269,9 +329,42 @@ func ApplyColAxis(data []shared.DataPoint, cfg parser.Config, parserKey, title s
 // Merge combines complete request datasets atomically. It intentionally accepts
 // datasets, not paths or directories.
 func Merge(datasets []shared.Dataset, dimension shared.Dimension) ([]shared.Dataset, error) {
+	return MergeWithOptions(datasets, MergeOptions{Dimension: dimension})
+}
+
+// MergeWithAncestry is Merge with tags that record a commit ordered by commit
+// ancestry (see shared.MergeDatasetsWithAncestry). isAncestor may be nil.
+func MergeWithAncestry(datasets []shared.Dataset, dimension shared.Dimension, isAncestor shared.AncestryFunc) ([]shared.Dataset, error) {
+	return MergeWithOptions(datasets, MergeOptions{Dimension: dimension, IsAncestor: isAncestor})
+}
+
+// MergeOptions configures MergeWithOptions.
+type MergeOptions struct {
+	// Dimension receives the tags: name (default), x, y or z.
+	Dimension shared.Dimension
+	// IsAncestor orders commit-tagged runs by ancestry; may be nil.
+	IsAncestor shared.AncestryFunc
+	// Retention prunes each merged dataset's tags; the zero value keeps all.
+	Retention shared.Retention
+	// Reconcile renames benchmarks, axis labels and stats before merging.
+	Reconcile shared.Reconcile
+	// OnConflict, when set, receives every input disagreement the merge had
+	// to resolve (see shared.MergeConflicts). Sources names the inputs in
+	// its messages, one per dataset; inputs are numbered when it is nil.
+	OnConflict func(shared.MergeConflict)
+	Sources    []string
+	// OnWarning, when set, receives a notice for every Reconcile rule that
+	// renames nothing in the inputs, a likely typo.
+	OnWarning func(string)
+}
+
+// MergeWithOptions reconciles names per opts.Reconcile, merges like Merge and
+// applies opts.Retention to every tagged result.
+func MergeWithOptions(datasets []shared.Dataset, opts MergeOptions) ([]shared.Dataset, error) {
 	if len(datasets) == 0 {
 		return nil, fmt.Errorf("at least one dataset is required to merge")
 	}
+	dimension := opts.Dimension
 	switch dimension {
 	case "", "name", shared.DimensionName:
 		dimension = shared.DimensionName
@@ -279,7 +372,40 @@ func Merge(datasets []shared.Dataset, dimension shared.Dimension) ([]shared.Data
 	default:
 		return nil, fmt.Errorf("invalid tag axis %q; expected name, x, y, or z", dimension)
 	}
-	return shared.MergeDatasets(datasets, dimension), nil
+	if err := opts.Retention.Validate(); err != nil {
+		return nil, &OptionError{Name: "retention", Err: err}
+	}
+	if err := opts.Reconcile.Validate(); err != nil {
+		return nil, &OptionError{Name: "reconcile", Err: err}
+	}
+	if opts.Sources != nil && len(opts.Sources) != len(datasets) {
+		return nil, fmt.Errorf("got %d sources for %d datasets", len(opts.Sources), len(datasets))
+	}
+	if opts.OnWarning != nil {
+		for _, rule := range opts.Reconcile.Unmatched(datasets) {
+			opts.OnWarning(fmt.Sprintf("reconcile: %s matches nothing", rule))
+		}
+	}
+	datasets = opts.Reconcile.Apply(datasets)
+	merged := shared.MergeDatasetsWithAncestry(datasets, dimension, opts.IsAncestor)
+	if opts.OnConflict != nil {
+		sources := opts.Sources
+		if sources == nil {
+			sources = make([]string, len(datasets))
+			for i := range sources {
+				sources[i] = fmt.Sprintf("input %d", i+1)
+			}
+		}
+		for _, c := range shared.MergeConflicts(datasets, sources, merged) {
+			opts.OnConflict(c)
+		}
+	}
+	if !opts.Retention.IsZero() {
+		for i := range merged {
+			merged[i], _ = shared.ApplyRetention(merged[i], dimension, opts.Retention)
+		}
+	}
+	return merged, nil
 }
 
 // GenerateUI serializes one or more datasets into a self-contained HTML page.
@@ -370,6 +496,13 @@ func Assemble(in AssembleInput) *shared.Dataset {
 		axes = shared.EnsureAxis(axes, shared.Dimension(cfg.ColAxis))
 	}
 	axes = appendMetricAxis(axes, cfg, points)
+	shared.ApplyDirections(points, cfg.Better)
+	preserveRows := parser.IsTabular(parserKey) && len(cfg.Group) == 0
+	// A histogram ships bins, not rows; every selected chart draws the bins.
+	if histogram := histogramConfig(charts); histogram != nil {
+		points, axes = shared.BinDataPoints(points, axes, histogram.BinOptions())
+		preserveRows = false
+	}
 	name := meta.Name
 	if name == "" && viewName != "" {
 		name = viewName
@@ -379,21 +512,32 @@ func Assemble(in AssembleInput) *shared.Dataset {
 		timestamp = time.Now().UTC().Format(time.RFC3339)
 	}
 	ds := &shared.Dataset{
-		ID:           strings.TrimSpace(meta.ID),
-		Name:         name,
-		Themes:       meta.Themes,
-		Description:  meta.Description,
-		Tag:          meta.Tag,
-		Timestamp:    timestamp,
-		Meta:         meta.System,
-		Axes:         axes,
-		Settings:     charts,
-		Data:         points,
-		PreserveRows: (parserKey == "csv" || parserKey == "json") && len(cfg.Group) == 0,
+		SchemaVersion: shared.CurrentSchemaVersion,
+		ID:            strings.TrimSpace(meta.ID),
+		Name:          name,
+		Themes:        meta.Themes,
+		Description:   meta.Description,
+		Tag:           meta.Tag,
+		Timestamp:     timestamp,
+		Commit:        meta.Commit,
+		Meta:          meta.System,
+		Axes:          axes,
+		Settings:      charts,
+		Data:          points,
+		PreserveRows:  preserveRows,
 	}
 	return ds
 }
 
+func histogramConfig(configs []internalcharts.ChartConfig) *histogramchart.Config {
+	for _, config := range configs {
+		if histogram, ok := config.(*histogramchart.Config); ok {
+			return histogram
+		}
+	}
+	return nil
+}
+
 func valueModeHasMetric(cfg parser.Config, points []shared.DataPoint) bool {
 	if cfg.MetricColumn != "" {
 		return true
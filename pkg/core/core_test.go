@@ -9,6 +9,7 @@ import (
 
 	internalcharts "github.com/goptics/vizb/internal/charts"
 	barchart "github.com/goptics/vizb/internal/charts/bar"
+	histogramchart "github.com/goptics/vizb/internal/charts/histogram"
 	linechart "github.com/goptics/vizb/internal/charts/line"
 	scatterchart "github.com/goptics/vizb/internal/charts/scatter"
 	"github.com/goptics/vizb/internal/flags"
@@ -33,6 +34,52 @@ func (s *CoreSuite) TestConvertCSV() {
 	s.Equal([]string{"x"}, []string{result.Dataset.Axes[0].Key})
 }
 
+func (s *CoreSuite) TestConvertAppliesBetterOverrides() {
+	result, err := Convert(ConvertInput{
+		Input:  []byte("region,latency,rps\nwest,12,900\n"),
+		Parser: "csv",
+		Config: parser.Config{
+			GroupPattern: "x",
+			Group:        []string{"region"},
+			Better:       map[string]shared.Direction{"rps": shared.DirectionHigher, "latnecy": shared.DirectionLower},
+		},
+		Charts: []internalcharts.ChartConfig{&barchart.Config{Type: "bar", Scale: "linear"}},
+	})
+	s.Require().NoError(err)
+	better := map[string]shared.Direction{}
+	for _, st := range result.Dataset.Data[0].Stats {
+		better[st.Type] = st.Better
+	}
+	s.Equal(map[string]shared.Direction{"latency": "", "rps": shared.DirectionHigher}, better)
+	s.Contains(result.Warnings, `better: no stat named "latnecy"`)
+}
+
+func (s *CoreSuite) TestConvertHistogramBinsRawRows() {
+	bins := 2
+	result, err := Convert(ConvertInput{
+		Input:  []byte("service,latency\napi,10\napi,12\napi,20\nweb,19\n"),
+		Parser: "csv",
+		Config: parser.Config{GroupPattern: "x", Group: []string{"service"}},
+		Charts: []internalcharts.ChartConfig{
+			&histogramchart.Config{Type: "histogram", Bins: &bins},
+			&barchart.Config{Type: "bar"},
+		},
+	})
+	s.Require().NoError(err)
+	ds := result.Dataset
+	s.False(ds.PreserveRows)
+	s.Equal([]shared.Axis{{Key: "x", Label: "bin"}, {Key: "y", Label: "service"}}, ds.Axes)
+	// Rows are binned before aggregation could sum them: api keeps three values.
+	counts := map[string]float64{}
+	for _, p := range ds.Data {
+		counts[p.YAxis+" "+p.XAxis] = *p.Stats[0].Value
+	}
+	s.Equal(map[string]float64{
+		"api [10, 15)": 2, "api [15, 20]": 1,
+		"web [10, 15)": 0, "web [15, 20]": 1,
+	}, counts)
+}
+
 func (s *CoreSuite) TestConvertColAxisTitle() {
 	result, err := Convert(ConvertInput{
 		Input:  []byte("load,default,chi\n100,1,2\n"),
@@ -95,6 +142,35 @@ func (s *CoreSuite) TestConvertJSONAndValidationFailures() {
 	s.ErrorContains(err, `--axes column "missing" not found`)
 }
 
+func (s *CoreSuite) TestValidateConfig() {
+	valid := parser.Config{GroupPattern: "x", MemUnit: "B", TimeUnit: "ns"}
+	s.NoError(ValidateConfig(valid))
+
+	for _, test := range []struct {
+		edit  func(*parser.Config)
+		name  string
+		index int
+		msg   string
+	}{
+		{func(c *parser.Config) { c.GroupPattern = "[" }, "groupPattern", 0, ""},
+		{func(c *parser.Config) { c.ColAxis = "w" }, "colAxis", 0, `invalid col axis "w"`},
+		{func(c *parser.Config) { c.Group = []string{"a", ""} }, "group", 1, "group columns must not be empty"},
+		{func(c *parser.Config) { c.GroupRegex = "(" }, "groupRegex", 0, "invalid group regex"},
+		{func(c *parser.Config) { c.Filter = "(" }, "filter", 0, "invalid filter regex"},
+		{func(c *parser.Config) { c.MemUnit = "TB" }, "memUnit", 0, `invalid memory unit "TB"`},
+		{func(c *parser.Config) { c.TimeUnit = "" }, "timeUnit", 0, `invalid time unit ""`},
+		{func(c *parser.Config) { c.NumberUnit = "Q" }, "numberUnit", 0, `invalid number unit "Q"`},
+	} {
+		cfg := valid
+		test.edit(&cfg)
+		var optionErr *OptionError
+		s.Require().ErrorAs(ValidateConfig(cfg), &optionErr, test.name)
+		s.Equal(test.name, optionErr.Name)
+		s.Equal(test.index, optionErr.Index, test.name)
+		s.ErrorContains(optionErr, test.msg, test.name)
+	}
+}
+
 func (s *CoreSuite) TestOperations() {
 	chart := &barchart.Config{Type: "bar", Scale: "linear"}
 	_, err := Convert(ConvertInput{
@@ -130,7 +206,7 @@ func (s *CoreSuite) TestConvertBranchErrorsAndAutoDetection() {
 		{"wrong JavaScript format", ConvertInput{Input: []byte("x,y\na,1\n"), Parser: "javascript", Charts: chart}, "does not match a supported JavaScript"},
 		{"wrong Rust format", ConvertInput{Input: []byte("x,y\na,1\n"), Parser: "rust", Charts: chart}, "does not match a supported Rust"},
 		{"bad filter", ConvertInput{Input: []byte("x,y\na,1\n"), Parser: "csv", Config: parser.Config{Filter: "["}, Charts: chart}, "invalid filter regex"},
-		{"json path on csv", ConvertInput{Input: []byte("x,y\na,1\n"), Parser: "csv", Config: parser.Config{JSONPath: ".data"}, Charts: chart}, "only supported by the json parser"},
+		{"json path on csv", ConvertInput{Input: []byte("x,y\na,1\n"), Parser: "csv", Config: parser.Config{JSONPath: ".data"}, Charts: chart}, "only supported by the json, yaml and toml parsers"},
 		{"missing json path", ConvertInput{Input: []byte(`[{"x":"a","y":1}]`), Parser: "json", Config: parser.Config{JSONPath: ".data"}, Charts: chart}, "cannot read key 'data'"},
 		{"no results", ConvertInput{Input: []byte("header\n"), Parser: "csv", Charts: chart}, "no dataset found"},
 		{"invalid swap", ConvertInput{Input: []byte("x,y\na,1\n"), Parser: "csv", Config: parser.Config{GroupPattern: "x", Group: []string{"x"}}, Charts: []internalcharts.ChartConfig{&barchart.Config{Type: "bar", Swap: "x:z"}}}, "swap"},
@@ -301,6 +377,23 @@ func (s *CoreSuite) TestConvertAutoJSONPathEnvelope() {
 	s.Equal("west", result.Dataset.Data[0].XAxis)
 }
 
+func (s *CoreSuite) TestConvertAutoYAMLWithJSONPath() {
+	result, err := Convert(ConvertInput{
+		Input:  []byte("data:\n  - region: west\n    latency: 12\n  - region: east\n    latency: 18\n"),
+		Parser: "auto",
+		Config: parser.Config{
+			JSONPath:     ".data",
+			GroupPattern: "x",
+			Group:        []string{"region"},
+		},
+		Charts: []internalcharts.ChartConfig{&barchart.Config{Type: "bar", Scale: "linear"}},
+	})
+
+	s.Require().NoError(err)
+	s.Require().Len(result.Dataset.Data, 2)
+	s.Equal("east", result.Dataset.Data[1].XAxis)
+}
+
 func (s *CoreSuite) TestConvertReturnsParserLookupError() {
 	chart := []internalcharts.ChartConfig{&barchart.Config{Type: "bar", Scale: "linear"}}
 	saved := parser.Parsers["csv"]
@@ -348,6 +441,64 @@ func (s *CoreSuite) TestMergeAndUIBranchErrors() {
 	s.Contains(html, "VIZB_CHARTS")
 }
 
+func (s *CoreSuite) TestMergeWithOptionsAppliesRetention() {
+	run := func(tag, ts string) shared.Dataset {
+		return shared.Dataset{Name: "Bench", Tag: tag, Timestamp: ts, Data: []shared.DataPoint{{XAxis: "sort"}}}
+	}
+	merged, err := MergeWithOptions([]shared.Dataset{
+		run("v1", "2026-05-01T00:00:00Z"),
+		run("v2", "2026-05-02T00:00:00Z"),
+		run("v3", "2026-05-03T00:00:00Z"),
+	}, MergeOptions{Retention: shared.Retention{KeepLast: 2}})
+	s.Require().NoError(err)
+	s.Require().Len(merged, 1)
+	s.Equal([]string{"v2", "v3"}, merged[0].Tags())
+	s.Equal([]string{"v2", "v3"}, []string{merged[0].Data[0].Name, merged[0].Data[1].Name})
+
+	_, err = MergeWithOptions([]shared.Dataset{run("v1", "")}, MergeOptions{Retention: shared.Retention{KeepLast: -1}})
+	var optErr *OptionError
+	s.Require().ErrorAs(err, &optErr)
+	s.Equal("retention", optErr.Name)
+}
+
+func (s *CoreSuite) TestMergeWithOptionsReconcilesAndReportsConflicts() {
+	old := shared.Dataset{Name: "BenchmarkOld", Tag: "v1", Timestamp: "2026-05-01T00:00:00Z",
+		Axes: []shared.Axis{{Key: "x", Label: "n"}}, Data: []shared.DataPoint{{XAxis: "sort"}}}
+	cur := shared.Dataset{Name: "BenchmarkNew", Tag: "v2", Timestamp: "2026-05-02T00:00:00Z",
+		Axes: []shared.Axis{{Key: "x", Label: "size"}}, Data: []shared.DataPoint{{XAxis: "sort"}}}
+
+	var conflicts []shared.MergeConflict
+	merged, err := MergeWithOptions([]shared.Dataset{old, cur}, MergeOptions{
+		Reconcile:  shared.Reconcile{Aliases: map[string]string{"BenchmarkOld": "BenchmarkNew"}},
+		OnConflict: func(c shared.MergeConflict) { conflicts = append(conflicts, c) },
+	})
+	s.Require().NoError(err)
+	s.Require().Len(merged, 1)
+	s.Equal("BenchmarkNew", merged[0].Name)
+	s.Equal([]string{"v1", "v2"}, merged[0].Tags())
+	s.Require().Len(conflicts, 1)
+	s.Equal(shared.ConflictAxis, conflicts[0].Kind)
+	s.Contains(conflicts[0].Message, `"n" in input 1, "size" in input 2`)
+
+	var warnings []string
+	_, err = MergeWithOptions([]shared.Dataset{old, cur}, MergeOptions{
+		Reconcile: shared.Reconcile{Aliases: map[string]string{"BenchmarkOld": "BenchmarkNew", "Typo": "X"}},
+		OnWarning: func(w string) { warnings = append(warnings, w) },
+	})
+	s.Require().NoError(err)
+	s.Equal([]string{`reconcile: alias "Typo" matches nothing`}, warnings)
+
+	_, err = MergeWithOptions([]shared.Dataset{old}, MergeOptions{
+		Reconcile: shared.Reconcile{Stats: map[string]string{"a": "b", "b": "a"}},
+	})
+	var optErr *OptionError
+	s.Require().ErrorAs(err, &optErr)
+	s.Equal("reconcile", optErr.Name)
+
+	_, err = MergeWithOptions([]shared.Dataset{old}, MergeOptions{Sources: []string{"a.json", "b.json"}})
+	s.ErrorContains(err, "sources")
+}
+
 func (s *CoreSuite) TestAssembleEmbedsThemesWithoutLegacyTheme() {
 	themes := []shared.Theme{{
 		Name:            "roma",
@@ -0,0 +1,160 @@
+package export
+
+import (
+	"math"
+
+	internal_charts "github.com/goptics/vizb/internal/charts"
+	barchart "github.com/goptics/vizb/internal/charts/bar"
+	linechart "github.com/goptics/vizb/internal/charts/line"
+	piechart "github.com/goptics/vizb/internal/charts/pie"
+	"github.com/goptics/vizb/internal/frame"
+)
+
+// echartsOption builds the ECharts option the UI would render for one chart,
+// minus its interactive extras (toolbox, theme switching, stats overlays).
+// Missing cells are null.
+func echartsOption(datasetName string, v view, fr frame.Frame, palette []string) map[string]any {
+	option := map[string]any{
+		"title": map[string]any{"text": fr.Title, "subtext": datasetName, "left": "center"},
+		"color": palette,
+	}
+	if v.chartType == piechart.Type {
+		echartsPie(option, v, fr)
+		return option
+	}
+
+	multi := len(fr.Series) > 1
+	grid := map[string]any{"left": "3%", "right": 24, "top": 64, "bottom": "3%", "containLabel": true}
+	if multi {
+		option["legend"] = map[string]any{"type": "scroll", "bottom": 0, "data": seriesNames(fr)}
+		grid["bottom"] = 32
+	}
+	option["grid"] = grid
+
+	switch v.chartType {
+	case barchart.Type:
+		option["tooltip"] = map[string]any{"trigger": "axis", "axisPointer": map[string]any{"type": "shadow"}}
+	case linechart.Type:
+		option["tooltip"] = map[string]any{"trigger": "axis"}
+	default:
+		option["tooltip"] = map[string]any{"trigger": "item"}
+	}
+
+	category := map[string]any{"type": "category", "data": fr.Categories}
+	if fr.XLabel != "" {
+		category["name"] = fr.XLabel
+	}
+	value := map[string]any{"type": "value", "name": valueTitle(fr)}
+	if v.log {
+		value["type"] = "log"
+		if v.logBase != nil {
+			value["logBase"] = *v.logBase
+		}
+	}
+	lo, hi := v.valueRange(fr)
+	if lo != nil {
+		value["min"] = lo
+	}
+	if hi != nil {
+		value["max"] = hi
+	}
+	if v.zeroBaseline {
+		value["scale"] = false
+	}
+	if v.inverse {
+		value["inverse"] = true
+	}
+	if v.horizontal {
+		option["xAxis"], option["yAxis"] = value, category
+	} else {
+		option["xAxis"], option["yAxis"] = category, value
+	}
+	if v.zoom != "" {
+		option["dataZoom"] = echartsZoom(v, grid)
+	}
+
+	labelPosition := "top"
+	switch {
+	case v.stack && v.chartType == barchart.Type:
+		labelPosition = "inside"
+	case v.horizontal:
+		labelPosition = "right"
+	}
+	series := make([]map[string]any, len(fr.Series))
+	for i, s := range fr.Series {
+		data := make([]any, len(s.Values))
+		for ci, x := range s.Values {
+			if !math.IsNaN(x) {
+				data[ci] = x
+			}
+		}
+		item := map[string]any{"name": s.Name, "type": v.chartType, "data": data}
+		if v.stack {
+			item["stack"] = "total"
+		}
+		if v.labels {
+			item["label"] = map[string]any{"show": true, "position": labelPosition}
+		}
+		if v.smooth {
+			item["smooth"] = true
+		}
+		if v.symbol != "" {
+			item["symbol"] = v.symbol
+		}
+		if v.symbolSize != nil {
+			item["symbolSize"] = *v.symbolSize
+		}
+		series[i] = item
+	}
+	option["series"] = series
+	return option
+}
+
+// echartsZoom adds inside and/or slider zoom on the category axis, as the UI's
+// applyAxisRange does, growing grid's free edge to fit the slider.
+func echartsZoom(v view, grid map[string]any) []map[string]any {
+	index := "xAxisIndex"
+	if v.horizontal {
+		index = "yAxisIndex"
+	}
+	var out []map[string]any
+	if v.zoom != internal_charts.ZoomSlider {
+		out = append(out, map[string]any{"type": "inside", index: 0, "start": 0, "end": 100, "filterMode": "filter"})
+	}
+	if v.zoom != internal_charts.ZoomInside {
+		slider := map[string]any{"type": "slider", index: 0, "start": 0, "end": 100, "filterMode": "filter"}
+		if v.horizontal {
+			slider["right"], slider["width"] = 8, 20
+			grid["right"] = 24 + 32
+		} else {
+			slider["bottom"], slider["height"] = 8, 28
+			bottom, _ := grid["bottom"].(int)
+			grid["bottom"] = max(bottom, 24) + 40
+		}
+		out = append(out, slider)
+	}
+	return out
+}
+
+// echartsPie draws one slice per category, valued at its total across series.
+// Non-positive totals are left out.
+func echartsPie(option map[string]any, v view, fr frame.Frame) {
+	var data []map[string]any
+	var names []string
+	for i, total := range fr.Totals() {
+		if total > 0 {
+			data = append(data, map[string]any{"name": fr.Categories[i], "value": total})
+			names = append(names, fr.Categories[i])
+		}
+	}
+	option["tooltip"] = map[string]any{"trigger": "item", "formatter": "{b}: {c} ({d}%)"}
+	option["legend"] = map[string]any{"type": "scroll", "orient": "vertical", "right": 10, "top": "middle", "data": names}
+	option["series"] = []map[string]any{{
+		"name":   fr.Title,
+		"type":   piechart.Type,
+		"radius": "60%",
+		"center": []string{"40%", "55%"},
+		"data":   data,
+		"label":  map[string]any{"show": v.labels},
+	}}
+}
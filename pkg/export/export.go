@@ -0,0 +1,210 @@
+// Package export converts vizb datasets into chart specs for other tools: a
+// Vega-Lite spec (Observable, Jupyter, vega-embed) or raw ECharts options
+// (Grafana panels, custom dashboards).
+//
+// Like pkg/svg it reads the same shared.Dataset and typed ChartConfigs as the
+// UI, draws one chart per chart config and stat type, and supports bar, line,
+// scatter and pie. The output carries the active theme's palette, sort, stack,
+// horizontal bars, labels, scale, axis range and (ECharts only) zoom. Output is
+// deterministic, so it can back golden-file tests.
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+
+	internal_charts "github.com/goptics/vizb/internal/charts"
+	barchart "github.com/goptics/vizb/internal/charts/bar"
+	linechart "github.com/goptics/vizb/internal/charts/line"
+	piechart "github.com/goptics/vizb/internal/charts/pie"
+	scatterchart "github.com/goptics/vizb/internal/charts/scatter"
+	"github.com/goptics/vizb/internal/frame"
+	"github.com/goptics/vizb/shared"
+)
+
+// Export formats.
+const (
+	FormatVegaLite = "vega-lite"
+	FormatECharts  = "echarts"
+)
+
+// Formats lists the export formats, for flag and request validation.
+var Formats = []string{FormatVegaLite, FormatECharts}
+
+// ErrNoCharts is returned by Document when no dataset has a chart the
+// exporters support.
+var ErrNoCharts = errors.New("no chart to export (supported: bar, line, scatter, pie)")
+
+// Supported reports whether chartType has an exporter.
+func Supported(chartType string) bool {
+	switch chartType {
+	case barchart.Type, linechart.Type, scatterchart.Type, piechart.Type:
+		return true
+	}
+	return false
+}
+
+// Document renders datasets in format as indented JSON. Vega-Lite output is a
+// single spec, with several charts stacked in a vconcat. ECharts output is the
+// option object for a single chart, else an array of options. skipped lists
+// the chart types without an exporter (each once, in settings order).
+func Document(format string, datasets []*shared.Dataset) (doc []byte, skipped []string, err error) {
+	if !slices.Contains(Formats, format) {
+		return nil, nil, fmt.Errorf("unknown export format %q (want %s)", format, strings.Join(Formats, " or "))
+	}
+	var charts []map[string]any
+	for _, ds := range datasets {
+		palette := frame.Palette(ds)
+		frames := frame.Build(ds)
+		for _, cfg := range ds.Settings {
+			v, ok := viewOf(cfg)
+			if !ok {
+				if !slices.Contains(skipped, cfg.ChartType()) {
+					skipped = append(skipped, cfg.ChartType())
+				}
+				continue
+			}
+			for _, fr := range frames {
+				fr.Sort(v.sort)
+				if format == FormatVegaLite {
+					charts = append(charts, vegaLiteChart(ds.Name, v, fr, palette))
+				} else {
+					charts = append(charts, echartsOption(ds.Name, v, fr, palette))
+				}
+			}
+		}
+	}
+	if len(charts) == 0 {
+		return nil, skipped, ErrNoCharts
+	}
+
+	var out any
+	switch {
+	case format == FormatVegaLite && len(charts) == 1:
+		charts[0]["$schema"] = vegaLiteSchema
+		out = charts[0]
+	case format == FormatVegaLite:
+		out = map[string]any{"$schema": vegaLiteSchema, "vconcat": charts}
+	case len(charts) == 1:
+		out = charts[0]
+	default:
+		out = charts
+	}
+	doc, err = json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return nil, skipped, fmt.Errorf("marshal %s export: %w", format, err)
+	}
+	return append(doc, '\n'), skipped, nil
+}
+
+// view is the part of a chart config the exporters honour.
+type view struct {
+	chartType    string
+	sort         *shared.Sort
+	log          bool
+	logBase      *float64
+	min, max     *shared.AxisBound
+	zeroBaseline bool
+	inverse      bool
+	zoom         string
+	stack        bool
+	horizontal   bool
+	labels       bool
+	smooth       bool
+	symbol       string
+	symbolSize   *float64
+}
+
+func viewOf(cfg internal_charts.ChartConfig) (view, bool) {
+	switch c := cfg.(type) {
+	case *barchart.Config:
+		return view{
+			chartType: barchart.Type, sort: c.Sort,
+			log: c.Scale == "log", logBase: c.LogBase, min: c.Min, max: c.Max,
+			zeroBaseline: boolValue(c.ZeroBaseline), inverse: boolValue(c.Inverse), zoom: c.Zoom,
+			stack: boolValue(c.Stack), horizontal: boolValue(c.Horizontal), labels: boolValue(c.ShowLabels),
+		}, true
+	case *linechart.Config:
+		return view{
+			chartType: linechart.Type, sort: c.Sort,
+			log: c.Scale == "log", logBase: c.LogBase, min: c.Min, max: c.Max,
+			zeroBaseline: boolValue(c.ZeroBaseline), inverse: boolValue(c.Inverse), zoom: c.Zoom,
+			stack: boolValue(c.Stack), labels: boolValue(c.ShowLabels),
+			smooth: boolValue(c.Smooth), symbol: c.Symbol, symbolSize: c.SymbolSize,
+		}, true
+	case *scatterchart.Config:
+		return view{
+			chartType: scatterchart.Type, sort: c.Sort,
+			log: c.Scale == "log", logBase: c.LogBase, min: c.Min, max: c.Max,
+			zeroBaseline: boolValue(c.ZeroBaseline), inverse: boolValue(c.Inverse), zoom: c.Zoom,
+			labels: boolValue(c.ShowLabels), symbol: c.Symbol, symbolSize: c.SymbolSize,
+		}, true
+	case *piechart.Config:
+		return view{chartType: piechart.Type, sort: c.Sort, labels: boolValue(c.ShowLabels)}, true
+	}
+	return view{}, false
+}
+
+// valueRange resolves --min/--max against the frame's plotted values, the way
+// the UI's applyAxisRange does: absolute bounds as given, pNN as the NNth
+// percentile. Bounds that cannot resolve come back nil.
+func (v view) valueRange(fr frame.Frame) (lo, hi any) {
+	var values []float64
+	for _, s := range fr.Series {
+		for _, x := range s.Values {
+			if !math.IsNaN(x) {
+				values = append(values, x)
+			}
+		}
+	}
+	resolve := func(b *shared.AxisBound) any {
+		if b == nil {
+			return nil
+		}
+		x := b.Value
+		if b.Percentile {
+			x = percentile(values, b.Value)
+		}
+		if math.IsNaN(x) || math.IsInf(x, 0) {
+			return nil
+		}
+		return x
+	}
+	return resolve(v.min), resolve(v.max)
+}
+
+// percentile is the p-th percentile (0–100) of values, interpolating linearly
+// between the closest ranks; NaN for an empty list.
+func percentile(values []float64, p float64) float64 {
+	if len(values) == 0 {
+		return math.NaN()
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	rank := p / 100 * float64(len(sorted)-1)
+	lo, hi := int(math.Floor(rank)), int(math.Ceil(rank))
+	return sorted[lo] + (sorted[hi]-sorted[lo])*(rank-float64(lo))
+}
+
+// valueTitle names the value axis: the stat unit when there is one, else the
+// stat type.
+func valueTitle(fr frame.Frame) string {
+	if unit := fr.Unit(); unit != "" {
+		return unit
+	}
+	return fr.StatType
+}
+
+func seriesNames(fr frame.Frame) []string {
+	names := make([]string, len(fr.Series))
+	for i, s := range fr.Series {
+		names[i] = s.Name
+	}
+	return names
+}
+
+func boolValue(b *bool) bool { return b != nil && *b }
@@ -0,0 +1,156 @@
+package export
+
+import (
+	"encoding/json"
+	"flag"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	internal_charts "github.com/goptics/vizb/internal/charts"
+	barchart "github.com/goptics/vizb/internal/charts/bar"
+	heatmapchart "github.com/goptics/vizb/internal/charts/heatmap"
+	linechart "github.com/goptics/vizb/internal/charts/line"
+	piechart "github.com/goptics/vizb/internal/charts/pie"
+	scatterchart "github.com/goptics/vizb/internal/charts/scatter"
+	"github.com/goptics/vizb/shared"
+	"github.com/stretchr/testify/suite"
+)
+
+var update = flag.Bool("update", false, "rewrite testdata/*.json golden files")
+
+type ExportSuite struct {
+	suite.Suite
+}
+
+// sortingDataset has three categories × two series for a single stat type,
+// with one missing cell.
+func sortingDataset(settings ...internal_charts.ChartConfig) *shared.Dataset {
+	row := func(x, y string, ns float64) shared.DataPoint {
+		return shared.DataPoint{XAxis: x, YAxis: y, Stats: []shared.Stat{{Type: "Execution Time (ns/op)", Value: shared.F64(ns)}}}
+	}
+	return &shared.Dataset{
+		Name:     "Sorting",
+		Axes:     []shared.Axis{{Key: "x", Label: "algorithm"}, {Key: "y", Label: "size"}},
+		Settings: settings,
+		Data: []shared.DataPoint{
+			row("Quick", "1K", 120), row("Quick", "1M", 98000),
+			row("Merge", "1K", 150), row("Merge", "1M", 132000),
+			row("Heap", "1K", 180),
+		},
+	}
+}
+
+func (s *ExportSuite) golden(name string, got []byte) {
+	path := filepath.Join("testdata", name+".json")
+	if *update {
+		s.Require().NoError(os.WriteFile(path, got, 0o644))
+	}
+	want, err := os.ReadFile(path)
+	s.Require().NoError(err, "run go test ./pkg/export -update to create %s", path)
+	s.Equal(string(want), string(got), "%s differs; rerun with -update after checking the change", path)
+}
+
+func (s *ExportSuite) TestGoldenFiles() {
+	yes := true
+	size := 10.0
+	base := 2.0
+	cases := []struct {
+		name   string
+		format string
+		cfg    internal_charts.ChartConfig
+	}{
+		{"vega-lite-bar", FormatVegaLite, &barchart.Config{Type: "bar"}},
+		{"vega-lite-bar-stacked-horizontal-labels", FormatVegaLite, &barchart.Config{
+			Type: "bar", Stack: &yes, Horizontal: &yes, ShowLabels: &yes,
+			Sort: &shared.Sort{Enabled: true, Order: "desc"},
+		}},
+		{"vega-lite-scatter", FormatVegaLite, &scatterchart.Config{Type: "scatter", Symbol: "diamond", SymbolSize: &size}},
+		{"vega-lite-pie", FormatVegaLite, &piechart.Config{Type: "pie", Sort: &shared.Sort{Enabled: true, Order: "asc"}}},
+		{"echarts-bar-zoom", FormatECharts, &barchart.Config{Type: "bar", Zoom: "both", Inverse: &yes}},
+		{"echarts-line-log", FormatECharts, &linechart.Config{
+			Type: "line", Scale: "log", LogBase: &base, Smooth: &yes,
+			Min: &shared.AxisBound{Value: 100}, Max: &shared.AxisBound{Value: 50, Percentile: true},
+		}},
+		{"echarts-pie", FormatECharts, &piechart.Config{Type: "pie", ShowLabels: &yes}},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			doc, skipped, err := Document(tc.format, []*shared.Dataset{sortingDataset(tc.cfg)})
+			s.Require().NoError(err)
+			s.Empty(skipped)
+			s.True(json.Valid(doc))
+			s.golden(tc.name, doc)
+		})
+	}
+}
+
+func (s *ExportSuite) TestDocumentShapes() {
+	ds := sortingDataset(&barchart.Config{Type: "bar"}, &linechart.Config{Type: "line"})
+
+	doc, _, err := Document(FormatVegaLite, []*shared.Dataset{ds})
+	s.Require().NoError(err)
+	var vl map[string]any
+	s.Require().NoError(json.Unmarshal(doc, &vl))
+	s.Equal(vegaLiteSchema, vl["$schema"])
+	s.Len(vl["vconcat"], 2)
+	s.Nil(vl["vconcat"].([]any)[0].(map[string]any)["$schema"], "only the top level names the schema")
+
+	doc, _, err = Document(FormatECharts, []*shared.Dataset{ds, sortingDataset(&piechart.Config{Type: "pie"})})
+	s.Require().NoError(err)
+	var options []map[string]any
+	s.Require().NoError(json.Unmarshal(doc, &options))
+	s.Len(options, 3)
+	s.Equal("pie", options[2]["series"].([]any)[0].(map[string]any)["type"])
+
+	doc, _, err = Document(FormatECharts, []*shared.Dataset{sortingDataset(&barchart.Config{Type: "bar"})})
+	s.Require().NoError(err)
+	var option map[string]any
+	s.Require().NoError(json.Unmarshal(doc, &option), "a single chart is a bare option object")
+	s.Contains(option, "series")
+}
+
+func (s *ExportSuite) TestDocumentSkipsUnsupportedCharts() {
+	ds := sortingDataset(&heatmapchart.Config{Type: "heatmap"}, &barchart.Config{Type: "bar"}, &heatmapchart.Config{Type: "heatmap"})
+	_, skipped, err := Document(FormatVegaLite, []*shared.Dataset{ds})
+	s.NoError(err)
+	s.Equal([]string{"heatmap"}, skipped)
+
+	_, skipped, err = Document(FormatECharts, []*shared.Dataset{sortingDataset(&heatmapchart.Config{Type: "heatmap"})})
+	s.ErrorIs(err, ErrNoCharts)
+	s.Equal([]string{"heatmap"}, skipped)
+}
+
+func (s *ExportSuite) TestDocumentRejectsUnknownFormat() {
+	_, _, err := Document("plotly", nil)
+	s.EqualError(err, `unknown export format "plotly" (want vega-lite or echarts)`)
+}
+
+func (s *ExportSuite) TestSortDoesNotLeakAcrossCharts() {
+	desc := &shared.Sort{Enabled: true, Order: "desc"}
+	doc, _, err := Document(FormatECharts, []*shared.Dataset{sortingDataset(&barchart.Config{Type: "bar", Sort: desc}, &linechart.Config{Type: "line"})})
+	s.Require().NoError(err)
+	var options []map[string]any
+	s.Require().NoError(json.Unmarshal(doc, &options))
+	s.Equal([]any{"Merge", "Quick", "Heap"}, options[0]["xAxis"].(map[string]any)["data"])
+	s.Equal([]any{"Quick", "Merge", "Heap"}, options[1]["xAxis"].(map[string]any)["data"])
+}
+
+func (s *ExportSuite) TestPercentile() {
+	s.True(math.IsNaN(percentile(nil, 50)))
+	s.Equal(2.0, percentile([]float64{3, 1, 2}, 50))
+	s.Equal(2.5, percentile([]float64{1, 2, 3, 4}, 50))
+	s.Equal(4.0, percentile([]float64{1, 2, 3, 4}, 100))
+}
+
+func (s *ExportSuite) TestSupported() {
+	for _, t := range []string{"bar", "line", "scatter", "pie"} {
+		s.True(Supported(t), t)
+	}
+	s.False(Supported("sankey"))
+}
+
+func TestExportSuite(t *testing.T) {
+	suite.Run(t, new(ExportSuite))
+}
@@ -0,0 +1,182 @@
+package export
+
+import (
+	"math"
+
+	barchart "github.com/goptics/vizb/internal/charts/bar"
+	linechart "github.com/goptics/vizb/internal/charts/line"
+	piechart "github.com/goptics/vizb/internal/charts/pie"
+	scatterchart "github.com/goptics/vizb/internal/charts/scatter"
+	"github.com/goptics/vizb/internal/frame"
+)
+
+const vegaLiteSchema = "https://vega.github.io/schema/vega-lite/v5.json"
+
+// Vega-Lite has no category zoom on band scales, so --zoom is not exported.
+// Data is inlined as long-form rows {category, series, value}; missing cells
+// are left out.
+
+// vegaLiteChart builds one unit (or layered, with labels) Vega-Lite spec.
+func vegaLiteChart(datasetName string, v view, fr frame.Frame, palette []string) map[string]any {
+	spec := map[string]any{
+		"title": map[string]any{"text": fr.Title, "subtitle": datasetName},
+	}
+	if v.chartType == piechart.Type {
+		vegaLitePie(spec, v, fr, palette)
+		return spec
+	}
+
+	var rows []map[string]any
+	for ci, category := range fr.Categories {
+		for _, s := range fr.Series {
+			if x := s.Values[ci]; !math.IsNaN(x) {
+				rows = append(rows, map[string]any{"category": category, "series": s.Name, "value": x})
+			}
+		}
+	}
+	spec["data"] = map[string]any{"values": rows}
+
+	category := map[string]any{"field": "category", "type": "nominal", "sort": fr.Categories, "title": nilIfEmpty(fr.XLabel)}
+	value := map[string]any{"field": "value", "type": "quantitative", "title": valueTitle(fr)}
+	if scale := vegaLiteScale(v, fr); len(scale) > 0 {
+		value["scale"] = scale
+	}
+	if v.chartType != scatterchart.Type {
+		value["stack"] = nil
+		if v.stack {
+			value["stack"] = "zero"
+		}
+	}
+	color := map[string]any{
+		"field": "series", "type": "nominal", "sort": seriesNames(fr),
+		"scale": map[string]any{"range": palette}, "title": nil,
+	}
+	if len(fr.Series) < 2 {
+		color["legend"] = nil
+	}
+
+	catKey, valKey, offsetKey := "x", "y", "xOffset"
+	if v.horizontal {
+		catKey, valKey, offsetKey = "y", "x", "yOffset"
+	}
+	encoding := map[string]any{catKey: category, valKey: value, "color": color}
+	if v.chartType == barchart.Type && !v.stack && len(fr.Series) > 1 {
+		encoding[offsetKey] = map[string]any{"field": "series", "sort": seriesNames(fr)}
+	}
+	spec["encoding"] = encoding
+
+	mark := vegaLiteMark(v)
+	if !v.labels {
+		spec["mark"] = mark
+		return spec
+	}
+	text := map[string]any{"type": "text", "dy": -8}
+	if v.horizontal {
+		text = map[string]any{"type": "text", "dx": 8, "align": "left"}
+	}
+	spec["layer"] = []map[string]any{
+		{"mark": mark},
+		{"mark": text, "encoding": map[string]any{"text": map[string]any{"field": "value", "type": "quantitative", "format": "~s"}}},
+	}
+	return spec
+}
+
+func vegaLiteMark(v view) map[string]any {
+	switch v.chartType {
+	case barchart.Type:
+		return map[string]any{"type": "bar", "tooltip": true}
+	case linechart.Type:
+		mark := map[string]any{"type": "line", "point": true, "tooltip": true}
+		if v.smooth {
+			mark["interpolate"] = "monotone"
+		}
+		return mark
+	default:
+		mark := map[string]any{"type": "point", "filled": true, "tooltip": true}
+		if shape, ok := vegaLiteShapes[v.symbol]; ok {
+			mark["shape"] = shape
+		}
+		if v.symbolSize != nil {
+			mark["size"] = *v.symbolSize * *v.symbolSize // diameter px → area px²
+		}
+		return mark
+	}
+}
+
+// vegaLiteShapes maps ECharts symbols onto Vega-Lite point shapes; the rest
+// keep the default circle.
+var vegaLiteShapes = map[string]string{
+	"circle":   "circle",
+	"rect":     "square",
+	"triangle": "triangle-up",
+	"diamond":  "diamond",
+}
+
+func vegaLiteScale(v view, fr frame.Frame) map[string]any {
+	scale := map[string]any{}
+	if v.log {
+		scale["type"] = "log"
+		if v.logBase != nil {
+			scale["base"] = *v.logBase
+		}
+	}
+	lo, hi := v.valueRange(fr)
+	if lo != nil {
+		scale["domainMin"] = lo
+	}
+	if hi != nil {
+		scale["domainMax"] = hi
+	}
+	if v.zeroBaseline {
+		scale["zero"] = true
+	}
+	if v.inverse {
+		scale["reverse"] = true
+	}
+	return scale
+}
+
+// vegaLitePie draws one arc per category, sized by its total across series.
+// Non-positive totals are left out, as in the SVG renderer.
+func vegaLitePie(spec map[string]any, v view, fr frame.Frame, palette []string) {
+	var rows []map[string]any
+	var categories []string
+	for i, total := range fr.Totals() {
+		if total > 0 {
+			rows = append(rows, map[string]any{"category": fr.Categories[i], "value": total})
+			categories = append(categories, fr.Categories[i])
+		}
+	}
+	spec["data"] = map[string]any{"values": rows}
+	spec["encoding"] = map[string]any{
+		"theta": map[string]any{"field": "value", "type": "quantitative", "stack": true},
+		"color": map[string]any{
+			"field": "category", "type": "nominal", "sort": categories,
+			"scale": map[string]any{"range": palette}, "title": nilIfEmpty(fr.XLabel),
+		},
+		"order": map[string]any{"field": "order", "type": "quantitative"},
+	}
+	for i, row := range rows {
+		row["order"] = i
+	}
+	arc := map[string]any{"type": "arc", "tooltip": true}
+	if !v.labels {
+		spec["mark"] = arc
+		return
+	}
+	arc["outerRadius"] = 120
+	spec["layer"] = []map[string]any{
+		{"mark": arc},
+		{
+			"mark":     map[string]any{"type": "text", "radius": 140},
+			"encoding": map[string]any{"text": map[string]any{"field": "category", "type": "nominal"}},
+		},
+	}
+}
+
+func nilIfEmpty(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
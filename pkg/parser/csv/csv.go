@@ -1,7 +1,6 @@
 package csv
 
 import (
-	"encoding/csv"
 	"fmt"
 	"io"
 	"math"
@@ -43,6 +42,14 @@ func ParseCSV(input io.Reader, cfg parser.Config) ([]shared.DataPoint, parser.Co
 
 type autoDetectFunc func(parser.Config, []string, [][]string) (parser.Config, error)
 
+// sampleRows is how many data rows are buffered for auto-detection and column
+// type inference. Everything after the sample is streamed row by row, so a
+// multi-GB export never has to fit in memory.
+const sampleRows = 1000
+
+// progressEvery is the row interval between cfg.Progress reports.
+const progressEvery = 10000
+
 func parseReader(input io.Reader, cfg parser.Config, autoDetect autoDetectFunc) ([]shared.DataPoint, parser.Config, error) {
 	var err error
 	cfg, err = parser.FinalizeGroupConfig(cfg)
@@ -50,32 +57,45 @@ func parseReader(input io.Reader, cfg parser.Config, autoDetect autoDetectFunc)
 		return nil, cfg, err
 	}
 
-	reader := csv.NewReader(input)
-	reader.FieldsPerRecord = -1 // allow ragged rows
-
-	rows, err := reader.ReadAll()
+	counter := &parser.CountingReader{R: input}
+	reader, dialect, err := openRecords(counter, cfg.CSV)
 	if err != nil {
-		return nil, cfg, fmt.Errorf("read CSV: %w", err)
+		return nil, cfg, err
 	}
+	if !cfg.QuietAutoDetect && cfg.CSV.Delimiter == "" {
+		parser.LogAutoDelimiter(dialect.Delimiter)
+	}
+	cfg.CSV = dialect
 
-	if len(rows) < 2 { // need header + at least one data row
+	stream, err := newRowStream(reader, counter, dialect.NoHeader, cfg.Progress)
+	if err != nil {
+		return nil, cfg, err
+	}
+	if stream.header == nil || len(stream.sample) == 0 { // need header + at least one data row
 		return nil, cfg, nil
 	}
 
-	headers := normalizeHeaders(rows[0])
-	dataRows := rows[1:]
+	headers := normalizeHeaders(stream.header)
+	sample := stream.sample
 
 	// Auto-group: when no grouping is configured, infer the category axis from
 	// the data so `vizb data.csv` produces a usable chart without -g/-p/-r/-x.
 	if !parser.HasSelect(cfg) {
 		autoHeaders := parser.FilterHeadersForAutoDetect(headers, cfg.Select)
-		cfg, err = autoDetect(cfg, autoHeaders, dataRows)
+		cfg, err = autoDetect(cfg, autoHeaders, sample)
 		if err != nil {
 			return nil, cfg, err
 		}
 	}
 
 	if (len(cfg.SelectViews) > 0 && !parser.IsExplicitGrouping(cfg)) || len(cfg.Axes) > 0 {
+		// Select/value modes emit one point per row with no aggregation, so
+		// their output already scales with the input; collect every row and
+		// let the shared dispatcher resolve axis kinds over the full column.
+		dataRows, err := stream.collect()
+		if err != nil {
+			return nil, cfg, err
+		}
 		cfg.Mode = parser.ResolveMode(cfg)
 		selectAxis := len(cfg.SelectViews) > 0 && !parser.IsExplicitGrouping(cfg)
 		flag := parser.AxisColumnLabel(selectAxis)
@@ -101,10 +121,12 @@ func parseReader(input io.Reader, cfg parser.Config, autoDetect autoDetectFunc)
 		return nil, cfg, err
 	}
 
-	chartCols := chartColumns(headers, groupSet, dataRows)
+	// Chart columns are inferred from the sample: a column with no numeric cell
+	// in the first sampleRows rows is treated as non-numeric for the whole file.
+	chartCols := chartColumns(headers, groupSet, sample)
 	var colLabels map[int]string
 	if len(cfg.Select) > 0 {
-		chartCols, colLabels, err = resolveExplicitChartColumns(headers, cfg, dataRows)
+		chartCols, colLabels, err = resolveExplicitChartColumns(headers, cfg, sample)
 		if err != nil {
 			return nil, cfg, err
 		}
@@ -113,9 +135,25 @@ func parseReader(input io.Reader, cfg parser.Config, autoDetect autoDetectFunc)
 		return nil, cfg, fmt.Errorf("no numeric columns found in CSV")
 	}
 
+	// Grouped rows are summed downstream anyway (shared.AggregateDataPoints);
+	// folding them in while streaming keeps memory proportional to the number
+	// of groups rather than the number of rows. Histograms need every value.
+	var agg *shared.PointAggregator
+	if len(cfg.Group) > 0 && !parser.HasDistributionChart(cfg) {
+		agg = shared.NewPointAggregator()
+	}
+
 	var results []shared.DataPoint
 
-	for _, row := range dataRows {
+	for {
+		row, err := stream.next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, cfg, err
+		}
+
 		groupValues := groupColumnValues(row, groupIdx)
 
 		var name, xAxis, yAxis, zAxis string
@@ -154,6 +192,7 @@ func parseReader(input io.Reader, cfg parser.Config, autoDetect autoDetectFunc)
 			}
 			stats = append(stats, shared.Stat{
 				Type:  utils.CreateStatType(label, cfg.NumberUnit, ""),
+				Unit:  cfg.NumberUnit,
 				Value: shared.F64(utils.FormatNumber(v, cfg.NumberUnit, cfg.Round)),
 			})
 		}
@@ -162,18 +201,140 @@ func parseReader(input io.Reader, cfg parser.Config, autoDetect autoDetectFunc)
 			continue
 		}
 
-		results = append(results, shared.DataPoint{
+		dp := shared.DataPoint{
 			Name:  name,
 			XAxis: xAxis,
 			YAxis: yAxis,
 			ZAxis: zAxis,
 			Stats: stats,
-		})
+		}
+		if agg != nil {
+			agg.Add(dp)
+			continue
+		}
+		results = append(results, dp)
 	}
 
+	if agg != nil {
+		return agg.Points(), cfg, nil
+	}
 	return results, cfg, nil
 }
 
+// rowStream replays the buffered sample and then keeps reading records,
+// counting data rows for progress reports.
+type rowStream struct {
+	reader   recordReader
+	counter  *parser.CountingReader
+	header   []string
+	sample   [][]string
+	pos      int
+	rows     int
+	done     bool
+	progress parser.ProgressFunc
+}
+
+// newRowStream reads the header and up to sampleRows data rows. A nil header
+// means the input was empty. With noHeader the first record is data and the
+// header is synthesized as col1..colN over the widest sampled row.
+func newRowStream(reader recordReader, counter *parser.CountingReader, noHeader bool, progress parser.ProgressFunc) (*rowStream, error) {
+	s := &rowStream{reader: reader, counter: counter, progress: progress}
+
+	if !noHeader {
+		header, err := reader.Read()
+		if err == io.EOF {
+			return s, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read CSV: %w", err)
+		}
+		s.header = header
+	}
+
+	for len(s.sample) < sampleRows {
+		row, err := reader.Read()
+		if err == io.EOF {
+			s.done = true
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read CSV: %w", err)
+		}
+		s.sample = append(s.sample, row)
+	}
+
+	if noHeader && len(s.sample) > 0 {
+		width := 0
+		for _, row := range s.sample {
+			width = max(width, len(row))
+		}
+		s.header = syntheticHeaders(width)
+	}
+	return s, nil
+}
+
+// syntheticHeaders names the columns of a header-less file col1..colN.
+func syntheticHeaders(n int) []string {
+	headers := make([]string, n)
+	for i := range headers {
+		headers[i] = fmt.Sprintf("col%d", i+1)
+	}
+	return headers
+}
+
+// next returns the next data row (sample first), or io.EOF once the input is
+// exhausted. The final progress report fires on EOF.
+func (s *rowStream) next() ([]string, error) {
+	var row []string
+	switch {
+	case s.pos < len(s.sample):
+		row = s.sample[s.pos]
+		s.pos++
+	case s.done:
+		return nil, s.finish()
+	default:
+		rec, err := s.reader.Read()
+		if err == io.EOF {
+			s.done = true
+			return nil, s.finish()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read CSV: %w", err)
+		}
+		row = rec
+	}
+
+	s.rows++
+	if s.progress != nil && s.rows%progressEvery == 0 {
+		s.progress(s.rows, s.counter.N)
+	}
+	return row, nil
+}
+
+func (s *rowStream) finish() error {
+	if s.progress != nil {
+		s.progress(s.rows, s.counter.N)
+		s.progress = nil // report completion once
+	}
+	return io.EOF
+}
+
+// collect drains the remaining rows into memory for the modes that need every
+// row at once.
+func (s *rowStream) collect() ([][]string, error) {
+	var rows [][]string
+	for {
+		row, err := s.next()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+}
+
 // csvRowReader adapts one CSV row to the parser.RowReader interface.
 type csvRowReader struct {
 	row     []string
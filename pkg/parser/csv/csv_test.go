@@ -2,8 +2,11 @@ package csv
 
 import (
 	"errors"
+	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"testing"
 
@@ -428,6 +431,182 @@ func (s *CSVSuite) TestParseCSVReturnsGroupRowError() {
 	s.ErrorContains(err, "tabular pattern is not configured")
 }
 
+func (s *CSVSuite) TestStreamedRowsPastSampleAreAggregated() {
+	var b strings.Builder
+	b.WriteString("region,sells\n")
+	rows := sampleRows*3 + 7
+	for i := range rows {
+		fmt.Fprintf(&b, "r%d,1\n", i%3)
+	}
+
+	var progressRows []int
+	var lastBytes int64
+	results, _, _, err := ParseCSV(strings.NewReader(b.String()), parser.Config{
+		GroupPattern: "x",
+		Group:        []string{"region"},
+		Progress: func(rows int, bytes int64) {
+			progressRows = append(progressRows, rows)
+			lastBytes = bytes
+		},
+	})
+	s.Require().NoError(err)
+	s.Require().Len(results, 3)
+	total := 0.0
+	for _, dp := range results {
+		total += *dp.Stats[0].Value
+	}
+	s.Equal(float64(rows), total)
+	s.Equal([]int{rows}, progressRows)
+	s.Equal(int64(b.Len()), lastBytes)
+}
+
+func (s *CSVSuite) TestHistogramKeepsEveryStreamedRow() {
+	var b strings.Builder
+	b.WriteString("region,sells\n")
+	rows := sampleRows + 5
+	for i := range rows {
+		fmt.Fprintf(&b, "r%d,%d\n", i%3, i)
+	}
+
+	results, _, _, err := ParseCSV(strings.NewReader(b.String()), parser.Config{
+		GroupPattern: "x",
+		Group:        []string{"region"},
+		ChartTypes:   []string{"histogram"},
+	})
+	s.Require().NoError(err)
+	s.Len(results, rows, "histogram bins need each value, not group sums")
+}
+
+func (s *CSVSuite) TestColumnTypesInferredFromSample() {
+	var b strings.Builder
+	b.WriteString("region,sells,late\n")
+	for range sampleRows {
+		b.WriteString("west,1,\n")
+	}
+	b.WriteString("east,2,99\n")
+
+	results, _ := mustParseCSVFile(s.T(), s.writeFile(b.String()), parser.Config{GroupPattern: "x", Group: []string{"region"}})
+	s.Require().Len(results, 2)
+	s.Equal([]string{"sells"}, statTypes(results[1].Stats))
+}
+
+func (s *CSVSuite) parseDialect(content string, d parser.CSVDialect) []shared.DataPoint {
+	results, _, _, err := ParseCSV(strings.NewReader(content), parser.Config{
+		GroupPattern:    "x",
+		Group:           []string{"name"},
+		CSV:             d,
+		QuietAutoDetect: true,
+	})
+	s.Require().NoError(err)
+	return results
+}
+
+func (s *CSVSuite) TestDialectSniffsDelimiters() {
+	for _, tc := range []struct{ name, content string }{
+		{"semicolon", "name;sells\na;10\nb;20\n"},
+		{"tab", "name\tsells\na\t10\nb\t20\n"},
+		{"pipe", "name|sells\na|10\nb|20\n"},
+		{"whitespace", "name   sells\na      10\nb   20\n"},
+	} {
+		s.Run(tc.name, func() {
+			results := s.parseDialect(tc.content, parser.CSVDialect{})
+			s.Require().Len(results, 2)
+			s.Equal("b", results[1].XAxis)
+			s.Equal(20.0, *results[1].Stats[0].Value)
+		})
+	}
+}
+
+func (s *CSVSuite) TestDialectQuotedDelimiterStaysInField() {
+	results := s.parseDialect("name;sells\n\"a;b\";10\nc;2\n", parser.CSVDialect{Delimiter: ";"})
+	s.Require().Len(results, 2)
+	s.Equal("a;b", results[0].XAxis)
+	s.Equal(2.0, *results[1].Stats[0].Value)
+}
+
+func (s *CSVSuite) TestDialectCustomQuoteAndEscape() {
+	content := "name,sells\n'a, inc',10\n'b \\'x\\'',20\n"
+	results := s.parseDialect(content, parser.CSVDialect{Quote: "'", Escape: "\\"})
+	s.Require().Len(results, 2)
+	s.Equal("a, inc", results[0].XAxis)
+	s.Equal("b 'x'", results[1].XAxis)
+}
+
+func (s *CSVSuite) TestDialectCustomQuoteUnterminatedErrors() {
+	_, _, _, err := ParseCSV(strings.NewReader("name,sells\n'a,10\n"), parser.Config{
+		GroupPattern: "x", Group: []string{"name"}, CSV: parser.CSVDialect{Quote: "'"},
+	})
+	s.ErrorContains(err, "unterminated quoted field")
+}
+
+func (s *CSVSuite) TestDialectCommentsAndPreamble() {
+	s.Run("sniffed hash preamble", func() {
+		results := s.parseDialect("# exported 2024-01-01\n# host: ci\nname,sells\na,10\n", parser.CSVDialect{})
+		s.Require().Len(results, 1)
+		s.Equal("a", results[0].XAxis)
+	})
+	s.Run("hash header is not a comment", func() {
+		results, _, _, err := ParseCSV(strings.NewReader("#,sells\n1,10\n"), parser.Config{
+			GroupPattern: "x", Group: []string{"#"}, QuietAutoDetect: true,
+		})
+		s.Require().NoError(err)
+		s.Require().Len(results, 1)
+		s.Equal("1", results[0].XAxis)
+	})
+	s.Run("explicit comment and skipped lines", func() {
+		content := "Report generated by tool\n\nname,sells\n; not data\na,10\n"
+		results := s.parseDialect(content, parser.CSVDialect{SkipLines: 2, Comment: ";"})
+		s.Require().Len(results, 1)
+		s.Equal(10.0, *results[0].Stats[0].Value)
+	})
+}
+
+func (s *CSVSuite) TestDialectNoHeaderSynthesizesNames() {
+	results, cfg, _, err := ParseCSV(strings.NewReader("a,10,1\nb,20\n"), parser.Config{
+		GroupPattern: "x", Group: []string{"col1"}, CSV: parser.CSVDialect{NoHeader: true},
+	})
+	s.Require().NoError(err)
+	s.True(cfg.CSV.NoHeader)
+	s.Require().Len(results, 2)
+	s.Equal("a", results[0].XAxis)
+	s.Equal([]string{"col2", "col3"}, statTypes(results[0].Stats))
+}
+
+func (s *CSVSuite) TestDialectEncodings() {
+	utf16 := func(text string, bigEndian bool) string {
+		var b []byte
+		if bigEndian {
+			b = []byte{0xFE, 0xFF}
+		} else {
+			b = []byte{0xFF, 0xFE}
+		}
+		for _, r := range text {
+			if bigEndian {
+				b = append(b, byte(r>>8), byte(r))
+			} else {
+				b = append(b, byte(r), byte(r>>8))
+			}
+		}
+		return string(b)
+	}
+	for _, tc := range []struct {
+		name, content string
+		encoding      string
+	}{
+		{"utf-8 bom", "\ufeffname,sells\ncafé,10\n", ""},
+		{"utf-16le bom", utf16("name\tsells\ncafé\t10\n", false), ""},
+		{"utf-16be bom", utf16("name,sells\ncafé,10\n", true), ""},
+		{"latin-1 sniffed", "name,sells\ncaf\xe9,10\n", ""},
+		{"latin-1 explicit", "name,sells\ncaf\xe9,10\n", "latin-1"},
+	} {
+		s.Run(tc.name, func() {
+			results := s.parseDialect(tc.content, parser.CSVDialect{Encoding: tc.encoding})
+			s.Require().Len(results, 1)
+			s.Equal("café", results[0].XAxis)
+		})
+	}
+}
+
 func TestCSVSuite(t *testing.T) {
 	suite.Run(t, new(CSVSuite))
 }
@@ -815,3 +994,54 @@ func (s *CSVErrorSuite) TestSelectMultiStatModeCustomTypeLabel() {
 func TestCSVAutoValueSuite(t *testing.T) {
 	suite.Run(t, new(CSVAutoValueSuite))
 }
+
+// syntheticCSV generates grouped CSV rows on the fly so the benchmark input
+// itself never occupies memory.
+type syntheticCSV struct {
+	rows, next int
+	buf        []byte
+}
+
+func (r *syntheticCSV) Read(p []byte) (int, error) {
+	for len(r.buf) < len(p) && r.next <= r.rows {
+		if r.next == 0 {
+			r.buf = append(r.buf, "region,host,latency,bytes\n"...)
+		} else {
+			r.buf = fmt.Appendf(r.buf, "region-%d,host-%d,%d.5,%d\n", r.next%8, r.next%16, r.next%1000, r.next)
+		}
+		r.next++
+	}
+	if len(r.buf) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// BenchmarkParseCSVStreaming parses grouped inputs of growing size. The
+// peak-heap-MB metric stays flat across sizes because rows are folded into
+// groups as they stream instead of being held in memory.
+func BenchmarkParseCSVStreaming(b *testing.B) {
+	for _, rows := range []int{10_000, 100_000, 1_000_000} {
+		b.Run(fmt.Sprintf("rows=%d", rows), func(b *testing.B) {
+			b.ReportAllocs()
+			var peak uint64
+			var ms runtime.MemStats
+			cfg := parser.Config{
+				GroupPattern: "x,y",
+				Group:        []string{"region", "host"},
+				Progress: func(int, int64) {
+					runtime.ReadMemStats(&ms)
+					peak = max(peak, ms.HeapInuse)
+				},
+			}
+			for b.Loop() {
+				if _, _, _, err := ParseCSV(&syntheticCSV{rows: rows}, cfg); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(peak)/(1<<20), "peak-heap-MB")
+		})
+	}
+}
@@ -0,0 +1,250 @@
+package csv
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/goptics/vizb/pkg/parser"
+)
+
+// sniffLines caps how many non-comment lines feed delimiter detection.
+const sniffLines = 20
+
+// recordReader yields one record per call and io.EOF at the end. encoding/csv
+// satisfies it for RFC 4180 dialects; dialectReader covers the rest.
+type recordReader interface {
+	Read() ([]string, error)
+}
+
+// openRecords decodes input, drops the preamble, resolves the dialect (sniffing
+// whatever cfg leaves empty) and returns a record reader positioned at the
+// header row.
+func openRecords(input io.Reader, d parser.CSVDialect) (recordReader, parser.CSVDialect, error) {
+	text, err := parser.NewTextReader(input, d.Encoding)
+	if err != nil {
+		return nil, d, err
+	}
+	br := bufio.NewReaderSize(text, 64*1024)
+
+	for range d.SkipLines {
+		if _, err := br.ReadString('\n'); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, d, fmt.Errorf("read CSV: %w", err)
+		}
+	}
+
+	d = sniffDialect(br, d)
+	return newRecordReader(br, d), d, nil
+}
+
+// sniffDialect fills an empty Delimiter and Comment from the buffered prefix
+// without consuming it.
+func sniffDialect(br *bufio.Reader, d parser.CSVDialect) parser.CSVDialect {
+	if d.Delimiter != "" && d.Comment != "" {
+		return d
+	}
+
+	prefix, err := br.Peek(br.Size())
+	lines := strings.Split(string(prefix), "\n")
+	if err == nil && len(lines) > 1 {
+		lines = lines[:len(lines)-1] // last line may be cut off by the window
+	}
+
+	quote := dialectRune(d.Quote, '"')
+
+	var comments, data []string
+	for _, line := range lines {
+		line = strings.TrimRight(line, "\r")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if d.Comment == "" && len(data) == 0 && strings.HasPrefix(line, "#") {
+			comments = append(comments, line)
+			continue
+		}
+		if d.Comment != "" && strings.HasPrefix(line, d.Comment) {
+			continue
+		}
+		data = append(data, line)
+		if len(data) == sniffLines {
+			break
+		}
+	}
+	if len(data) == 0 { // nothing but '#' lines: treat them as data, not comments
+		data, comments = comments, nil
+	}
+
+	if d.Delimiter == "" {
+		d.Delimiter, _ = parser.SniffDelimiter(data, quote)
+	}
+
+	// A leading '#' block is a comment preamble unless it is shaped like the
+	// rows below it (a header such as "#,name,value").
+	if d.Comment == "" && len(comments) > 0 && d.Delimiter != parser.DelimiterWhitespace {
+		want := strings.Count(data[0], d.Delimiter)
+		preamble := true
+		for _, c := range comments {
+			if strings.Count(c, d.Delimiter) == want {
+				preamble = false
+				break
+			}
+		}
+		if preamble {
+			d.Comment = "#"
+		}
+	}
+	return d
+}
+
+// newRecordReader uses encoding/csv whenever the dialect is RFC 4180 with a
+// different separator, keeping its strict quote handling and speed; custom
+// quote/escape characters and whitespace splitting go through dialectReader.
+func newRecordReader(br *bufio.Reader, d parser.CSVDialect) recordReader {
+	delim := dialectRune(d.Delimiter, ',')
+	comment := dialectRune(d.Comment, 0)
+	quote := dialectRune(d.Quote, '"')
+	escape := dialectRune(d.Escape, quote)
+
+	if d.Delimiter != parser.DelimiterWhitespace && quote == '"' && escape == '"' {
+		reader := csv.NewReader(br)
+		reader.FieldsPerRecord = -1 // allow ragged rows
+		reader.Comma = delim
+		reader.Comment = comment
+		return reader
+	}
+
+	return &dialectReader{
+		r:          br,
+		delim:      delim,
+		whitespace: d.Delimiter == parser.DelimiterWhitespace,
+		quote:      quote,
+		escape:     escape,
+		comment:    comment,
+	}
+}
+
+// dialectRune decodes a one-character dialect setting, or def when unset.
+func dialectRune(s string, def rune) rune {
+	if s == "" {
+		return def
+	}
+	r, _ := utf8.DecodeRuneInString(s)
+	return r
+}
+
+// dialectReader splits records for dialects encoding/csv cannot express:
+// a custom quote character, a backslash-style escape, or whitespace-run
+// separators. Quoted fields may span lines; bare quotes inside unquoted
+// fields are kept literally.
+type dialectReader struct {
+	r          *bufio.Reader
+	delim      rune
+	whitespace bool
+	quote      rune
+	escape     rune
+	comment    rune
+	line       int
+}
+
+func (d *dialectReader) readLine() (string, error) {
+	line, err := d.r.ReadString('\n')
+	if err == io.EOF && line != "" {
+		err = nil
+	}
+	if err != nil {
+		return "", err
+	}
+	d.line++
+	return strings.TrimSuffix(strings.TrimSuffix(line, "\n"), "\r"), nil
+}
+
+func (d *dialectReader) Read() ([]string, error) {
+	var line string
+	for {
+		var err error
+		line, err = d.readLine()
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if d.comment != 0 && strings.HasPrefix(line, string(d.comment)) {
+			continue
+		}
+		break
+	}
+
+	var (
+		fields  []string
+		field   strings.Builder
+		started bool // the current field has content or an opening quote
+		quoted  bool
+		start   = d.line
+	)
+	endField := func() {
+		fields = append(fields, field.String())
+		field.Reset()
+		started, quoted = false, false
+	}
+
+	runes := []rune(line)
+	for i := 0; ; i++ {
+		if i >= len(runes) {
+			if !quoted {
+				break
+			}
+			next, err := d.readLine()
+			if err == io.EOF {
+				return nil, fmt.Errorf("record on line %d: unterminated quoted field", start)
+			}
+			if err != nil {
+				return nil, err
+			}
+			field.WriteByte('\n')
+			runes, i = []rune(next), -1
+			continue
+		}
+
+		r := runes[i]
+		if quoted {
+			switch {
+			case r == d.escape && d.escape != d.quote && i+1 < len(runes):
+				i++
+				field.WriteRune(runes[i])
+			case r == d.quote && d.escape == d.quote && i+1 < len(runes) && runes[i+1] == d.quote:
+				i++
+				field.WriteRune(r)
+			case r == d.quote:
+				quoted = false
+			default:
+				field.WriteRune(r)
+			}
+			continue
+		}
+
+		switch {
+		case d.whitespace && (r == ' ' || r == '\t'):
+			if started {
+				endField()
+			}
+		case !d.whitespace && r == d.delim:
+			endField()
+		case r == d.quote && !started:
+			started, quoted = true, true
+		default:
+			started = true
+			field.WriteRune(r)
+		}
+	}
+	if started || !d.whitespace {
+		endField()
+	}
+	return fields, nil
+}
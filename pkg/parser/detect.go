@@ -11,7 +11,9 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/BurntSushi/toml"
 	"github.com/goptics/vizb/shared"
+	"gopkg.in/yaml.v3"
 )
 
 var (
@@ -32,14 +34,46 @@ func DetectParser(filename string) string {
 	}
 	defer f.Close()
 
-	csvHint := strings.EqualFold(filepath.Ext(filename), ".csv") || looksLikeCSV(filename)
-	return detectParser(f, csvHint)
+	if key := documentExtParser(filepath.Ext(filename)); key != "" {
+		return key
+	}
+
+	csvHint := isDelimitedExt(filepath.Ext(filename)) || looksLikeCSV(filename)
+	text, err := NewTextReader(f, "")
+	if err != nil {
+		return "go"
+	}
+	return detectParser(text, csvHint)
+}
+
+// documentExtParser maps a YAML or TOML extension to its parser key, or "".
+func documentExtParser(ext string) string {
+	switch strings.ToLower(ext) {
+	case ".yaml", ".yml":
+		return "yaml"
+	case ".toml":
+		return "toml"
+	}
+	return ""
+}
+
+// isDelimitedExt reports whether ext names a delimited-text file.
+func isDelimitedExt(ext string) bool {
+	switch strings.ToLower(ext) {
+	case ".csv", ".tsv", ".tab", ".psv":
+		return true
+	}
+	return false
 }
 
 // DetectParserBytes detects a parser from inline content without relying on a
 // filename or extension. It shares the same signature ordering as DetectParser.
 func DetectParserBytes(data []byte) string {
-	return detectParser(bytes.NewReader(data), looksLikeCSVReader(bytes.NewReader(data)))
+	text, err := NewTextReader(bytes.NewReader(data), "")
+	if err != nil {
+		return "go"
+	}
+	return detectParser(text, looksLikeCSVReader(bytes.NewReader(data)))
 }
 
 func detectParser(input io.Reader, csvHint bool) string {
@@ -54,20 +88,35 @@ func detectParser(input io.Reader, csvHint bool) string {
 		sawTinybench  bool
 		sawVitest     bool
 		sawGoText     bool
+		nonEmpty      int // non-empty lines seen
+		objectLines   int // leading run of complete one-line JSON objects
+		brokenFirst   int // 1 when the first line is a malformed JSON Lines row
+		sample        []string
 	)
 
 	for lines := 0; scanner.Scan() && lines < 200; lines++ {
 		line := detectAnsiRe.ReplaceAllString(scanner.Text(), "")
+		sample = append(sample, line)
 		trimmed := strings.TrimSpace(line)
 		if trimmed == "" {
 			continue
 		}
 
+		objectLine := strings.HasPrefix(trimmed, "{") && json.Valid([]byte(trimmed))
 		if firstNonEmpty == "" {
 			firstNonEmpty = trimmed
-			var event shared.BenchEvent
-			sawGoJSON = json.Unmarshal([]byte(trimmed), &event) == nil && event.Action != ""
+			sawGoJSON = isBenchEvent(trimmed)
+			// A malformed first JSON Lines row; "{" or a trailing "{"/"[" opens a
+			// pretty-printed document instead.
+			if strings.HasPrefix(trimmed, "{") && !objectLine &&
+				!strings.HasSuffix(trimmed, "{") && !strings.HasSuffix(trimmed, "[") {
+				brokenFirst = 1
+			}
 		}
+		if objectLine && objectLines+brokenFirst == nonEmpty {
+			objectLines++
+		}
+		nonEmpty++
 
 		switch {
 		case divanRe.MatchString(trimmed),
@@ -84,9 +133,9 @@ func detectParser(input io.Reader, csvHint bool) string {
 		if strings.HasPrefix(trimmed, "·") && len(strings.Fields(trimmed)) >= 11 {
 			sawVitest = true
 		}
+		// pkg:/cpu: alone are too common as YAML keys to mark Go output.
 		if strings.Contains(line, "ns/op") ||
-			strings.HasPrefix(trimmed, "goos:") || strings.HasPrefix(trimmed, "goarch:") ||
-			strings.HasPrefix(trimmed, "pkg:") || strings.HasPrefix(trimmed, "cpu:") ||
+			strings.HasPrefix(line, "goos:") || strings.HasPrefix(line, "goarch:") ||
 			goBenchRe.MatchString(trimmed) {
 			sawGoText = true
 		}
@@ -101,8 +150,11 @@ func detectParser(input io.Reader, csvHint bool) string {
 		return "go"
 	}
 
-	// 2. Generic JSON array.
-	if strings.HasPrefix(firstNonEmpty, "[") {
+	// 2. Generic JSON array, or JSON Lines (two or more one-object lines, the
+	// first possibly malformed; a lone object stays the vizb Dataset / go
+	// fallback).
+	if (strings.HasPrefix(firstNonEmpty, "[") && !tomlTableRe.MatchString(firstNonEmpty)) ||
+		(objectLines > 0 && objectLines+brokenFirst >= 2) {
 		return "json"
 	}
 
@@ -123,16 +175,94 @@ func detectParser(input io.Reader, csvHint bool) string {
 		return "csv"
 	}
 
-	// 8-9. Go benchmark text, else fallback.
+	// 8. Go benchmark text.
 	if sawGoText {
 		return "go"
 	}
 
+	// 9. YAML/TOML documents by structure, else fallback.
+	if key := detectDocument(sample); key != "" {
+		return key
+	}
+
 	return "go"
 }
 
-// looksLikeCSV reports whether the file parses as comma-separated rows with at
-// least two columns in each of the first two records.
+var (
+	tomlTableRe = regexp.MustCompile(`^\[\[?\s*[A-Za-z0-9_\-"'][A-Za-z0-9_\-."' ]*\]\]?\s*(#.*)?$`)
+	tomlKeyRe   = regexp.MustCompile(`^[A-Za-z0-9_\-"'][A-Za-z0-9_\-."' ]*=\s*\S`)
+	yamlKeyRe   = regexp.MustCompile(`^(---|-\s|[A-Za-z_"'][^:#{}\[\],=]*:(\s|$))`)
+)
+
+// detectDocument recognises a sampled YAML or TOML document: its first
+// non-comment line must look like a TOML table/key or a YAML key/list item,
+// and the sample must decode as that format. YAML additionally has to be a
+// mapping or a list holding collections, since almost any text is a valid
+// YAML scalar.
+func detectDocument(lines []string) string {
+	first := ""
+	for _, line := range lines {
+		if t := strings.TrimSpace(line); t != "" && !strings.HasPrefix(t, "#") {
+			first = line
+			break
+		}
+	}
+	if first == "" {
+		return ""
+	}
+	text := strings.Join(lines, "\n")
+
+	if tomlTableRe.MatchString(first) || tomlKeyRe.MatchString(first) {
+		var doc map[string]any
+		if _, err := toml.Decode(text, &doc); err == nil && len(doc) > 0 {
+			return "toml"
+		}
+	}
+
+	if yamlKeyRe.MatchString(first) {
+		var doc any
+		if yaml.Unmarshal([]byte(text), &doc) == nil && yamlCollection(doc) {
+			return "yaml"
+		}
+	}
+	return ""
+}
+
+// yamlCollection reports whether doc is a mapping, or a list with at least one
+// mapping or list element (a plain bullet list is prose, not data).
+func yamlCollection(doc any) bool {
+	switch v := doc.(type) {
+	case map[string]any:
+		return len(v) > 0
+	case []any:
+		for _, e := range v {
+			switch e.(type) {
+			case map[string]any, []any:
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// benchEventActions is the test2json Action vocabulary; a JSON line is only a
+// Go event when its Action is one of these, so JSON Lines logs that happen to
+// carry an "Action" field are not mistaken for `go test -json` output.
+var benchEventActions = map[string]bool{
+	"start": true, "run": true, "pause": true, "cont": true, "pass": true,
+	"bench": true, "fail": true, "output": true, "skip": true,
+	"build-output": true, "build-fail": true,
+}
+
+// isBenchEvent reports whether line decodes as a shared.BenchEvent.
+func isBenchEvent(line string) bool {
+	var event shared.BenchEvent
+	return json.Unmarshal([]byte(line), &event) == nil && benchEventActions[event.Action]
+}
+
+// looksLikeCSV reports whether the file parses as delimited rows (comma,
+// semicolon, tab or pipe) with at least two columns in each of the first two
+// records.
 func looksLikeCSV(filename string) bool {
 	f, err := os.Open(filename)
 	if err != nil {
@@ -144,8 +274,42 @@ func looksLikeCSV(filename string) bool {
 }
 
 func looksLikeCSVReader(input io.Reader) bool {
-	r := csv.NewReader(input)
+	text, err := NewTextReader(input, "")
+	if err != nil {
+		return false
+	}
+
+	scanner := bufio.NewScanner(text)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var lines []string
+	for scanner.Scan() && len(lines) < 20 {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) < 2 {
+		return false
+	}
+
+	delim := ","
+	if d, ok := SniffDelimiter(lines, '"'); ok && d != DelimiterWhitespace {
+		delim = d
+	}
+	// Go benchmark text is tab-separated too; it must not read as TSV.
+	if delim == "\t" {
+		for _, line := range lines {
+			if goBenchRe.MatchString(strings.TrimSpace(line)) {
+				return false
+			}
+		}
+	}
+
+	r := csv.NewReader(strings.NewReader(strings.Join(lines, "\n")))
 	r.FieldsPerRecord = -1
+	r.Comma = []rune(delim)[0]
 
 	first, err := r.Read()
 	if err != nil || len(first) < 2 {
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"unicode/utf16"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -46,9 +47,28 @@ const (
 
 	jsonArraySample = `[{"name":"a","sells":10},{"name":"b","sells":20}]`
 
+	jsonLinesSample = `{"name":"a","sells":10}` + "\n" + `{"name":"b","sells":20}` + "\n"
+
+	// An audit log with its own "Action" field is JSON Lines, not test2json.
+	actionLogSample = `{"Action":"deploy","service":"api","ms":120}` + "\n" + `{"Action":"rollback","service":"api","ms":80}` + "\n"
+
+	yamlSample = "# capacity plan\nplans:\n  - name: web\n    cpu: 4\n    memory: 8\n  - name: worker\n    cpu: 8\n    memory: 16\n"
+
+	tomlSample = "[[plans]]\nname = \"web\"\ncpu = 4\n\n[[plans]]\nname = \"worker\"\ncpu = 8\n"
+
 	vizbBenchmarkSample = `{"name":"Benchmarks","data":[{"name":"a","stats":[]}]}`
 )
 
+// utf16LE encodes s as UTF-16LE with a byte-order mark, the shape Excel's
+// "Unicode text" export produces.
+func utf16LE(s string) string {
+	out := []byte{0xFF, 0xFE}
+	for _, u := range utf16.Encode([]rune(s)) {
+		out = append(out, byte(u), byte(u>>8))
+	}
+	return string(out)
+}
+
 func (s *DetectSuite) TestDetectParser() {
 	t := s.T()
 	cases := []struct {
@@ -65,8 +85,25 @@ func (s *DetectSuite) TestDetectParser() {
 		{"js vitest", "vitest.txt", vitestSample, "js:vitest"},
 		{"csv by content", "data.txt", csvSample, "csv"},
 		{"csv by extension", "data.csv", "name;only;semicolons\n", "csv"},
+		{"tsv by extension", "data.tsv", "name\tonly\n", "csv"},
+		{"tsv by content", "data.txt", "name\tsells\na\t10\nb\t20\n", "csv"},
+		{"semicolon csv by content", "data.txt", "name;sells\na;10,5\nb;20,1\n", "csv"},
+		{"pipe csv by content", "data.txt", "name|sells\na|10\nb|20\n", "csv"},
+		{"utf-16 csv by content", "data.txt", utf16LE(csvSample), "csv"},
+		{"go text without header is not tsv", "bench.txt", "BenchmarkFoo-8\t1000\t123 ns/op\nBenchmarkBar-8\t2000\t456 ns/op\n", "go"},
 		{"json array by content", "data.txt", jsonArraySample, "json"},
+		{"json lines by content", "data.ndjson", jsonLinesSample, "json"},
+		{"json lines with a malformed first row", "data.jsonl", `{"name":"a",` + "\n" + jsonLinesSample, "json"},
+		{"pretty json object is not json lines", "data.txt", "{\n" + jsonLinesSample, "go"},
+		{"json lines with non-test2json action", "audit.log", actionLogSample, "json"},
+		{"go json events stream", "bench.json", goJSONSample + goJSONSample, "go"},
 		{"vizb benchmark json falls back to go", "out.json", vizbBenchmarkSample, "go"},
+		{"yaml by extension", "plan.yml", "anything: at all\n", "yaml"},
+		{"toml by extension", "plan.toml", "", "toml"},
+		{"yaml by content", "plan.txt", yamlSample, "yaml"},
+		{"toml by content", "plan.txt", tomlSample, "toml"},
+		{"toml table header is not a json array", "plan.txt", "[server]\nnodes = 4\n", "toml"},
+		{"bullet list is not yaml", "notes.txt", "- first\n- second\n", "go"},
 		{"empty falls back to go", "empty.txt", "", "go"},
 		{"garbage falls back to go", "junk.txt", "just some random text\nwith no markers\n", "go"},
 	}
@@ -104,7 +141,13 @@ func (s *DetectSuite) TestDetectParserBytes() {
 		{"js tinybench", tinybenchSample, "js:tinybench"},
 		{"js vitest", vitestSample, "js:vitest"},
 		{"csv", csvSample, "csv"},
+		{"tsv", "name\tsells\na\t10\nb\t20\n", "csv"},
+		{"semicolon csv", "name;sells\na;10\nb;20\n", "csv"},
 		{"json", jsonArraySample, "json"},
+		{"json lines", jsonLinesSample, "json"},
+		{"json lines with non-test2json action", actionLogSample, "json"},
+		{"yaml", yamlSample, "yaml"},
+		{"toml", tomlSample, "toml"},
 	}
 
 	for _, tc := range cases {
@@ -0,0 +1,321 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/goptics/vizb/pkg/cliout"
+)
+
+// DelimiterWhitespace splits fields on runs of spaces/tabs (fixed-width and
+// `column -t` style exports) instead of a single separator character.
+const DelimiterWhitespace = "whitespace"
+
+// delimiterCandidates are the single-character separators the sniffer tries,
+// in tie-break order (comma wins a tie so plain CSV never changes meaning).
+var delimiterCandidates = []string{",", ";", "\t", "|"}
+
+// TextEncodings are the accepted --encoding values. Empty sniffs: a UTF-8 or
+// UTF-16 byte-order mark wins, otherwise a prefix that is not valid UTF-8 is
+// read as Latin-1.
+var TextEncodings = []string{"utf-8", "utf-16", "utf-16le", "utf-16be", "latin-1"}
+
+// CSVDialect describes how a delimited text file is laid out. The zero value
+// is "sniff everything": delimiter and comment prefix are detected from the
+// first lines, quoting follows RFC 4180 and the first row is the header.
+type CSVDialect struct {
+	Delimiter string // single character or DelimiterWhitespace; empty = sniff
+	Quote     string // quote character; empty = '"'
+	Escape    string // escape character inside quoted fields; empty = doubled quote
+	Comment   string // line-comment prefix character; empty = sniff a leading '#' block
+	SkipLines int    // raw preamble lines dropped before anything else is read
+	NoHeader  bool   // first row is data; columns are named col1..colN
+	Encoding  string // one of TextEncodings; empty = sniff
+}
+
+// NormalizeDelimiter maps friendly --delimiter spellings (tab, \t, semicolon,
+// pipe, space) onto the separator they name. Unknown values pass through for
+// ValidateDelimiter to judge.
+func NormalizeDelimiter(s string) string {
+	switch strings.ToLower(s) {
+	case "comma":
+		return ","
+	case "semicolon":
+		return ";"
+	case "tab", `\t`:
+		return "\t"
+	case "pipe":
+		return "|"
+	case "space", "spaces", "whitespace", "ws":
+		return DelimiterWhitespace
+	}
+	return s
+}
+
+// ValidateDelimiter accepts DelimiterWhitespace or one character that is not a
+// quote, newline or the Unicode replacement character.
+func ValidateDelimiter(s string) error {
+	if s == DelimiterWhitespace {
+		return nil
+	}
+	if err := ValidateDialectChar(s); err != nil {
+		return fmt.Errorf("delimiter %w", err)
+	}
+	if s == `"` {
+		return fmt.Errorf("delimiter cannot be the quote character")
+	}
+	return nil
+}
+
+// ValidateDialectChar checks a --quote/--escape/--comment value: exactly one
+// character, not a line break.
+func ValidateDialectChar(s string) error {
+	r, size := utf8.DecodeRuneInString(s)
+	if s == "" || size != len(s) || r == utf8.RuneError {
+		return fmt.Errorf("must be a single character, got %q", s)
+	}
+	if r == '\r' || r == '\n' {
+		return fmt.Errorf("cannot be a line break")
+	}
+	return nil
+}
+
+// ValidateEncoding accepts the empty string (sniff) or one of TextEncodings.
+func ValidateEncoding(s string) error {
+	if s == "" {
+		return nil
+	}
+	for _, e := range TextEncodings {
+		if s == e {
+			return nil
+		}
+	}
+	return fmt.Errorf("must be one of %s", strings.Join(TextEncodings, ", "))
+}
+
+// NormalizeEncoding lowercases an --encoding value and folds common aliases
+// (utf8, iso-8859-1, latin1) onto the canonical TextEncodings spelling.
+func NormalizeEncoding(s string) string {
+	switch e := strings.ToLower(strings.TrimSpace(s)); e {
+	case "utf8":
+		return "utf-8"
+	case "utf16":
+		return "utf-16"
+	case "utf16le":
+		return "utf-16le"
+	case "utf16be":
+		return "utf-16be"
+	case "latin1", "iso-8859-1", "iso8859-1":
+		return "latin-1"
+	default:
+		return e
+	}
+}
+
+// NewTextReader returns input decoded to UTF-8 with any byte-order mark
+// removed. A UTF-16 BOM decides the byte order over the utf-16le/be spelling.
+// See TextEncodings for how an empty encoding is sniffed.
+func NewTextReader(input io.Reader, encoding string) (io.Reader, error) {
+	if err := ValidateEncoding(encoding); err != nil {
+		return nil, fmt.Errorf("encoding %w", err)
+	}
+
+	br := bufio.NewReaderSize(input, 64*1024)
+	bom, _ := br.Peek(3)
+
+	switch {
+	case bytes.HasPrefix(bom, []byte{0xEF, 0xBB, 0xBF}) && (encoding == "" || encoding == "utf-8"):
+		_, _ = br.Discard(3)
+		return br, nil
+	case bytes.HasPrefix(bom, []byte{0xFF, 0xFE}) && (encoding == "" || strings.HasPrefix(encoding, "utf-16")):
+		_, _ = br.Discard(2)
+		return &utf16Reader{src: br}, nil
+	case bytes.HasPrefix(bom, []byte{0xFE, 0xFF}) && (encoding == "" || strings.HasPrefix(encoding, "utf-16")):
+		_, _ = br.Discard(2)
+		return &utf16Reader{src: br, bigEndian: true}, nil
+	}
+
+	switch encoding {
+	case "utf-16", "utf-16le":
+		return &utf16Reader{src: br}, nil
+	case "utf-16be":
+		return &utf16Reader{src: br, bigEndian: true}, nil
+	case "latin-1":
+		return &latin1Reader{src: br}, nil
+	case "":
+		prefix, _ := br.Peek(4096)
+		if !validUTF8Prefix(prefix) {
+			return &latin1Reader{src: br}, nil
+		}
+	}
+	return br, nil
+}
+
+// validUTF8Prefix is utf8.Valid that tolerates a rune cut off by the end of
+// the peeked window.
+func validUTF8Prefix(b []byte) bool {
+	for i := 0; i < utf8.UTFMax && len(b) > 0; i++ {
+		if utf8.Valid(b) {
+			return true
+		}
+		b = b[:len(b)-1]
+	}
+	return utf8.Valid(b)
+}
+
+// utf16Reader transcodes a UTF-16 byte stream to UTF-8.
+type utf16Reader struct {
+	src       *bufio.Reader
+	bigEndian bool
+	out       []byte
+	err       error
+}
+
+func (r *utf16Reader) unit() (uint16, error) {
+	var b [2]byte
+	if _, err := io.ReadFull(r.src, b[:]); err != nil {
+		if err == io.ErrUnexpectedEOF {
+			return 0, fmt.Errorf("utf-16 input has an odd number of bytes")
+		}
+		return 0, err
+	}
+	if r.bigEndian {
+		return uint16(b[0])<<8 | uint16(b[1]), nil
+	}
+	return uint16(b[1])<<8 | uint16(b[0]), nil
+}
+
+func (r *utf16Reader) Read(p []byte) (int, error) {
+	for len(r.out) < len(p) && r.err == nil {
+		u, err := r.unit()
+		if err != nil {
+			r.err = err
+			break
+		}
+		ch := rune(u)
+		if utf16.IsSurrogate(ch) {
+			lo, err := r.unit()
+			if err != nil {
+				r.err = err
+				break
+			}
+			ch = utf16.DecodeRune(ch, rune(lo))
+		}
+		r.out = utf8.AppendRune(r.out, ch)
+	}
+	if len(r.out) == 0 {
+		return 0, r.err
+	}
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
+
+// latin1Reader transcodes ISO-8859-1 bytes (one byte per code point) to UTF-8.
+type latin1Reader struct {
+	src *bufio.Reader
+	out []byte
+}
+
+func (r *latin1Reader) Read(p []byte) (int, error) {
+	for len(r.out) < len(p) {
+		b, err := r.src.ReadByte()
+		if err != nil {
+			if len(r.out) == 0 {
+				return 0, err
+			}
+			break
+		}
+		r.out = utf8.AppendRune(r.out, rune(b))
+	}
+	n := copy(p, r.out)
+	r.out = r.out[n:]
+	return n, nil
+}
+
+// SniffDelimiter picks the separator used by the sampled lines. A candidate
+// qualifies when it occurs (outside quotes) on the first line and the same
+// number of times on at least 80% of the lines; the most frequent qualifying
+// candidate wins. With no qualifying candidate, lines that consistently split
+// into two or more whitespace-separated fields yield DelimiterWhitespace.
+// ok is false when nothing fits (callers fall back to a comma).
+func SniffDelimiter(lines []string, quote rune) (delim string, ok bool) {
+	if len(lines) == 0 {
+		return ",", false
+	}
+
+	best, bestCount := "", 0
+	for _, cand := range delimiterCandidates {
+		sep, _ := utf8.DecodeRuneInString(cand)
+		first := countOutsideQuotes(lines[0], sep, quote)
+		if first == 0 || first <= bestCount {
+			continue
+		}
+		matches := 0
+		for _, line := range lines {
+			if countOutsideQuotes(line, sep, quote) == first {
+				matches++
+			}
+		}
+		if matches*5 >= len(lines)*4 {
+			best, bestCount = cand, first
+		}
+	}
+	if best != "" {
+		return best, true
+	}
+
+	first := len(strings.Fields(lines[0]))
+	if first >= 2 {
+		matches := 0
+		for _, line := range lines {
+			if len(strings.Fields(line)) == first {
+				matches++
+			}
+		}
+		if matches*5 >= len(lines)*4 {
+			return DelimiterWhitespace, true
+		}
+	}
+	return ",", false
+}
+
+func countOutsideQuotes(line string, sep, quote rune) int {
+	n, quoted := 0, false
+	for _, r := range line {
+		switch {
+		case r == quote:
+			quoted = !quoted
+		case r == sep && !quoted:
+			n++
+		}
+	}
+	return n
+}
+
+// DelimiterLabel renders a delimiter for messages ("tab" instead of a raw \t).
+func DelimiterLabel(d string) string {
+	switch d {
+	case "\t":
+		return "tab"
+	case " ":
+		return "space"
+	case "":
+		return ","
+	}
+	return d
+}
+
+// LogAutoDelimiter prints a sniffed non-comma delimiter, mirroring
+// LogAutoGroup, so a semicolon or TSV file never changes meaning silently.
+func LogAutoDelimiter(delim string) {
+	if delim == "" || delim == "," {
+		return
+	}
+	cliout.InfoPair("Auto-detected delimiter", DelimiterLabel(delim))
+}
@@ -95,18 +95,27 @@ func ParseGoBenchmark(input io.Reader, cfg parser.Config) ([]shared.DataPoint, p
 			switch value.Unit {
 			case "sec/op":
 				benchStat = shared.Stat{
-					Type:  utils.CreateStatType("Execution Time", cfg.TimeUnit, "op"),
-					Value: shared.F64(utils.FormatTime(value.OrigValue, cfg.TimeUnit, cfg.Round)),
+					Type:   utils.CreateStatType("Execution Time", cfg.TimeUnit, "op"),
+					Unit:   cfg.TimeUnit,
+					Per:    "op",
+					Better: shared.DirectionLower,
+					Value:  shared.F64(utils.FormatTime(value.OrigValue, cfg.TimeUnit, cfg.Round)),
 				}
 			case "B/op":
 				benchStat = shared.Stat{
-					Type:  utils.CreateStatType("Memory Usage", cfg.MemUnit, "op"),
-					Value: shared.F64(utils.FormatMem(value.Value, cfg.MemUnit, cfg.Round)),
+					Type:   utils.CreateStatType("Memory Usage", cfg.MemUnit, "op"),
+					Unit:   cfg.MemUnit,
+					Per:    "op",
+					Better: shared.DirectionLower,
+					Value:  shared.F64(utils.FormatMem(value.Value, cfg.MemUnit, cfg.Round)),
 				}
 			case "allocs/op":
 				benchStat = shared.Stat{
-					Type:  utils.CreateStatType("Allocations", cfg.NumberUnit, "op"),
-					Value: shared.F64(utils.FormatNumber(value.Value, cfg.NumberUnit, cfg.Round)),
+					Type:   utils.CreateStatType("Allocations", cfg.NumberUnit, "op"),
+					Unit:   cfg.NumberUnit,
+					Per:    "op",
+					Better: shared.DirectionLower,
+					Value:  shared.F64(utils.FormatNumber(value.Value, cfg.NumberUnit, cfg.Round)),
 				}
 			case "B/s", "MB/s", "GB/s":
 				val, unit := value.OrigValue, value.OrigUnit
@@ -115,9 +124,13 @@ func ParseGoBenchmark(input io.Reader, cfg parser.Config) ([]shared.DataPoint, p
 					val, unit = value.Value, value.Unit
 				}
 
+				statUnit, per, _ := strings.Cut(unit, "/")
 				benchStat = shared.Stat{
-					Type:  utils.CreateStatType("Throughput", unit, ""),
-					Value: shared.F64(utils.FormatNumber(val, "", cfg.Round)),
+					Type:   utils.CreateStatType("Throughput", unit, ""),
+					Unit:   statUnit,
+					Per:    per,
+					Better: shared.DirectionForUnit(statUnit, per),
+					Value:  shared.F64(utils.FormatNumber(val, "", cfg.Round)),
 				}
 			default:
 				customType := "Metric"
@@ -126,9 +139,13 @@ func ParseGoBenchmark(input io.Reader, cfg parser.Config) ([]shared.DataPoint, p
 					customType = "Throughput"
 				}
 
+				statUnit, per, _ := strings.Cut(value.Unit, "/")
 				benchStat = shared.Stat{
-					Type:  utils.CreateStatType(customType, value.Unit, ""),
-					Value: shared.F64(utils.FormatNumber(value.Value, "", cfg.Round)),
+					Type:   utils.CreateStatType(customType, value.Unit, ""),
+					Unit:   statUnit,
+					Per:    per,
+					Better: shared.DirectionForUnit(statUnit, per),
+					Value:  shared.F64(utils.FormatNumber(value.Value, "", cfg.Round)),
 				}
 			}
 
@@ -164,6 +181,7 @@ func ParseGoBenchmark(input io.Reader, cfg parser.Config) ([]shared.DataPoint, p
 		for i := range results {
 			results[i].Stats = append(results[i].Stats, shared.Stat{
 				Type:  utils.CreateStatType("Iterations", cfg.NumberUnit, ""),
+				Unit:  cfg.NumberUnit,
 				Value: shared.F64(utils.FormatNumber(float64(allIters[i]), cfg.NumberUnit, cfg.Round)),
 			})
 		}
@@ -173,7 +191,7 @@ func ParseGoBenchmark(input io.Reader, cfg parser.Config) ([]shared.DataPoint, p
 		system.CPU = &shared.CPUInfo{Name: cpuName, Cores: cpuCount}
 	}
 	var systemOutput *shared.Meta
-	if system != (shared.Meta{}) {
+	if system.CPU != nil || system.OS != "" || system.Arch != "" || system.Pkg != "" {
 		systemOutput = &system
 	}
 	return results, cfg, systemOutput, nil
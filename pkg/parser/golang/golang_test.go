@@ -434,6 +434,26 @@ func (s *GoBenchmarkSuite) TestParseGoBenchmarkJSONEventErrors() {
 	})
 }
 
+func (s *GoBenchmarkSuite) TestParseGoBenchmarkSetsStatUnits() {
+	content := "BenchmarkUnits 100 1500 ns/op 64 B/op 2 allocs/op 12.5 MB/s 3 hits"
+	cfg := parser.Config{GroupPattern: "y", TimeUnit: "us", MemUnit: "KB"}
+	results, _, _, err := ParseGoBenchmark(strings.NewReader(content), cfg)
+	s.Require().NoError(err)
+	s.Require().Len(results, 1)
+
+	var units [][4]string
+	for _, st := range results[0].Stats {
+		units = append(units, [4]string{st.Type, st.Unit, st.Per, string(st.Better)})
+	}
+	s.Equal([][4]string{
+		{"Execution Time (us/op)", "us", "op", "lower"},
+		{"Memory Usage (KB/op)", "KB", "op", "lower"},
+		{"Allocations/op", "", "op", "lower"},
+		{"Throughput (MB/s)", "MB", "s", "higher"},
+		{"Metric (hits)", "hits", "", ""},
+	}, units)
+}
+
 func TestGoBenchmarkSuite(t *testing.T) {
 	suite.Run(t, new(GoBenchmarkSuite))
 }
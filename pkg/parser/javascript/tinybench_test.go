@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/goptics/vizb/pkg/parser"
+	"github.com/goptics/vizb/shared"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -62,6 +63,27 @@ func (s *TinyBenchSuite) TestRealTinybenchOutput() {
 	assertStat(s.T(), last.Stats[8], "Samples", 1950, "")
 }
 
+func (s *TinyBenchSuite) TestStatDirections() {
+	results, _, _, err := ParseTinyBenchBenchmark(javascriptTestInput(s.T(), testSortingTable), s.cfg)
+	s.Require().NoError(err)
+
+	better := map[string]shared.Direction{}
+	for _, st := range results[0].Stats {
+		better[st.Type] = st.Better
+	}
+	s.Equal(map[string]shared.Direction{
+		"Latency avg (ns)":       shared.DirectionLower,
+		"Latency RME (%)":        shared.DirectionLower,
+		"Latency med (ns)":       shared.DirectionLower,
+		"Latency MAD (ns)":       shared.DirectionLower,
+		"Throughput avg (ops/s)": shared.DirectionHigher,
+		"Throughput RME (%)":     shared.DirectionLower,
+		"Throughput med (ops/s)": shared.DirectionHigher,
+		"Throughput MAD (ops/s)": shared.DirectionLower,
+		"Samples":                "",
+	}, better, "spreads improve downward, whatever their unit")
+}
+
 func (s *TinyBenchSuite) TestUnitConversionToUs() {
 	s.cfg.TimeUnit = "us"
 
@@ -0,0 +1,98 @@
+package json
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+
+	"github.com/goptics/vizb/pkg/parser"
+	"github.com/goptics/vizb/shared"
+)
+
+// OrderedObject is a decoded mapping that remembers its key order. Documents
+// decoded outside encoding/json (YAML, TOML) build it so flattened columns keep
+// their source order instead of Go's map order.
+type OrderedObject struct {
+	Keys   []string
+	Values map[string]any
+}
+
+// NewOrderedObject returns an empty OrderedObject ready for Set.
+func NewOrderedObject() OrderedObject {
+	return OrderedObject{Values: map[string]any{}}
+}
+
+// Set stores v under key, appending key on first use (last value wins).
+func (o *OrderedObject) Set(key string, v any) {
+	if _, ok := o.Values[key]; !ok {
+		o.Keys = append(o.Keys, key)
+	}
+	o.Values[key] = v
+}
+
+// MarshalJSON writes the object with its keys in insertion order.
+func (o OrderedObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, k := range o.Keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		val, err := json.Marshal(finite(o.Values[k]))
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(key)
+		buf.WriteByte(':')
+		buf.Write(val)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// finite replaces NaN/Inf (legal in YAML and TOML, not in JSON) with null so
+// the field is skipped like any other non-numeric value.
+func finite(v any) any {
+	switch t := v.(type) {
+	case float64:
+		if math.IsNaN(t) || math.IsInf(t, 0) {
+			return nil
+		}
+	case []any:
+		out := make([]any, len(t))
+		for i, e := range t {
+			out[i] = finite(e)
+		}
+		return out
+	}
+	return v
+}
+
+// ParseDocument charts an already-decoded document with the json parser's
+// rules. root is built from OrderedObject, []any and scalars; cfg.JSONPath
+// selects rows from it exactly like --json-path does for JSON files. Without a
+// path the root must be an array of objects, a matrix array, or a single
+// object (one row).
+func ParseDocument(root any, cfg parser.Config) ([]shared.DataPoint, parser.Config, *shared.Meta, error) {
+	if root == nil {
+		return nil, cfg, nil, nil
+	}
+	steps, err := parsePath(cfg.JSONPath)
+	if err != nil {
+		return nil, cfg, nil, err
+	}
+
+	raw, err := selectRows(root, steps, "", cfg.JSONPath, cfg.JSONPathKeys)
+	if err != nil {
+		if cfg.JSONPath == "" {
+			err = fmt.Errorf("document is a scalar, not an array or object")
+		}
+		return nil, cfg, nil, err
+	}
+	return ParseJSON(bytes.NewReader(raw), cfg)
+}
@@ -1,6 +1,7 @@
 package json
 
 import (
+	"bufio"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -59,7 +60,8 @@ func stringify(v any) string {
 	return ""
 }
 
-// ParseJSON turns a JSON array of objects or arrays into benchmark data. Each
+// ParseJSON turns a JSON array of objects or arrays, or JSON Lines (one object
+// per line), into benchmark data. Each
 // numeric field becomes a chart series (field name = Stat.Type); non-numeric
 // fields are ignored unless named in --group/-g, whose values are joined with
 // the separators from --group-pattern/-p and routed through the grouping
@@ -77,10 +79,19 @@ func parseReader(input io.Reader, cfg parser.Config, logAuto bool) ([]shared.Dat
 		return nil, cfg, err
 	}
 
-	dec := json.NewDecoder(input)
+	br := bufio.NewReader(input)
+	if first, _ := peekNonSpace(br); first == '{' {
+		rows, colOrder, seenCol, ok, err := decodeLines(br, cfg.Progress, logAuto)
+		if err != nil || !ok {
+			return nil, cfg, err
+		}
+		return chartRows(rows, colOrder, seenCol, cfg, logAuto)
+	}
+
+	dec := json.NewDecoder(br)
 
-	// Top-level must be a JSON array; otherwise unsupported (object form is
-	// consumed earlier by convertToBenchmark).
+	// Top-level must be a JSON array or JSON Lines; a single object is
+	// unsupported (the object form is consumed earlier by convertToBenchmark).
 	tok, err := dec.Token()
 	if err != nil {
 		if errors.Is(err, io.EOF) {
@@ -97,10 +108,34 @@ func parseReader(input io.Reader, cfg parser.Config, logAuto bool) ([]shared.Dat
 		return nil, cfg, fmt.Errorf("read JSON: %w", err)
 	}
 
+	return chartRows(rows, colOrder, seenCol, cfg, logAuto)
+}
+
+// chartRows turns decoded, flattened rows into data points: auto-grouping,
+// --select/axis modes, then one point per row with a stat per numeric field.
+func chartRows(rows []map[string]any, colOrder []string, seenCol map[string]bool, cfg parser.Config, logAuto bool) ([]shared.DataPoint, parser.Config, error) {
+	var err error
 	if len(rows) == 0 {
 		return nil, cfg, nil
 	}
 
+	// --json-path-keys columns are dimensions: without other grouping they
+	// fill x, y and z in path order, and they never chart as stats.
+	var keyCols []string
+	if cfg.JSONPathKeys {
+		keyCols = keyColumnNames(cfg.JSONPath)
+	}
+	if len(keyCols) > 0 && !parser.HasSelect(cfg) && parser.AutoGroupApplies(cfg) {
+		cfg.Group = keyCols[:min(len(keyCols), 3)]
+		cfg.GroupPattern = strings.Join([]string{"x", "y", "z"}[:len(cfg.Group)], ",")
+		if cfg, err = parser.FinalizeGroupConfig(cfg); err != nil {
+			return nil, cfg, err
+		}
+		if logAuto {
+			parser.LogAutoGroup(cfg.Group)
+		}
+	}
+
 	// Auto-group: when no grouping is configured, infer the category axis from
 	// the data so `vizb data.json` produces a usable chart without -g/-p/-r/-x.
 	if !parser.HasSelect(cfg) && parser.AutoGroupApplies(cfg) {
@@ -145,6 +180,9 @@ func parseReader(input io.Reader, cfg parser.Config, logAuto bool) ([]shared.Dat
 		return nil, cfg, err
 	}
 
+	for _, k := range keyCols {
+		groupSet[k] = true
+	}
 	chartCols := chartColumns(colOrder, groupSet, rows)
 	var fieldLabels map[string]string
 	if len(cfg.Select) > 0 {
@@ -199,6 +237,7 @@ func parseReader(input io.Reader, cfg parser.Config, logAuto bool) ([]shared.Dat
 			}
 			stats = append(stats, shared.Stat{
 				Type:  utils.CreateStatType(label, cfg.NumberUnit, ""),
+				Unit:  cfg.NumberUnit,
 				Value: shared.F64(utils.FormatNumber(num, cfg.NumberUnit, cfg.Round)),
 			})
 		}
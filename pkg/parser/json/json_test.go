@@ -1,6 +1,7 @@
 package json
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"math"
@@ -508,6 +509,91 @@ func (s *JSONSuite) TestNonArrayInputReturnsNil() {
 	s.Nil(pts)
 }
 
+func (s *JSONSuite) TestJSONLinesRowsFlattenedLikeArrayElements() {
+	s.cfg.Group = []string{"name"}
+	j := `{"name":"alpha","sells":10,"mem":{"alloc":5}}` + "\n\n" +
+		`{"name":"beta","sells":20,"mem":{"alloc":7},"tags":[1,2]}` + "\r\n"
+
+	results, _ := mustParseJSONFile(s.T(), s.writeFile(j), s.cfg)
+
+	s.Require().Len(results, 2)
+	s.Equal("alpha", results[0].XAxis)
+	s.Equal("beta", results[1].XAxis)
+	s.Equal([]string{"sells", "mem.alloc"}, statTypes(results[1].Stats))
+	s.Equal(7.0, *results[1].Stats[1].Value)
+}
+
+func (s *JSONSuite) TestJSONLinesSkipsMalformedLines() {
+	s.cfg.Group = []string{"name"}
+	s.cfg.QuietAutoDetect = true
+	j := strings.Join([]string{
+		`{"name":"alpha","sells":10}`,
+		`{"name":"broken",`,
+		`[1,2,3]`,
+		`42`,
+		`{"name":"twice","sells":1} {"name":"x","sells":2}`,
+		`{"name":"beta","sells":20}`,
+	}, "\n")
+
+	results, _ := mustParseJSONFile(s.T(), s.writeFile(j), s.cfg)
+
+	s.Require().Len(results, 2)
+	s.Equal("alpha", results[0].XAxis)
+	s.Equal("beta", results[1].XAxis)
+}
+
+func (s *JSONSuite) TestJSONPathKeysAreDimensionsNotStats() {
+	s.cfg.AutoGroup = true
+	s.cfg.QuietAutoDetect = true
+	s.cfg.JSONPath = ".platforms[].runs[]"
+	s.cfg.JSONPathKeys = true
+	raw, err := SelectBytes([]byte(`{"platforms":{"linux":{"runs":[{"ms":5},{"ms":6}]},"darwin":{"runs":[{"ms":7}]}}}`), s.cfg.JSONPath, true)
+	s.Require().NoError(err)
+
+	results, cfg, _, err := ParseJSON(bytes.NewReader(raw), s.cfg)
+	s.Require().NoError(err)
+	s.Equal([]string{"platforms", "runs"}, cfg.Group)
+	s.Require().Len(results, 3)
+	s.Equal([]string{"linux", "1"}, []string{results[1].XAxis, results[1].YAxis})
+	s.Equal([]string{"ms"}, statTypes(results[1].Stats))
+
+	s.cfg.Group = []string{"platforms"}
+	results, _, _, err = ParseJSON(bytes.NewReader(raw), s.cfg)
+	s.Require().NoError(err)
+	s.Equal([]string{"ms"}, statTypes(results[0].Stats), "an explicit group still keeps runs out of the stats")
+}
+
+func (s *JSONSuite) TestJSONLinesSkipsMalformedFirstLine() {
+	s.cfg.Group = []string{"name"}
+	s.cfg.QuietAutoDetect = true
+	j := `{"name":"broken","sells":}` + "\n" + `{"name":"alpha","sells":10}` + "\n" + `{"name":"beta","sells":20}`
+
+	results, _ := mustParseJSONFile(s.T(), s.writeFile(j), s.cfg)
+
+	s.Require().Len(results, 2)
+	s.Equal("alpha", results[0].XAxis)
+	s.Equal("beta", results[1].XAxis)
+}
+
+func (s *JSONSuite) TestPrettyPrintedObjectIsNotJSONLines() {
+	pts, _ := mustParseJSONFile(s.T(), s.writeFile("{\n  \"name\": \"a\",\n  \"sells\": 10\n}\n"), s.cfg)
+	s.Nil(pts)
+	pts, _ = mustParseJSONFile(s.T(), s.writeFile("{\"runs\": [\n  {\"sells\": 10}\n]}\n"), s.cfg)
+	s.Nil(pts)
+}
+
+func (s *JSONSuite) TestJSONLinesReportsProgress() {
+	var rows int
+	var bytes int64
+	s.cfg.Progress = func(r int, b int64) { rows, bytes = r, b }
+	j := `{"sells":10}` + "\n" + `{"sells":20}` + "\n"
+
+	_, _ = mustParseJSONFile(s.T(), s.writeFile(j), s.cfg)
+
+	s.Equal(2, rows)
+	s.Equal(int64(len(j)), bytes)
+}
+
 func (s *JSONSuite) TestParseJSONReturnsResultsAndErrors() {
 	results, cfg, _, err := ParseJSON(strings.NewReader(`[{"name":"alpha","sells":10}]`), parser.Config{
 		GroupPattern: "x",
@@ -953,7 +1039,7 @@ func (s *JSONAutoValueSuite) TestNoHeaderMatrixFourNumericColumnsUsesMetric() {
 
 func (s *JSONAutoValueSuite) TestNestedMatrixViaJSONPathAutoValue() {
 	source := s.writeFile(`{"payload":{"rows":[[1,2],[3,4]]}}`)
-	selected, err := SelectPath(source, ".payload.rows")
+	selected, err := SelectPath(source, ".payload.rows", false)
 	s.Require().NoError(err)
 
 	results, _ := mustParseJSONFile(s.T(), s.writeFile(string(selected)), s.cfg)
@@ -0,0 +1,129 @@
+package json
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/goptics/vizb/pkg/cliout"
+	"github.com/goptics/vizb/pkg/parser"
+)
+
+// progressEvery is how many JSON Lines rows pass between progress reports.
+const progressEvery = 10000
+
+// errNotObject marks a JSON Lines row that is valid JSON but not an object.
+var errNotObject = errors.New("not a JSON object")
+
+// peekNonSpace returns the first non-whitespace byte without consuming it.
+func peekNonSpace(br *bufio.Reader) (byte, error) {
+	for n := 1; ; n++ {
+		buf, err := br.Peek(n)
+		if len(buf) < n {
+			return 0, err
+		}
+		switch c := buf[n-1]; c {
+		case ' ', '\t', '\r', '\n':
+		default:
+			return c, nil
+		}
+	}
+}
+
+// decodeLines reads JSON Lines: one object per line, flattened exactly like an
+// array element. Input is consumed line by line, so a malformed line is
+// skipped (and reported when logAuto is set) instead of aborting the file.
+//
+// ok is false when the input is not JSON Lines at all: the first line opens a
+// pretty-printed document, no line is a complete object, or there is only one
+// line (a single object, which stays the Dataset form). A malformed first line
+// is otherwise skipped like any other.
+func decodeLines(br *bufio.Reader, progress parser.ProgressFunc, logAuto bool) (rows []map[string]any, colOrder []string, seenCol map[string]bool, ok bool, err error) {
+	seenCol = map[string]bool{}
+
+	var (
+		lineNo, lines int
+		bytesRead     int64
+		skipped       int
+		firstBad      int
+		firstErr      error
+	)
+	for {
+		line, rerr := br.ReadBytes('\n')
+		bytesRead += int64(len(line))
+		if rerr != nil && !errors.Is(rerr, io.EOF) {
+			return nil, nil, nil, false, fmt.Errorf("read JSON: %w", rerr)
+		}
+		if len(line) > 0 {
+			lineNo++
+		}
+
+		if trimmed := bytes.TrimSpace(line); len(trimmed) > 0 {
+			lines++
+			leaves, derr := decodeLine(trimmed)
+			switch {
+			case derr != nil && lines == 1 && opensDocument(trimmed):
+				return nil, nil, nil, false, nil
+			case derr != nil:
+				skipped++
+				if firstErr == nil {
+					firstBad, firstErr = lineNo, derr
+				}
+			default:
+				rows = append(rows, leavesToRow(leaves, seenCol, &colOrder))
+				if progress != nil && len(rows)%progressEvery == 0 {
+					progress(len(rows), bytesRead)
+				}
+			}
+		}
+
+		if rerr != nil {
+			break
+		}
+	}
+
+	if lines < 2 || len(rows) == 0 {
+		return nil, nil, nil, false, nil
+	}
+	if progress != nil {
+		progress(len(rows), bytesRead)
+	}
+	if skipped > 0 && logAuto {
+		cliout.Warnf("Skipped %d malformed JSON Lines row(s); first at line %d: %v", skipped, firstBad, firstErr)
+	}
+
+	return rows, colOrder, seenCol, true, nil
+}
+
+// opensDocument reports whether a line that is not a complete object starts a
+// pretty-printed document instead: "{", or a first key whose value opens on
+// the next line.
+func opensDocument(line []byte) bool {
+	return bytes.HasSuffix(line, []byte("{")) || bytes.HasSuffix(line, []byte("["))
+}
+
+// decodeLine flattens one JSON Lines row. Anything but a single complete
+// object on the line is an error.
+func decodeLine(line []byte) ([]leaf, error) {
+	dec := json.NewDecoder(bytes.NewReader(line))
+
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '{' {
+		return nil, errNotObject
+	}
+
+	leaves, err := decodeObjectBody(dec, "")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("unexpected data after object")
+	}
+	return leaves, nil
+}
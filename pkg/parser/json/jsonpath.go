@@ -1,140 +1,257 @@
 package json
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"os"
-	"strconv"
-	"strings"
 )
 
-// SelectPath reads the JSON file, navigates to the node named by a jq-like dot
-// path, and returns it re-marshalled as a JSON array ready for ParseJSON.
+// SelectPath reads the JSON file, evaluates a --json-path expression (see
+// parsePath for the grammar) and returns the matched rows as a JSON array
+// ready for ParseJSON.
 //
-// Path grammar (a deliberate subset of jq): an optional leading '.', then
-// segments separated by '.'. A segment is an object key, optionally followed by
-// one or more '[n]' array indices; a bare trailing '[]' is identity sugar for
-// "the array itself". Examples: ".data.results", ".runs[0].samples", "results[]".
+// Each matched node contributes rows: an array contributes its elements, an
+// object is one row, and several matches are concatenated. A path whose only
+// match is a scalar is an error. With parentKeys, every wildcard step adds a
+// column holding the key or index the row came from, named after the key
+// before the wildcard ("runs" for .runs[]), "key" at the root, or "path" for
+// recursive descent.
 //
-// The resolved node is coerced to an array: an array is used as-is; a single
-// object is wrapped into a one-element array (so a path to one object still
-// charts); anything else is an error.
-//
-// ponytail: whole-file load is opt-in (--json-path only); the default JSON path
-// stays streaming. Upgrade to a streaming seek only if huge enveloped files
-// become a real case.
-func SelectPath(filename, path string) ([]byte, error) {
-	raw, err := os.ReadFile(filename)
+// The leading run of plain keys and indices is resolved by seeking through
+// the token stream, so siblings of the selected node are skipped rather than
+// unmarshalled; only the selected node is held in memory, and when nothing
+// follows the prefix its bytes are passed through untouched.
+func SelectPath(filename, path string, parentKeys bool) ([]byte, error) {
+	f, err := os.Open(filename)
 	if err != nil {
 		return nil, fmt.Errorf("error reading JSON: %w", err)
 	}
-	return SelectBytes(raw, path)
+	defer f.Close()
+	return selectReader(f, path, parentKeys)
 }
 
 // SelectBytes applies a json path to request-scoped JSON without reading from
 // the filesystem. It is the safe counterpart to SelectPath.
-func SelectBytes(raw []byte, path string) ([]byte, error) {
+func SelectBytes(raw []byte, path string, parentKeys bool) ([]byte, error) {
+	return selectReader(bytes.NewReader(raw), path, parentKeys)
+}
 
-	var root any
-	if err := json.Unmarshal(raw, &root); err != nil {
-		return nil, fmt.Errorf("error parsing JSON: %w", err)
+func selectReader(input io.Reader, path string, parentKeys bool) ([]byte, error) {
+	steps, err := parsePath(path)
+	if err != nil {
+		return nil, err
 	}
 
-	node, err := navigate(root, path)
-	if err != nil {
+	prefix := 0
+	for prefix < len(steps) && (steps[prefix].kind == stepKey || steps[prefix].kind == stepIndex && steps[prefix].index >= 0) {
+		prefix++
+	}
+
+	dec := json.NewDecoder(input)
+	dec.UseNumber()
+	if err := seek(dec, steps[:prefix]); err != nil {
 		return nil, err
 	}
 
-	switch v := node.(type) {
-	case []any:
-		return json.Marshal(v)
-	case map[string]any:
-		return json.Marshal([]any{v})
-	default:
+	if prefix == len(steps) {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return nil, fmt.Errorf("error parsing JSON: %w", err)
+		}
+		switch first(raw) {
+		case '[':
+			return raw, nil
+		case '{':
+			return append(append([]byte{'['}, raw...), ']'), nil
+		}
 		return nil, fmt.Errorf("--json-path '%s' resolves to a scalar, not an array or object", path)
 	}
+
+	node, err := decodeOrdered(dec)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing JSON: %w", err)
+	}
+	lastKey := ""
+	for _, st := range steps[:prefix] {
+		if st.kind == stepKey {
+			lastKey = st.key
+		}
+	}
+	return selectRows(node, steps[prefix:], lastKey, path, parentKeys)
 }
 
-// navigate walks node following the path segments.
-func navigate(node any, path string) (any, error) {
-	for _, seg := range tokenize(path) {
-		var err error
-		if node, err = step(node, seg); err != nil {
-			return nil, err
+// selectRows evaluates steps over an in-memory tree and marshals the matches
+// as one JSON array of rows.
+func selectRows(root any, steps []pathStep, lastKey, path string, parentKeys bool) ([]byte, error) {
+	nodes, err := evalPath(root, steps, lastKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("--json-path '%s' matched nothing", path)
+	}
+
+	rows := []any{}
+	for _, n := range nodes {
+		var keys []keyColumn
+		if parentKeys {
+			keys = n.keys
+		}
+		switch v := n.value.(type) {
+		case []any:
+			for _, e := range v {
+				rows = append(rows, withKeys(e, keys))
+			}
+		default:
+			if _, ok := asObject(v); ok {
+				rows = append(rows, withKeys(v, keys))
+			} else if len(nodes) == 1 {
+				return nil, fmt.Errorf("--json-path '%s' resolves to a scalar, not an array or object", path)
+			}
 		}
 	}
-	return node, nil
+	return json.Marshal(finite(rows))
 }
 
-// pathSeg is one key plus any array indices that follow it. A leading index
-// (key == "" with indices) handles a path that starts at an array, and the
-// "[]" identity sugar yields a key-less, index-less no-op.
-type pathSeg struct {
-	key     string
-	indices []int
+// withKeys prepends wildcard key columns to an object row. Names repeated by
+// nested wildcards get a numeric suffix; a field the row already has wins.
+func withKeys(row any, keys []keyColumn) any {
+	obj, ok := asObject(row)
+	if !ok || len(keys) == 0 {
+		return row
+	}
+
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = k.name
+	}
+	out := NewOrderedObject()
+	for i, name := range numberRepeats(names) {
+		out.Set(name, keys[i].value)
+	}
+	for _, k := range obj.Keys {
+		out.Set(k, obj.Values[k])
+	}
+	return out
 }
 
-// tokenize splits a dot path into segments. It is lenient: a leading '.' and a
-// trailing "[]" are stripped, and empty pieces are skipped.
-func tokenize(path string) []pathSeg {
-	path = strings.TrimSpace(path)
-	path = strings.TrimPrefix(path, ".")
+func first(raw []byte) byte {
+	raw = bytes.TrimSpace(raw)
+	if len(raw) == 0 {
+		return 0
+	}
+	return raw[0]
+}
 
-	var segs []pathSeg
-	for _, piece := range strings.Split(path, ".") {
-		if piece == "" {
-			continue
+// seek advances dec to the value named by a prefix of plain key and
+// non-negative index steps, skipping everything before it token by token.
+func seek(dec *json.Decoder, steps []pathStep) error {
+	for _, st := range steps {
+		tok, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("error parsing JSON: %w", err)
 		}
+		d, isDelim := tok.(json.Delim)
 
-		key := piece
-		var indices []int
-		if i := strings.IndexByte(piece, '['); i >= 0 {
-			key = piece[:i]
-			rest := piece[i:]
-			for rest != "" {
-				end := strings.IndexByte(rest, ']')
-				if end <= 1 { // "[]" identity or malformed → skip
-					rest = rest[min(end+1, len(rest)):]
-					continue
+		switch st.kind {
+		case stepKey:
+			if !isDelim || d != '{' {
+				return fmt.Errorf("--json-path: cannot read key '%s' from a non-object", st.key)
+			}
+			found := false
+			for dec.More() {
+				keyTok, err := dec.Token()
+				if err != nil {
+					return fmt.Errorf("error parsing JSON: %w", err)
+				}
+				if keyTok == st.key {
+					found = true
+					break
 				}
-				if n, err := strconv.Atoi(rest[1:end]); err == nil {
-					indices = append(indices, n)
+				if err := skipValue(dec); err != nil {
+					return fmt.Errorf("error parsing JSON: %w", err)
 				}
-				rest = rest[end+1:]
 			}
-		}
+			if !found {
+				return fmt.Errorf("--json-path: key '%s' not found", st.key)
+			}
 
-		if key == "" && len(indices) == 0 {
-			continue
+		case stepIndex:
+			if !isDelim || d != '[' {
+				return fmt.Errorf("--json-path: cannot index [%d] into a non-array", st.index)
+			}
+			n := 0
+			for ; n < st.index && dec.More(); n++ {
+				if err := skipValue(dec); err != nil {
+					return fmt.Errorf("error parsing JSON: %w", err)
+				}
+			}
+			if !dec.More() {
+				return fmt.Errorf("--json-path: index [%d] out of range (len %d)", st.index, n)
+			}
 		}
-		segs = append(segs, pathSeg{key: key, indices: indices})
 	}
-	return segs
+	return nil
 }
 
-// step applies a single segment (key lookup then any array indices).
-func step(node any, seg pathSeg) (any, error) {
-	if seg.key != "" {
-		obj, ok := node.(map[string]any)
-		if !ok {
-			return nil, fmt.Errorf("--json-path: cannot read key '%s' from a non-object", seg.key)
-		}
-		v, ok := obj[seg.key]
-		if !ok {
-			return nil, fmt.Errorf("--json-path: key '%s' not found", seg.key)
+// skipValue consumes one complete value.
+func skipValue(dec *json.Decoder) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); ok && (d == '{' || d == '[') {
+		return skipContainerBody(dec)
+	}
+	return nil
+}
+
+// decodeOrdered decodes the next value keeping object key order (objects
+// become OrderedObject, numbers json.Number).
+func decodeOrdered(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
 		}
-		node = v
+		return nil, err
 	}
 
-	for _, idx := range seg.indices {
-		arr, ok := node.([]any)
-		if !ok {
-			return nil, fmt.Errorf("--json-path: cannot index [%d] into a non-array", idx)
+	d, ok := tok.(json.Delim)
+	if !ok {
+		return tok, nil
+	}
+	switch d {
+	case '{':
+		obj := NewOrderedObject()
+		for dec.More() {
+			keyTok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			key, _ := keyTok.(string)
+			v, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			obj.Set(key, v)
 		}
-		if idx < 0 || idx >= len(arr) {
-			return nil, fmt.Errorf("--json-path: index [%d] out of range (len %d)", idx, len(arr))
+		_, err := dec.Token() // '}'
+		return obj, err
+	case '[':
+		arr := []any{}
+		for dec.More() {
+			v, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, v)
 		}
-		node = arr[idx]
+		_, err := dec.Token() // ']'
+		return arr, err
 	}
-	return node, nil
+	return nil, fmt.Errorf("unexpected %v", d)
 }
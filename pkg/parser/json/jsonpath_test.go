@@ -89,7 +89,7 @@ func (s *JSONPathSuite) TestSelectPath() {
 			f := filepath.Join(t.TempDir(), "in.json")
 			require.NoError(t, os.WriteFile(f, []byte(tc.input), 0o644))
 
-			got, err := SelectPath(f, tc.path)
+			got, err := SelectPath(f, tc.path, false)
 			if tc.wantErr != "" {
 				require.Error(t, err)
 				require.Contains(t, err.Error(), tc.wantErr)
@@ -103,32 +103,140 @@ func (s *JSONPathSuite) TestSelectPath() {
 
 func (s *JSONPathSuite) TestSelectPathReadAndParseErrors() {
 	t := s.T()
-	_, err := SelectPath(filepath.Join(t.TempDir(), "missing.json"), ".rows")
+	_, err := SelectPath(filepath.Join(t.TempDir(), "missing.json"), ".rows", false)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "error reading JSON")
 
 	f := filepath.Join(t.TempDir(), "bad.json")
 	require.NoError(t, os.WriteFile(f, []byte(`{"rows":`), 0o644))
-	_, err = SelectPath(f, ".rows")
+	_, err = SelectPath(f, ".rows", false)
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "error parsing JSON")
 }
 
-func (s *JSONPathSuite) TestTokenizeLenientMalformedSegments() {
+func (s *JSONPathSuite) TestJQStyleExpressions() {
 	t := s.T()
-	require.Empty(t, tokenize("[]"))
+	cases := []struct {
+		name  string
+		input string
+		path  string
+		keys  bool
+		want  string
+	}{
+		{
+			name:  "wildcard concatenates matched arrays",
+			input: `{"runs":[{"results":[{"n":1},{"n":2}]},{"results":[{"n":3}]}]}`,
+			path:  ".runs[].results",
+			want:  `[{"n":1},{"n":2},{"n":3}]`,
+		},
+		{
+			name:  "wildcard skips elements missing the key",
+			input: `{"runs":[{"results":[{"n":1}]},{"other":true},5]}`,
+			path:  ".runs[].results",
+			want:  `[{"n":1}]`,
+		},
+		{
+			name:  "recursive descent",
+			input: `{"a":{"samples":[{"v":1}]},"b":[{"c":{"samples":[{"v":2}]}}]}`,
+			path:  "..samples",
+			want:  `[{"v":1},{"v":2}]`,
+		},
+		{
+			name:  "slice with negative bound",
+			input: `{"rows":[{"i":0},{"i":1},{"i":2},{"i":3},{"i":4}]}`,
+			path:  ".rows[1:-1]",
+			want:  `[{"i":1},{"i":2},{"i":3}]`,
+		},
+		{
+			name:  "negative index",
+			input: `{"rows":[{"i":0},{"i":1}]}`,
+			path:  ".rows[-1]",
+			want:  `[{"i":1}]`,
+		},
+		{
+			name:  "object value iteration",
+			input: `{"linux":{"ms":5},"darwin":{"ms":7}}`,
+			path:  ".[]",
+			want:  `[{"ms":5},{"ms":7}]`,
+		},
+		{
+			name:  "select on string equality",
+			input: `{"runs":[{"status":"ok","ms":1},{"status":"fail","ms":9},{"status":"ok","ms":2}]}`,
+			path:  `.runs[] | select(.status == "ok")`,
+			want:  `[{"status":"ok","ms":1},{"status":"ok","ms":2}]`,
+		},
+		{
+			name:  "select on numeric comparison and nested path",
+			input: `[{"m":{"ms":5}},{"m":{"ms":50}},{"x":1}]`,
+			path:  `.[] | select(.m.ms >= 10)`,
+			want:  `[{"m":{"ms":50}}]`,
+		},
+		{
+			name:  "select truthiness then key",
+			input: `{"runs":[{"keep":true,"r":[{"n":1}]},{"keep":false,"r":[{"n":2}]}]}`,
+			path:  `.runs[] | select(.keep) | .r`,
+			want:  `[{"n":1}]`,
+		},
+		{
+			name:  "quoted and bracketed keys",
+			input: `{"a b":{"c.d":[{"n":1}]}}`,
+			path:  `."a b"["c.d"]`,
+			want:  `[{"n":1}]`,
+		},
+		{
+			name:  "parent keys injected for object and array wildcards",
+			input: `{"platforms":{"linux":{"runs":[{"ms":5}]},"darwin":{"runs":[{"ms":7},{"ms":8}]}}}`,
+			path:  ".platforms[].runs[]",
+			keys:  true,
+			want:  `[{"platforms":"linux","runs":"0","ms":5},{"platforms":"darwin","runs":"0","ms":7},{"platforms":"darwin","runs":"1","ms":8}]`,
+		},
+		{
+			name:  "parent keys keep object key order",
+			input: `{"zeta":{"v":1},"alpha":{"v":2}}`,
+			path:  ".[]",
+			keys:  true,
+			want:  `[{"key":"zeta","v":1},{"key":"alpha","v":2}]`,
+		},
+	}
 
-	gapped := tokenize(".rows..items")
-	require.Len(t, gapped, 2)
-	require.Equal(t, "rows", gapped[0].key)
-	require.Equal(t, "items", gapped[1].key)
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := SelectBytes([]byte(tc.input), tc.path, tc.keys)
+			require.NoError(t, err)
+			require.JSONEq(t, tc.want, string(got))
+		})
+	}
+}
+
+func (s *JSONPathSuite) TestParentKeysPreserveColumnOrder() {
+	got, err := SelectBytes([]byte(`{"x":{"zeta":1,"alpha":2}}`), ".[]", true)
+	s.Require().NoError(err)
+	s.Equal(`[{"key":"x","zeta":1,"alpha":2}]`, string(got))
+}
+
+func (s *JSONPathSuite) TestPlainPrefixPassesNodeThroughUntouched() {
+	got, err := SelectBytes([]byte(`{"skip":[1,2,{"deep":[]}],"data":{"rows":[{"b":1,"a":2}]}}`), ".data.rows", false)
+	s.Require().NoError(err)
+	s.Equal(`[{"b":1,"a":2}]`, string(got))
+}
 
-	got := tokenize(".rows[].items[x][1]")
-	require.Len(t, got, 2)
-	require.Equal(t, "rows", got[0].key)
-	require.Empty(t, got[0].indices)
-	require.Equal(t, "items", got[1].key)
-	require.Equal(t, []int{1}, got[1].indices)
+func (s *JSONPathSuite) TestPathSyntaxAndMatchErrors() {
+	cases := []struct {
+		path, input, wantErr string
+	}{
+		{".rows[x]", `{"rows":[]}`, "invalid index [x]"},
+		{".rows[1", `{"rows":[]}`, "unclosed '['"},
+		{`select(.a = 1)`, `[]`, "expected ')'"},
+		{`.[] | select(.a == bogus)`, `[]`, "JSON literal"},
+		{".rows ^", `{"rows":[]}`, "unexpected"},
+		{".runs[].missing", `{"runs":[{"a":1}]}`, "matched nothing"},
+		{".n[]", `{"n":5}`, "cannot iterate over a scalar"},
+	}
+	for _, tc := range cases {
+		_, err := SelectBytes([]byte(tc.input), tc.path, false)
+		s.Require().Error(err, tc.path)
+		s.Contains(err.Error(), tc.wantErr, tc.path)
+	}
 }
 
 func TestJSONPathSuite(t *testing.T) {
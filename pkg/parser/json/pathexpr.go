@@ -0,0 +1,592 @@
+package json
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// stepKind is one --json-path operation.
+type stepKind int
+
+const (
+	stepKey     stepKind = iota // .key, ."key", ["key"]
+	stepIndex                   // [n], negative counts from the end
+	stepSlice                   // [from:to], either bound optional
+	stepIterate                 // [] / .[]: array elements or object values
+	stepRecurse                 // ..: the node and every descendant
+	stepSelect                  // select(.path op literal)
+)
+
+// pathStep is one parsed --json-path step.
+type pathStep struct {
+	kind     stepKind
+	key      string
+	index    int
+	from, to *int
+	cond     *pathCond
+}
+
+// fansOut reports whether the step can yield more (or fewer) than one node,
+// which switches evaluation from strict to lenient.
+func (s pathStep) fansOut() bool {
+	return s.kind == stepSlice || s.kind == stepIterate || s.kind == stepRecurse || s.kind == stepSelect
+}
+
+// pathCond is a select() filter: a relative path compared against a JSON
+// literal. An empty op tests truthiness (anything but false and null).
+type pathCond struct {
+	path  []pathStep
+	op    string
+	value any
+}
+
+// parsePath parses the jq subset accepted by --json-path:
+//
+//	.a.b  ."odd key"  ["odd key"]   object keys (leading '.' optional)
+//	[n]  [-1]                       array index
+//	[2:5]  [:3]  [1:]               array slice
+//	[]  .[]                         iterate array elements / object values
+//	..                              recursive descent (..samples)
+//	select(.status == "ok")         filter; ==, !=, <, <=, >, >= or bare .path
+//	a | b                           pipe, same as a.b
+func parsePath(path string) ([]pathStep, error) {
+	p := pathParser{src: strings.TrimSpace(path)}
+	steps, err := p.steps(false)
+	if err != nil {
+		return nil, fmt.Errorf("--json-path '%s': %w", path, err)
+	}
+	return steps, nil
+}
+
+type pathParser struct {
+	src string
+	pos int
+}
+
+func (p *pathParser) peek(s string) bool { return strings.HasPrefix(p.src[p.pos:], s) }
+
+func (p *pathParser) skipSpace() {
+	for p.pos < len(p.src) && (p.src[p.pos] == ' ' || p.src[p.pos] == '\t') {
+		p.pos++
+	}
+}
+
+// steps parses until the end of input, or (inCond) until an operator or ')'.
+func (p *pathParser) steps(inCond bool) ([]pathStep, error) {
+	var out []pathStep
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.src) {
+			return out, nil
+		}
+		if inCond && (p.peek(")") || strings.ContainsAny(p.src[p.pos:p.pos+1], "=!<>")) {
+			return out, nil
+		}
+
+		switch {
+		case p.peek("|") && !inCond:
+			p.pos++
+		case p.peek("select("):
+			if inCond {
+				return nil, fmt.Errorf("nested select() at offset %d", p.pos)
+			}
+			p.pos += len("select(")
+			cond, err := p.cond()
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, pathStep{kind: stepSelect, cond: cond})
+		case p.peek(".."):
+			p.pos += 2
+			out = append(out, pathStep{kind: stepRecurse})
+			if key, ok, err := p.key(); err != nil {
+				return nil, err
+			} else if ok {
+				out = append(out, pathStep{kind: stepKey, key: key})
+			}
+		case p.peek("."):
+			p.pos++
+			if key, ok, err := p.key(); err != nil {
+				return nil, err
+			} else if ok {
+				out = append(out, pathStep{kind: stepKey, key: key})
+			}
+		case p.peek("["):
+			step, err := p.bracket()
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, step)
+		case len(out) == 0:
+			// A bare leading key ("rows") is accepted for convenience.
+			key, ok, err := p.key()
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				return nil, fmt.Errorf("unexpected %q at offset %d", p.src[p.pos:p.pos+1], p.pos)
+			}
+			out = append(out, pathStep{kind: stepKey, key: key})
+		default:
+			return nil, fmt.Errorf("unexpected %q at offset %d", p.src[p.pos:p.pos+1], p.pos)
+		}
+	}
+}
+
+// key reads an identifier or a double-quoted key at the cursor, if any.
+func (p *pathParser) key() (string, bool, error) {
+	if p.peek(`"`) {
+		s, err := p.quoted()
+		return s, err == nil, err
+	}
+	start := p.pos
+	for p.pos < len(p.src) {
+		c := p.src[p.pos]
+		if c == '_' || c == '-' || c == '$' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80 {
+			p.pos++
+			continue
+		}
+		break
+	}
+	return p.src[start:p.pos], p.pos > start, nil
+}
+
+// quoted reads a JSON string literal at the cursor.
+func (p *pathParser) quoted() (string, error) {
+	end := p.pos + 1
+	for end < len(p.src) && p.src[end] != '"' {
+		if p.src[end] == '\\' {
+			end++
+		}
+		end++
+	}
+	if end >= len(p.src) {
+		return "", fmt.Errorf("unterminated string at offset %d", p.pos)
+	}
+	var s string
+	if err := json.Unmarshal([]byte(p.src[p.pos:end+1]), &s); err != nil {
+		return "", fmt.Errorf("invalid string at offset %d", p.pos)
+	}
+	p.pos = end + 1
+	return s, nil
+}
+
+// bracket parses [], ["key"], [n] or [from:to].
+func (p *pathParser) bracket() (pathStep, error) {
+	start := p.pos
+	p.pos++ // '['
+	p.skipSpace()
+
+	if p.peek(`"`) {
+		key, err := p.quoted()
+		if err != nil {
+			return pathStep{}, err
+		}
+		p.skipSpace()
+		if !p.peek("]") {
+			return pathStep{}, fmt.Errorf("expected ']' at offset %d", p.pos)
+		}
+		p.pos++
+		return pathStep{kind: stepKey, key: key}, nil
+	}
+
+	end := strings.IndexByte(p.src[p.pos:], ']')
+	if end < 0 {
+		return pathStep{}, fmt.Errorf("unclosed '[' at offset %d", start)
+	}
+	body := strings.TrimSpace(p.src[p.pos : p.pos+end])
+	p.pos += end + 1
+
+	if body == "" {
+		return pathStep{kind: stepIterate}, nil
+	}
+	if lo, hi, ok := strings.Cut(body, ":"); ok {
+		from, err := sliceBound(lo)
+		if err != nil {
+			return pathStep{}, fmt.Errorf("invalid slice [%s]", body)
+		}
+		to, err := sliceBound(hi)
+		if err != nil {
+			return pathStep{}, fmt.Errorf("invalid slice [%s]", body)
+		}
+		return pathStep{kind: stepSlice, from: from, to: to}, nil
+	}
+	n, err := strconv.Atoi(body)
+	if err != nil {
+		return pathStep{}, fmt.Errorf("invalid index [%s]", body)
+	}
+	return pathStep{kind: stepIndex, index: n}, nil
+}
+
+func sliceBound(s string) (*int, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return nil, err
+	}
+	return &n, nil
+}
+
+// cond parses the body of select( ... ) including the closing ')'.
+func (p *pathParser) cond() (*pathCond, error) {
+	p.skipSpace()
+	if !p.peek(".") {
+		return nil, fmt.Errorf("select() must start with a '.' path at offset %d", p.pos)
+	}
+	path, err := p.steps(true)
+	if err != nil {
+		return nil, err
+	}
+	c := &pathCond{path: path}
+
+	p.skipSpace()
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.peek(op) {
+			c.op = op
+			p.pos += len(op)
+			break
+		}
+	}
+	if c.op != "" {
+		p.skipSpace()
+		end := p.pos
+		for quoted := false; end < len(p.src) && (quoted || p.src[end] != ')'); end++ {
+			switch p.src[end] {
+			case '\\':
+				end++
+			case '"':
+				quoted = !quoted
+			}
+		}
+		lit := strings.TrimSpace(p.src[p.pos:min(end, len(p.src))])
+		dec := json.NewDecoder(strings.NewReader(lit))
+		dec.UseNumber()
+		if err := dec.Decode(&c.value); err != nil || dec.More() {
+			return nil, fmt.Errorf("select() needs a JSON literal after %s, got %q", c.op, lit)
+		}
+		p.pos = end
+	}
+
+	p.skipSpace()
+	if !p.peek(")") {
+		return nil, fmt.Errorf("expected ')' at offset %d", p.pos)
+	}
+	p.pos++
+	return c, nil
+}
+
+// pathNode is one evaluation result plus the wildcard keys that led to it.
+type pathNode struct {
+	value any
+	keys  []keyColumn
+}
+
+// keyColumn records which key (object iteration) or index (array iteration)
+// a wildcard step took; it becomes a column with --json-path-keys. Indices are
+// strings too: they label a position, they are not a measurement.
+type keyColumn struct {
+	name  string
+	value string
+}
+
+// keyColumnNames returns the --json-path-keys columns path adds to each row,
+// in order: one per wildcard, named as evalPath names them, with repeats
+// numbered as withKeys numbers them.
+func keyColumnNames(path string) []string {
+	steps, err := parsePath(path)
+	if err != nil {
+		return nil
+	}
+	var names []string
+	lastKey := ""
+	for _, st := range steps {
+		switch st.kind {
+		case stepKey:
+			lastKey = st.key
+		case stepSlice, stepIterate:
+			names = append(names, cmp.Or(lastKey, "key"))
+		case stepRecurse:
+			names = append(names, "path")
+		}
+	}
+	return numberRepeats(names)
+}
+
+// numberRepeats suffixes the second and later uses of a column name with
+// their count: runs, runs2, runs3.
+func numberRepeats(names []string) []string {
+	out := make([]string, len(names))
+	seen := map[string]int{}
+	for i, name := range names {
+		out[i] = name
+		if seen[name]++; seen[name] > 1 {
+			out[i] = fmt.Sprintf("%s%d", name, seen[name])
+		}
+	}
+	return out
+}
+
+// evalPath runs steps over root. Until the first wildcard the walk is strict:
+// a missing key, wrong type or bad index is an error naming the segment. After
+// a wildcard, branches that do not match are dropped silently, as in jq's `?`.
+// lastKey is the key that led to root (when a prefix was already resolved); it
+// names the column of a wildcard applied directly to root.
+func evalPath(root any, steps []pathStep, lastKey string) ([]pathNode, error) {
+	nodes := []pathNode{{value: root}}
+	strict := true
+
+	for _, st := range steps {
+		var next []pathNode
+		for _, n := range nodes {
+			out, err := applyStep(n, st, lastKey, strict)
+			if err != nil {
+				return nil, err
+			}
+			next = append(next, out...)
+		}
+		nodes = next
+		if st.fansOut() {
+			strict = false
+		}
+		if st.kind == stepKey {
+			lastKey = st.key
+		}
+	}
+	return nodes, nil
+}
+
+func applyStep(n pathNode, st pathStep, lastKey string, strict bool) ([]pathNode, error) {
+	child := func(v any, name, key string) pathNode {
+		keys := n.keys
+		if name != "" {
+			keys = append(append([]keyColumn(nil), n.keys...), keyColumn{name: name, value: key})
+		}
+		return pathNode{value: v, keys: keys}
+	}
+	colName := cmp.Or(lastKey, "key")
+
+	switch st.kind {
+	case stepKey:
+		obj, ok := asObject(n.value)
+		if !ok {
+			if strict {
+				return nil, fmt.Errorf("--json-path: cannot read key '%s' from a non-object", st.key)
+			}
+			return nil, nil
+		}
+		v, ok := obj.Values[st.key]
+		if !ok {
+			if strict {
+				return nil, fmt.Errorf("--json-path: key '%s' not found", st.key)
+			}
+			return nil, nil
+		}
+		return []pathNode{child(v, "", "")}, nil
+
+	case stepIndex:
+		arr, ok := n.value.([]any)
+		if !ok {
+			if strict {
+				return nil, fmt.Errorf("--json-path: cannot index [%d] into a non-array", st.index)
+			}
+			return nil, nil
+		}
+		i := st.index
+		if i < 0 {
+			i += len(arr)
+		}
+		if i < 0 || i >= len(arr) {
+			if strict {
+				return nil, fmt.Errorf("--json-path: index [%d] out of range (len %d)", st.index, len(arr))
+			}
+			return nil, nil
+		}
+		return []pathNode{child(arr[i], "", "")}, nil
+
+	case stepSlice:
+		arr, ok := n.value.([]any)
+		if !ok {
+			if strict {
+				return nil, fmt.Errorf("--json-path: cannot slice a non-array")
+			}
+			return nil, nil
+		}
+		from, to := sliceRange(len(arr), st.from, st.to)
+		out := make([]pathNode, 0, to-from)
+		for i := from; i < to; i++ {
+			out = append(out, child(arr[i], colName, strconv.Itoa(i)))
+		}
+		return out, nil
+
+	case stepIterate:
+		switch v := n.value.(type) {
+		case []any:
+			out := make([]pathNode, len(v))
+			for i, e := range v {
+				out[i] = child(e, colName, strconv.Itoa(i))
+			}
+			return out, nil
+		default:
+			if obj, ok := asObject(v); ok {
+				out := make([]pathNode, len(obj.Keys))
+				for i, k := range obj.Keys {
+					out[i] = child(obj.Values[k], colName, k)
+				}
+				return out, nil
+			}
+		}
+		if strict {
+			return nil, fmt.Errorf("--json-path: cannot iterate over a scalar")
+		}
+		return nil, nil
+
+	case stepRecurse:
+		var out []pathNode
+		var walk func(v any, at string)
+		walk = func(v any, at string) {
+			out = append(out, child(v, "path", at))
+			switch t := v.(type) {
+			case []any:
+				for i, e := range t {
+					walk(e, fmt.Sprintf("%s[%d]", at, i))
+				}
+			default:
+				if obj, ok := asObject(t); ok {
+					for _, k := range obj.Keys {
+						walk(obj.Values[k], strings.TrimPrefix(at+"."+k, "."))
+					}
+				}
+			}
+		}
+		walk(n.value, "")
+		return out, nil
+
+	case stepSelect:
+		if st.cond.match(n.value) {
+			return []pathNode{n}, nil
+		}
+		return nil, nil
+	}
+	return nil, nil
+}
+
+// sliceRange clamps jq-style slice bounds (negative = from the end).
+func sliceRange(n int, from, to *int) (int, int) {
+	lo, hi := 0, n
+	if from != nil {
+		lo = *from
+	}
+	if to != nil {
+		hi = *to
+	}
+	if lo < 0 {
+		lo += n
+	}
+	if hi < 0 {
+		hi += n
+	}
+	lo = min(max(lo, 0), n)
+	hi = min(max(hi, lo), n)
+	return lo, hi
+}
+
+// asObject views a decoded mapping as an OrderedObject. A plain map (from
+// json.Unmarshal) is given a sorted key order.
+func asObject(v any) (OrderedObject, bool) {
+	switch t := v.(type) {
+	case OrderedObject:
+		return t, true
+	case map[string]any:
+		o := OrderedObject{Values: t, Keys: make([]string, 0, len(t))}
+		for k := range t {
+			o.Keys = append(o.Keys, k)
+		}
+		slices.Sort(o.Keys)
+		return o, true
+	}
+	return OrderedObject{}, false
+}
+
+// match evaluates the condition against node; the first value the relative
+// path yields is compared.
+func (c *pathCond) match(node any) bool {
+	nodes, _ := evalPath(node, c.path, "")
+	if len(nodes) == 0 {
+		return c.op == "!="
+	}
+	v := nodes[0].value
+
+	if c.op == "" {
+		b, isBool := v.(bool)
+		return v != nil && (!isBool || b)
+	}
+
+	if a, ok := pathNumber(v); ok {
+		if b, ok := pathNumber(c.value); ok {
+			return compareOrdered(a, b, c.op)
+		}
+	}
+	if a, ok := v.(string); ok {
+		if b, ok := c.value.(string); ok {
+			return compareOrdered(a, b, c.op)
+		}
+	}
+
+	equal := v == nil && c.value == nil
+	if a, ok := v.(bool); ok {
+		b, isBool := c.value.(bool)
+		equal = isBool && a == b
+	}
+	switch c.op {
+	case "==":
+		return equal
+	case "!=":
+		return !equal
+	}
+	return false
+}
+
+func compareOrdered[T float64 | string](a, b T, op string) bool {
+	switch op {
+	case "==":
+		return a == b
+	case "!=":
+		return a != b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	}
+	return false
+}
+
+// pathNumber reads the numeric types decoders produce (json.Number from the
+// ordered JSON decoder, float64/int from YAML and TOML).
+func pathNumber(v any) (float64, bool) {
+	switch t := v.(type) {
+	case json.Number:
+		f, err := t.Float64()
+		return f, err == nil && !math.IsNaN(f)
+	case float64:
+		return t, !math.IsNaN(t)
+	case int:
+		return float64(t), true
+	case int64:
+		return float64(t), true
+	case uint64:
+		return float64(t), true
+	}
+	return 0, false
+}
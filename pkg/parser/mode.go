@@ -42,6 +42,13 @@ func HasEdgeChart(cfg Config) bool {
 	return slices.Contains(cfg.ChartTypes, "sankey") || slices.Contains(cfg.ChartTypes, "chord")
 }
 
+// HasDistributionChart reports whether any requested chart type bins raw
+// values (currently histogram). Tabular rows then skip group aggregation so
+// every value reaches the binning step.
+func HasDistributionChart(cfg Config) bool {
+	return slices.Contains(cfg.ChartTypes, "histogram")
+}
+
 // HasSankeyChart reports whether any requested chart type is sankey.
 // Deprecated: use HasEdgeChart when deciding parser behavior.
 func HasSankeyChart(cfg Config) bool {
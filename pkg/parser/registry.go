@@ -24,17 +24,39 @@ type Config struct {
 	MemUnit         string
 	TimeUnit        string
 	NumberUnit      string
-	Round           bool         // when true, Format* rounds values to 2 decimals in output data
-	Select          []ColumnSpec // grouped mode: numeric stat columns
-	SelectViews     []SelectView // solo axis mode: one entry per --select occurrence
-	Axes            []ColumnSpec // auto-value mode: numeric cols placed on x,y[,z]
-	MetricColumn    string       // auto-value: 4th numeric col → visualMap metric
-	JSONPath        string       // json only: jq-like dot path to the nested array to chart
-	AutoGroup       bool         // csv/json: infer group columns when no explicit grouping is configured
-	ChartTypes      []string     // csv/json auto-value eligibility check (scatter/bar/line only)
-	Mode            Mode         // resolved once in ParseConfig so downstream switches on cfg.Mode
-	ColAxis         string       // csv/json: place numeric column names on this axis (n/x/y/z); empty = one chart per column
-	QuietAutoDetect bool         // suppress csv/json auto-detection notices for request-scoped callers
+	Round           bool                        // when true, Format* rounds values to 2 decimals in output data
+	Select          []ColumnSpec                // grouped mode: numeric stat columns
+	SelectViews     []SelectView                // solo axis mode: one entry per --select occurrence
+	Axes            []ColumnSpec                // auto-value mode: numeric cols placed on x,y[,z]
+	MetricColumn    string                      // auto-value: 4th numeric col → visualMap metric
+	CSV             CSVDialect                  // csv only: delimiter/quoting/encoding/header layout (zero = sniff)
+	JSONPath        string                      // json/yaml/toml: jq-subset path selecting the rows to chart
+	JSONPathKeys    bool                        // json/yaml/toml: add the keys/indices --json-path wildcards walked as columns
+	AutoGroup       bool                        // tabular parsers: infer group columns when no explicit grouping is configured
+	ChartTypes      []string                    // csv/json auto-value eligibility check (scatter/bar/line only)
+	Mode            Mode                        // resolved once in ParseConfig so downstream switches on cfg.Mode
+	ColAxis         string                      // csv/json: place numeric column names on this axis (n/x/y/z); empty = one chart per column
+	Better          map[string]shared.Direction // stat name → better direction, overriding what the parser inferred (--better)
+	QuietAutoDetect bool                        // suppress csv/json auto-detection notices for request-scoped callers
+	Progress        ProgressFunc                // optional: streaming parsers report rows/bytes consumed
+}
+
+// ProgressFunc receives streaming ingest progress: data rows consumed so far
+// and bytes read from the input. Parsers call it periodically (not per row)
+// and once more when the input is exhausted.
+type ProgressFunc func(rows int, bytes int64)
+
+// CountingReader counts the raw bytes read through it so streaming parsers can
+// report byte progress independent of any decoding layered on top.
+type CountingReader struct {
+	R io.Reader
+	N int64
+}
+
+func (c *CountingReader) Read(p []byte) (int, error) {
+	n, err := c.R.Read(p)
+	c.N += int64(n)
+	return n, err
 }
 
 // Mode is the resolved parse mode for a Config. Set once in ParseConfig so
@@ -72,6 +94,27 @@ func GetParser(key string) (ParseFunc, error) {
 	return fn, nil
 }
 
+// IsTabular reports whether key names a parser that reads generic tables (one
+// data point per row, numeric columns as stats) rather than benchmark output.
+// Tabular parsers share auto-group, --select, --axes and row aggregation.
+func IsTabular(key string) bool {
+	switch key {
+	case "csv", "json", "yaml", "toml":
+		return true
+	}
+	return false
+}
+
+// SupportsJSONPath reports whether key names a parser that honours
+// --json-path (the json parser and the document parsers built on it).
+func SupportsJSONPath(key string) bool {
+	switch key {
+	case "json", "yaml", "toml":
+		return true
+	}
+	return false
+}
+
 func AvailableParsers() []string {
 	keys := make([]string, 0, len(Parsers))
 	for k := range Parsers {
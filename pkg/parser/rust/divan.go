@@ -82,10 +82,10 @@ func ParseDivanBenchmark(input io.Reader, cfg parser.Config) ([]shared.DataPoint
 			YAxis: yAxis,
 			ZAxis: zAxis,
 			Stats: []shared.Stat{
-				{Type: utils.CreateStatType("Latency fastest", cfg.TimeUnit, ""), Value: shared.F64(utils.ConvertTime(fastestNs, "ns", cfg.TimeUnit, cfg.Round))},
-				{Type: utils.CreateStatType("Latency slowest", cfg.TimeUnit, ""), Value: shared.F64(utils.ConvertTime(slowestNs, "ns", cfg.TimeUnit, cfg.Round)), Symbol: "±"},
-				{Type: utils.CreateStatType("Latency median", cfg.TimeUnit, ""), Value: shared.F64(utils.ConvertTime(medianNs, "ns", cfg.TimeUnit, cfg.Round))},
-				{Type: utils.CreateStatType("Latency mean", cfg.TimeUnit, ""), Value: shared.F64(utils.ConvertTime(meanNs, "ns", cfg.TimeUnit, cfg.Round))},
+				{Type: utils.CreateStatType("Latency fastest", cfg.TimeUnit, ""), Unit: cfg.TimeUnit, Better: shared.DirectionLower, Value: shared.F64(utils.ConvertTime(fastestNs, "ns", cfg.TimeUnit, cfg.Round))},
+				{Type: utils.CreateStatType("Latency slowest", cfg.TimeUnit, ""), Unit: cfg.TimeUnit, Better: shared.DirectionLower, Value: shared.F64(utils.ConvertTime(slowestNs, "ns", cfg.TimeUnit, cfg.Round)), Symbol: "±"},
+				{Type: utils.CreateStatType("Latency median", cfg.TimeUnit, ""), Unit: cfg.TimeUnit, Better: shared.DirectionLower, Value: shared.F64(utils.ConvertTime(medianNs, "ns", cfg.TimeUnit, cfg.Round))},
+				{Type: utils.CreateStatType("Latency mean", cfg.TimeUnit, ""), Unit: cfg.TimeUnit, Better: shared.DirectionLower, Value: shared.F64(utils.ConvertTime(meanNs, "ns", cfg.TimeUnit, cfg.Round))},
 				{Type: "Samples", Value: shared.F64(samples)},
 			},
 		})
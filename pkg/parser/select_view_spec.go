@@ -328,6 +328,7 @@ func AppendMultiSelectStatPoint(
 			}
 			dp.Stats = append(dp.Stats, shared.Stat{
 				Type:  utils.CreateStatType(SelectStatType(view), numberUnit, ""),
+				Unit:  numberUnit,
 				Value: shared.F64(utils.FormatNumber(row.Value, numberUnit, round)),
 			})
 		}
@@ -346,6 +347,7 @@ func AppendMultiSelectStatPoint(
 			XAxis: row.DimVal,
 			Stats: []shared.Stat{{
 				Type:  utils.CreateStatType(SelectStatType(view), numberUnit, ""),
+				Unit:  numberUnit,
 				Value: shared.F64(utils.FormatNumber(row.Value, numberUnit, round)),
 			}},
 		})
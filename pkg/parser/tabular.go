@@ -195,6 +195,7 @@ func ParseEdgeMode(rows []RowReader, cfg Config) ([]shared.DataPoint, error) {
 			}
 			stats = append(stats, shared.Stat{
 				Type:  utils.CreateStatType(EdgeStatType(view), cfg.NumberUnit, ""),
+				Unit:  cfg.NumberUnit,
 				Value: shared.F64(utils.FormatNumber(v, cfg.NumberUnit, cfg.Round)),
 			})
 		}
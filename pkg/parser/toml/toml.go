@@ -0,0 +1,115 @@
+package toml
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"github.com/goptics/vizb/pkg/parser"
+	jsonparser "github.com/goptics/vizb/pkg/parser/json"
+	"github.com/goptics/vizb/shared"
+)
+
+func init() {
+	parser.Parsers["toml"] = ParseTOML
+}
+
+// ParseTOML charts a TOML document with the json parser's rules. The document
+// root is a table, so without --json-path it is one row; point --json-path at
+// an array of tables (`[[plans]]` → `.plans`) or a matrix array to get one row
+// per element. Keys keep their source order; dates and times keep the form
+// they were written in.
+func ParseTOML(input io.Reader, cfg parser.Config) ([]shared.DataPoint, parser.Config, *shared.Meta, error) {
+	var root map[string]any
+	md, err := toml.NewDecoder(input).Decode(&root)
+	if err != nil {
+		return nil, cfg, nil, fmt.Errorf("read TOML: %w", err)
+	}
+	if len(root) == 0 {
+		return nil, cfg, nil, nil
+	}
+
+	rank := map[string]int{}
+	for i, k := range md.Keys() {
+		path := strings.Join(k, "\x00")
+		if _, ok := rank[path]; !ok {
+			rank[path] = i
+		}
+	}
+	return jsonparser.ParseDocument(treeValue(root, "", rank), cfg)
+}
+
+// treeValue converts a decoded TOML value to the tree jsonparser.ParseDocument
+// expects, ordering table keys by where they first appear in the source.
+func treeValue(v any, path string, rank map[string]int) any {
+	switch t := v.(type) {
+	case map[string]any:
+		return orderedTable(t, path, rank)
+	case []map[string]any:
+		out := make([]any, len(t))
+		for i, tbl := range t {
+			out[i] = orderedTable(tbl, path, rank)
+		}
+		return out
+	case []any:
+		out := make([]any, len(t))
+		for i, e := range t {
+			out[i] = treeValue(e, path, rank)
+		}
+		return out
+	case int64:
+		return float64(t)
+	case time.Time:
+		return formatTime(t)
+	default:
+		return v
+	}
+}
+
+func orderedTable(tbl map[string]any, path string, rank map[string]int) jsonparser.OrderedObject {
+	childPath := func(k string) string {
+		if path == "" {
+			return k
+		}
+		return path + "\x00" + k
+	}
+
+	keys := make([]string, 0, len(tbl))
+	for k := range tbl {
+		keys = append(keys, k)
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		ri, iok := rank[childPath(keys[i])]
+		rj, jok := rank[childPath(keys[j])]
+		if iok != jok {
+			return iok
+		}
+		if ri != rj {
+			return ri < rj
+		}
+		return keys[i] < keys[j]
+	})
+
+	obj := jsonparser.NewOrderedObject()
+	for _, k := range keys {
+		obj.Set(k, treeValue(tbl[k], childPath(k), rank))
+	}
+	return obj
+}
+
+// formatTime renders TOML local dates/times without the zone the decoder
+// attaches to them, and offset date-times as RFC 3339.
+func formatTime(t time.Time) string {
+	switch t.Location().String() {
+	case "date-local":
+		return t.Format(time.DateOnly)
+	case "time-local":
+		return t.Format("15:04:05.999999999")
+	case "datetime-local":
+		return t.Format("2006-01-02T15:04:05.999999999")
+	}
+	return t.Format(time.RFC3339Nano)
+}
@@ -0,0 +1,105 @@
+package toml
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/goptics/vizb/pkg/parser"
+	"github.com/goptics/vizb/shared"
+	"github.com/stretchr/testify/suite"
+)
+
+func statTypes(stats []shared.Stat) []string {
+	out := make([]string, len(stats))
+	for i, s := range stats {
+		out[i] = s.Type
+	}
+	return out
+}
+
+type TOMLSuite struct {
+	suite.Suite
+	cfg parser.Config
+}
+
+func (s *TOMLSuite) SetupTest() {
+	s.cfg = parser.Config{GroupPattern: "x", QuietAutoDetect: true}
+}
+
+func (s *TOMLSuite) parse(doc string) []shared.DataPoint {
+	points, _, _, err := ParseTOML(strings.NewReader(doc), s.cfg)
+	s.Require().NoError(err)
+	return points
+}
+
+func (s *TOMLSuite) TestArrayOfTablesViaJSONPathKeepsSourceOrder() {
+	s.cfg.Group = []string{"name"}
+	s.cfg.JSONPath = ".plans"
+	doc := `
+[[plans]]
+name = "web"
+replicas = 3
+limits = { memory = 512, cpu = 2 }
+
+[[plans]]
+name = "worker"
+replicas = 5
+limits = { memory = 1024, cpu = 4 }
+`
+	points := s.parse(doc)
+
+	s.Require().Len(points, 2)
+	s.Equal("worker", points[1].XAxis)
+	s.Equal([]string{"replicas", "limits.memory", "limits.cpu"}, statTypes(points[0].Stats))
+	s.Equal(1024.0, *points[1].Stats[1].Value)
+}
+
+func (s *TOMLSuite) TestRootTableIsOneRow() {
+	points := s.parse("zeta = 1\nalpha = 2.5\n[mem]\nalloc = 3\n")
+
+	s.Require().Len(points, 1)
+	s.Equal([]string{"zeta", "alpha", "mem.alloc"}, statTypes(points[0].Stats))
+}
+
+func (s *TOMLSuite) TestMatrixArray() {
+	s.cfg.JSONPath = ".rows"
+	points := s.parse(`rows = [["name", "nodes"], ["api", 4], ["db", 2]]`)
+
+	s.Require().Len(points, 2)
+	s.Equal([]string{"nodes"}, statTypes(points[0].Stats))
+}
+
+func (s *TOMLSuite) TestDatesKeepWrittenFormAndNonFiniteSkipped() {
+	s.cfg.Group = []string{"day"}
+	s.cfg.JSONPath = ".samples"
+	doc := `
+[[samples]]
+day = 2024-01-02
+v = 1
+bad = nan
+
+[[samples]]
+day = 2024-01-03
+v = 2
+bad = inf
+`
+	points := s.parse(doc)
+
+	s.Require().Len(points, 2)
+	s.Equal("2024-01-02", points[0].XAxis)
+	s.Equal([]string{"v"}, statTypes(points[0].Stats))
+}
+
+func (s *TOMLSuite) TestEmptyAndMalformedDocuments() {
+	s.Nil(s.parse(""))
+
+	_, _, _, err := ParseTOML(strings.NewReader("a = \n"), s.cfg)
+	s.ErrorContains(err, "read TOML")
+
+	_, _, _, err = ParseTOML(strings.NewReader("a = 1\n"), parser.Config{GroupPattern: "x", JSONPath: ".missing"})
+	s.ErrorContains(err, "key 'missing' not found")
+}
+
+func TestTOMLSuite(t *testing.T) {
+	suite.Run(t, new(TOMLSuite))
+}
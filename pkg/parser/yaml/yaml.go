@@ -0,0 +1,130 @@
+package yaml
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/goptics/vizb/pkg/parser"
+	jsonparser "github.com/goptics/vizb/pkg/parser/json"
+	"github.com/goptics/vizb/shared"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+func init() {
+	parser.Parsers["yaml"] = ParseYAML
+}
+
+// ParseYAML charts a YAML document with the json parser's rules: a sequence of
+// mappings or a matrix of sequences becomes rows, a single mapping becomes one
+// row, nested mappings flatten to dotted keys and --json-path navigates to a
+// nested sequence. Mapping keys keep their source order; anchors, aliases and
+// `<<` merge keys are resolved. A multi-document stream (`---` separated) is
+// one row per document.
+func ParseYAML(input io.Reader, cfg parser.Config) ([]shared.DataPoint, parser.Config, *shared.Meta, error) {
+	dec := yamlv3.NewDecoder(input)
+
+	var docs []any
+	for {
+		var node yamlv3.Node
+		if err := dec.Decode(&node); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, cfg, nil, fmt.Errorf("read YAML: %w", err)
+		}
+		v, err := nodeValue(&node)
+		if err != nil {
+			return nil, cfg, nil, fmt.Errorf("read YAML: %w", err)
+		}
+		docs = append(docs, v)
+	}
+
+	switch len(docs) {
+	case 0:
+		return nil, cfg, nil, nil
+	case 1:
+		return jsonparser.ParseDocument(docs[0], cfg)
+	default:
+		return jsonparser.ParseDocument(docs, cfg)
+	}
+}
+
+// nodeValue converts a YAML node to the tree jsonparser.ParseDocument expects.
+func nodeValue(n *yamlv3.Node) (any, error) {
+	switch n.Kind {
+	case yamlv3.DocumentNode:
+		if len(n.Content) == 0 {
+			return nil, nil
+		}
+		return nodeValue(n.Content[0])
+	case yamlv3.AliasNode:
+		return nodeValue(n.Alias)
+	case yamlv3.SequenceNode:
+		out := make([]any, 0, len(n.Content))
+		for _, c := range n.Content {
+			v, err := nodeValue(c)
+			if err != nil {
+				return nil, err
+			}
+			out = append(out, v)
+		}
+		return out, nil
+	case yamlv3.MappingNode:
+		obj := jsonparser.NewOrderedObject()
+		if err := mergeMapping(&obj, n, false); err != nil {
+			return nil, err
+		}
+		return obj, nil
+	default:
+		if n.ShortTag() == "!!timestamp" {
+			return n.Value, nil // keep dates as written for group labels
+		}
+		var v any
+		if err := n.Decode(&v); err != nil {
+			return nil, err
+		}
+		return v, nil
+	}
+}
+
+// mergeMapping copies a mapping node's pairs into obj. Pairs from `<<` merge
+// keys (and, when merged is set, the whole node) never override a key the
+// mapping sets itself.
+func mergeMapping(obj *jsonparser.OrderedObject, n *yamlv3.Node, merged bool) error {
+	if n.Kind == yamlv3.AliasNode {
+		n = n.Alias
+	}
+	if n.Kind != yamlv3.MappingNode {
+		return fmt.Errorf("line %d: merge value is not a mapping", n.Line)
+	}
+
+	var merges []*yamlv3.Node
+	for i := 0; i+1 < len(n.Content); i += 2 {
+		k, v := n.Content[i], n.Content[i+1]
+		if k.Tag == "!!merge" || (k.Kind == yamlv3.ScalarNode && k.Value == "<<" && k.Style == 0) {
+			if v.Kind == yamlv3.SequenceNode {
+				merges = append(merges, v.Content...)
+			} else {
+				merges = append(merges, v)
+			}
+			continue
+		}
+
+		if _, exists := obj.Values[k.Value]; merged && exists {
+			continue
+		}
+		val, err := nodeValue(v)
+		if err != nil {
+			return err
+		}
+		obj.Set(k.Value, val)
+	}
+
+	for _, m := range merges {
+		if err := mergeMapping(obj, m, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package yaml
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/goptics/vizb/pkg/parser"
+	"github.com/goptics/vizb/shared"
+	"github.com/stretchr/testify/suite"
+)
+
+func statTypes(stats []shared.Stat) []string {
+	out := make([]string, len(stats))
+	for i, s := range stats {
+		out[i] = s.Type
+	}
+	return out
+}
+
+type YAMLSuite struct {
+	suite.Suite
+	cfg parser.Config
+}
+
+func (s *YAMLSuite) SetupTest() {
+	s.cfg = parser.Config{GroupPattern: "x", QuietAutoDetect: true}
+}
+
+func (s *YAMLSuite) parse(doc string) []shared.DataPoint {
+	points, _, _, err := ParseYAML(strings.NewReader(doc), s.cfg)
+	s.Require().NoError(err)
+	return points
+}
+
+func (s *YAMLSuite) TestSequenceOfMappingsFlattenedInSourceOrder() {
+	s.cfg.Group = []string{"name"}
+	doc := `
+- name: web
+  replicas: 3
+  limits:
+    memory: 512
+    cpu: 2
+- name: worker
+  replicas: 5
+  limits:
+    memory: 1024
+    cpu: 4
+`
+	points := s.parse(doc)
+
+	s.Require().Len(points, 2)
+	s.Equal("web", points[0].XAxis)
+	s.Equal([]string{"replicas", "limits.memory", "limits.cpu"}, statTypes(points[0].Stats))
+	s.Equal(1024.0, *points[1].Stats[1].Value)
+}
+
+func (s *YAMLSuite) TestJSONPathNavigatesToNestedSequence() {
+	s.cfg.Group = []string{"name"}
+	s.cfg.JSONPath = ".plans[0].services"
+	doc := `
+plans:
+  - quarter: Q1
+    services:
+      - {name: api, nodes: 4}
+      - {name: db, nodes: 2}
+`
+	points := s.parse(doc)
+
+	s.Require().Len(points, 2)
+	s.Equal("db", points[1].XAxis)
+	s.Equal(2.0, *points[1].Stats[0].Value)
+}
+
+func (s *YAMLSuite) TestJSONPathWildcardSelectAndParentKeys() {
+	s.cfg.Group = []string{"regions", "name"}
+	s.cfg.GroupPattern = "x,y"
+	s.cfg.JSONPath = `.regions[] | select(.active) | .services`
+	s.cfg.JSONPathKeys = true
+	doc := `
+regions:
+  eu:
+    active: true
+    services: [{name: api, nodes: 4}]
+  us:
+    active: false
+    services: [{name: api, nodes: 9}]
+  ap:
+    active: true
+    services: [{name: api, nodes: 2}]
+`
+	points := s.parse(doc)
+
+	s.Require().Len(points, 2)
+	s.Equal("eu", points[0].XAxis)
+	s.Equal("ap", points[1].XAxis)
+	s.Equal(2.0, *points[1].Stats[0].Value)
+}
+
+func (s *YAMLSuite) TestSingleMappingIsOneRow() {
+	points := s.parse("cpu: 4\nmemory: 8\n")
+
+	s.Require().Len(points, 1)
+	s.Equal([]string{"cpu", "memory"}, statTypes(points[0].Stats))
+}
+
+func (s *YAMLSuite) TestMatrixSequence() {
+	points := s.parse("- [name, nodes]\n- [api, 4]\n- [db, 2]\n")
+
+	s.Require().Len(points, 2)
+	s.Equal([]string{"nodes"}, statTypes(points[0].Stats))
+}
+
+func (s *YAMLSuite) TestAnchorsAndMergeKeys() {
+	s.cfg.Group = []string{"name"}
+	doc := `
+base: &base
+  cpu: 2
+  memory: 4
+services:
+  - <<: *base
+    name: api
+  - <<: *base
+    name: db
+    memory: 16
+`
+	s.cfg.JSONPath = ".services"
+	points := s.parse(doc)
+
+	s.Require().Len(points, 2)
+	s.Equal([]string{"cpu", "memory"}, statTypes(points[1].Stats))
+	s.Equal(2.0, *points[0].Stats[0].Value)
+	s.Equal(16.0, *points[1].Stats[1].Value)
+}
+
+func (s *YAMLSuite) TestMultiDocumentStreamIsOneRowPerDocument() {
+	s.cfg.Group = []string{"name"}
+	points := s.parse("name: a\nv: 1\n---\nname: b\nv: 2\n")
+
+	s.Require().Len(points, 2)
+	s.Equal("b", points[1].XAxis)
+}
+
+func (s *YAMLSuite) TestTimestampsAndNonFiniteValues() {
+	s.cfg.Group = []string{"day"}
+	points := s.parse("- {day: 2024-01-02, v: 1, bad: .nan}\n- {day: 2024-01-03, v: 2, bad: .inf}\n")
+
+	s.Require().Len(points, 2)
+	s.Equal("2024-01-02", points[0].XAxis)
+	s.Equal([]string{"v"}, statTypes(points[0].Stats))
+}
+
+func (s *YAMLSuite) TestEmptyAndScalarDocuments() {
+	s.Nil(s.parse(""))
+
+	_, _, _, err := ParseYAML(strings.NewReader("just text\n"), s.cfg)
+	s.ErrorContains(err, "scalar")
+
+	_, _, _, err = ParseYAML(strings.NewReader("a: [1,\n"), s.cfg)
+	s.ErrorContains(err, "read YAML")
+}
+
+func TestYAMLSuite(t *testing.T) {
+	suite.Run(t, new(YAMLSuite))
+}
@@ -0,0 +1,139 @@
+package svg
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Fixed presentation colors; series colors come from the theme.
+const (
+	textColor   = "#333333"
+	mutedColor  = "#6e7079"
+	gridColor   = "#e0e6f1"
+	background  = "#ffffff"
+	fontFamily  = "Helvetica, Arial, sans-serif"
+	fontSize    = 12
+	titleSize   = 16
+	charWidthEm = 0.6 // average glyph advance; there are no font metrics here
+)
+
+// canvas accumulates one SVG document. Every coordinate goes through num so
+// the output is byte-for-byte stable.
+type canvas struct {
+	b bytes.Buffer
+}
+
+func openDocument(b *bytes.Buffer, w, h int) {
+	fmt.Fprintf(b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="%s" font-size="%d">`+"\n",
+		w, h, w, h, fontFamily, fontSize)
+}
+
+// documentBody strips the outer <svg …> and </svg> of a document written by
+// canvas, for nesting it inside Grid.
+func documentBody(doc []byte) []byte {
+	start := bytes.IndexByte(doc, '>') + 1
+	end := bytes.LastIndex(doc, []byte("</svg>"))
+	if start <= 0 || end < start {
+		return doc
+	}
+	return doc[start:end]
+}
+
+func (c *canvas) open(w, h int) {
+	openDocument(&c.b, w, h)
+	c.rect(0, 0, float64(w), float64(h), background, "")
+}
+
+func (c *canvas) close() []byte {
+	c.b.WriteString("</svg>\n")
+	return c.b.Bytes()
+}
+
+func (c *canvas) rect(x, y, w, h float64, fill, extra string) {
+	fmt.Fprintf(&c.b, `<rect x="%s" y="%s" width="%s" height="%s" fill="%s"%s/>`+"\n", num(x), num(y), num(w), num(h), fill, attr(extra))
+}
+
+func (c *canvas) line(x1, y1, x2, y2 float64, stroke string) {
+	fmt.Fprintf(&c.b, `<line x1="%s" y1="%s" x2="%s" y2="%s" stroke="%s"/>`+"\n", num(x1), num(y1), num(x2), num(y2), stroke)
+}
+
+func (c *canvas) circle(cx, cy, r float64, fill, extra string) {
+	fmt.Fprintf(&c.b, `<circle cx="%s" cy="%s" r="%s" fill="%s"%s/>`+"\n", num(cx), num(cy), num(r), fill, attr(extra))
+}
+
+func (c *canvas) path(d, fill, extra string) {
+	fmt.Fprintf(&c.b, `<path d="%s" fill="%s"%s/>`+"\n", d, fill, attr(extra))
+}
+
+// text writes s at (x, y); anchor is start, middle or end. extra carries any
+// further attributes (font-size, transform, …).
+func (c *canvas) text(x, y float64, s, anchor, fill, extra string) {
+	fmt.Fprintf(&c.b, `<text x="%s" y="%s" text-anchor="%s" fill="%s"%s>%s</text>`+"\n", num(x), num(y), anchor, fill, attr(extra), escape(s))
+}
+
+func attr(extra string) string {
+	if extra == "" {
+		return ""
+	}
+	return " " + extra
+}
+
+var xmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "'", "&apos;")
+
+func escape(s string) string { return xmlEscaper.Replace(s) }
+
+// num formats a coordinate with at most two decimals and no "-0".
+func num(v float64) string {
+	s := strconv.FormatFloat(math.Round(v*100)/100, 'f', -1, 64)
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+// textWidth estimates the rendered width of s at size px.
+func textWidth(s string, size float64) float64 {
+	return float64(utf8.RuneCountInString(s)) * size * charWidthEm
+}
+
+// truncate shortens s to at most n runes, ending in "…".
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	r := []rune(s)
+	return string(r[:n-1]) + "…"
+}
+
+// formatNumber renders an axis or legend value: compact k/M/G/T from 10,000
+// up, otherwise the shortest form after rounding off float noise.
+func formatNumber(v float64) string {
+	a := math.Abs(v)
+	for _, u := range []struct {
+		div    float64
+		suffix string
+	}{{1e12, "T"}, {1e9, "G"}, {1e6, "M"}, {1e4, "k"}} {
+		if a >= u.div {
+			div := u.div
+			if u.suffix == "k" {
+				div = 1e3
+			}
+			return trimFloat(v/div, 2) + u.suffix
+		}
+	}
+	return trimFloat(v, 6)
+}
+
+// trimFloat rounds v to at most decimals places and drops trailing zeros.
+func trimFloat(v float64, decimals int) string {
+	p := math.Pow(10, float64(decimals))
+	s := strconv.FormatFloat(math.Round(v*p)/p, 'f', -1, 64)
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
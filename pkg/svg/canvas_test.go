@@ -0,0 +1,31 @@
+package svg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type CanvasSuite struct {
+	suite.Suite
+}
+
+func (s *CanvasSuite) TestFormatNumber() {
+	s.Equal("0", formatNumber(0))
+	s.Equal("0.3", formatNumber(0.1+0.2))
+	s.Equal("9999", formatNumber(9999))
+	s.Equal("12.5k", formatNumber(12500))
+	s.Equal("8.39M", formatNumber(8_388_608))
+	s.Equal("-2G", formatNumber(-2e9))
+}
+
+func (s *CanvasSuite) TestNumAndEscape() {
+	s.Equal("0", num(-0.001))
+	s.Equal("1.23", num(1.234))
+	s.Equal("a &lt;b&gt; &amp; &quot;c&quot;", escape(`a <b> & "c"`))
+	s.Equal("abcd…", truncate("abcdefgh", 5))
+}
+
+func TestCanvasSuite(t *testing.T) {
+	suite.Run(t, new(CanvasSuite))
+}
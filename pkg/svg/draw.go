@@ -0,0 +1,360 @@
+package svg
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	barchart "github.com/goptics/vizb/internal/charts/bar"
+	linechart "github.com/goptics/vizb/internal/charts/line"
+	"github.com/goptics/vizb/internal/frame"
+)
+
+// Layout in px.
+const (
+	padding       = 16
+	titleBaseline = 28
+	legendRow     = 20
+	legendSwatch  = 12
+	maxLabelRunes = 24
+)
+
+type cartesianStyle struct {
+	kind       string // bar, line or scatter
+	log        bool
+	stack      bool
+	horizontal bool // bar only: categories on the y axis
+}
+
+// drawTitle writes the chart title and a legend of names (when there is more
+// than one), wrapping legend rows to the image width. It returns the y where
+// the plot may start.
+func drawTitle(c *canvas, title string, names []string, palette []string) float64 {
+	c.text(Width/2, titleBaseline, title, "middle", textColor, fmt.Sprintf(`font-size="%d" font-weight="bold"`, titleSize))
+	top := float64(titleBaseline + 16)
+	if len(names) < 2 {
+		return top
+	}
+
+	type item struct {
+		label string
+		width float64
+	}
+	var rows [][]item
+	var widths []float64
+	for _, name := range names {
+		label := truncate(name, maxLabelRunes)
+		w := legendSwatch + 6 + textWidth(label, fontSize) + 16
+		if len(rows) == 0 || widths[len(rows)-1]+w > Width-2*padding {
+			rows = append(rows, nil)
+			widths = append(widths, 0)
+		}
+		rows[len(rows)-1] = append(rows[len(rows)-1], item{label, w})
+		widths[len(rows)-1] += w
+	}
+	i := 0
+	for r, row := range rows {
+		x := (Width - widths[r]) / 2
+		y := top + float64(r)*legendRow
+		for _, it := range row {
+			c.rect(x, y, legendSwatch, legendSwatch, palette[i%len(palette)], `rx="2"`)
+			c.text(x+legendSwatch+6, y+legendSwatch-2, it.label, "start", textColor, "")
+			x += it.width
+			i++
+		}
+	}
+	return top + float64(len(rows))*legendRow + 4
+}
+
+// drawCartesian renders bar, line and scatter charts: categories along one
+// axis, values along the other. Horizontal bars swap the two; every drawing
+// step goes through at(), which maps (category px, value px) to (x, y).
+func drawCartesian(c *canvas, fr frame.Frame, palette []string, st cartesianStyle) {
+	names := make([]string, len(fr.Series))
+	for i, s := range fr.Series {
+		names[i] = s.Name
+	}
+	top := drawTitle(c, fr.Title, names, palette)
+
+	// Stacked charts plot running totals; each cell keeps its own base.
+	tops := make([][]float64, len(fr.Series))
+	bases := make([][]float64, len(fr.Series))
+	var all []float64
+	running := make([]float64, len(fr.Categories))
+	for si, s := range fr.Series {
+		tops[si] = make([]float64, len(s.Values))
+		bases[si] = make([]float64, len(s.Values))
+		for ci, v := range s.Values {
+			tops[si][ci] = v
+			if st.stack && !math.IsNaN(v) {
+				bases[si][ci] = running[ci]
+				running[ci] += v
+				tops[si][ci] = running[ci]
+			}
+			all = append(all, tops[si][ci])
+		}
+	}
+	vs := newValueScale(all, st.log)
+
+	tickLabels := make([]string, len(vs.ticks))
+	tickW := 0.0
+	for i, t := range vs.ticks {
+		tickLabels[i] = formatNumber(t)
+		tickW = math.Max(tickW, textWidth(tickLabels[i], fontSize))
+	}
+	catLabels := make([]string, len(fr.Categories))
+	catW := 0.0
+	for i, cat := range fr.Categories {
+		catLabels[i] = truncate(cat, maxLabelRunes)
+		catW = math.Max(catW, textWidth(catLabels[i], fontSize))
+	}
+	unit := fr.Unit()
+
+	// Plot box: left/right/bottom leave room for tick labels, category labels
+	// and axis names.
+	var left, bottom float64
+	right := float64(Width - padding - 8)
+	rotate := false
+	if st.horizontal {
+		left = padding + catW + 8
+		if fr.XLabel != "" {
+			left += legendRow
+		}
+		bottom = Height - padding - fontSize - 8
+		if unit != "" {
+			bottom -= legendRow
+		}
+	} else {
+		left = padding + tickW + 8
+		if unit != "" {
+			left += legendRow
+		}
+		band := (right - left) / float64(len(fr.Categories))
+		rotate = catW > band-4
+		catBand := fontSize + 8.0
+		if rotate {
+			catBand = math.Min(catW*math.Sqrt2/2+fontSize, 110)
+		}
+		bottom = Height - padding - catBand
+		if fr.XLabel != "" {
+			bottom -= legendRow
+		}
+	}
+
+	catStart, catEnd := left, right // category axis span in px
+	valStart, valEnd := bottom, top // value axis, from the base up
+	if st.horizontal {
+		catStart, catEnd = top, bottom
+		valStart, valEnd = left, right
+	}
+	band := (catEnd - catStart) / float64(len(fr.Categories))
+	valuePx := func(v float64) (float64, bool) {
+		f, ok := vs.frac(v)
+		return valStart + f*(valEnd-valStart), ok
+	}
+	at := func(cat, val float64) (x, y float64) {
+		if st.horizontal {
+			return val, cat
+		}
+		return cat, val
+	}
+
+	// Grid lines and value tick labels.
+	for i, t := range vs.ticks {
+		p, _ := valuePx(t)
+		x1, y1 := at(catStart, p)
+		x2, y2 := at(catEnd, p)
+		c.line(x1, y1, x2, y2, gridColor)
+		if st.horizontal {
+			c.text(p, bottom+fontSize+6, tickLabels[i], "middle", mutedColor, "")
+		} else {
+			c.text(left-8, p+4, tickLabels[i], "end", mutedColor, "")
+		}
+	}
+
+	// Category axis line, labels (thinned to fit) and axis names.
+	x1, y1 := at(catStart, valStart)
+	x2, y2 := at(catEnd, valStart)
+	c.line(x1, y1, x2, y2, mutedColor)
+	every := 1
+	if st.horizontal || rotate {
+		every = int(math.Ceil((fontSize + 2) / band))
+	}
+	for i, label := range catLabels {
+		if i%every != 0 {
+			continue
+		}
+		mid := catStart + band*(float64(i)+0.5)
+		switch {
+		case st.horizontal:
+			c.text(left-8, mid+4, label, "end", mutedColor, "")
+		case rotate:
+			y := bottom + fontSize
+			c.text(mid, y, label, "end", mutedColor, fmt.Sprintf(`transform="rotate(-45 %s %s)"`, num(mid), num(y)))
+		default:
+			c.text(mid, bottom+fontSize+6, label, "middle", mutedColor, "")
+		}
+	}
+	if fr.XLabel != "" {
+		if st.horizontal {
+			x, y := float64(padding+fontSize), (top+bottom)/2
+			c.text(x, y, fr.XLabel, "middle", textColor, fmt.Sprintf(`transform="rotate(-90 %s %s)"`, num(x), num(y)))
+		} else {
+			c.text((left+right)/2, Height-padding, fr.XLabel, "middle", textColor, "")
+		}
+	}
+	if unit != "" {
+		if st.horizontal {
+			c.text((left+right)/2, Height-padding, unit, "middle", textColor, "")
+		} else {
+			x, y := float64(padding+fontSize), (top+bottom)/2
+			c.text(x, y, unit, "middle", textColor, fmt.Sprintf(`transform="rotate(-90 %s %s)"`, num(x), num(y)))
+		}
+	}
+
+	switch st.kind {
+	case barchart.Type:
+		drawBars(c, fr, palette, st, band, catStart, tops, bases, vs, valuePx, at)
+	default:
+		for si := range fr.Series {
+			color := palette[si%len(palette)]
+			var d strings.Builder
+			var markers [][2]float64
+			pen := false
+			for ci, v := range tops[si] {
+				p, ok := valuePx(v)
+				if !ok {
+					pen = false
+					continue
+				}
+				x, y := at(catStart+band*(float64(ci)+0.5), p)
+				markers = append(markers, [2]float64{x, y})
+				cmd := "M"
+				if pen {
+					cmd = "L"
+				}
+				fmt.Fprintf(&d, " %s%s %s", cmd, num(x), num(y))
+				pen = true
+			}
+			if st.kind == linechart.Type && d.Len() > 0 {
+				c.path(strings.TrimSpace(d.String()), "none", fmt.Sprintf(`stroke="%s" stroke-width="2" stroke-linejoin="round"`, color))
+			}
+			for _, m := range markers {
+				if st.kind == linechart.Type {
+					c.circle(m[0], m[1], 3, background, fmt.Sprintf(`stroke="%s" stroke-width="1.5"`, color))
+				} else {
+					c.circle(m[0], m[1], 5, color, `fill-opacity="0.8"`)
+				}
+			}
+		}
+	}
+}
+
+func drawBars(
+	c *canvas, fr frame.Frame, palette []string, st cartesianStyle,
+	band, catStart float64, tops, bases [][]float64, vs valueScale,
+	valuePx func(float64) (float64, bool), at func(cat, val float64) (float64, float64),
+) {
+	n := float64(len(fr.Series))
+	groupW := band * 0.7
+	barW := groupW / n
+	if st.stack {
+		barW = band * 0.6
+	}
+	for si := range fr.Series {
+		color := palette[si%len(palette)]
+		for ci, v := range tops[si] {
+			end, ok := valuePx(v)
+			if !ok {
+				continue
+			}
+			base := vs.base()
+			if st.stack && bases[si][ci] != 0 {
+				base = bases[si][ci]
+			}
+			start, ok := valuePx(base)
+			if !ok {
+				start, _ = valuePx(vs.lo)
+			}
+			offset := band*0.15 + float64(si)*barW
+			if st.stack {
+				offset = band * 0.2
+			}
+			c0 := catStart + band*float64(ci) + offset
+			x1, y1 := at(c0, start)
+			x2, y2 := at(c0+barW, end)
+			c.rect(math.Min(x1, x2), math.Min(y1, y2), math.Abs(x2-x1), math.Abs(y2-y1), color, "")
+		}
+	}
+}
+
+// drawPie renders one slice per category, sized by its total across series,
+// with a legend of names and shares on the right. Non-positive totals are
+// left out, as a pie cannot show them.
+func drawPie(c *canvas, fr frame.Frame, palette []string) {
+	top := drawTitle(c, fr.Title, nil, palette)
+	totals := fr.Totals()
+	sum := 0.0
+	for _, v := range totals {
+		if v > 0 {
+			sum += v
+		}
+	}
+	if sum == 0 {
+		return
+	}
+
+	cx, cy := Width*0.36, (top+Height-padding)/2
+	r := math.Min(Height-padding-top, Width*0.6) / 2 * 0.92
+	angle := -math.Pi / 2
+	for i, v := range totals {
+		if v <= 0 {
+			continue
+		}
+		color := palette[i%len(palette)]
+		sweep := v / sum * 2 * math.Pi
+		if sweep >= 2*math.Pi-1e-9 {
+			c.circle(cx, cy, r, color, "")
+			break
+		}
+		x1, y1 := cx+r*math.Cos(angle), cy+r*math.Sin(angle)
+		angle += sweep
+		x2, y2 := cx+r*math.Cos(angle), cy+r*math.Sin(angle)
+		large := 0
+		if sweep > math.Pi {
+			large = 1
+		}
+		d := fmt.Sprintf("M%s %s L%s %s A%s %s 0 %d 1 %s %s Z",
+			num(cx), num(cy), num(x1), num(y1), num(r), num(r), large, num(x2), num(y2))
+		c.path(d, color, fmt.Sprintf(`stroke="%s" stroke-width="1"`, background))
+	}
+
+	// Legend: one row per slice, cut off with "+N more" when it overflows.
+	x := Width * 0.68
+	rows := int((Height - padding - top) / legendRow)
+	shown := 0
+	for i, v := range totals {
+		if v <= 0 {
+			continue
+		}
+		y := top + float64(shown)*legendRow
+		if shown == rows-1 && countPositive(totals[i:]) > 1 {
+			c.text(x, y+legendSwatch-2, fmt.Sprintf("+%d more", countPositive(totals[i:])), "start", mutedColor, "")
+			break
+		}
+		label := fmt.Sprintf("%s — %s%%", truncate(fr.Categories[i], maxLabelRunes), trimFloat(v/sum*100, 1))
+		c.rect(x, y, legendSwatch, legendSwatch, palette[i%len(palette)], `rx="2"`)
+		c.text(x+legendSwatch+6, y+legendSwatch-2, label, "start", textColor, "")
+		shown++
+	}
+}
+
+func countPositive(values []float64) int {
+	n := 0
+	for _, v := range values {
+		if v > 0 {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,110 @@
+package svg
+
+import "math"
+
+// valueScale maps values onto [0, 1] along the value axis, with "nice" tick
+// values (1/2/5 × 10ⁿ steps on linear axes, powers of ten on log axes).
+type valueScale struct {
+	lo, hi float64
+	log    bool
+	ticks  []float64
+}
+
+const targetTicks = 5
+
+// newValueScale fits a scale to values (NaN ignored). Linear scales always
+// include zero, like the UI's default value axis; log scales ignore values
+// at or below zero, which they cannot show.
+func newValueScale(values []float64, log bool) valueScale {
+	if log {
+		return newLogScale(values)
+	}
+	lo, hi := 0.0, 0.0
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			lo, hi = math.Min(lo, v), math.Max(hi, v)
+		}
+	}
+	if lo == hi {
+		hi = lo + 1
+	}
+	step := niceStep((hi - lo) / targetTicks)
+	s := valueScale{lo: math.Floor(lo/step) * step, hi: math.Ceil(hi/step) * step}
+	for i := 0; ; i++ {
+		t := s.lo + float64(i)*step
+		if t > s.hi+step/2 {
+			break
+		}
+		s.ticks = append(s.ticks, roundNoise(t))
+	}
+	return s
+}
+
+func newLogScale(values []float64) valueScale {
+	minExp, maxExp := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if v > 0 {
+			e := math.Log10(v)
+			minExp, maxExp = math.Min(minExp, math.Floor(e)), math.Max(maxExp, math.Ceil(e))
+		}
+	}
+	if math.IsInf(minExp, 1) {
+		minExp, maxExp = 0, 1
+	}
+	if maxExp <= minExp {
+		maxExp = minExp + 1
+	}
+	s := valueScale{lo: math.Pow(10, minExp), hi: math.Pow(10, maxExp), log: true}
+	stride := math.Ceil((maxExp - minExp) / 8) // keep at most ~8 labels
+	for e := minExp; e <= maxExp; e += stride {
+		s.ticks = append(s.ticks, math.Pow(10, e))
+	}
+	return s
+}
+
+// niceStep rounds raw up to 1, 2, 5 or 10 times a power of ten.
+func niceStep(raw float64) float64 {
+	mag := math.Pow(10, math.Floor(math.Log10(raw)))
+	switch r := raw / mag; {
+	case r <= 1:
+		return mag
+	case r <= 2:
+		return 2 * mag
+	case r <= 5:
+		return 5 * mag
+	default:
+		return 10 * mag
+	}
+}
+
+// roundNoise drops float accumulation error (0.30000000000000004 → 0.3).
+func roundNoise(v float64) float64 {
+	if v == 0 {
+		return 0
+	}
+	p := math.Pow(10, 12-math.Ceil(math.Log10(math.Abs(v))))
+	return math.Round(v*p) / p
+}
+
+// frac positions v on the scale, 0 at lo and 1 at hi. ok is false for values
+// the scale cannot show (NaN, or ≤ 0 on a log scale).
+func (s valueScale) frac(v float64) (f float64, ok bool) {
+	if math.IsNaN(v) {
+		return 0, false
+	}
+	if s.log {
+		if v <= 0 {
+			return 0, false
+		}
+		return (math.Log10(v) - math.Log10(s.lo)) / (math.Log10(s.hi) - math.Log10(s.lo)), true
+	}
+	return (v - s.lo) / (s.hi - s.lo), true
+}
+
+// base is where bars grow from: zero, or the bottom of a log axis.
+func (s valueScale) base() float64 {
+	if s.log {
+		return s.lo
+	}
+	return math.Max(s.lo, math.Min(0, s.hi))
+}
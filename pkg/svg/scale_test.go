@@ -0,0 +1,45 @@
+package svg
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ScaleSuite struct {
+	suite.Suite
+}
+
+func (s *ScaleSuite) TestLinearIncludesZeroWithNiceTicks() {
+	vs := newValueScale([]float64{12, 87, math.NaN()}, false)
+	s.Equal(0.0, vs.lo)
+	s.Equal(100.0, vs.hi)
+	s.Equal([]float64{0, 20, 40, 60, 80, 100}, vs.ticks)
+	s.Equal(0.0, vs.base())
+
+	neg := newValueScale([]float64{-0.3, 0.1}, false)
+	s.Equal([]float64{-0.3, -0.2, -0.1, 0, 0.1}, neg.ticks)
+
+	flat := newValueScale(nil, false)
+	s.Equal(0.0, flat.lo)
+	s.Equal(1.0, flat.hi)
+}
+
+func (s *ScaleSuite) TestLogUsesPowersOfTen() {
+	vs := newValueScale([]float64{0, 3, 4500}, true)
+	s.Equal(1.0, vs.lo)
+	s.Equal(10000.0, vs.hi)
+	s.Equal([]float64{1, 10, 100, 1000, 10000}, vs.ticks)
+	s.Equal(1.0, vs.base())
+
+	_, ok := vs.frac(0)
+	s.False(ok, "log scales cannot show zero")
+	f, ok := vs.frac(100)
+	s.True(ok)
+	s.InDelta(0.5, f, 1e-9)
+}
+
+func TestScaleSuite(t *testing.T) {
+	suite.Run(t, new(ScaleSuite))
+}
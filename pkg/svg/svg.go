@@ -0,0 +1,139 @@
+// Package svg renders vizb datasets to static SVG in pure Go, for wikis, emails
+// and PR comments where the interactive HTML report cannot be embedded.
+//
+// It reads the same shared.Dataset and per-chart ChartConfig as the UI and
+// draws one image per chart and stat type. Bar, line, scatter and pie are
+// supported; they honour the active theme (Dataset.Themes[0], else the default
+// palette), sort, stack, horizontal bars and log scale. Output is
+// deterministic — same dataset, same bytes — so it can back golden-file tests.
+package svg
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+
+	internal_charts "github.com/goptics/vizb/internal/charts"
+	barchart "github.com/goptics/vizb/internal/charts/bar"
+	linechart "github.com/goptics/vizb/internal/charts/line"
+	piechart "github.com/goptics/vizb/internal/charts/pie"
+	scatterchart "github.com/goptics/vizb/internal/charts/scatter"
+	"github.com/goptics/vizb/internal/frame"
+	"github.com/goptics/vizb/shared"
+)
+
+// Image dimensions in px. Grid cells keep the same size.
+const (
+	Width  = 800
+	Height = 450
+)
+
+// Image is one rendered chart: Name is a file-name-safe slug
+// ("bar-execution-time-ns-op") and SVG the complete document.
+type Image struct {
+	Name string
+	SVG  []byte
+}
+
+// Supported reports whether chartType has an SVG renderer.
+func Supported(chartType string) bool {
+	switch chartType {
+	case barchart.Type, linechart.Type, scatterchart.Type, piechart.Type:
+		return true
+	}
+	return false
+}
+
+// Render draws every supported chart in ds.Settings once per stat type, in
+// settings order. skipped lists the chart types without a renderer (each
+// once, in settings order) so callers can warn about them.
+func Render(ds *shared.Dataset) (images []Image, skipped []string) {
+	frames := frame.Build(ds)
+	palette := frame.Palette(ds)
+	for _, cfg := range ds.Settings {
+		chartType := cfg.ChartType()
+		if !Supported(chartType) {
+			if !slices.Contains(skipped, chartType) {
+				skipped = append(skipped, chartType)
+			}
+			continue
+		}
+		for _, fr := range frames {
+			images = append(images, Image{
+				Name: Slug(chartType + " " + fr.StatType),
+				SVG:  renderChart(cfg, fr, palette),
+			})
+		}
+	}
+	return images, skipped
+}
+
+// Grid lays images out as a sprite sheet, columns wide, each in its own
+// nested <svg> so ids and coordinates never collide.
+func Grid(images []Image, columns int) []byte {
+	if columns < 1 {
+		columns = 1
+	}
+	columns = min(columns, max(len(images), 1))
+	rows := (len(images) + columns - 1) / columns
+
+	var b bytes.Buffer
+	openDocument(&b, columns*Width, max(rows, 1)*Height)
+	for i, img := range images {
+		x := (i % columns) * Width
+		y := (i / columns) * Height
+		fmt.Fprintf(&b, `<svg x="%d" y="%d" width="%d" height="%d" viewBox="0 0 %d %d">`, x, y, Width, Height, Width, Height)
+		b.Write(documentBody(img.SVG))
+		b.WriteString("</svg>\n")
+	}
+	b.WriteString("</svg>\n")
+	return b.Bytes()
+}
+
+func renderChart(cfg internal_charts.ChartConfig, fr frame.Frame, palette []string) []byte {
+	var c canvas
+	c.open(Width, Height)
+	switch cfg := cfg.(type) {
+	case *barchart.Config:
+		fr.Sort(cfg.Sort)
+		drawCartesian(&c, fr, palette, cartesianStyle{
+			kind:       barchart.Type,
+			log:        cfg.Scale == "log",
+			stack:      boolValue(cfg.Stack),
+			horizontal: boolValue(cfg.Horizontal),
+		})
+	case *linechart.Config:
+		fr.Sort(cfg.Sort)
+		drawCartesian(&c, fr, palette, cartesianStyle{
+			kind:  linechart.Type,
+			log:   cfg.Scale == "log",
+			stack: boolValue(cfg.Stack),
+		})
+	case *scatterchart.Config:
+		fr.Sort(cfg.Sort)
+		drawCartesian(&c, fr, palette, cartesianStyle{kind: scatterchart.Type, log: cfg.Scale == "log"})
+	case *piechart.Config:
+		fr.Sort(cfg.Sort)
+		drawPie(&c, fr, palette)
+	}
+	return c.close()
+}
+
+func boolValue(b *bool) bool { return b != nil && *b }
+
+// Slug lowercases s and collapses every run of non-alphanumerics to one "-".
+func Slug(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+			dash = false
+		} else if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(b.String(), "-")
+}
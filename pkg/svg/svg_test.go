@@ -0,0 +1,148 @@
+package svg
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	internal_charts "github.com/goptics/vizb/internal/charts"
+	barchart "github.com/goptics/vizb/internal/charts/bar"
+	heatmapchart "github.com/goptics/vizb/internal/charts/heatmap"
+	linechart "github.com/goptics/vizb/internal/charts/line"
+	piechart "github.com/goptics/vizb/internal/charts/pie"
+	scatterchart "github.com/goptics/vizb/internal/charts/scatter"
+	"github.com/goptics/vizb/shared"
+	"github.com/stretchr/testify/suite"
+)
+
+var update = flag.Bool("update", false, "rewrite testdata/*.svg golden files")
+
+type SVGSuite struct {
+	suite.Suite
+}
+
+// groupedDataset has two categories × two series for one stat type, plus an
+// untouched second stat so every config renders two images.
+func groupedDataset(settings ...internal_charts.ChartConfig) *shared.Dataset {
+	row := func(x, y string, ns, b float64) shared.DataPoint {
+		return shared.DataPoint{XAxis: x, YAxis: y, Stats: []shared.Stat{
+			{Type: "Execution Time (ns/op)", Value: shared.F64(ns)},
+			{Type: "Memory Usage (B/op)", Value: shared.F64(b)},
+		}}
+	}
+	return &shared.Dataset{
+		Name:     "Sorting",
+		Axes:     []shared.Axis{{Key: "x", Label: "algorithm"}, {Key: "y", Label: "size"}},
+		Settings: settings,
+		Data: []shared.DataPoint{
+			row("Quick", "1K", 120, 16),
+			row("Quick", "1M", 98000, 4096),
+			row("Merge", "1K", 150, 2048),
+			row("Merge", "1M", 132000, 8_388_608),
+			row("Heap", "1K", 180, 0),
+			row("Heap", "1M", 156000, 0),
+		},
+	}
+}
+
+func (s *SVGSuite) golden(name string, got []byte) {
+	path := filepath.Join("testdata", name+".svg")
+	if *update {
+		s.Require().NoError(os.WriteFile(path, got, 0o644))
+	}
+	want, err := os.ReadFile(path)
+	s.Require().NoError(err, "run go test ./pkg/svg -update to create %s", path)
+	s.Equal(string(want), string(got), "%s differs; rerun with -update after checking the change", path)
+}
+
+func (s *SVGSuite) TestGoldenFiles() {
+	yes := true
+	cases := []struct {
+		name string
+		cfg  internal_charts.ChartConfig
+	}{
+		{"bar", &barchart.Config{Type: "bar"}},
+		{"bar-horizontal-sorted", &barchart.Config{Type: "bar", Horizontal: &yes, Sort: &shared.Sort{Enabled: true, Order: "desc"}}},
+		{"bar-stacked", &barchart.Config{Type: "bar", Stack: &yes}},
+		{"line-log", &linechart.Config{Type: "line", Scale: "log"}},
+		{"scatter", &scatterchart.Config{Type: "scatter"}},
+		{"pie", &piechart.Config{Type: "pie"}},
+	}
+	for _, tc := range cases {
+		s.Run(tc.name, func() {
+			images, skipped := Render(groupedDataset(tc.cfg))
+			s.Empty(skipped)
+			s.Require().Len(images, 2)
+			s.golden(tc.name, images[0].SVG)
+		})
+	}
+}
+
+func (s *SVGSuite) TestRenderIsDeterministic() {
+	first, _ := Render(groupedDataset(&barchart.Config{Type: "bar"}, &linechart.Config{Type: "line"}))
+	second, _ := Render(groupedDataset(&barchart.Config{Type: "bar"}, &linechart.Config{Type: "line"}))
+	s.Equal(first, second)
+}
+
+func (s *SVGSuite) TestRenderNamesImagesAndSkipsUnsupported() {
+	images, skipped := Render(groupedDataset(
+		&barchart.Config{Type: "bar"},
+		&heatmapchart.Config{Type: "heatmap"},
+		&piechart.Config{Type: "pie"},
+	))
+	var names []string
+	for _, img := range images {
+		names = append(names, img.Name)
+	}
+	s.Equal([]string{
+		"bar-execution-time-ns-op", "bar-memory-usage-b-op",
+		"pie-execution-time-ns-op", "pie-memory-usage-b-op",
+	}, names)
+	s.Equal([]string{"heatmap"}, skipped)
+}
+
+func (s *SVGSuite) TestThemeColors() {
+	ds := groupedDataset(&barchart.Config{Type: "bar"})
+	images, _ := Render(ds)
+	s.Contains(string(images[0].SVG), `fill="#5470C6"`, "default palette")
+
+	ds.Themes = []shared.Theme{{Name: "mine", Colors: []string{"#112233", "#445566"}}}
+	images, _ = Render(ds)
+	s.Contains(string(images[0].SVG), `fill="#112233"`)
+	s.NotContains(string(images[0].SVG), `fill="#5470C6"`)
+}
+
+func (s *SVGSuite) TestSortDoesNotLeakAcrossCharts() {
+	desc := &shared.Sort{Enabled: true, Order: "desc"}
+	images, _ := Render(groupedDataset(&barchart.Config{Type: "bar", Sort: desc}, &linechart.Config{Type: "line"}))
+	unsorted, _ := Render(groupedDataset(&linechart.Config{Type: "line"}))
+	s.Equal(unsorted[0].SVG, images[2].SVG)
+}
+
+func (s *SVGSuite) TestGrid() {
+	images, _ := Render(groupedDataset(&barchart.Config{Type: "bar"}, &piechart.Config{Type: "pie"}))
+	grid := Grid(images, 2)
+	s.True(bytes.HasPrefix(grid, []byte(`<svg xmlns="http://www.w3.org/2000/svg" width="1600" height="900"`)))
+	s.Equal(4, bytes.Count(grid, []byte(`<svg x=`)))
+	s.Contains(string(grid), `<svg x="800" y="450"`)
+	s.Equal(1, bytes.Count(grid, []byte("xmlns")), "nested images drop their own root")
+}
+
+func (s *SVGSuite) TestSupported() {
+	for _, t := range []string{"bar", "line", "scatter", "pie"} {
+		s.True(Supported(t), t)
+	}
+	s.False(Supported("heatmap"))
+}
+
+func (s *SVGSuite) TestSlug() {
+	s.Equal("bar-memory-usage-b-op", Slug("bar Memory Usage (B/op)"))
+	s.Equal("v1-2", Slug("--V1.2--"))
+	s.Equal("", Slug("()"))
+}
+
+func TestSVGSuite(t *testing.T) {
+	suite.Run(t, new(SVGSuite))
+}
@@ -95,6 +95,70 @@ func (s *ChunksSuite) TestSelectChunks() {
 		assert.NotContains(t, got, root3D, "3D renderer is pruned when needs3D is false")
 	})
 
+	t.Run("treemap and sunburst keep only their own renderers", func(t *testing.T) {
+		got := decodeChunks(t, string(SelectChunks([]string{"treemap", "sunburst"}, false, false)))
+
+		assert.Contains(t, got, entry, "entry chunk is always shipped")
+		for _, name := range []string{"treemap", "sunburst"} {
+			if root, ok := VizbChartRoots[name]; ok {
+				assert.Contains(t, got, root, "%s renderer is kept", name)
+			}
+		}
+		for _, name := range []string{"bar", "line", "scatter", "pie", "heatmap", "radar", "sankey", "chord", "3d"} {
+			assert.NotContains(t, got, VizbChartRoots[name], "unselected %s renderer is pruned", name)
+		}
+	})
+
+	t.Run("histogram keeps only its own renderer", func(t *testing.T) {
+		got := decodeChunks(t, string(SelectChunks([]string{"histogram"}, false, false)))
+
+		assert.Contains(t, got, entry, "entry chunk is always shipped")
+		if root, ok := VizbChartRoots["histogram"]; ok {
+			assert.Contains(t, got, root, "histogram renderer is kept")
+		}
+		for _, name := range []string{"bar", "line", "scatter", "pie", "heatmap", "radar", "sankey", "chord", "treemap", "sunburst", "3d"} {
+			assert.NotContains(t, got, VizbChartRoots[name], "unselected %s renderer is pruned", name)
+		}
+	})
+
+	t.Run("parallel keeps only its own renderer", func(t *testing.T) {
+		got := decodeChunks(t, string(SelectChunks([]string{"parallel"}, false, false)))
+
+		assert.Contains(t, got, entry, "entry chunk is always shipped")
+		if root, ok := VizbChartRoots["parallel"]; ok {
+			assert.Contains(t, got, root, "parallel renderer is kept")
+		}
+		for _, name := range []string{"bar", "line", "scatter", "pie", "heatmap", "radar", "sankey", "chord", "treemap", "sunburst", "histogram", "3d"} {
+			assert.NotContains(t, got, VizbChartRoots[name], "unselected %s renderer is pruned", name)
+		}
+	})
+
+	t.Run("calendar keeps only its own renderer", func(t *testing.T) {
+		got := decodeChunks(t, string(SelectChunks([]string{"calendar"}, false, false)))
+
+		assert.Contains(t, got, entry, "entry chunk is always shipped")
+		if root, ok := VizbChartRoots["calendar"]; ok {
+			assert.Contains(t, got, root, "calendar renderer is kept")
+		}
+		for _, name := range []string{"bar", "line", "scatter", "pie", "heatmap", "radar", "sankey", "chord", "treemap", "sunburst", "histogram", "parallel", "3d"} {
+			assert.NotContains(t, got, VizbChartRoots[name], "unselected %s renderer is pruned", name)
+		}
+	})
+
+	t.Run("bump and waterfall keep only their own renderers", func(t *testing.T) {
+		got := decodeChunks(t, string(SelectChunks([]string{"bump", "waterfall"}, false, false)))
+
+		assert.Contains(t, got, entry, "entry chunk is always shipped")
+		for _, name := range []string{"bump", "waterfall"} {
+			if root, ok := VizbChartRoots[name]; ok {
+				assert.Contains(t, got, root, "%s renderer is kept", name)
+			}
+		}
+		for _, name := range []string{"bar", "line", "scatter", "pie", "heatmap", "radar", "sankey", "chord", "treemap", "sunburst", "histogram", "parallel", "calendar", "3d"} {
+			assert.NotContains(t, got, VizbChartRoots[name], "unselected %s renderer is pruned", name)
+		}
+	})
+
 	t.Run("empty selection ships default renderers", func(t *testing.T) {
 		got := decodeChunks(t, string(SelectChunks(nil, false, false)))
 
@@ -0,0 +1,156 @@
+package vizb
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	histogramchart "github.com/goptics/vizb/internal/charts/histogram"
+	"github.com/goptics/vizb/pkg/core"
+	"github.com/goptics/vizb/shared"
+)
+
+// axisKeys are the dimension keys a dataset's axes use, in serial order.
+var axisKeys = []string{"name", "x", "y", "z"}
+
+// Builder assembles a Dataset from points added by hand. Its methods return
+// the builder so calls chain; Build checks the result.
+type Builder struct {
+	ds     shared.Dataset
+	charts []Chart
+	time   time.Time
+}
+
+// NewDataset starts a dataset called name.
+func NewDataset(name string) *Builder {
+	return &Builder{ds: shared.Dataset{Name: name}}
+}
+
+func (b *Builder) ID(id string) *Builder { b.ds.ID = strings.TrimSpace(id); return b }
+
+func (b *Builder) Description(text string) *Builder { b.ds.Description = text; return b }
+
+// Tag labels the run, e.g. a version, for Merge to order runs by.
+func (b *Builder) Tag(tag string) *Builder { b.ds.Tag = tag; return b }
+
+// Timestamp is when the run happened; Build uses the current time when unset.
+func (b *Builder) Timestamp(t time.Time) *Builder { b.time = t; return b }
+
+// Commit records the source revision the run measured.
+func (b *Builder) Commit(c Commit) *Builder { b.ds.Commit = &c; return b }
+
+// Meta records the run environment.
+func (b *Builder) Meta(m Meta) *Builder { b.ds.Meta = &m; return b }
+
+// Themes sets the theme catalog; the first theme is active.
+func (b *Builder) Themes(themes ...Theme) *Builder { b.ds.Themes = themes; return b }
+
+// Axes names the dimensions the points use, keyed "name", "x", "y" or "z".
+// Without it Build gives every dimension a point sets an unlabelled axis.
+func (b *Builder) Axes(axes ...Axis) *Builder { b.ds.Axes = axes; return b }
+
+// Add appends data points.
+func (b *Builder) Add(points ...DataPoint) *Builder {
+	b.ds.Data = append(b.ds.Data, points...)
+	return b
+}
+
+// Annotate adds reference marks drawn on every bar, line and scatter chart.
+func (b *Builder) Annotate(marks ...Annotation) *Builder {
+	b.ds.Annotations = append(b.ds.Annotations, marks...)
+	return b
+}
+
+// Charts chooses the charts, in render order; bar, line and pie when none
+// are chosen. Each chart type may appear once.
+func (b *Builder) Charts(charts ...Chart) *Builder { b.charts = charts; return b }
+
+// PreserveRows keeps points that share their dimension values as separate
+// rows instead of the UI averaging them.
+func (b *Builder) PreserveRows(preserve bool) *Builder { b.ds.PreserveRows = preserve; return b }
+
+// Build checks the dataset and resolves its charts. A histogram chart bins
+// the points as vizb histogram does. Options the data can't use are dropped
+// and reported by Dataset.Warnings; options that contradict it are errors.
+func (b *Builder) Build() (*Dataset, error) {
+	ds := b.ds
+	ds.Data = slices.Clone(ds.Data)
+	if len(ds.Data) == 0 {
+		return nil, fmt.Errorf("vizb: dataset %q has no data points", ds.Name)
+	}
+	if len(ds.Axes) == 0 {
+		ds.Axes = inferAxes(ds.Data)
+	} else if err := validateAxes(ds.Axes); err != nil {
+		return nil, fmt.Errorf("vizb: %w", err)
+	}
+	for i, a := range ds.Annotations {
+		if err := a.Validate(); err != nil {
+			return nil, fmt.Errorf("vizb: annotation %d: %w", i+1, err)
+		}
+	}
+
+	settings, err := chartConfigs(b.charts)
+	if err != nil {
+		return nil, fmt.Errorf("vizb: %w", err)
+	}
+	ds.Settings = settings
+	for _, cfg := range settings {
+		if histogram, ok := cfg.(*histogramchart.Config); ok {
+			ds.Data, ds.Axes = shared.BinDataPoints(ds.Data, ds.Axes, histogram.BinOptions())
+			ds.PreserveRows = false
+			break
+		}
+	}
+
+	t := b.time
+	if t.IsZero() {
+		t = time.Now()
+	}
+	ds.Timestamp = t.UTC().Format(time.RFC3339)
+	ds.SchemaVersion = shared.CurrentSchemaVersion
+
+	warnings, err := core.CheckCharts(&ds)
+	if err != nil {
+		return nil, fmt.Errorf("vizb: %w", err)
+	}
+	return wrap(ds, warnings), nil
+}
+
+// inferAxes gives each dimension set on any point an unlabelled axis.
+func inferAxes(points []DataPoint) []Axis {
+	var axes []Axis
+	for _, key := range axisKeys {
+		if slices.ContainsFunc(points, func(p DataPoint) bool { return dimensionValue(p, key) != "" }) {
+			axes = append(axes, Axis{Key: key})
+		}
+	}
+	return axes
+}
+
+func dimensionValue(p DataPoint, key string) string {
+	switch key {
+	case "name":
+		return p.Name
+	case "x":
+		return p.XAxis
+	case "y":
+		return p.YAxis
+	default:
+		return p.ZAxis
+	}
+}
+
+func validateAxes(axes []Axis) error {
+	seen := map[string]bool{}
+	for _, a := range axes {
+		if !slices.Contains(axisKeys, a.Key) {
+			return fmt.Errorf("invalid axis key %q; expected %s", a.Key, strings.Join(axisKeys, ", "))
+		}
+		if seen[a.Key] {
+			return fmt.Errorf("axis %q given twice", a.Key)
+		}
+		seen[a.Key] = true
+	}
+	return nil
+}
@@ -0,0 +1,425 @@
+package vizb
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	internalcharts "github.com/goptics/vizb/internal/charts"
+	"github.com/goptics/vizb/internal/flags"
+	"github.com/goptics/vizb/internal/specparse"
+	"github.com/goptics/vizb/shared"
+
+	// Chart types register their config factory and option descriptors in
+	// init, as the vizb command does.
+	_ "github.com/goptics/vizb/internal/charts/all"
+)
+
+// Chart is one chart a dataset renders, built by a constructor such as Bar or
+// Line. Option values use the same syntax as the matching vizb <chart> flag
+// and are checked when the chart is used by Build or Convert.
+type Chart struct {
+	typ  string
+	seed map[string]any
+	err  error
+}
+
+// Type is the chart type, e.g. "bar".
+func (c Chart) Type() string { return c.typ }
+
+// config resolves the chart's defaults and options into its typed config.
+func (c Chart) config() (internalcharts.ChartConfig, error) {
+	if c.typ == "" {
+		return nil, fmt.Errorf("chart has no type; build charts with Bar, Line, ...")
+	}
+	if c.err != nil {
+		return nil, fmt.Errorf("%s chart: %w", c.typ, c.err)
+	}
+	cfg, err := internalcharts.Materialise(c.typ, maps.Clone(c.seed), nil)
+	if err != nil {
+		return nil, fmt.Errorf("%s chart: %w", c.typ, err)
+	}
+	return cfg, nil
+}
+
+// defaultCharts are the charts vizb draws when none are chosen.
+func defaultCharts() []Chart {
+	out := make([]Chart, len(shared.DefaultChartTypes))
+	for i, typ := range shared.DefaultChartTypes {
+		out[i] = Chart{typ: typ}
+	}
+	return out
+}
+
+func chartConfigs(charts []Chart) ([]internalcharts.ChartConfig, error) {
+	if len(charts) == 0 {
+		charts = defaultCharts()
+	}
+	configs := make([]internalcharts.ChartConfig, 0, len(charts))
+	seen := map[string]bool{}
+	for _, c := range charts {
+		cfg, err := c.config()
+		if err != nil {
+			return nil, err
+		}
+		if seen[c.typ] {
+			return nil, fmt.Errorf("%s chart given twice", c.typ)
+		}
+		seen[c.typ] = true
+		configs = append(configs, cfg)
+	}
+	return configs, nil
+}
+
+// StatPanel enables the statistics panel (--stat). Math limits it to some
+// categories (e.g. "center", "percentiles"); empty shows them all.
+type StatPanel struct {
+	Math []string
+}
+
+// ChartOptions are the options every chart in the bar family takes.
+type ChartOptions struct {
+	Swap   string     `flag:"swap"`        // axis permutation, e.g. "yxn"
+	Sort   string     `flag:"sort"`        // asc or desc
+	Labels bool       `flag:"show-labels"` // data labels on the chart
+	Stat   *StatPanel `flag:"stat"`
+}
+
+// ValueAxisOptions shape the value axis of bar, line and scatter charts.
+type ValueAxisOptions struct {
+	Scale        string  `flag:"scale"`         // linear (default) or log
+	Min          string  `flag:"min"`           // a number, or a percentile such as "p5"
+	Max          string  `flag:"max"`           // a number, or a percentile such as "p95"
+	LogBase      float64 `flag:"log-base"`      // base for the log scale; 10 when zero
+	ZeroBaseline bool    `flag:"zero-baseline"` // start the axis at zero
+	Inverse      bool    `flag:"inverse"`
+	Zoom         string  `flag:"zoom"` // slider, inside or both
+}
+
+// Mark is a reference mark (--mark): a threshold line, band or point.
+type Mark struct {
+	Type  string   `flag:"type"` // hline, vline, band or point
+	Stat  string   `flag:"stat"`
+	Value *float64 `flag:"value"`
+	From  *float64 `flag:"from"`
+	To    *float64 `flag:"to"`
+	At    string   `flag:"at"`
+	Label string   `flag:"label"`
+	Color string   `flag:"color"`
+}
+
+// Background draws a category background behind bars (--bg). The zero value
+// turns it on with the default style.
+type Background struct {
+	Color         string   `flag:"color"`
+	BorderColor   string   `flag:"borderColor"`
+	BorderWidth   *float64 `flag:"borderWidth"`
+	BorderType    string   `flag:"borderType"`
+	BorderRadius  string   `flag:"borderRadius"`
+	ShadowBlur    *float64 `flag:"shadowBlur"`
+	ShadowColor   string   `flag:"shadowColor"`
+	ShadowOffsetX *float64 `flag:"shadowOffsetX"`
+	ShadowOffsetY *float64 `flag:"shadowOffsetY"`
+	Opacity       *float64 `flag:"opacity"`
+}
+
+// Brush styles axis brushing on parallel charts (--brush). The zero value
+// keeps the renderer's defaults.
+type Brush struct {
+	Color           string   `flag:"color"`
+	Width           *float64 `flag:"width"`
+	Opacity         *float64 `flag:"opacity"`
+	ActiveOpacity   *float64 `flag:"activeOpacity"`
+	InactiveOpacity *float64 `flag:"inactiveOpacity"`
+}
+
+// BarOptions mirror the vizb bar flags.
+type BarOptions struct {
+	ChartOptions
+	ValueAxisOptions
+	Stack           bool        `flag:"stack"`
+	Y2              string      `flag:"y2"` // "auto" or comma-separated series names
+	ThreeD          bool        `flag:"3d"`
+	ThreeDRotate    bool        `flag:"3d-rotate"`
+	ThreeDVisualMap bool        `flag:"3d-visualmap"`
+	Horizontal      bool        `flag:"horizontal"`
+	BorderRadius    string      `flag:"border-radius"` // "8" or "8,8,0,0"
+	Background      *Background `flag:"bg"`
+	Marks           []Mark      `flag:"mark"`
+}
+
+// LineOptions mirror the vizb line flags.
+type LineOptions struct {
+	ChartOptions
+	ValueAxisOptions
+	Stack           bool    `flag:"stack"`
+	Y2              string  `flag:"y2"`
+	ThreeD          bool    `flag:"3d"`
+	ThreeDRotate    bool    `flag:"3d-rotate"`
+	ThreeDVisualMap bool    `flag:"3d-visualmap"`
+	Symbol          string  `flag:"symbol"`
+	SymbolSize      float64 `flag:"symbol-size"`
+	Smooth          bool    `flag:"smooth"`
+	Marks           []Mark  `flag:"mark"`
+}
+
+// ScatterOptions mirror the vizb scatter flags.
+type ScatterOptions struct {
+	ChartOptions
+	ValueAxisOptions
+	ThreeD          bool    `flag:"3d"`
+	ThreeDRotate    bool    `flag:"3d-rotate"`
+	ThreeDVisualMap bool    `flag:"3d-visualmap"`
+	VisualMap       bool    `flag:"visualmap"`
+	Symbol          string  `flag:"symbol"`
+	SymbolSize      float64 `flag:"symbol-size"`
+	Marks           []Mark  `flag:"mark"`
+}
+
+// HistogramOptions mirror the vizb histogram flags.
+type HistogramOptions struct {
+	ChartOptions
+	Bins       int     `flag:"bins"`
+	BinWidth   float64 `flag:"bin-width"`
+	BinMethod  string  `flag:"bin-method"` // count, width, fd or log
+	Cumulative bool    `flag:"cumulative"`
+}
+
+// HierarchyOptions mirror the vizb treemap and sunburst flags.
+type HierarchyOptions struct {
+	ChartOptions
+	LeafDepth   int    `flag:"leaf-depth"`
+	LabelLevels int    `flag:"label-levels"`
+	ValueStat   string `flag:"value-stat"`
+}
+
+// ParallelOptions mirror the vizb parallel flags.
+type ParallelOptions struct {
+	Swap      string            `flag:"swap"`
+	Sort      string            `flag:"sort"`
+	Stat      *StatPanel        `flag:"stat"`
+	Scale     string            `flag:"scale"`
+	AxisScale map[string]string `flag:"axis-scale"` // stat type → linear or log
+	Brush     *Brush            `flag:"brush"`
+}
+
+// CalendarOptions mirror the vizb calendar flags.
+type CalendarOptions struct {
+	Swap      string     `flag:"swap"`
+	Labels    bool       `flag:"show-labels"`
+	Stat      *StatPanel `flag:"stat"`
+	WeekStart string     `flag:"week-start"` // sunday or monday
+	Years     string     `flag:"years"`      // "2024" or "2022-2024"
+	CellSize  int        `flag:"cell-size"`
+	VisualMap bool       `flag:"visualmap"`
+}
+
+// BumpOptions mirror the vizb bump flags.
+type BumpOptions struct {
+	Labels  bool       `flag:"show-labels"`
+	Stat    *StatPanel `flag:"stat"`
+	TagAxis string     `flag:"tag-axis"` // n, x, y or z; detected when empty
+	Rank    string     `flag:"rank"`     // asc or desc
+}
+
+// WaterfallOptions mirror the vizb waterfall flags.
+type WaterfallOptions struct {
+	Sort    string     `flag:"sort"`
+	Labels  bool       `flag:"show-labels"`
+	Stat    *StatPanel `flag:"stat"`
+	TagAxis string     `flag:"tag-axis"`
+	From    string     `flag:"from"` // oldest tag when empty
+	To      string     `flag:"to"`   // latest tag when empty
+}
+
+// Bar is a bar chart.
+func Bar(opts BarOptions) Chart { return newChart("bar", opts) }
+
+// Line is a line chart.
+func Line(opts LineOptions) Chart { return newChart("line", opts) }
+
+// Scatter is a scatter chart.
+func Scatter(opts ScatterOptions) Chart { return newChart("scatter", opts) }
+
+// Pie is a pie chart.
+func Pie(opts ChartOptions) Chart { return newChart("pie", opts) }
+
+// Radar is a radar chart.
+func Radar(opts ChartOptions) Chart { return newChart("radar", opts) }
+
+// Heatmap is a heatmap chart.
+func Heatmap(opts ChartOptions) Chart { return newChart("heatmap", opts) }
+
+// Sankey is a sankey chart: source on x, target on y.
+func Sankey(opts ChartOptions) Chart { return newChart("sankey", opts) }
+
+// Chord is a chord chart: source on x, target on y.
+func Chord(opts ChartOptions) Chart { return newChart("chord", opts) }
+
+// Histogram is a histogram of the active stat.
+func Histogram(opts HistogramOptions) Chart { return newChart("histogram", opts) }
+
+// Treemap is a treemap of the dataset's dimensions.
+func Treemap(opts HierarchyOptions) Chart { return newChart("treemap", opts) }
+
+// Sunburst is a sunburst of the dataset's dimensions.
+func Sunburst(opts HierarchyOptions) Chart { return newChart("sunburst", opts) }
+
+// Parallel is a parallel-coordinates chart, one axis per stat type.
+func Parallel(opts ParallelOptions) Chart { return newChart("parallel", opts) }
+
+// Calendar is a calendar heatmap of dates on x.
+func Calendar(opts CalendarOptions) Chart { return newChart("calendar", opts) }
+
+// Bump ranks every benchmark at each tag of a merged dataset.
+func Bump(opts BumpOptions) Chart { return newChart("bump", opts) }
+
+// Waterfall breaks the change between two tags of a merged dataset down by
+// benchmark.
+func Waterfall(opts WaterfallOptions) Chart { return newChart("waterfall", opts) }
+
+func newChart(typ string, opts any) Chart {
+	seed, err := chartSeed(typ, reflect.ValueOf(opts))
+	return Chart{typ: typ, seed: seed, err: err}
+}
+
+// chartSeed turns an options struct into the chart's config seed, validating
+// and encoding each set field through the descriptor of the flag its tag
+// names, exactly as the command line does.
+func chartSeed(typ string, opts reflect.Value) (map[string]any, error) {
+	byName := map[string]flags.Flag{}
+	for _, f := range internalcharts.FlagsFor(typ) {
+		byName[f.Name] = f
+	}
+	seed := map[string]any{}
+	var walk func(v reflect.Value) error
+	walk = func(v reflect.Value) error {
+		for i := range v.NumField() {
+			field, value := v.Type().Field(i), v.Field(i)
+			if field.Anonymous {
+				if err := walk(value); err != nil {
+					return err
+				}
+				continue
+			}
+			name := field.Tag.Get("flag")
+			if name == "" || value.IsZero() {
+				continue
+			}
+			f, ok := byName[name]
+			if !ok {
+				return fmt.Errorf("%s charts have no %s option", typ, name)
+			}
+			payload, err := flagPayload(f, value)
+			if err != nil {
+				return fmt.Errorf("%s: %w", field.Name, err)
+			}
+			seed[f.JSONKey] = payload
+		}
+		return nil
+	}
+	if err := walk(opts); err != nil {
+		return nil, err
+	}
+	return seed, nil
+}
+
+func flagPayload(f flags.Flag, v reflect.Value) (any, error) {
+	switch f.Kind {
+	case flags.KindStat:
+		panel := v.Interface().(*StatPanel)
+		for _, m := range panel.Math {
+			if !slices.Contains(shared.ValidStatMath, m) {
+				return nil, fmt.Errorf("stat category %q is invalid (valid: %s)", m, strings.Join(shared.ValidStatMath, ", "))
+			}
+		}
+		return map[string]any{"enabled": true, "math": append([]string{}, panel.Math...)}, nil
+	case flags.KindObject:
+		if v.Kind() == reflect.Slice {
+			list := make([]any, 0, v.Len())
+			for i := range v.Len() {
+				bag, err := objectPayload(f, v.Index(i))
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, bag)
+			}
+			return list, nil
+		}
+		return objectPayload(f, v.Elem())
+	case flags.KindBool:
+		return encodeFlag(f, v.Bool()), nil
+	}
+
+	raw := scalarString(v)
+	if f.Normalizer != nil {
+		raw = f.Normalizer(raw)
+	}
+	// Options the command line warns about and resets are errors here: a
+	// program has no user to read the warning.
+	if f.ValidSet != nil && !slices.Contains(f.ValidSet, raw) {
+		return nil, fmt.Errorf("invalid %s %q (valid: %s)", f.EffectiveLabel(), raw, strings.Join(f.ValidSet, ", "))
+	}
+	if f.Validate != nil {
+		if err := f.Validate(raw); err != nil {
+			return nil, err
+		}
+	}
+	switch f.Kind {
+	case flags.KindInt:
+		return encodeFlag(f, int(v.Int())), nil
+	case flags.KindFloat:
+		return encodeFlag(f, v.Float()), nil
+	default:
+		return encodeFlag(f, raw), nil
+	}
+}
+
+// objectPayload validates an object option's set fields as the object flag's
+// props and encodes the resulting bag.
+func objectPayload(f flags.Flag, v reflect.Value) (any, error) {
+	var props []specparse.Prop
+	for i := range v.NumField() {
+		name, value := v.Type().Field(i).Tag.Get("flag"), v.Field(i)
+		if name == "" || value.IsZero() {
+			continue
+		}
+		if value.Kind() == reflect.Pointer {
+			value = value.Elem()
+		}
+		props = append(props, specparse.Prop{Key: name, Value: scalarString(value), HasValue: true})
+	}
+	bag, err := shared.ParseObjectBag(props, f.ObjectFields)
+	if err != nil {
+		return nil, err
+	}
+	return encodeFlag(f, bag), nil
+}
+
+// scalarString renders an option value in its flag syntax.
+func scalarString(v reflect.Value) string {
+	switch v.Kind() {
+	case reflect.Int:
+		return strconv.Itoa(int(v.Int()))
+	case reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, 64)
+	case reflect.Map:
+		pairs := make([]string, 0, v.Len())
+		for _, k := range slices.Sorted(maps.Keys(v.Interface().(map[string]string))) {
+			pairs = append(pairs, k+"="+v.MapIndex(reflect.ValueOf(k)).String())
+		}
+		return strings.Join(pairs, ",")
+	default:
+		return v.String()
+	}
+}
+
+func encodeFlag(f flags.Flag, v any) any {
+	if f.Encode != nil {
+		return f.Encode(v)
+	}
+	return v
+}
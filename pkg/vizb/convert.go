@@ -0,0 +1,141 @@
+package vizb
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/goptics/vizb/pkg/core"
+	"github.com/goptics/vizb/pkg/parser"
+	"github.com/goptics/vizb/shared"
+)
+
+// Options configure Convert. The zero value auto-detects the input format
+// and draws the default charts; the fields match the flags of the root vizb
+// command and the fields of a POST / request to vizb serve.
+type Options struct {
+	// Parser is the input format, e.g. "go", "csv", "criterion"; empty
+	// detects it from the input.
+	Parser string
+
+	Name        string
+	ID          string
+	Description string
+	Tag         string
+	Timestamp   time.Time // the conversion time when zero
+	Commit      *Commit
+	Meta        *Meta // the environment a Go benchmark run reports when nil
+	Themes      []Theme
+
+	// GroupPattern splits benchmark names into dimensions, e.g. "x/y";
+	// "x" when empty. GroupRegex, when set, is used instead.
+	GroupPattern string
+	GroupRegex   string
+	// Group lists the tabular columns that make up the dimensions.
+	Group []string
+	// ColAxis places the numeric column names of grouped tabular input on
+	// this axis ("n", "x", "y" or "z").
+	ColAxis string
+	// Filter keeps only benchmarks whose name matches the regex.
+	Filter string
+
+	MemUnit    string // b, B (default), KB, MB or GB
+	TimeUnit   string // ns (default), us, ms or s
+	NumberUnit string // K, M, B or T; raw numbers when empty
+	Round      bool   // round values to two decimals
+	// JSONPath selects the rows of json, yaml and toml input.
+	JSONPath string
+	// Better overrides which way named stats improve.
+	Better map[string]Direction
+
+	// Charts are the charts to draw; bar, line and pie when empty.
+	Charts []Chart
+}
+
+// Convert reads benchmark or tabular input and builds its Dataset, as the
+// root vizb command and POST / do. Options the data can't use are dropped and reported by
+// Dataset.Warnings.
+func Convert(r io.Reader, opts Options) (*Dataset, error) {
+	input, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("vizb: %w", err)
+	}
+	cfg, err := parserConfig(opts)
+	if err != nil {
+		return nil, fmt.Errorf("vizb: %w", err)
+	}
+	charts, err := chartConfigs(opts.Charts)
+	if err != nil {
+		return nil, fmt.Errorf("vizb: %w", err)
+	}
+	var timestamp string
+	if !opts.Timestamp.IsZero() {
+		timestamp = opts.Timestamp.UTC().Format(time.RFC3339)
+	}
+	result, err := core.Convert(core.ConvertInput{
+		Input:  input,
+		Parser: opts.Parser,
+		Config: cfg,
+		Metadata: core.Metadata{
+			ID:          opts.ID,
+			Name:        opts.Name,
+			Themes:      opts.Themes,
+			Description: opts.Description,
+			Tag:         opts.Tag,
+			Commit:      opts.Commit,
+			System:      opts.Meta,
+			Timestamp:   timestamp,
+		},
+		Charts: charts,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("vizb: %w", err)
+	}
+	return wrap(*result.Dataset, result.Warnings), nil
+}
+
+// parserConfig checks opts and resolves them into the parser configuration,
+// the way the convert API does for a request.
+func parserConfig(opts Options) (parser.Config, error) {
+	cfg := parser.Config{
+		GroupPattern: "x",
+		GroupRegex:   opts.GroupRegex,
+		Group:        slices.Clone(opts.Group),
+		ColAxis:      opts.ColAxis,
+		Filter:       opts.Filter,
+		MemUnit:      "B",
+		TimeUnit:     "ns",
+		NumberUnit:   opts.NumberUnit,
+		Round:        opts.Round,
+		JSONPath:     opts.JSONPath,
+		Better:       maps.Clone(opts.Better),
+	}
+	if opts.GroupPattern != "" {
+		cfg.GroupPattern = opts.GroupPattern
+	}
+	if opts.MemUnit != "" {
+		cfg.MemUnit = opts.MemUnit
+	}
+	if opts.TimeUnit != "" {
+		cfg.TimeUnit = opts.TimeUnit
+	}
+	if err := core.ValidateConfig(cfg); err != nil {
+		return cfg, err
+	}
+	for name, d := range cfg.Better {
+		parsed, err := shared.ParseDirection(string(d))
+		if err != nil {
+			return cfg, fmt.Errorf("better %q: %w", name, err)
+		}
+		cfg.Better[name] = parsed
+	}
+
+	cfg, err := parser.ResolveGroupConfig(cfg)
+	if err != nil {
+		return cfg, err
+	}
+	cfg.Mode = parser.ResolveMode(cfg)
+	return cfg, nil
+}
@@ -0,0 +1,223 @@
+// Package vizb is the Go API for building, converting, merging and rendering
+// vizb Datasets. It offers what the vizb command does without flags, files
+// or process exits: every failure is returned as an error.
+//
+// A Dataset comes from a Builder (points added by hand), from Convert
+// (benchmark or tabular input in any format the command reads) or from
+// ReadDatasets (Dataset JSON written by vizb). Charts are chosen with the
+// typed constructors Bar, Line, Pie, ... whose options mirror the flags of
+// the matching vizb <chart> command.
+//
+//	ds, err := vizb.NewDataset("Sort").
+//		Axes(vizb.Axis{Key: "x", Label: "size"}).
+//		Add(vizb.DataPoint{XAxis: "1024", Stats: []vizb.Stat{vizb.Measure("Execution Time", 812, "ns", "op")}}).
+//		Charts(vizb.Bar(vizb.BarOptions{Stack: true}), vizb.Line(vizb.LineOptions{})).
+//		Build()
+//	if err != nil { ... }
+//	err = vizb.RenderHTML(w, ds)
+package vizb
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/goptics/vizb/pkg/core"
+	"github.com/goptics/vizb/shared"
+)
+
+// The plain data types of a Dataset, shared with the Dataset JSON format.
+type (
+	Axis          = shared.Axis
+	Stat          = shared.Stat
+	DataPoint     = shared.DataPoint
+	Meta          = shared.Meta
+	CPUInfo       = shared.CPUInfo
+	Commit        = shared.Commit
+	Theme         = shared.Theme
+	Annotation    = shared.Annotation
+	Direction     = shared.Direction
+	Retention     = shared.Retention
+	Reconcile     = shared.Reconcile
+	MergeConflict = shared.MergeConflict
+)
+
+// Directions a stat improves in.
+const (
+	Lower   = shared.DirectionLower
+	Higher  = shared.DirectionHigher
+	Neutral = shared.DirectionNeutral
+)
+
+// Measure is a stat named the way the parsers name theirs: the label reads
+// "name (unit/per)", e.g. Measure("Execution Time", 812, "ns", "op"), and
+// the better direction follows from the unit.
+func Measure(name string, value float64, unit, per string) Stat {
+	return Stat{
+		Type:   shared.StatLabel(name, unit, per),
+		Value:  shared.F64(value),
+		Unit:   unit,
+		Per:    per,
+		Better: shared.DirectionForUnit(unit, per),
+	}
+}
+
+// Dataset is one chartable dataset: its metadata, axes, points and charts.
+// Its JSON form is the Dataset file format vizb reads and writes.
+type Dataset struct {
+	ds       shared.Dataset
+	warnings []string
+}
+
+func wrap(ds shared.Dataset, warnings []string) *Dataset {
+	return &Dataset{ds: ds, warnings: warnings}
+}
+
+func unwrap(datasets []*Dataset) []shared.Dataset {
+	out := make([]shared.Dataset, len(datasets))
+	for i, d := range datasets {
+		out[i] = d.ds
+	}
+	return out
+}
+
+func (d *Dataset) Name() string        { return d.ds.Name }
+func (d *Dataset) ID() string          { return d.ds.ID }
+func (d *Dataset) Tag() string         { return d.ds.Tag }
+func (d *Dataset) Description() string { return d.ds.Description }
+
+// Timestamp is the RFC 3339 time the dataset was produced.
+func (d *Dataset) Timestamp() string { return d.ds.Timestamp }
+
+// Commit is the source revision the run measured, or nil.
+func (d *Dataset) Commit() *Commit { return d.ds.Commit }
+
+// Meta is the run environment, or nil.
+func (d *Dataset) Meta() *Meta { return d.ds.Meta }
+
+// Axes returns a copy of the dataset's axes.
+func (d *Dataset) Axes() []Axis { return slices.Clone(d.ds.Axes) }
+
+// Points returns a copy of the dataset's data points.
+func (d *Dataset) Points() []DataPoint {
+	out := slices.Clone(d.ds.Data)
+	for i := range out {
+		out[i].Stats = slices.Clone(out[i].Stats)
+	}
+	return out
+}
+
+// Annotations returns a copy of the dataset-wide reference marks.
+func (d *Dataset) Annotations() []Annotation { return slices.Clone(d.ds.Annotations) }
+
+// Tags are the distinct tags of a merged dataset, oldest first.
+func (d *Dataset) Tags() []string { return d.ds.Tags() }
+
+// Charts are the dataset's chart types, in render order.
+func (d *Dataset) Charts() []string {
+	out := make([]string, 0, len(d.ds.Settings))
+	for _, cfg := range d.ds.Settings {
+		out = append(out, cfg.ChartType())
+	}
+	return out
+}
+
+// Warnings are the notices for chart options the data can't use, which the
+// command line prints and skips. Only datasets from Build and Convert carry
+// them.
+func (d *Dataset) Warnings() []string { return slices.Clone(d.warnings) }
+
+func (d *Dataset) MarshalJSON() ([]byte, error) { return json.Marshal(&d.ds) }
+
+// UnmarshalJSON reads one Dataset object, migrating older schema versions.
+func (d *Dataset) UnmarshalJSON(b []byte) error {
+	if bytes.HasPrefix(bytes.TrimSpace(b), []byte("[")) {
+		return fmt.Errorf("vizb: expected a Dataset object, got an array")
+	}
+	datasets, err := shared.DecodeDatasets(b)
+	if err != nil {
+		return fmt.Errorf("vizb: %w", err)
+	}
+	*d = Dataset{ds: datasets[0]}
+	return nil
+}
+
+// ReadDatasets reads Dataset JSON, one object or an array, as written by
+// vizb or WriteJSON.
+func ReadDatasets(r io.Reader) ([]*Dataset, error) {
+	content, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("vizb: %w", err)
+	}
+	datasets, err := shared.DecodeDatasets(content)
+	if err != nil {
+		return nil, fmt.Errorf("vizb: %w", err)
+	}
+	out := make([]*Dataset, len(datasets))
+	for i, ds := range datasets {
+		out[i] = wrap(ds, nil)
+	}
+	return out, nil
+}
+
+// WriteJSON writes datasets as indented Dataset JSON: an object for one
+// dataset, an array for several.
+func WriteJSON(w io.Writer, datasets ...*Dataset) error {
+	if len(datasets) == 0 {
+		return fmt.Errorf("vizb: at least one dataset is required")
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if len(datasets) == 1 {
+		return enc.Encode(datasets[0])
+	}
+	return enc.Encode(datasets)
+}
+
+// RenderHTML writes the self-contained HTML page that charts datasets.
+func RenderHTML(w io.Writer, datasets ...*Dataset) error {
+	html, err := core.GenerateUI(unwrap(datasets), nil)
+	if err != nil {
+		return fmt.Errorf("vizb: %w", err)
+	}
+	_, err = io.WriteString(w, html)
+	return err
+}
+
+// MergeOptions configures Merge.
+type MergeOptions struct {
+	// TagAxis receives the tags: "name" (default), "x", "y" or "z".
+	TagAxis string
+	// Retention prunes each merged dataset's tags; the zero value keeps all.
+	Retention Retention
+	// Reconcile renames benchmarks, axis labels and stats before merging.
+	Reconcile Reconcile
+	// OnConflict, when set, receives every input disagreement the merge had
+	// to resolve. Inputs are named "input 1", "input 2", ... in its messages.
+	OnConflict func(MergeConflict)
+	// OnWarning, when set, receives a notice for every Reconcile rule that
+	// renames nothing, as vizb merge prints.
+	OnWarning func(string)
+}
+
+// Merge combines tagged runs of the same benchmarks into one dataset per
+// name, with each run's tag on opts.TagAxis, as vizb merge does.
+func Merge(datasets []*Dataset, opts MergeOptions) ([]*Dataset, error) {
+	merged, err := core.MergeWithOptions(unwrap(datasets), core.MergeOptions{
+		Dimension:  shared.Dimension(opts.TagAxis),
+		Retention:  opts.Retention,
+		Reconcile:  opts.Reconcile,
+		OnConflict: opts.OnConflict,
+		OnWarning:  opts.OnWarning,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("vizb: %w", err)
+	}
+	out := make([]*Dataset, len(merged))
+	for i, ds := range merged {
+		out[i] = wrap(ds, nil)
+	}
+	return out, nil
+}
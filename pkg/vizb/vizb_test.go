@@ -0,0 +1,215 @@
+package vizb_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goptics/vizb/pkg/vizb"
+	"github.com/stretchr/testify/suite"
+)
+
+type VizbSuite struct{ suite.Suite }
+
+const goBench = `goos: linux
+goarch: amd64
+BenchmarkSort/1024-8   1000   812 ns/op   64 B/op   1 allocs/op
+BenchmarkSort/4096-8   1000  3400 ns/op  256 B/op   1 allocs/op
+PASS
+`
+
+func sortPoint(x string, ns float64) vizb.DataPoint {
+	return vizb.DataPoint{XAxis: x, Stats: []vizb.Stat{vizb.Measure("Execution Time", ns, "ns", "op")}}
+}
+
+// settings returns the JSON settings of the dataset's charts.
+func (s *VizbSuite) settings(ds *vizb.Dataset) []map[string]any {
+	raw, err := json.Marshal(ds)
+	s.Require().NoError(err)
+	var out struct{ Settings []map[string]any }
+	s.Require().NoError(json.Unmarshal(raw, &out))
+	return out.Settings
+}
+
+func (s *VizbSuite) TestBuildInfersAxesAndDefaultCharts() {
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.FixedZone("CET", 3600))
+	ds, err := vizb.NewDataset("Sort").
+		Tag("v1").
+		Timestamp(at).
+		Add(sortPoint("1024", 812), sortPoint("4096", 3400)).
+		Build()
+	s.Require().NoError(err)
+	s.Equal("Sort", ds.Name())
+	s.Equal("v1", ds.Tag())
+	s.Equal("2026-03-01T11:00:00Z", ds.Timestamp())
+	s.Equal([]vizb.Axis{{Key: "x"}}, ds.Axes())
+	s.Equal([]string{"bar", "line", "pie"}, ds.Charts())
+	s.Equal("Execution Time (ns/op)", ds.Points()[0].Stats[0].Type)
+	s.Empty(ds.Warnings())
+}
+
+func (s *VizbSuite) TestBuildRejectsBadInput() {
+	for name, b := range map[string]*vizb.Builder{
+		"no points":   vizb.NewDataset("Empty"),
+		"axis key":    vizb.NewDataset("A").Axes(vizb.Axis{Key: "w"}).Add(sortPoint("1", 1)),
+		"swap":        vizb.NewDataset("A").Add(sortPoint("1", 1)).Charts(vizb.Bar(vizb.BarOptions{ChartOptions: vizb.ChartOptions{Swap: "zyx"}})),
+		"scale":       vizb.NewDataset("A").Add(sortPoint("1", 1)).Charts(vizb.Bar(vizb.BarOptions{ValueAxisOptions: vizb.ValueAxisOptions{Scale: "cubic"}})),
+		"stat":        vizb.NewDataset("A").Add(sortPoint("1", 1)).Charts(vizb.Pie(vizb.ChartOptions{Stat: &vizb.StatPanel{Math: []string{"mode"}}})),
+		"mark":        vizb.NewDataset("A").Add(sortPoint("1", 1)).Charts(vizb.Line(vizb.LineOptions{Marks: []vizb.Mark{{Type: "arrow"}}})),
+		"twice":       vizb.NewDataset("A").Add(sortPoint("1", 1)).Charts(vizb.Pie(vizb.ChartOptions{}), vizb.Pie(vizb.ChartOptions{})),
+		"zero chart":  vizb.NewDataset("A").Add(sortPoint("1", 1)).Charts(vizb.Chart{}),
+		"annotation":  vizb.NewDataset("A").Add(sortPoint("1", 1)).Annotate(vizb.Annotation{Type: "hline"}),
+		"bins":        vizb.NewDataset("A").Add(sortPoint("1", 1)).Charts(vizb.Histogram(vizb.HistogramOptions{BinMethod: "guess"})),
+		"object enum": vizb.NewDataset("A").Add(sortPoint("1", 1)).Charts(vizb.Bar(vizb.BarOptions{Background: &vizb.Background{BorderType: "wavy"}})),
+	} {
+		_, err := b.Build()
+		s.Error(err, name)
+		if err != nil {
+			s.True(strings.HasPrefix(err.Error(), "vizb: "), err.Error())
+		}
+	}
+}
+
+func (s *VizbSuite) TestChartOptionsMatchTheFlags() {
+	ds, err := vizb.NewDataset("Sort").
+		Add(sortPoint("1024", 812), sortPoint("4096", 3400)).
+		Charts(
+			vizb.Bar(vizb.BarOptions{
+				ChartOptions:     vizb.ChartOptions{Sort: "desc", Labels: true, Stat: &vizb.StatPanel{Math: []string{"center"}}},
+				ValueAxisOptions: vizb.ValueAxisOptions{Scale: "log", ZeroBaseline: true},
+				Horizontal:       true,
+				Background:       &vizb.Background{Color: "#eee"},
+				Marks:            []vizb.Mark{{Type: "hline", Value: new(1000.0), Label: "budget"}},
+			}),
+			vizb.Line(vizb.LineOptions{Smooth: true, SymbolSize: 6}),
+		).
+		Build()
+	s.Require().NoError(err)
+	settings := s.settings(ds)
+	s.Require().Len(settings, 2)
+
+	bar := settings[0]
+	s.Equal("bar", bar["type"])
+	s.Equal("log", bar["scale"])
+	s.Equal(true, bar["horizontal"])
+	s.Equal(true, bar["showLabels"])
+	s.Equal(map[string]any{"enabled": true, "order": "desc"}, bar["sort"])
+	s.Equal(map[string]any{"enabled": true, "math": []any{"center"}}, bar["stat"])
+	s.Equal("#eee", bar["background"].(map[string]any)["color"])
+	s.Equal([]any{map[string]any{"type": "hline", "value": 1000.0, "label": "budget"}}, bar["mark"])
+
+	line := settings[1]
+	s.Equal(true, line["smooth"])
+	s.Equal(6.0, line["symbolSize"])
+	s.Equal("linear", line["scale"], "unset options keep the flag default")
+}
+
+func (s *VizbSuite) TestHistogramBinsPoints() {
+	b := vizb.NewDataset("Latency")
+	for _, v := range []float64{10, 11, 12, 30, 31} {
+		b.Add(vizb.DataPoint{Name: "api", Stats: []vizb.Stat{{Type: "latency", Value: &v}}})
+	}
+	ds, err := b.Charts(vizb.Histogram(vizb.HistogramOptions{Bins: 2})).Build()
+	s.Require().NoError(err)
+	s.Len(ds.Points(), 2)
+}
+
+func (s *VizbSuite) TestConvert() {
+	ds, err := vizb.Convert(strings.NewReader(goBench), vizb.Options{
+		Name:         "Sort",
+		GroupPattern: "n/x",
+		TimeUnit:     "us",
+		Charts:       []vizb.Chart{vizb.Bar(vizb.BarOptions{})},
+	})
+	s.Require().NoError(err)
+	s.Equal("Sort", ds.Name())
+	s.Equal([]string{"bar"}, ds.Charts())
+	s.Equal("linux", ds.Meta().OS)
+	points := ds.Points()
+	s.Require().Len(points, 2)
+	s.Equal("1024", points[0].XAxis)
+	s.Equal("Execution Time (us/op)", points[0].Stats[0].Type)
+
+	_, err = vizb.Convert(strings.NewReader(goBench), vizb.Options{TimeUnit: "hours"})
+	s.ErrorContains(err, "time unit")
+	_, err = vizb.Convert(strings.NewReader(goBench), vizb.Options{Better: map[string]vizb.Direction{"ops": "up"}})
+	s.ErrorContains(err, "better")
+	_, err = vizb.Convert(strings.NewReader(""), vizb.Options{})
+	s.ErrorContains(err, "input is empty")
+}
+
+func (s *VizbSuite) TestMergeAndRender() {
+	var runs []*vizb.Dataset
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, ns := range []float64{900, 800} {
+		ds, err := vizb.NewDataset("Sort").
+			Tag(fmt.Sprintf("v%d", i+1)).
+			Timestamp(start.AddDate(0, 0, i)).
+			Add(sortPoint("1024", ns)).
+			Build()
+		s.Require().NoError(err)
+		runs = append(runs, ds)
+	}
+	merged, err := vizb.Merge(runs, vizb.MergeOptions{})
+	s.Require().NoError(err)
+	s.Require().Len(merged, 1)
+	s.Equal([]string{"v1", "v2"}, merged[0].Tags())
+	s.Len(merged[0].Points(), 2)
+
+	_, err = vizb.Merge(runs, vizb.MergeOptions{TagAxis: "w"})
+	s.Error(err)
+
+	var page bytes.Buffer
+	s.Require().NoError(vizb.RenderHTML(&page, merged...))
+	s.Contains(page.String(), "<html")
+	s.Error(vizb.RenderHTML(&page))
+}
+
+func (s *VizbSuite) TestJSONRoundTrip() {
+	a, err := vizb.NewDataset("A").Add(sortPoint("1", 1)).Build()
+	s.Require().NoError(err)
+	b, err := vizb.NewDataset("B").Add(sortPoint("1", 2)).Charts(vizb.Pie(vizb.ChartOptions{})).Build()
+	s.Require().NoError(err)
+
+	var one, both bytes.Buffer
+	s.Require().NoError(vizb.WriteJSON(&one, a))
+	s.Require().NoError(vizb.WriteJSON(&both, a, b))
+	s.True(strings.HasPrefix(one.String(), "{"))
+	s.True(strings.HasPrefix(both.String(), "["))
+
+	read, err := vizb.ReadDatasets(&both)
+	s.Require().NoError(err)
+	s.Require().Len(read, 2)
+	s.Equal("B", read[1].Name())
+	s.Equal([]string{"pie"}, read[1].Charts())
+	s.Equal(b.Points(), read[1].Points())
+
+	var single vizb.Dataset
+	s.Require().NoError(json.Unmarshal(one.Bytes(), &single))
+	s.Equal("A", single.Name())
+	s.Error(json.Unmarshal(both.Bytes(), &single))
+	_, err = vizb.ReadDatasets(strings.NewReader("nope"))
+	s.Error(err)
+}
+
+// The library must not drag the CLI, and cobra with it, into importers.
+func (s *VizbSuite) TestDoesNotDependOnTheCLI() {
+	gocmd, err := exec.LookPath("go")
+	if err != nil {
+		s.T().Skip("go command not available")
+	}
+	out, err := exec.Command(gocmd, "list", "-deps", ".").Output()
+	s.Require().NoError(err)
+	for _, pkg := range strings.Fields(string(out)) {
+		s.False(strings.HasPrefix(pkg, "github.com/goptics/vizb/cmd/"), "pkg/vizb imports %s", pkg)
+		s.False(strings.HasPrefix(pkg, "github.com/spf13/cobra"), "pkg/vizb imports %s", pkg)
+	}
+}
+
+func TestVizbSuite(t *testing.T) {
+	suite.Run(t, new(VizbSuite))
+}
@@ -0,0 +1,353 @@
+// Package vizbtest records Go benchmarks straight into a vizb Dataset,
+// keeping what benchmark text loses: the axis each sub-benchmark parameter
+// belongs to, custom metrics, allocations and every -count sample.
+//
+// Benchmarks call Report (and ReportMetric for custom metrics); TestMain
+// hands over to Run, which writes the Dataset once the benchmarks finish:
+//
+//	func TestMain(m *testing.M) { os.Exit(vizbtest.Run(m, vizbtest.Options{})) }
+//
+//	func BenchmarkSort(b *testing.B) {
+//		for _, n := range []int{1024, 4096} {
+//			b.Run(strconv.Itoa(n), func(b *testing.B) {
+//				vizbtest.Report(b, vizbtest.Axis("size", n))
+//				for b.Loop() {
+//					sortInts(n)
+//				}
+//			})
+//		}
+//	}
+//
+// go test -bench . -vizb.tag v1.2.0 then writes vizb.json, ready for
+// vizb merge and vizb ui. Benchmarks that call neither Report nor
+// ReportMetric are not recorded.
+//
+// Only what goes through this package is recorded. A metric reported with
+// b.ReportMetric directly never reaches the Dataset; use ReportMetric. B/op
+// and allocs/op are measured from runtime.MemStats whether or not the
+// benchmark calls b.ReportAllocs or runs with -benchmem, counting from the
+// first Report or ReportMetric call. Unlike testing's own figures they
+// include allocations made while the timer was stopped, so call Report
+// after any setup you want left out.
+package vizbtest
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/goptics/vizb/pkg/vizb"
+)
+
+var (
+	outFlag = flag.String("vizb.out", "", "write the recorded benchmarks to this Dataset file (default vizb.json)")
+	tagFlag = flag.String("vizb.tag", "", "tag the recorded run, e.g. a version, for vizb merge")
+)
+
+// Dimension places a sub-benchmark on an axis; see Axis.
+type Dimension struct {
+	label string
+	value string
+}
+
+// Axis names one parameter of the benchmark. The first Axis of a Report goes
+// on x, the second on y and the third on z; label is the axis title, taken
+// from the first benchmark that reports it.
+func Axis(label string, value any) Dimension {
+	return Dimension{label: label, value: fmt.Sprint(value)}
+}
+
+// Report records this run of b with the given axes. Call it anywhere in the
+// benchmark function; the sample is taken when the function returns. Without
+// axes the sub-benchmark names fill x, y and z: BenchmarkSort/1024/quick is
+// benchmark Sort at x=1024, y=quick.
+func Report(b *testing.B, dims ...Dimension) {
+	b.Helper()
+	if len(dims) > 3 {
+		b.Fatalf("vizbtest: at most three axes, got %d", len(dims))
+	}
+	pendingRun(b).dims = dims
+}
+
+// ReportMetric reports n unit on b, as b.ReportMetric does, and records it
+// with b's sample. "ns/op", "B/op" and "allocs/op" replace the measured
+// figures. Metrics passed to b.ReportMetric directly are not recorded.
+func ReportMetric(b *testing.B, n float64, unit string) {
+	b.Helper()
+	b.ReportMetric(n, unit)
+	run := pendingRun(b)
+	run.metrics = slices.DeleteFunc(run.metrics, func(m metric) bool { return m.unit == unit })
+	run.metrics = append(run.metrics, metric{unit: unit, value: n})
+}
+
+type metric struct {
+	unit  string
+	value float64
+}
+
+// sample is one invocation of a benchmark function.
+type sample struct {
+	name    string
+	dims    []Dimension
+	metrics []metric
+	n       int
+	elapsed time.Duration
+	final   bool
+	// mallocs and bytes are the heap allocations of the whole invocation;
+	// start holds the counters they are measured from.
+	mallocs, bytes uint64
+	start          runtime.MemStats
+}
+
+// recorder holds the samples of every benchmark in the test binary.
+type recorder struct {
+	mu      sync.Mutex
+	pending map[*testing.B]*sample
+	samples []*sample
+	last    map[string]int // benchmark name → index of its latest sample
+}
+
+var rec = newRecorder()
+
+func newRecorder() *recorder {
+	return &recorder{pending: map[*testing.B]*sample{}, last: map[string]int{}}
+}
+
+// pendingRun returns the sample being collected for the current invocation
+// of b, registering its capture on first use.
+func pendingRun(b *testing.B) *sample {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	if s, ok := rec.pending[b]; ok {
+		return s
+	}
+	s := &sample{name: b.Name()}
+	rec.pending[b] = s
+	b.Cleanup(func() {
+		// Cleanups run after the timer stops, so Elapsed is the invocation's.
+		s.n, s.elapsed = b.N, b.Elapsed()
+		var end runtime.MemStats
+		runtime.ReadMemStats(&end)
+		s.mallocs, s.bytes = end.Mallocs-s.start.Mallocs, end.TotalAlloc-s.start.TotalAlloc
+		s.final = isFinal(s.n, s.elapsed)
+		rec.mu.Lock()
+		defer rec.mu.Unlock()
+		delete(rec.pending, b)
+		rec.add(s)
+	})
+	runtime.ReadMemStats(&s.start)
+	return s
+}
+
+// add keeps s, dropping the ramp-up probe testing ran just before it: a
+// shorter, non-final invocation of the same benchmark.
+func (r *recorder) add(s *sample) {
+	if s.n == 0 {
+		return
+	}
+	if i, ok := r.last[s.name]; ok {
+		if prev := r.samples[i]; !prev.final && s.n > prev.n {
+			r.samples[i] = s
+			return
+		}
+	}
+	r.last[s.name] = len(r.samples)
+	r.samples = append(r.samples, s)
+}
+
+// isFinal reports whether an invocation of n iterations lasting elapsed is
+// the one testing reports rather than a probe that sizes b.N: it ran the
+// -benchtime count, or for -benchtime long (capped at 1e9 iterations).
+func isFinal(n int, elapsed time.Duration) bool {
+	benchtime := "1s"
+	if f := flag.Lookup("test.benchtime"); f != nil {
+		benchtime = f.Value.String()
+	}
+	if count, ok := strings.CutSuffix(benchtime, "x"); ok {
+		want, err := strconv.Atoi(count)
+		return err != nil || n >= want
+	}
+	d, err := time.ParseDuration(benchtime)
+	return err != nil || elapsed >= d || n >= 1e9
+}
+
+// Options configure the Dataset Run writes.
+type Options struct {
+	// Path is the output file; -vizb.out overrides it. "vizb.json" in the
+	// package directory when both are empty.
+	Path string
+	// Name is the dataset name vizb merge joins runs on; the package
+	// directory's name when empty.
+	Name string
+	// Tag labels the run; -vizb.tag overrides it.
+	Tag         string
+	Description string
+	// Charts are the charts to draw; bar, line and pie when empty.
+	Charts []vizb.Chart
+}
+
+// Run runs the tests and benchmarks, writes the recorded benchmarks as a
+// Dataset and returns the exit code for os.Exit. A failed write is reported
+// on stderr and fails the run.
+func Run(m *testing.M, opts Options) int {
+	code := m.Run()
+	if *outFlag != "" {
+		opts.Path = *outFlag
+	}
+	if *tagFlag != "" {
+		opts.Tag = *tagFlag
+	}
+	if err := rec.write(opts); err != nil {
+		fmt.Fprintln(os.Stderr, "vizbtest:", err)
+		if code == 0 {
+			code = 1
+		}
+	}
+	return code
+}
+
+// write saves the recorded samples as a Dataset; nothing when none were.
+func (r *recorder) write(opts Options) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(r.samples) == 0 {
+		return nil
+	}
+	if opts.Path == "" {
+		opts.Path = "vizb.json"
+	}
+	if opts.Name == "" {
+		if wd, err := os.Getwd(); err == nil {
+			opts.Name = filepath.Base(wd)
+		}
+	}
+	ds, err := r.dataset(opts)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(opts.Path)
+	if err != nil {
+		return err
+	}
+	if err := vizb.WriteJSON(f, ds); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// dimKeys are the axes sub-benchmark parameters fill, in order.
+var dimKeys = []string{"x", "y", "z"}
+
+func (r *recorder) dataset(opts Options) (*vizb.Dataset, error) {
+	labels := map[string]string{}
+	used := map[string]bool{}
+	sameN := true
+	points := make([]vizb.DataPoint, 0, len(r.samples))
+	for _, s := range r.samples {
+		sameN = sameN && s.n == r.samples[0].n
+		parts := strings.Split(s.name, "/")
+		point := vizb.DataPoint{Name: strings.TrimPrefix(parts[0], "Benchmark"), Stats: s.stats()}
+		values := parts[1:]
+		if len(s.dims) > 0 {
+			values = make([]string, len(s.dims))
+			for i, d := range s.dims {
+				values[i] = d.value
+				if labels[dimKeys[i]] == "" {
+					labels[dimKeys[i]] = d.label
+				}
+			}
+		}
+		if len(values) > len(dimKeys) {
+			values = append(values[:2], strings.Join(values[2:], "/"))
+		}
+		for i, v := range values {
+			used[dimKeys[i]] = true
+			switch dimKeys[i] {
+			case "x":
+				point.XAxis = v
+			case "y":
+				point.YAxis = v
+			default:
+				point.ZAxis = v
+			}
+		}
+		if point.Name != "" {
+			used["name"] = true
+		}
+		points = append(points, point)
+	}
+	if !sameN {
+		for i, s := range r.samples {
+			points[i].Stats = append(points[i].Stats, vizb.Measure("Iterations", float64(s.n), "", ""))
+		}
+	}
+	var axes []vizb.Axis
+	for _, key := range append([]string{"name"}, dimKeys...) {
+		if used[key] {
+			axes = append(axes, vizb.Axis{Key: key, Label: labels[key]})
+		}
+	}
+
+	return vizb.NewDataset(opts.Name).
+		Tag(opts.Tag).
+		Description(opts.Description).
+		Meta(vizb.Meta{
+			OS:   runtime.GOOS,
+			Arch: runtime.GOARCH,
+			CPU:  &vizb.CPUInfo{Cores: runtime.GOMAXPROCS(0)},
+			Env:  map[string]string{"go": runtime.Version()},
+		}).
+		Axes(axes...).
+		Add(points...).
+		Charts(opts.Charts...).
+		Build()
+}
+
+// stats labels the sample's measurements as the Go benchmark parser does,
+// so recorded runs merge with converted benchmark output. Per-op allocations
+// are truncated to whole numbers, as go test prints them.
+func (s *sample) stats() []vizb.Stat {
+	nsPerOp := float64(s.elapsed.Nanoseconds()) / float64(s.n)
+	out := []vizb.Stat{
+		vizb.Measure("Execution Time", nsPerOp, "ns", "op"),
+		metricStat(float64(s.bytes/uint64(s.n)), "B/op"),
+		metricStat(float64(s.mallocs/uint64(s.n)), "allocs/op"),
+	}
+	for _, m := range s.metrics {
+		switch m.unit {
+		case "ns/op":
+			out[0] = vizb.Measure("Execution Time", m.value, "ns", "op")
+		case "B/op":
+			out[1] = metricStat(m.value, m.unit)
+		case "allocs/op":
+			out[2] = metricStat(m.value, m.unit)
+		default:
+			out = append(out, metricStat(m.value, m.unit))
+		}
+	}
+	return out
+}
+
+func metricStat(value float64, unit string) vizb.Stat {
+	switch unit {
+	case "B/op":
+		return vizb.Measure("Memory Usage", value, "B", "op")
+	case "allocs/op":
+		return vizb.Measure("Allocations", value, "", "op")
+	}
+	name := "Metric"
+	if strings.HasSuffix(unit, "/s") {
+		name = "Throughput"
+	}
+	statUnit, per, _ := strings.Cut(unit, "/")
+	return vizb.Measure(name, value, statUnit, per)
+}
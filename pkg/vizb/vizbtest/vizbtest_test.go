@@ -0,0 +1,149 @@
+package vizbtest
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/goptics/vizb/pkg/vizb"
+	"github.com/stretchr/testify/suite"
+)
+
+type VizbtestSuite struct {
+	suite.Suite
+	benchtime string
+}
+
+func (s *VizbtestSuite) SetupTest() {
+	rec = newRecorder()
+	s.benchtime = flag.Lookup("test.benchtime").Value.String()
+	s.Require().NoError(flag.Set("test.benchtime", "10x"))
+}
+
+func (s *VizbtestSuite) TearDownTest() {
+	s.Require().NoError(flag.Set("test.benchtime", s.benchtime))
+}
+
+func (s *VizbtestSuite) TestKeepsTheReportedRunNotTheProbes() {
+	testing.Benchmark(func(b *testing.B) {
+		Report(b)
+		for range b.N {
+		}
+	})
+	testing.Benchmark(func(b *testing.B) {
+		ReportMetric(b, 42, "hits/op")
+		for b.Loop() {
+		}
+	})
+	testing.Benchmark(func(b *testing.B) {}) // not recorded
+
+	s.Require().Len(rec.samples, 2, "the b.N=1 probe is replaced")
+	s.Equal(10, rec.samples[0].n)
+	s.Equal(10, rec.samples[1].n)
+	s.Equal([]metric{{unit: "hits/op", value: 42}}, rec.samples[1].metrics)
+	s.Empty(rec.pending)
+}
+
+var sink []byte
+
+func (s *VizbtestSuite) TestRecordsAllocations() {
+	testing.Benchmark(func(b *testing.B) {
+		Report(b)
+		for range b.N {
+			sink = make([]byte, 4096)
+		}
+	})
+	s.Require().Len(rec.samples, 1)
+	stats := rec.samples[0].stats()
+	s.Equal("Memory Usage (B/op)", stats[1].Type)
+	s.GreaterOrEqual(*stats[1].Value, 4096.0)
+	s.Equal("Allocations/op", stats[2].Type)
+	s.GreaterOrEqual(*stats[2].Value, 1.0)
+}
+
+func (s *VizbtestSuite) TestCountSamplesAreKept() {
+	for _, n := range []int{1, 100, 100, 1, 100} {
+		rec.add(&sample{name: "BenchmarkSort", n: n, final: n == 100})
+	}
+	s.Len(rec.samples, 3)
+}
+
+func (s *VizbtestSuite) TestIsFinal() {
+	s.False(isFinal(1, time.Hour))
+	s.True(isFinal(10, 0))
+	s.Require().NoError(flag.Set("test.benchtime", "1s"))
+	s.False(isFinal(1000, 10*time.Millisecond))
+	s.True(isFinal(1000, time.Second))
+	s.True(isFinal(1e9, time.Millisecond))
+}
+
+func (s *VizbtestSuite) TestDataset() {
+	rec.samples = []*sample{
+		{name: "BenchmarkSort/1024", dims: []Dimension{Axis("size", 1024), Axis("algo", "quick")}, n: 10, elapsed: 1000,
+			mallocs: 35, bytes: 645},
+		{name: "BenchmarkSort/4096", dims: []Dimension{Axis("size", 4096), Axis("algo", "merge")}, n: 20, elapsed: 8000,
+			metrics: []metric{{"ns/op", 350}, {"B/op", 64}, {"allocs/op", 2}, {"MB/s", 90}, {"hits", 3}}},
+		{name: "BenchmarkMap/a/b/c/d", n: 10, elapsed: 50},
+	}
+	ds, err := rec.dataset(Options{Name: "sorting", Tag: "v1"})
+	s.Require().NoError(err)
+	s.Equal("sorting", ds.Name())
+	s.Equal("v1", ds.Tag())
+	s.Equal([]vizb.Axis{{Key: "name"}, {Key: "x", Label: "size"}, {Key: "y", Label: "algo"}, {Key: "z"}}, ds.Axes())
+
+	points := ds.Points()
+	s.Require().Len(points, 3)
+	s.Equal(vizb.DataPoint{Name: "Sort", XAxis: "1024", YAxis: "quick", Stats: []vizb.Stat{
+		vizb.Measure("Execution Time", 100, "ns", "op"),
+		vizb.Measure("Memory Usage", 64, "B", "op"),
+		vizb.Measure("Allocations", 3, "", "op"),
+		vizb.Measure("Iterations", 10, "", ""),
+	}}, points[0])
+
+	var types []string
+	for _, st := range points[1].Stats {
+		types = append(types, st.Type)
+	}
+	s.Equal([]string{"Execution Time (ns/op)", "Memory Usage (B/op)", "Allocations/op", "Throughput (MB/s)", "Metric (hits)", "Iterations"}, types)
+	s.InDelta(350.0, *points[1].Stats[0].Value, 1e-9, "a reported ns/op wins")
+	s.InDelta(64.0, *points[1].Stats[1].Value, 1e-9, "a reported B/op wins")
+	s.Equal(vizb.Higher, points[1].Stats[3].Better)
+
+	s.Equal("Map", points[2].Name)
+	s.Equal([]string{"a", "b", "c/d"}, []string{points[2].XAxis, points[2].YAxis, points[2].ZAxis})
+}
+
+func (s *VizbtestSuite) TestWriteProducesMergeableRuns() {
+	dir := s.T().TempDir()
+	var runs []*vizb.Dataset
+	for _, tag := range []string{"v1", "v2"} {
+		rec = newRecorder()
+		rec.add(&sample{name: "BenchmarkSort", dims: []Dimension{Axis("size", 64)}, n: 10, elapsed: 100, final: true})
+		path := filepath.Join(dir, tag+".json")
+		s.Require().NoError(rec.write(Options{Path: path, Name: "sorting", Tag: tag}))
+
+		f, err := os.Open(path)
+		s.Require().NoError(err)
+		read, err := vizb.ReadDatasets(f)
+		f.Close()
+		s.Require().NoError(err)
+		runs = append(runs, read...)
+	}
+	merged, err := vizb.Merge(runs, vizb.MergeOptions{})
+	s.Require().NoError(err)
+	s.Require().Len(merged, 1)
+	s.ElementsMatch([]string{"v1", "v2"}, merged[0].Tags())
+	s.Len(merged[0].Points(), 2)
+}
+
+func (s *VizbtestSuite) TestWriteWithoutSamplesIsANoop() {
+	path := filepath.Join(s.T().TempDir(), "vizb.json")
+	s.Require().NoError(rec.write(Options{Path: path}))
+	s.NoFileExists(path)
+}
+
+func TestVizbtestSuite(t *testing.T) {
+	suite.Run(t, new(VizbtestSuite))
+}
@@ -1,9 +1,6 @@
 package shared
 
-import (
-	"fmt"
-	"math"
-)
+import "math"
 
 // AggregateDataPoints groups DataPoints by (Name, XAxis, YAxis, ZAxis) and sums
 // Stat.Value for matching stat types within each group. Order of first occurrence
@@ -11,57 +8,90 @@ import (
 // data point (e.g. multiple sales on the same date/region) and the goal is a single
 // summed value per combination.
 func AggregateDataPoints(points []DataPoint) []DataPoint {
-	type key struct{ name, x, y, z string }
+	agg := NewPointAggregator()
+	for i := range points {
+		agg.Add(points[i])
+	}
+	return agg.Points()
+}
 
-	order := make([]key, 0, len(points))
-	groups := make(map[key]*DataPoint, len(points))
+type pointKey struct{ name, x, y, z string }
 
-	for i := range points {
-		dp := &points[i]
-		k := key{dp.Name, dp.XAxis, dp.YAxis, dp.ZAxis}
+type statKey struct{ typ, symbol string }
 
-		existing, found := groups[k]
-		if !found {
-			clone := DataPoint{
+type aggregateGroup struct {
+	point   DataPoint
+	statIdx map[statKey]int
+}
+
+// PointAggregator is the incremental form of AggregateDataPoints. Streaming
+// parsers fold each row in as it is read, so memory grows with the number of
+// distinct (Name, XAxis, YAxis, ZAxis) keys instead of the number of rows.
+type PointAggregator struct {
+	order  []pointKey
+	groups map[pointKey]*aggregateGroup
+}
+
+// NewPointAggregator returns an empty aggregator.
+func NewPointAggregator() *PointAggregator {
+	return &PointAggregator{groups: map[pointKey]*aggregateGroup{}}
+}
+
+// Add folds dp into its group: the first point for a key is cloned, later
+// points sum their values into stats of the same type and symbol and append
+// stat types not seen before.
+func (a *PointAggregator) Add(dp DataPoint) {
+	k := pointKey{dp.Name, dp.XAxis, dp.YAxis, dp.ZAxis}
+
+	g, found := a.groups[k]
+	if !found {
+		g = &aggregateGroup{
+			point: DataPoint{
 				Name:   dp.Name,
 				XAxis:  dp.XAxis,
 				YAxis:  dp.YAxis,
 				ZAxis:  dp.ZAxis,
 				Metric: dp.Metric,
 				Stats:  make([]Stat, len(dp.Stats)),
-			}
-			copy(clone.Stats, dp.Stats)
-			groups[k] = &clone
-			order = append(order, k)
-			continue
+			},
+			statIdx: make(map[statKey]int, len(dp.Stats)),
 		}
-
-		statIdx := make(map[string]int, len(existing.Stats))
-		for i, s := range existing.Stats {
-			statIdx[fmt.Sprintf("%s|%s", s.Type, s.Symbol)] = i
+		copy(g.point.Stats, dp.Stats)
+		for i, s := range g.point.Stats {
+			g.statIdx[statKey{s.Type, s.Symbol}] = i
 		}
+		a.groups[k] = g
+		a.order = append(a.order, k)
+		return
+	}
 
-		for _, s := range dp.Stats {
-			sk := fmt.Sprintf("%s|%s", s.Type, s.Symbol)
-			if idx, ok := statIdx[sk]; ok {
-				if s.Value != nil {
-					var base float64
-					if existing.Stats[idx].Value != nil {
-						base = *existing.Stats[idx].Value
-					}
-					v := base + *s.Value
-					existing.Stats[idx].Value = &v
+	existing := &g.point
+	for _, s := range dp.Stats {
+		sk := statKey{s.Type, s.Symbol}
+		if idx, ok := g.statIdx[sk]; ok {
+			if s.Value != nil {
+				var base float64
+				if existing.Stats[idx].Value != nil {
+					base = *existing.Stats[idx].Value
 				}
-			} else {
-				existing.Stats = append(existing.Stats, s)
-				statIdx[sk] = len(existing.Stats) - 1
+				v := base + *s.Value
+				existing.Stats[idx].Value = &v
 			}
+		} else {
+			existing.Stats = append(existing.Stats, s)
+			g.statIdx[sk] = len(existing.Stats) - 1
 		}
 	}
+}
 
-	result := make([]DataPoint, 0, len(order))
-	for _, k := range order {
-		result = append(result, *groups[k])
+// Len reports the number of distinct groups folded so far.
+func (a *PointAggregator) Len() int { return len(a.order) }
+
+// Points returns one aggregated DataPoint per group in first-occurrence order.
+func (a *PointAggregator) Points() []DataPoint {
+	result := make([]DataPoint, 0, len(a.order))
+	for _, k := range a.order {
+		result = append(result, a.groups[k].point)
 	}
 	return result
 }
@@ -181,6 +181,24 @@ func (s *AggregateSuite) TestRoundStatValuesSkipsNilAndNonFinite() {
 	s.Equal(1.01, *points[0].Stats[4].Value)
 }
 
+func (s *AggregateSuite) TestPointAggregatorMatchesBatchAggregation() {
+	in := []DataPoint{
+		{XAxis: "A", Stats: []Stat{{Type: "v", Value: F64(1)}}},
+		{XAxis: "B", Stats: []Stat{{Type: "v", Value: F64(2)}}},
+		{XAxis: "A", Stats: []Stat{{Type: "v", Value: F64(3)}, {Type: "w", Value: F64(4)}}},
+	}
+
+	agg := NewPointAggregator()
+	for _, dp := range in {
+		agg.Add(dp)
+	}
+
+	s.Equal(2, agg.Len())
+	s.Equal(AggregateDataPoints(in), agg.Points())
+	v, _ := statVal(agg.Points()[0].Stats, "v")
+	s.Equal(4.0, v)
+}
+
 func TestAggregateSuite(t *testing.T) {
 	suite.Run(t, new(AggregateSuite))
 }
@@ -0,0 +1,78 @@
+package shared
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	internal_charts "github.com/goptics/vizb/internal/charts"
+)
+
+// Annotation is one reference mark drawn over a chart: a horizontal line at a
+// stat value (hline), a vertical line at an axis value (vline), a shaded stat
+// range (band), or a callout at one axis value and stat value (point). It is
+// both a --mark object on bar/line/scatter configs and an entry of the
+// dataset-level Annotations, which every such chart draws.
+//
+// Stat keys the mark to the chart of one stat type, by full name or unit
+// (see charts.MarkStatMatches); empty draws it on every stat. Numeric fields are
+// pointers so an explicit zero (value=0 for "allocs == 0") survives the round
+// trip.
+type Annotation struct {
+	Type  string   `json:"type"` // hline, vline, band, point
+	Stat  string   `json:"stat,omitempty"`
+	Value *float64 `json:"value,omitempty"` // hline, point
+	From  *float64 `json:"from,omitempty"`  // band
+	To    *float64 `json:"to,omitempty"`    // band
+	At    string   `json:"at,omitempty"`    // vline, point: an axis value, e.g. "1024"
+	Label string   `json:"label,omitempty"`
+	Color string   `json:"color,omitempty"`
+}
+
+// Validate reports whether the annotation carries the fields its type needs.
+func (a Annotation) Validate() error {
+	if err := internal_charts.ValidateMarkTypeValue(a.Type); err != nil {
+		return err
+	}
+	switch a.Type {
+	case internal_charts.MarkHLine:
+		if a.Value == nil {
+			return fmt.Errorf("hline mark requires value")
+		}
+	case internal_charts.MarkVLine:
+		if a.At == "" {
+			return fmt.Errorf("vline mark requires at (an axis value)")
+		}
+	case internal_charts.MarkBand:
+		if a.From == nil || a.To == nil {
+			return fmt.Errorf("band mark requires from and to")
+		}
+		if *a.From > *a.To {
+			return fmt.Errorf("band mark from (%g) is greater than to (%g)", *a.From, *a.To)
+		}
+	case internal_charts.MarkPoint:
+		if a.At == "" || a.Value == nil {
+			return fmt.Errorf("point mark requires at and value")
+		}
+	}
+	return nil
+}
+
+// UnmarshalJSON decodes an annotation strictly (unknown fields are errors)
+// and validates it. Type is case-insensitive on the wire and stored lowercase.
+func (a *Annotation) UnmarshalJSON(data []byte) error {
+	type plain Annotation
+	var out plain
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&out); err != nil {
+		return fmt.Errorf("mark: %w", err)
+	}
+	out.Type = strings.ToLower(out.Type)
+	if err := Annotation(out).Validate(); err != nil {
+		return fmt.Errorf("mark: %w", err)
+	}
+	*a = Annotation(out)
+	return nil
+}
@@ -0,0 +1,71 @@
+package shared
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AnnotationSuite struct {
+	suite.Suite
+}
+
+func (s *AnnotationSuite) TestValidateRequiresFieldsPerType() {
+	valid := []Annotation{
+		{Type: "hline", Value: F64(0)},
+		{Type: "vline", At: "1024"},
+		{Type: "band", From: F64(10), To: F64(10)},
+		{Type: "point", At: "Sort", Value: F64(42), Label: "regression"},
+	}
+	for _, a := range valid {
+		s.NoError(a.Validate(), a.Type)
+	}
+
+	for _, c := range []struct {
+		a    Annotation
+		want string
+	}{
+		{Annotation{}, "mark type"},
+		{Annotation{Type: "arrow"}, `mark type "arrow" is invalid`},
+		{Annotation{Type: "hline"}, "hline mark requires value"},
+		{Annotation{Type: "vline", Value: F64(1)}, "vline mark requires at"},
+		{Annotation{Type: "band", From: F64(1)}, "band mark requires from and to"},
+		{Annotation{Type: "band", From: F64(2), To: F64(1)}, "from (2) is greater than to (1)"},
+		{Annotation{Type: "point", At: "Sort"}, "point mark requires at and value"},
+	} {
+		s.ErrorContains(c.a.Validate(), c.want)
+	}
+}
+
+func (s *AnnotationSuite) TestUnmarshalJSONValidatesAndLowercasesType() {
+	var a Annotation
+	s.Require().NoError(json.Unmarshal([]byte(`{"type":"HLine","stat":"ns/op","value":0}`), &a))
+	s.Equal(Annotation{Type: "hline", Stat: "ns/op", Value: F64(0)}, a)
+
+	s.ErrorContains(json.Unmarshal([]byte(`{"type":"hline"}`), &a), "mark: hline mark requires value")
+	s.ErrorContains(json.Unmarshal([]byte(`{"type":"hline","value":1,"y":2}`), &a), "unknown field")
+}
+
+func (s *AnnotationSuite) TestDatasetRoundTripsAnnotations() {
+	raw := `{"name":"D","axes":[],"settings":[{"type":"bar","mark":[{"type":"vline","at":"1024"}]}],"data":[],` +
+		`"annotations":[{"type":"hline","stat":"ns/op","value":50,"label":"p99 SLO"}]}`
+	var d Dataset
+	s.Require().NoError(json.Unmarshal([]byte(raw), &d))
+	s.Equal([]Annotation{{Type: "hline", Stat: "ns/op", Value: F64(50), Label: "p99 SLO"}}, d.Annotations)
+
+	out, err := json.Marshal(d)
+	s.Require().NoError(err)
+	s.Contains(string(out), `"annotations":[{"type":"hline","stat":"ns/op","value":50,"label":"p99 SLO"}]`)
+	s.Contains(string(out), `"mark":[{"type":"vline","at":"1024"}]`)
+}
+
+func (s *AnnotationSuite) TestDatasetRejectsInvalidAnnotation() {
+	var d Dataset
+	err := json.Unmarshal([]byte(`{"name":"D","axes":[],"settings":[],"data":[],"annotations":[{"type":"band","from":1}]}`), &d)
+	s.ErrorContains(err, "band mark requires from and to")
+}
+
+func TestAnnotationSuite(t *testing.T) {
+	suite.Run(t, new(AnnotationSuite))
+}
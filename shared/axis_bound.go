@@ -0,0 +1,45 @@
+package shared
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	internal_charts "github.com/goptics/vizb/internal/charts"
+)
+
+// AxisBound is one end of a value-axis range (--min / --max): an absolute
+// value, or a percentile (0–100) of the plotted values. JSON is a number for
+// absolute bounds and a "p95"-style string for percentiles.
+type AxisBound struct {
+	Value      float64
+	Percentile bool
+}
+
+// MarshalJSON writes a number, or "p<N>" for percentile bounds.
+func (b AxisBound) MarshalJSON() ([]byte, error) {
+	if b.Percentile {
+		return json.Marshal("p" + strconv.FormatFloat(b.Value, 'f', -1, 64))
+	}
+	return json.Marshal(b.Value)
+}
+
+// UnmarshalJSON accepts a finite number or a "p<N>" percentile string.
+func (b *AxisBound) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	var s string
+	if len(data) > 0 && data[0] == '"' {
+		if err := json.Unmarshal(data, &s); err != nil {
+			return fmt.Errorf("axis bound: %w", err)
+		}
+	} else {
+		s = string(data)
+	}
+	v, pct, err := internal_charts.ParseAxisBound(s)
+	if err != nil {
+		return err
+	}
+	*b = AxisBound{Value: v, Percentile: pct}
+	return nil
+}
@@ -0,0 +1,50 @@
+package shared
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AxisBoundSuite struct {
+	suite.Suite
+}
+
+func (s *AxisBoundSuite) TestMarshal() {
+	raw, err := json.Marshal(AxisBound{Value: 0.5})
+	s.Require().NoError(err)
+	s.Equal("0.5", string(raw))
+
+	raw, err = json.Marshal(AxisBound{Value: 99.9, Percentile: true})
+	s.Require().NoError(err)
+	s.Equal(`"p99.9"`, string(raw))
+}
+
+func (s *AxisBoundSuite) TestUnmarshal() {
+	cases := []struct {
+		in   string
+		want AxisBound
+	}{
+		{"0", AxisBound{}},
+		{"-12.5", AxisBound{Value: -12.5}},
+		{`"p5"`, AxisBound{Value: 5, Percentile: true}},
+		{`"P95"`, AxisBound{Value: 95, Percentile: true}},
+	}
+	for _, tc := range cases {
+		var b AxisBound
+		s.Require().NoError(json.Unmarshal([]byte(tc.in), &b), tc.in)
+		s.Equal(tc.want, b, tc.in)
+	}
+}
+
+func (s *AxisBoundSuite) TestUnmarshalRejects() {
+	for _, in := range []string{`"p150"`, `"tall"`, `true`, `[1]`} {
+		var b AxisBound
+		s.Error(json.Unmarshal([]byte(in), &b), in)
+	}
+}
+
+func TestAxisBoundSuite(t *testing.T) {
+	suite.Run(t, new(AxisBoundSuite))
+}
@@ -0,0 +1,15 @@
+package shared
+
+// Brush styles axis brushing on the parallel chart: dragging along an axis
+// selects a value range, and lines outside every selection fade. Color,
+// Width, and Opacity style the selection box; ActiveOpacity and
+// InactiveOpacity apply to lines inside and outside it. Unset fields keep the
+// renderer defaults; numeric fields are pointers so an explicit zero survives
+// the round trip.
+type Brush struct {
+	Color           string   `json:"color,omitempty"`
+	Width           *float64 `json:"width,omitempty"`
+	Opacity         *float64 `json:"opacity,omitempty"`
+	ActiveOpacity   *float64 `json:"activeOpacity,omitempty"`
+	InactiveOpacity *float64 `json:"inactiveOpacity,omitempty"`
+}
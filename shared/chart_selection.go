@@ -8,8 +8,9 @@ import (
 )
 
 // ValidChartTypes is every chart type the CLI accepts via --charts.
-// sankey and chord are opt-in only (not in DefaultChartTypes).
-var ValidChartTypes = []string{"bar", "line", "scatter", "pie", "heatmap", "radar", "sankey", "chord"}
+// sankey, chord, treemap, sunburst, histogram, parallel, calendar, bump, and
+// waterfall are opt-in only (not in DefaultChartTypes).
+var ValidChartTypes = []string{"bar", "line", "scatter", "pie", "heatmap", "radar", "sankey", "chord", "treemap", "sunburst", "histogram", "parallel", "calendar", "bump", "waterfall"}
 
 // DefaultChartTypes is the --charts default when the user does not pass -c.
 var DefaultChartTypes = []string{"bar", "line", "pie"}
@@ -178,6 +178,12 @@ func ParseOverrides(specs []string, charts []string, axes []Axis) (map[string]in
 			if err != nil {
 				return nil, nil, err
 			}
+			if f.Repeatable {
+				// Repeated keys (within one spec or across specs) accumulate.
+				list, _ := payload[f.JSONKey].([]any)
+				payload[f.JSONKey] = append(list, pv)
+				continue
+			}
 			payload[f.JSONKey] = pv
 		}
 	}
@@ -442,6 +442,46 @@ func (s *ChartSpecSuite) TestParseOverridesBarBgUnwrappedScalarIsError() {
 	}
 }
 
+// --- --chart repeatable object values (mark) ---
+
+func (s *ChartSpecSuite) TestParseOverridesMarkAccumulates() {
+	specs := []string{
+		"bar:mark={type=hline;value=50;stat=ns/op;label=SLO};mark={type=vline;at=1024}",
+		"bar:mark={type=band;from=0;to=10}",
+	}
+	got, warnings, err := ParseOverrides(specs, []string{"bar"}, s.xynAxes)
+	s.Require().NoError(err)
+	s.Empty(warnings)
+	s.Equal([]any{
+		map[string]any{"type": "hline", "value": float64(50), "stat": "ns/op", "label": "SLO"},
+		map[string]any{"type": "vline", "at": "1024"},
+		map[string]any{"type": "band", "from": float64(0), "to": float64(10)},
+	}, s.payload(got["bar"])["mark"])
+}
+
+func (s *ChartSpecSuite) TestParseOverridesMarkInvalid() {
+	cases := []struct {
+		name string
+		spec string
+		want string
+	}{
+		{"bare", "bar:mark", "mark type"},
+		{"unknown type", "bar:mark={type=arrow}", "mark type \"arrow\" is invalid"},
+		{"unknown key", "bar:mark={type=hline;y=1}", "unknown object field"},
+		{"hline without value", "bar:mark={type=hline}", "hline mark requires value"},
+		{"vline without at", "bar:mark={type=vline}", "vline mark requires at"},
+		{"reversed band", "bar:mark={type=band;from=5;to=1}", "greater than to"},
+		{"point without at", "bar:mark={type=point;value=1}", "point mark requires at and value"},
+	}
+	for _, c := range cases {
+		s.Run(c.name, func() {
+			_, _, err := ParseOverrides([]string{c.spec}, []string{"bar"}, s.xynAxes)
+			s.Require().Error(err)
+			s.Contains(err.Error(), c.want)
+		})
+	}
+}
+
 func (s *ChartSpecSuite) TestParseOverridesBarBgInvalidFields() {
 	cases := []struct {
 		name string
@@ -0,0 +1,98 @@
+package shared
+
+import (
+	"slices"
+	"sort"
+	"time"
+)
+
+// Commit identifies the source revision a run measured. `--tag auto` records
+// it from the git repository of the working directory.
+type Commit struct {
+	SHA string `json:"sha"`
+	// Time is the committer time, RFC 3339 in the committer's zone.
+	Time    string `json:"time,omitempty"`
+	Author  string `json:"author,omitempty"`
+	Subject string `json:"subject,omitempty"`
+}
+
+// AncestryFunc reports whether commit ancestor is reachable from commit
+// descendant. Callers with access to the repository pass one to
+// MergeDatasetsWithAncestry; it must return false for unknown commits.
+type AncestryFunc func(ancestor, descendant string) bool
+
+// run is what merge ordering knows about one tagged dataset or history entry.
+type run struct {
+	timestamp string
+	commit    *Commit
+}
+
+// orderRuns returns the indices of runs, oldest first. Commits only decide
+// when every run carries one: then commit ancestry (if isAncestor is set)
+// comes first and committer time orders unrelated commits, so re-running a
+// benchmark on an old commit today still sorts it before newer commits.
+// Otherwise, and for ties, the wall-clock Timestamp decides, then input
+// order. Mixing the two rules pairwise would not be transitive, so one rule
+// is picked for the whole group.
+func orderRuns(runs []run, isAncestor AncestryFunc) []int {
+	order := make([]int, len(runs))
+	for i := range order {
+		order[i] = i
+	}
+
+	byCommit := len(runs) > 1
+	commitTimes := make([]time.Time, len(runs))
+	timesOK := true
+	for i, r := range runs {
+		if r.commit == nil {
+			byCommit = false
+			break
+		}
+		t, err := time.Parse(time.RFC3339, r.commit.Time)
+		commitTimes[i], timesOK = t, timesOK && err == nil
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		a, b := order[i], order[j]
+		if byCommit && timesOK && !commitTimes[a].Equal(commitTimes[b]) {
+			return commitTimes[a].Before(commitTimes[b])
+		}
+		return runs[a].timestamp < runs[b].timestamp
+	})
+	if !byCommit || isAncestor == nil {
+		return order
+	}
+
+	// Ancestry is a partial order: repeatedly take the earliest remaining run
+	// with no remaining ancestor.
+	before := make([][]bool, len(runs))
+	for i := range runs {
+		before[i] = make([]bool, len(runs))
+		for j := range runs {
+			if runs[i].commit.SHA != runs[j].commit.SHA {
+				before[i][j] = isAncestor(runs[i].commit.SHA, runs[j].commit.SHA)
+			}
+		}
+	}
+	out := make([]int, 0, len(order))
+	for len(order) > 0 {
+		next := 0
+		for k, i := range order {
+			if !slices.ContainsFunc(order, func(j int) bool { return before[j][i] }) {
+				next = k
+				break
+			}
+		}
+		out = append(out, order[next])
+		order = slices.Delete(order, next, next+1)
+	}
+	return out
+}
+
+// Clone returns a copy of c; nil stays nil.
+func (c *Commit) Clone() *Commit {
+	if c == nil {
+		return nil
+	}
+	out := *c
+	return &out
+}
@@ -3,16 +3,25 @@ package shared
 import (
 	"encoding/json"
 	"fmt"
+	"maps"
 	"strings"
 
 	internal_charts "github.com/goptics/vizb/internal/charts"
 	"github.com/goptics/vizb/pkg/style"
 )
 
+// Stat is one measurement of a data point. Type is the human label, unit
+// included ("Execution Time (ns/op)"); Unit and Per carry the unit in
+// structured form ("ns", "op") so values can be converted and formatted
+// without parsing the label. See StatLabel. Better says which way the stat
+// improves; empty is unknown.
 type Stat struct {
-	Type   string   `json:"type,omitempty"`
-	Value  *float64 `json:"value,omitempty"`
-	Symbol string   `json:"symbol,omitempty"`
+	Type   string    `json:"type,omitempty"`
+	Value  *float64  `json:"value,omitempty"`
+	Unit   string    `json:"unit,omitempty"`
+	Per    string    `json:"per,omitempty"`
+	Better Direction `json:"better,omitempty"`
+	Symbol string    `json:"symbol,omitempty"`
 }
 
 // F64 returns a pointer to f, used when setting Stat.Value so that zero
@@ -59,12 +68,30 @@ type Meta struct {
 	OS   string   `json:"os,omitempty"`
 	Arch string   `json:"arch,omitempty"`
 	Pkg  string   `json:"pkg,omitempty"`
+	// Env is free-form key/value context about the run, such as the host,
+	// toolchain versions and git commit recorded by --capture-env.
+	Env map[string]string `json:"env,omitempty"`
+}
+
+// Clone returns a deep copy of m; nil stays nil.
+func (m *Meta) Clone() *Meta {
+	if m == nil {
+		return nil
+	}
+	out := *m
+	if m.CPU != nil {
+		cpu := *m.CPU
+		out.CPU = &cpu
+	}
+	out.Env = maps.Clone(m.Env)
+	return &out
 }
 
 type HistoryEntry struct {
-	Tag       string `json:"tag"`
-	Timestamp string `json:"timestamp"`
-	Meta      *Meta  `json:"meta,omitempty"`
+	Tag       string  `json:"tag"`
+	Timestamp string  `json:"timestamp"`
+	Commit    *Commit `json:"commit,omitempty"`
+	Meta      *Meta   `json:"meta,omitempty"`
 }
 
 // Theme is a fully expanded color theme embedded on a dataset.
@@ -77,10 +104,16 @@ type Theme struct {
 }
 
 type Dataset struct {
-	ID        string `json:"id,omitempty"`
-	Tag       string `json:"tag,omitempty"`
-	Timestamp string `json:"timestamp,omitempty"`
-	Name      string `json:"name"`
+	// SchemaVersion is the wire version the dataset was written at (see
+	// CurrentSchemaVersion); 0 for files that pre-date it. MigrateDataset
+	// upgrades from it and stamps the current version.
+	SchemaVersion int    `json:"schemaVersion,omitempty"`
+	ID            string `json:"id,omitempty"`
+	Tag           string `json:"tag,omitempty"`
+	Timestamp     string `json:"timestamp,omitempty"`
+	// Commit is the source revision the run measured, when known.
+	Commit *Commit `json:"commit,omitempty"`
+	Name   string  `json:"name"`
 	// Themes is the data-owned theme catalog. Themes[0] is active when present.
 	// New output writes Themes only (not the legacy Theme string).
 	Themes []Theme `json:"themes,omitempty"`
@@ -94,12 +127,68 @@ type Dataset struct {
 	Axes        []Axis                        `json:"axes"`
 	Settings    []internal_charts.ChartConfig `json:"settings"`
 	Data        []DataPoint                   `json:"data"`
+	// Annotations are reference marks (thresholds, targets, bands, callouts)
+	// drawn on every bar, line, and scatter chart of the dataset, alongside
+	// each chart's own --mark list.
+	Annotations []Annotation `json:"annotations,omitempty"`
 	// PreserveRows tells the UI not to average duplicate (x,y,z) keys. True for
 	// ungrouped csv/json tabular data (including solo/multi --select); false for
 	// --group aggregations and benchmark parsers where rows are already collapsed.
 	PreserveRows bool `json:"preserveRows,omitempty"`
 }
 
+// StatTypes returns the distinct non-empty Stat.Type values in d.Data, in
+// first-seen order.
+func (d *Dataset) StatTypes() []string {
+	var out []string
+	seen := map[string]bool{}
+	for _, p := range d.Data {
+		for _, st := range p.Stats {
+			if st.Type != "" && !seen[st.Type] {
+				seen[st.Type] = true
+				out = append(out, st.Type)
+			}
+		}
+	}
+	return out
+}
+
+// axesWhere returns the keys of the dataset's dimensions (name/x/y/z, in axes
+// order) whose non-empty values all satisfy match. A dimension with no values
+// never matches.
+func (d *Dataset) axesWhere(match func(string) bool) []string {
+	fields := map[string]func(DataPoint) string{
+		"name": func(p DataPoint) string { return p.Name },
+		"x":    func(p DataPoint) string { return p.XAxis },
+		"y":    func(p DataPoint) string { return p.YAxis },
+		"z":    func(p DataPoint) string { return p.ZAxis },
+	}
+	var out []string
+	for _, axis := range d.Axes {
+		get, ok := fields[axis.Key]
+		if !ok {
+			continue
+		}
+		seen := false
+		all := true
+		for _, p := range d.Data {
+			v := get(p)
+			if v == "" {
+				continue
+			}
+			seen = true
+			if !match(v) {
+				all = false
+				break
+			}
+		}
+		if seen && all {
+			out = append(out, axis.Key)
+		}
+	}
+	return out
+}
+
 // UnmarshalJSON decodes a Dataset, dispatching each entry in "settings" to the
 // chart-type-specific Config via the charts registry. The new wire format is
 //
@@ -111,30 +200,36 @@ type Dataset struct {
 //
 // — a single object. UnmarshalJSON cannot decode that into []ChartConfig, so
 // it leaves Settings nil and MigrateDataset converts the legacy struct to the
-// new shape. The default Marshal path (no MarshalJSON override) iterates the
+// new shape. The object is an error once schemaVersion says the file is past
+// it. The default Marshal path (no MarshalJSON override) iterates the
 // slice and writes each struct's `type` field naturally.
 func (d *Dataset) UnmarshalJSON(data []byte) error {
 	var raw struct {
-		ID           string          `json:"id,omitempty"`
-		Tag          string          `json:"tag,omitempty"`
-		Timestamp    string          `json:"timestamp,omitempty"`
-		Name         string          `json:"name"`
-		Themes       []Theme         `json:"themes,omitempty"`
-		Theme        string          `json:"theme,omitempty"`
-		History      []HistoryEntry  `json:"history,omitempty"`
-		Description  string          `json:"description,omitempty"`
-		Meta         *Meta           `json:"meta,omitempty"`
-		Axes         []Axis          `json:"axes"`
-		Settings     json.RawMessage `json:"settings"`
-		Data         []DataPoint     `json:"data"`
-		PreserveRows bool            `json:"preserveRows,omitempty"`
+		SchemaVersion int             `json:"schemaVersion,omitempty"`
+		ID            string          `json:"id,omitempty"`
+		Tag           string          `json:"tag,omitempty"`
+		Timestamp     string          `json:"timestamp,omitempty"`
+		Commit        *Commit         `json:"commit,omitempty"`
+		Name          string          `json:"name"`
+		Themes        []Theme         `json:"themes,omitempty"`
+		Theme         string          `json:"theme,omitempty"`
+		History       []HistoryEntry  `json:"history,omitempty"`
+		Description   string          `json:"description,omitempty"`
+		Meta          *Meta           `json:"meta,omitempty"`
+		Axes          []Axis          `json:"axes"`
+		Settings      json.RawMessage `json:"settings"`
+		Data          []DataPoint     `json:"data"`
+		PreserveRows  bool            `json:"preserveRows,omitempty"`
+		Annotations   []Annotation    `json:"annotations,omitempty"`
 	}
 	if err := json.Unmarshal(data, &raw); err != nil {
 		return err
 	}
+	d.SchemaVersion = raw.SchemaVersion
 	d.ID = raw.ID
 	d.Tag = raw.Tag
 	d.Timestamp = raw.Timestamp
+	d.Commit = raw.Commit
 	d.Name = raw.Name
 	d.Themes = raw.Themes
 	d.Theme = raw.Theme
@@ -144,9 +239,14 @@ func (d *Dataset) UnmarshalJSON(data []byte) error {
 	d.Axes = raw.Axes
 	d.Data = raw.Data
 	d.PreserveRows = raw.PreserveRows
+	d.Annotations = raw.Annotations
 
 	// No settings, JSON null, or legacy v0.12.0 single object — leave
 	// Settings nil so MigrateDataset can populate it from the legacy struct.
+	// A file that declares a version past that shape must use the array.
+	if len(raw.Settings) > 0 && raw.Settings[0] == '{' && raw.SchemaVersion >= SchemaVersionSettings {
+		return fmt.Errorf("dataset settings: expected JSON array at schemaVersion %d", raw.SchemaVersion)
+	}
 	if len(raw.Settings) == 0 || raw.Settings[0] != '[' {
 		d.Settings = nil
 		d.migrateLegacyTheme()
@@ -0,0 +1,40 @@
+package shared
+
+import "time"
+
+// dateLayouts are the date forms recognised on a dimension value, most common
+// first. Only the calendar day is used; a time of day is accepted and dropped.
+var dateLayouts = []string{
+	"2006-01-02",
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006/01/02",
+	"2006.01.02",
+}
+
+// ParseDate parses s as a calendar date in one of dateLayouts.
+func ParseDate(s string) (time.Time, bool) {
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// YearRange is an inclusive span of calendar years.
+type YearRange struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+}
+
+// DateAxes returns the keys of the dataset's dimensions whose values are all
+// dates (see ParseDate), in axes order. A dimension with no values is not
+// date-like.
+func (d *Dataset) DateAxes() []string {
+	return d.axesWhere(func(v string) bool {
+		_, ok := ParseDate(v)
+		return ok
+	})
+}
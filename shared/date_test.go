@@ -0,0 +1,40 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DateSuite struct {
+	suite.Suite
+}
+
+func (s *DateSuite) TestParseDate() {
+	for _, in := range []string{"2024-03-09", "2024-03-09T10:20:30Z", "2024-03-09T10:20:30", "2024-03-09 10:20:30", "2024/03/09", "2024.03.09"} {
+		t, ok := ParseDate(in)
+		s.True(ok, in)
+		s.Equal("2024-03-09", t.Format("2006-01-02"), in)
+	}
+	for _, in := range []string{"", "March 9", "2024-13-01", "20240309", "v1.2.3"} {
+		_, ok := ParseDate(in)
+		s.False(ok, in)
+	}
+}
+
+func (s *DateSuite) TestDateAxes() {
+	d := &Dataset{
+		Axes: []Axis{{Key: "name"}, {Key: "x"}, {Key: "y"}, {Key: "z"}},
+		Data: []DataPoint{
+			{Name: "repo", XAxis: "2024-01-01", YAxis: "2024-01-01", ZAxis: ""},
+			{Name: "repo", XAxis: "2024-01-02", YAxis: "push", ZAxis: ""},
+			{Name: "repo", XAxis: "", YAxis: "2024-01-03"},
+		},
+	}
+	s.Equal([]string{"x"}, d.DateAxes(), "empty values are skipped; a dimension without values is not date-like")
+	s.Empty((&Dataset{Axes: []Axis{{Key: "x"}}}).DateAxes())
+}
+
+func TestDateSuite(t *testing.T) {
+	suite.Run(t, new(DateSuite))
+}
@@ -0,0 +1,324 @@
+package shared
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"reflect"
+	"slices"
+	"strings"
+
+	internal_charts "github.com/goptics/vizb/internal/charts"
+)
+
+// Diff is the semantic difference between two Dataset files. Datasets pair
+// up by name; a name repeated within a file pairs by occurrence. The zero
+// Diff means the files match.
+type Diff struct {
+	Added   []string      `json:"added,omitempty"`
+	Removed []string      `json:"removed,omitempty"`
+	Changed []DatasetDiff `json:"changed,omitempty"`
+}
+
+// IsZero reports whether the two sides matched.
+func (d Diff) IsZero() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// DatasetDiff is what changed inside one dataset present on both sides.
+type DatasetDiff struct {
+	Name          string          `json:"name"`
+	Axes          *AxesChange     `json:"axes,omitempty"`
+	AddedCharts   []string        `json:"addedCharts,omitempty"`
+	RemovedCharts []string        `json:"removedCharts,omitempty"`
+	Settings      []SettingChange `json:"settings,omitempty"`
+	AddedPoints   []PointKey      `json:"addedPoints,omitempty"`
+	RemovedPoints []PointKey      `json:"removedPoints,omitempty"`
+	ChangedPoints []PointChange   `json:"changedPoints,omitempty"`
+}
+
+func (d DatasetDiff) isZero() bool {
+	return d.Axes == nil && len(d.AddedCharts) == 0 && len(d.RemovedCharts) == 0 && len(d.Settings) == 0 &&
+		len(d.AddedPoints) == 0 && len(d.RemovedPoints) == 0 && len(d.ChangedPoints) == 0
+}
+
+// AxesChange holds both sides' axes when they differ in keys, labels or types.
+type AxesChange struct {
+	Before []Axis `json:"before"`
+	After  []Axis `json:"after"`
+}
+
+// SettingChange is one chart setting that differs. Chart is the chart type,
+// suffixed "#2", "#3"… for repeated charts of one type. A nil side means the
+// field is unset there.
+type SettingChange struct {
+	Chart  string `json:"chart"`
+	Field  string `json:"field"`
+	Before any    `json:"before,omitempty"`
+	After  any    `json:"after,omitempty"`
+}
+
+// PointKey identifies a data point by its dimension values. Occurrence
+// numbers repeated keys from 1, as in datasets written with preserveRows.
+type PointKey struct {
+	Name       string `json:"name,omitempty"`
+	XAxis      string `json:"xAxis,omitempty"`
+	YAxis      string `json:"yAxis,omitempty"`
+	ZAxis      string `json:"zAxis,omitempty"`
+	Occurrence int    `json:"occurrence,omitempty"`
+}
+
+func (k PointKey) String() string {
+	var parts []string
+	for _, v := range []string{k.Name, k.XAxis, k.YAxis, k.ZAxis} {
+		if v != "" {
+			parts = append(parts, v)
+		}
+	}
+	s := strings.Join(parts, " / ")
+	if s == "" {
+		s = "(empty)"
+	}
+	if k.Occurrence > 1 {
+		s += fmt.Sprintf(" #%d", k.Occurrence)
+	}
+	return s
+}
+
+// PointChange lists the stats that differ on one data point.
+type PointChange struct {
+	Point PointKey     `json:"point"`
+	Stats []StatChange `json:"stats"`
+}
+
+// StatChange is one stat whose value differs. Stats pair up by label without
+// unit and per, so a run recorded in ms compares against one in ns: Before is
+// converted to After's unit and Type is After's label. When the units can't
+// be converted BeforeType holds the old label and Delta is nil. A stat only
+// on one side has the other side nil.
+type StatChange struct {
+	Type       string   `json:"type"`
+	BeforeType string   `json:"beforeType,omitempty"`
+	Before     *float64 `json:"before,omitempty"`
+	After      *float64 `json:"after,omitempty"`
+	Delta      *float64 `json:"delta,omitempty"`
+	// Percent is Delta relative to Before; nil when Before is zero.
+	Percent *float64 `json:"percent,omitempty"`
+}
+
+// DiffDatasets compares before with after. Stat value changes within
+// tolerance percent of the old value are ignored; 0 reports every change.
+func DiffDatasets(before, after []Dataset, tolerance float64) Diff {
+	var out Diff
+	beforeKeys, beforeByKey := keyDatasets(before)
+	afterKeys, afterByKey := keyDatasets(after)
+	for _, k := range beforeKeys {
+		b := beforeByKey[k]
+		a, ok := afterByKey[k]
+		if !ok {
+			out.Removed = append(out.Removed, k.label())
+			continue
+		}
+		if d := diffDataset(b, a, tolerance); !d.isZero() {
+			d.Name = k.label()
+			out.Changed = append(out.Changed, d)
+		}
+	}
+	for _, k := range afterKeys {
+		if _, ok := beforeByKey[k]; !ok {
+			out.Added = append(out.Added, k.label())
+		}
+	}
+	return out
+}
+
+type datasetKey struct {
+	name       string
+	occurrence int
+}
+
+func (k datasetKey) label() string {
+	name := k.name
+	if name == "" {
+		name = "(unnamed)"
+	}
+	if k.occurrence > 1 {
+		name += fmt.Sprintf(" #%d", k.occurrence)
+	}
+	return name
+}
+
+func keyDatasets(datasets []Dataset) ([]datasetKey, map[datasetKey]*Dataset) {
+	seen := map[string]int{}
+	keys := make([]datasetKey, 0, len(datasets))
+	byKey := make(map[datasetKey]*Dataset, len(datasets))
+	for i := range datasets {
+		name := datasets[i].Name
+		seen[name]++
+		k := datasetKey{name, seen[name]}
+		keys = append(keys, k)
+		byKey[k] = &datasets[i]
+	}
+	return keys, byKey
+}
+
+func diffDataset(before, after *Dataset, tolerance float64) DatasetDiff {
+	var d DatasetDiff
+	if !slices.Equal(before.Axes, after.Axes) {
+		d.Axes = &AxesChange{Before: before.Axes, After: after.Axes}
+	}
+	d.AddedCharts, d.RemovedCharts, d.Settings = diffSettings(before.Settings, after.Settings)
+	d.AddedPoints, d.RemovedPoints, d.ChangedPoints = diffPoints(before.Data, after.Data, tolerance)
+	return d
+}
+
+// diffSettings compares chart configs paired by type, field by field on
+// their JSON form.
+func diffSettings(before, after []internal_charts.ChartConfig) (added, removed []string, changed []SettingChange) {
+	beforeKeys, beforeByKey := keyCharts(before)
+	afterKeys, afterByKey := keyCharts(after)
+	for _, k := range beforeKeys {
+		a, ok := afterByKey[k]
+		if !ok {
+			removed = append(removed, k)
+			continue
+		}
+		b := beforeByKey[k]
+		fields := slices.Sorted(maps.Keys(b))
+		for f := range a {
+			if _, ok := b[f]; !ok {
+				fields = append(fields, f)
+			}
+		}
+		slices.Sort(fields)
+		for _, f := range fields {
+			if f == "type" || reflect.DeepEqual(b[f], a[f]) {
+				continue
+			}
+			changed = append(changed, SettingChange{Chart: k, Field: f, Before: b[f], After: a[f]})
+		}
+	}
+	for _, k := range afterKeys {
+		if _, ok := beforeByKey[k]; !ok {
+			added = append(added, k)
+		}
+	}
+	return added, removed, changed
+}
+
+func keyCharts(configs []internal_charts.ChartConfig) ([]string, map[string]map[string]any) {
+	seen := map[string]int{}
+	var keys []string
+	byKey := map[string]map[string]any{}
+	for _, cfg := range configs {
+		if cfg == nil {
+			continue
+		}
+		t := cfg.ChartType()
+		seen[t]++
+		k := t
+		if seen[t] > 1 {
+			k = fmt.Sprintf("%s#%d", t, seen[t])
+		}
+		fields := map[string]any{}
+		if raw, err := json.Marshal(cfg); err == nil {
+			_ = json.Unmarshal(raw, &fields)
+		}
+		keys = append(keys, k)
+		byKey[k] = fields
+	}
+	return keys, byKey
+}
+
+func diffPoints(before, after []DataPoint, tolerance float64) (added, removed []PointKey, changed []PointChange) {
+	beforeKeys, beforeByKey := keyPoints(before)
+	afterKeys, afterByKey := keyPoints(after)
+	for _, k := range beforeKeys {
+		a, ok := afterByKey[k]
+		if !ok {
+			removed = append(removed, k)
+			continue
+		}
+		if stats := diffStats(beforeByKey[k].Stats, a.Stats, tolerance); len(stats) > 0 {
+			changed = append(changed, PointChange{Point: k, Stats: stats})
+		}
+	}
+	for _, k := range afterKeys {
+		if _, ok := beforeByKey[k]; !ok {
+			added = append(added, k)
+		}
+	}
+	return added, removed, changed
+}
+
+func keyPoints(points []DataPoint) ([]PointKey, map[PointKey]*DataPoint) {
+	seen := map[PointKey]int{}
+	keys := make([]PointKey, 0, len(points))
+	byKey := make(map[PointKey]*DataPoint, len(points))
+	for i, p := range points {
+		k := PointKey{Name: p.Name, XAxis: p.XAxis, YAxis: p.YAxis, ZAxis: p.ZAxis}
+		seen[k]++
+		if n := seen[k]; n > 1 {
+			k.Occurrence = n
+		}
+		keys = append(keys, k)
+		byKey[k] = &points[i]
+	}
+	return keys, byKey
+}
+
+type diffStatKey struct{ base, per string }
+
+func diffStats(before, after []Stat, tolerance float64) []StatChange {
+	afterByKey := map[diffStatKey]Stat{}
+	for _, st := range after {
+		afterByKey[diffStatKey{st.BaseType(), st.Per}] = st
+	}
+	matched := map[diffStatKey]bool{}
+	var out []StatChange
+	for _, b := range before {
+		k := diffStatKey{b.BaseType(), b.Per}
+		a, ok := afterByKey[k]
+		if !ok {
+			out = append(out, StatChange{Type: b.Type, Before: b.Value})
+			continue
+		}
+		matched[k] = true
+		if c, changed := diffStat(b, a, tolerance); changed {
+			out = append(out, c)
+		}
+	}
+	for _, a := range after {
+		if !matched[diffStatKey{a.BaseType(), a.Per}] {
+			out = append(out, StatChange{Type: a.Type, After: a.Value})
+		}
+	}
+	return out
+}
+
+func diffStat(before, after Stat, tolerance float64) (StatChange, bool) {
+	c := StatChange{Type: after.Type, Before: before.Value, After: after.Value}
+	if before.Value == nil || after.Value == nil {
+		return c, before.Value != after.Value
+	}
+	old, ok := ConvertUnit(*before.Value, before.Unit, after.Unit)
+	if !ok {
+		c.BeforeType = before.Type
+		return c, true
+	}
+	c.Before = F64(old)
+	delta := *after.Value - old
+	if delta == 0 {
+		return c, false
+	}
+	c.Delta = F64(delta)
+	if old != 0 {
+		pct := delta / math.Abs(old) * 100
+		if math.Abs(pct) <= tolerance {
+			return c, false
+		}
+		c.Percent = F64(pct)
+	}
+	return c, true
+}
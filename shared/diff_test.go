@@ -0,0 +1,127 @@
+package shared_test
+
+import (
+	"testing"
+
+	internal_charts "github.com/goptics/vizb/internal/charts"
+	barchart "github.com/goptics/vizb/internal/charts/bar"
+	piechart "github.com/goptics/vizb/internal/charts/pie"
+	"github.com/goptics/vizb/shared"
+	"github.com/stretchr/testify/suite"
+)
+
+type DiffSuite struct {
+	suite.Suite
+}
+
+func point(x string, stats ...shared.Stat) shared.DataPoint {
+	return shared.DataPoint{XAxis: x, Stats: stats}
+}
+
+func timeStat(v float64, unit string) shared.Stat {
+	return shared.Stat{Type: shared.StatLabel("Execution Time", unit, "op"), Unit: unit, Per: "op", Value: shared.F64(v)}
+}
+
+func (s *DiffSuite) TestIdenticalFilesHaveNoDiff() {
+	ds := []shared.Dataset{{Name: "Bench", Axes: []shared.Axis{{Key: "x"}}, Data: []shared.DataPoint{point("sort", timeStat(1, "ns"))}}}
+	s.True(shared.DiffDatasets(ds, ds, 0).IsZero())
+}
+
+func (s *DiffSuite) TestDatasetsAddedAndRemoved() {
+	before := []shared.Dataset{{Name: "A"}, {Name: "B"}, {Name: "B"}}
+	after := []shared.Dataset{{Name: "B"}, {Name: "C"}, {}}
+	diff := shared.DiffDatasets(before, after, 0)
+	s.Equal([]string{"A", "B #2"}, diff.Removed)
+	s.Equal([]string{"C", "(unnamed)"}, diff.Added)
+	s.Empty(diff.Changed)
+}
+
+func (s *DiffSuite) TestAxesAndSettings() {
+	before := []shared.Dataset{{
+		Name:     "Bench",
+		Axes:     []shared.Axis{{Key: "x", Label: "n"}},
+		Settings: []internal_charts.ChartConfig{&barchart.Config{Type: "bar", Scale: "linear"}, &piechart.Config{Type: "pie"}},
+	}}
+	after := []shared.Dataset{{
+		Name:     "Bench",
+		Axes:     []shared.Axis{{Key: "x", Label: "size"}},
+		Settings: []internal_charts.ChartConfig{&barchart.Config{Type: "bar", Scale: "log", Zoom: "x"}, &barchart.Config{Type: "bar"}},
+	}}
+	diff := shared.DiffDatasets(before, after, 0)
+	s.Require().Len(diff.Changed, 1)
+	d := diff.Changed[0]
+	s.Equal("Bench", d.Name)
+	s.Equal(&shared.AxesChange{Before: before[0].Axes, After: after[0].Axes}, d.Axes)
+	s.Equal([]string{"pie"}, d.RemovedCharts)
+	s.Equal([]string{"bar#2"}, d.AddedCharts)
+	s.Equal([]shared.SettingChange{
+		{Chart: "bar", Field: "scale", Before: "linear", After: "log"},
+		{Chart: "bar", Field: "zoom", After: "x"},
+	}, d.Settings)
+}
+
+func (s *DiffSuite) TestPointsAndStatDeltas() {
+	before := []shared.Dataset{{Name: "Bench", Data: []shared.DataPoint{
+		point("sort", timeStat(1000, "ns"), shared.Stat{Type: "Allocs", Value: shared.F64(3)}),
+		point("map", timeStat(5, "ns")),
+		point("gone"),
+	}}}
+	after := []shared.Dataset{{Name: "Bench", Data: []shared.DataPoint{
+		point("sort", timeStat(1.5, "us"), shared.Stat{Type: "Bytes", Value: shared.F64(8)}),
+		point("map", timeStat(5, "ns")),
+		point("new"),
+	}}}
+	diff := shared.DiffDatasets(before, after, 0)
+	s.Require().Len(diff.Changed, 1)
+	d := diff.Changed[0]
+	s.Equal([]shared.PointKey{{XAxis: "gone"}}, d.RemovedPoints)
+	s.Equal([]shared.PointKey{{XAxis: "new"}}, d.AddedPoints)
+	s.Require().Len(d.ChangedPoints, 1)
+	s.Equal("sort", d.ChangedPoints[0].Point.String())
+
+	stats := d.ChangedPoints[0].Stats
+	s.Require().Len(stats, 3)
+	s.Equal("Execution Time (us/op)", stats[0].Type)
+	s.Empty(stats[0].BeforeType, "ns converts to us")
+	s.InDelta(1.0, *stats[0].Before, 1e-9)
+	s.InDelta(0.5, *stats[0].Delta, 1e-9)
+	s.InDelta(50.0, *stats[0].Percent, 1e-9)
+	s.Equal(shared.StatChange{Type: "Allocs", Before: shared.F64(3)}, stats[1])
+	s.Equal(shared.StatChange{Type: "Bytes", After: shared.F64(8)}, stats[2])
+}
+
+func (s *DiffSuite) TestIncompatibleUnitsAndZeroBase() {
+	before := []shared.Dataset{{Name: "Bench", Data: []shared.DataPoint{
+		point("a", timeStat(3, "B")),
+		point("b", shared.Stat{Type: "Hits", Value: shared.F64(0)}),
+	}}}
+	after := []shared.Dataset{{Name: "Bench", Data: []shared.DataPoint{
+		point("a", timeStat(4, "ns")),
+		point("b", shared.Stat{Type: "Hits", Value: shared.F64(2)}),
+	}}}
+	points := shared.DiffDatasets(before, after, 0).Changed[0].ChangedPoints
+	s.Require().Len(points, 2)
+	s.Equal("Execution Time (B/op)", points[0].Stats[0].BeforeType)
+	s.Nil(points[0].Stats[0].Delta)
+	s.InDelta(2.0, *points[1].Stats[0].Delta, 1e-9)
+	s.Nil(points[1].Stats[0].Percent)
+}
+
+func (s *DiffSuite) TestToleranceAndRepeatedPoints() {
+	before := []shared.Dataset{{Name: "Bench", PreserveRows: true, Data: []shared.DataPoint{
+		point("a", timeStat(100, "ns")), point("a", timeStat(100, "ns")),
+	}}}
+	after := []shared.Dataset{{Name: "Bench", PreserveRows: true, Data: []shared.DataPoint{
+		point("a", timeStat(104, "ns")), point("a", timeStat(110, "ns")),
+	}}}
+	s.Len(shared.DiffDatasets(before, after, 0).Changed[0].ChangedPoints, 2)
+
+	points := shared.DiffDatasets(before, after, 5).Changed[0].ChangedPoints
+	s.Require().Len(points, 1, "the 4% change is within tolerance")
+	s.Equal(shared.PointKey{XAxis: "a", Occurrence: 2}, points[0].Point)
+	s.Equal("a #2", points[0].Point.String())
+}
+
+func TestDiffSuite(t *testing.T) {
+	suite.Run(t, new(DiffSuite))
+}
@@ -9,6 +9,11 @@ const (
 	DimensionZAxis Dimension = "z"
 )
 
+// Value returns p's field on this dimension.
+func (d Dimension) Value(p DataPoint) string {
+	return dimFieldValue(p, d)
+}
+
 // AxisKey returns the dataset.axes key for this inject dimension.
 func (d Dimension) AxisKey() string {
 	switch d {
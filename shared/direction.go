@@ -0,0 +1,100 @@
+package shared
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Direction says which way a stat improves. Empty means unknown.
+type Direction string
+
+const (
+	// DirectionLower marks costs: time, memory, allocations per op.
+	DirectionLower Direction = "lower"
+	// DirectionHigher marks rates: throughput, ops/s, hz.
+	DirectionHigher Direction = "higher"
+	// DirectionNeutral marks stats with no better end, such as sample counts.
+	DirectionNeutral Direction = "neutral"
+)
+
+// ValidDirections is the ordered list of accepted Direction values.
+var ValidDirections = []string{string(DirectionLower), string(DirectionHigher), string(DirectionNeutral)}
+
+// ParseDirection reads a Direction, case-insensitively.
+func ParseDirection(s string) (Direction, error) {
+	d := strings.ToLower(strings.TrimSpace(s))
+	if !slices.Contains(ValidDirections, d) {
+		return "", fmt.Errorf("direction %q is invalid (must be %s)", s, strings.Join(ValidDirections, ", "))
+	}
+	return Direction(d), nil
+}
+
+// DirectionForUnit infers the better direction from a stat's unit: rates
+// ("/s", ops, hz) are higher-is-better; durations, sizes and anything per op
+// are lower-is-better. Other units are unknown.
+func DirectionForUnit(unit, per string) Direction {
+	switch {
+	case per == "s" || slices.Contains([]string{"ops", "hz", "Hz"}, unit):
+		return DirectionHigher
+	case per == "op":
+		return DirectionLower
+	}
+	for _, family := range unitFamilies[:2] { // time and memory
+		if _, ok := family[unit]; ok {
+			return DirectionLower
+		}
+	}
+	return ""
+}
+
+// ParseDirectionRule reads one --better entry, "name=higher", into the stat
+// name and its direction.
+func ParseDirectionRule(raw string) (string, Direction, error) {
+	name, value, ok := strings.Cut(raw, "=")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		return "", "", fmt.Errorf("--better %q must be name=lower|higher|neutral", raw)
+	}
+	d, err := ParseDirection(value)
+	if err != nil {
+		return "", "", fmt.Errorf("--better %q: %w", raw, err)
+	}
+	return name, d, nil
+}
+
+// ApplyDirections overrides Stat.Better from rules keyed by stat name. A rule
+// matches a stat by its label with or without the unit ("latency" matches
+// "latency (K)"), case-insensitively.
+func ApplyDirections(points []DataPoint, rules map[string]Direction) {
+	for i := range points {
+		for j := range points[i].Stats {
+			st := &points[i].Stats[j]
+			for name, d := range rules {
+				if directionRuleMatches(name, *st) {
+					st.Better = d
+				}
+			}
+		}
+	}
+}
+
+// UnmatchedDirectionRules returns the rule names that match no stat in points,
+// sorted, so callers can report likely typos.
+func UnmatchedDirectionRules(points []DataPoint, rules map[string]Direction) []string {
+	var unmatched []string
+	for name := range rules {
+		found := slices.ContainsFunc(points, func(p DataPoint) bool {
+			return slices.ContainsFunc(p.Stats, func(st Stat) bool { return directionRuleMatches(name, st) })
+		})
+		if !found {
+			unmatched = append(unmatched, name)
+		}
+	}
+	slices.Sort(unmatched)
+	return unmatched
+}
+
+func directionRuleMatches(name string, st Stat) bool {
+	return strings.EqualFold(name, st.Type) || strings.EqualFold(name, st.BaseType())
+}
@@ -0,0 +1,71 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type DirectionSuite struct {
+	suite.Suite
+}
+
+func (s *DirectionSuite) TestParseDirection() {
+	d, err := ParseDirection(" Higher ")
+	s.Require().NoError(err)
+	s.Equal(DirectionHigher, d)
+
+	_, err = ParseDirection("up")
+	s.ErrorContains(err, "lower, higher, neutral")
+}
+
+func (s *DirectionSuite) TestDirectionForUnit() {
+	tests := []struct {
+		unit, per string
+		want      Direction
+	}{
+		{"ns", "op", DirectionLower},
+		{"B", "op", DirectionLower},
+		{"", "op", DirectionLower},
+		{"MB", "s", DirectionHigher},
+		{"ops", "", DirectionHigher},
+		{"hz", "", DirectionHigher},
+		{"ms", "", DirectionLower},
+		{"KB", "", DirectionLower},
+		{"hits", "", ""},
+		{"", "", ""},
+	}
+	for _, tt := range tests {
+		s.Equal(tt.want, DirectionForUnit(tt.unit, tt.per), "%s/%s", tt.unit, tt.per)
+	}
+}
+
+func (s *DirectionSuite) TestParseDirectionRule() {
+	name, d, err := ParseDirectionRule("rps=HIGHER")
+	s.Require().NoError(err)
+	s.Equal("rps", name)
+	s.Equal(DirectionHigher, d)
+
+	for _, raw := range []string{"rps", "=higher", "rps=up"} {
+		_, _, err := ParseDirectionRule(raw)
+		s.Error(err, raw)
+	}
+}
+
+func (s *DirectionSuite) TestApplyDirectionsMatchesBaseType() {
+	points := []DataPoint{{Stats: []Stat{
+		{Type: "Latency (ms)", Unit: "ms", Better: DirectionLower},
+		{Type: "rps"},
+	}}}
+	rules := map[string]Direction{"latency": DirectionHigher, "RPS": DirectionNeutral, "missing": DirectionLower}
+
+	ApplyDirections(points, rules)
+
+	s.Equal(DirectionHigher, points[0].Stats[0].Better)
+	s.Equal(DirectionNeutral, points[0].Stats[1].Better)
+	s.Equal([]string{"missing"}, UnmatchedDirectionRules(points, rules))
+}
+
+func TestDirectionSuite(t *testing.T) {
+	suite.Run(t, new(DirectionSuite))
+}
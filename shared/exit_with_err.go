@@ -12,6 +12,12 @@ import (
 //
 // Does not use log.Fatal so temp-file cleanup always runs before OsExit.
 func ExitWithError(msg string, err error) {
+	ExitWithErrorCode(1, msg, err)
+}
+
+// ExitWithErrorCode is ExitWithError with an explicit exit status, for
+// commands such as diff that reserve 1 for a non-error outcome.
+func ExitWithErrorCode(code int, msg string, err error) {
 	if err != nil {
 		cliout.Error(fmt.Sprintf("%s: %v", msg, err))
 	} else {
@@ -19,5 +25,5 @@ func ExitWithError(msg string, err error) {
 	}
 
 	TempFiles.RemoveAll()
-	OsExit(1)
+	OsExit(code)
 }
@@ -0,0 +1,238 @@
+package shared
+
+import (
+	"math"
+	"slices"
+	"sort"
+	"strconv"
+
+	internal_charts "github.com/goptics/vizb/internal/charts"
+)
+
+// BinOptions configures BinDataPoints. Count is used by the count and log
+// methods, Width by the width method; zero means unset. Method resolves via
+// internal_charts.ResolveBinMethod.
+type BinOptions struct {
+	Method string
+	Count  int
+	Width  float64
+}
+
+// BinDataPoints replaces raw rows with histogram bins. Every stat type is
+// binned on its own: the edges come from all of that stat's values, so the
+// series drawn over each other share the same bins. Each bin becomes one
+// DataPoint per series with the bin label on x, the series on y, and the row
+// count as the stat value (empty bins included, in ascending bin order).
+//
+// The series is the outermost grouping dimension present (name, then x, y,
+// z); deeper dimensions are pooled into it. The returned axes describe the
+// binned shape. Non-positive values are left out of log bins.
+func BinDataPoints(points []DataPoint, axes []Axis, opts BinOptions) ([]DataPoint, []Axis) {
+	seriesField, seriesLabel := histogramSeriesField(points, axes)
+	method := internal_charts.ResolveBinMethod(opts.Method, opts.Count, opts.Width)
+
+	type samples struct {
+		series []string
+		values map[string][]float64
+	}
+	var statTypes []string
+	byStat := map[string]*samples{}
+	for _, p := range points {
+		series := seriesField(p)
+		for _, st := range p.Stats {
+			if st.Value == nil {
+				continue
+			}
+			v := *st.Value
+			if method == internal_charts.BinMethodLog && v <= 0 {
+				continue
+			}
+			s, ok := byStat[st.Type]
+			if !ok {
+				s = &samples{values: map[string][]float64{}}
+				byStat[st.Type] = s
+				statTypes = append(statTypes, st.Type)
+			}
+			if _, ok := s.values[series]; !ok {
+				s.series = append(s.series, series)
+			}
+			s.values[series] = append(s.values[series], v)
+		}
+	}
+
+	var out []DataPoint
+	for _, statType := range statTypes {
+		s := byStat[statType]
+		var all []float64
+		for _, series := range s.series {
+			all = append(all, s.values[series]...)
+		}
+		slices.Sort(all)
+		edges := histogramEdges(all, method, opts)
+		labels := binLabels(edges)
+		for _, series := range s.series {
+			counts := make([]int, len(labels))
+			for _, v := range s.values[series] {
+				counts[binIndex(edges, v)]++
+			}
+			for i, label := range labels {
+				out = append(out, DataPoint{
+					XAxis: label,
+					YAxis: series,
+					Stats: []Stat{{Type: statType, Value: F64(float64(counts[i]))}},
+				})
+			}
+		}
+	}
+
+	binned := []Axis{{Key: "x", Label: "bin"}}
+	if seriesLabel != "" {
+		binned = append(binned, Axis{Key: "y", Label: seriesLabel})
+	}
+	return out, binned
+}
+
+// histogramSeriesField picks the outermost dimension that carries a value in
+// points and returns its accessor and axis label. With no dimensions every
+// value falls into one unnamed series.
+func histogramSeriesField(points []DataPoint, axes []Axis) (func(DataPoint) string, string) {
+	fields := []struct {
+		key string
+		get func(DataPoint) string
+	}{
+		{"name", func(p DataPoint) string { return p.Name }},
+		{"x", func(p DataPoint) string { return p.XAxis }},
+		{"y", func(p DataPoint) string { return p.YAxis }},
+		{"z", func(p DataPoint) string { return p.ZAxis }},
+	}
+	for _, f := range fields {
+		if !slices.ContainsFunc(points, func(p DataPoint) bool { return f.get(p) != "" }) {
+			continue
+		}
+		label := f.key
+		for _, a := range axes {
+			if a.Key == f.key && a.Label != "" {
+				label = a.Label
+			}
+		}
+		return f.get, label
+	}
+	return func(DataPoint) string { return "" }, ""
+}
+
+// histogramEdges returns ascending bin edges (len = bins+1) for sorted values.
+func histogramEdges(sorted []float64, method string, opts BinOptions) []float64 {
+	if len(sorted) == 0 {
+		return nil
+	}
+	lo, hi := sorted[0], sorted[len(sorted)-1]
+	if lo == hi {
+		return []float64{lo, hi}
+	}
+
+	switch method {
+	case internal_charts.BinMethodWidth:
+		start := math.Floor(lo/opts.Width) * opts.Width
+		n := int(math.Floor((hi-start)/opts.Width)) + 1
+		if n > internal_charts.MaxHistogramBins {
+			return linearEdges(lo, hi, internal_charts.MaxHistogramBins)
+		}
+		edges := make([]float64, n+1)
+		for i := range edges {
+			edges[i] = start + float64(i)*opts.Width
+		}
+		return edges
+	case internal_charts.BinMethodCount:
+		return linearEdges(lo, hi, min(opts.Count, internal_charts.MaxHistogramBins))
+	case internal_charts.BinMethodLog:
+		n := opts.Count
+		if n <= 0 {
+			logs := make([]float64, len(sorted))
+			for i, v := range sorted {
+				logs[i] = math.Log10(v)
+			}
+			n = freedmanDiaconisBins(logs)
+		}
+		edges := linearEdges(math.Log10(lo), math.Log10(hi), min(n, internal_charts.MaxHistogramBins))
+		for i, e := range edges {
+			edges[i] = math.Pow(10, e)
+		}
+		edges[0], edges[len(edges)-1] = lo, hi // exact despite pow rounding
+		return edges
+	default:
+		return linearEdges(lo, hi, freedmanDiaconisBins(sorted))
+	}
+}
+
+func linearEdges(lo, hi float64, n int) []float64 {
+	n = max(n, 1)
+	edges := make([]float64, n+1)
+	step := (hi - lo) / float64(n)
+	for i := range edges {
+		edges[i] = lo + float64(i)*step
+	}
+	edges[n] = hi
+	return edges
+}
+
+// freedmanDiaconisBins sizes bins at 2·IQR·n^(-1/3) over sorted values. A zero
+// IQR (most values identical) falls back to Sturges' rule.
+func freedmanDiaconisBins(sorted []float64) int {
+	n := float64(len(sorted))
+	span := sorted[len(sorted)-1] - sorted[0]
+	width := 2 * (quantile(sorted, 0.75) - quantile(sorted, 0.25)) / math.Cbrt(n)
+	if width <= 0 || span <= 0 {
+		return max(1, min(int(math.Ceil(math.Log2(n)))+1, internal_charts.MaxHistogramBins))
+	}
+	return max(1, min(int(math.Ceil(span/width)), internal_charts.MaxHistogramBins))
+}
+
+// quantile linearly interpolates the q-th quantile of sorted values.
+func quantile(sorted []float64, q float64) float64 {
+	pos := q * float64(len(sorted)-1)
+	i := int(pos)
+	if i+1 >= len(sorted) {
+		return sorted[len(sorted)-1]
+	}
+	return sorted[i] + (pos-float64(i))*(sorted[i+1]-sorted[i])
+}
+
+// binIndex finds the bin holding v: [edges[i], edges[i+1]), with the last bin
+// closed so the maximum lands in it.
+func binIndex(edges []float64, v float64) int {
+	last := len(edges) - 2
+	i := sort.Search(last+1, func(i int) bool { return edges[i+1] > v })
+	return min(i, last)
+}
+
+// binLabels renders "[lo, hi)" per bin, closing the last one. A single-value
+// input yields one bin labelled by that value.
+func binLabels(edges []float64) []string {
+	if len(edges) == 2 && edges[0] == edges[1] {
+		return []string{formatBinEdge(edges[0])}
+	}
+	labels := make([]string, len(edges)-1)
+	for i := range labels {
+		closing := ")"
+		if i == len(labels)-1 {
+			closing = "]"
+		}
+		labels[i] = "[" + formatBinEdge(edges[i]) + ", " + formatBinEdge(edges[i+1]) + closing
+	}
+	return labels
+}
+
+// formatBinEdge keeps four significant digits without switching to exponent
+// notation for ordinary magnitudes.
+func formatBinEdge(v float64) string {
+	if v == 0 {
+		return "0"
+	}
+	digits := 3 - int(math.Floor(math.Log10(math.Abs(v))))
+	scale := math.Pow(10, float64(digits))
+	rounded := math.Round(v*scale) / scale
+	if math.Abs(rounded) >= 1e15 || math.Abs(rounded) < 1e-6 {
+		return strconv.FormatFloat(rounded, 'g', 4, 64)
+	}
+	return strconv.FormatFloat(rounded, 'f', -1, 64)
+}
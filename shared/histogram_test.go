@@ -0,0 +1,129 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type HistogramSuite struct {
+	suite.Suite
+}
+
+func valuePoints(series string, values ...float64) []DataPoint {
+	out := make([]DataPoint, len(values))
+	for i, v := range values {
+		out[i] = DataPoint{YAxis: series, Stats: []Stat{{Type: "latency", Value: F64(v)}}}
+	}
+	return out
+}
+
+// binCounts flattens binned points to "series bin" → count.
+func binCounts(points []DataPoint) map[string]float64 {
+	out := map[string]float64{}
+	for _, p := range points {
+		out[p.YAxis+" "+p.XAxis] = *p.Stats[0].Value
+	}
+	return out
+}
+
+func (s *HistogramSuite) TestCountMethodClosesLastBin() {
+	points := valuePoints("api", 0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10)
+
+	out, axes := BinDataPoints(points, nil, BinOptions{Count: 5})
+	s.Require().Len(out, 5)
+	labels := make([]string, len(out))
+	for i, p := range out {
+		labels[i] = p.XAxis
+	}
+	s.Equal([]string{"[0, 2)", "[2, 4)", "[4, 6)", "[6, 8)", "[8, 10]"}, labels)
+	s.Equal(3.0, *out[4].Stats[0].Value, "the maximum lands in the last bin")
+	s.Equal([]Axis{{Key: "x", Label: "bin"}, {Key: "y", Label: "y"}}, axes)
+}
+
+func (s *HistogramSuite) TestOverlaySeriesShareEdgesAndKeepEmptyBins() {
+	points := append(valuePoints("api", 1, 2, 3), valuePoints("web", 9, 10)...)
+
+	out, axes := BinDataPoints(points, []Axis{{Key: "y", Label: "service"}}, BinOptions{Count: 3})
+	s.Require().Len(out, 6)
+	s.Equal(map[string]float64{
+		"api [1, 4)": 3, "api [4, 7)": 0, "api [7, 10]": 0,
+		"web [1, 4)": 0, "web [4, 7)": 0, "web [7, 10]": 2,
+	}, binCounts(out))
+	s.Equal("api", out[0].YAxis, "series keep first-seen order")
+	s.Equal(Axis{Key: "y", Label: "service"}, axes[1])
+}
+
+func (s *HistogramSuite) TestSeriesIsOutermostDimension() {
+	points := []DataPoint{
+		{Name: "run-a", XAxis: "GET", Stats: []Stat{{Type: "ms", Value: F64(1)}}},
+		{Name: "run-b", XAxis: "POST", Stats: []Stat{{Type: "ms", Value: F64(3)}}},
+	}
+
+	out, axes := BinDataPoints(points, []Axis{{Key: "name", Label: "run"}, {Key: "x", Label: "method"}}, BinOptions{Count: 2})
+	s.Equal(map[string]float64{
+		"run-a [1, 2)": 1, "run-a [2, 3]": 0,
+		"run-b [1, 2)": 0, "run-b [2, 3]": 1,
+	}, binCounts(out))
+	s.Equal("run", axes[1].Label)
+}
+
+func (s *HistogramSuite) TestNoDimensionsYieldsOneUnnamedSeries() {
+	out, axes := BinDataPoints(valuePoints("", 1, 2), nil, BinOptions{Count: 1})
+	s.Require().Len(out, 1)
+	s.Empty(out[0].YAxis)
+	s.Equal([]Axis{{Key: "x", Label: "bin"}}, axes)
+}
+
+func (s *HistogramSuite) TestWidthMethodAlignsToMultiples() {
+	out, _ := BinDataPoints(valuePoints("", 3, 7, 12), nil, BinOptions{Width: 5})
+	s.Equal(map[string]float64{" [0, 5)": 1, " [5, 10)": 1, " [10, 15]": 1}, binCounts(out))
+}
+
+func (s *HistogramSuite) TestLogMethodDropsNonPositiveValues() {
+	out, _ := BinDataPoints(valuePoints("", -5, 0, 1, 10, 100, 1000), nil, BinOptions{Method: "log", Count: 3})
+	s.Equal(map[string]float64{" [1, 10)": 1, " [10, 100)": 1, " [100, 1000]": 2}, binCounts(out))
+}
+
+func (s *HistogramSuite) TestEachStatBinnedOnItsOwnRange() {
+	points := []DataPoint{
+		{YAxis: "api", Stats: []Stat{{Type: "ms", Value: F64(1)}, {Type: "bytes", Value: F64(100)}}},
+		{YAxis: "api", Stats: []Stat{{Type: "ms", Value: F64(2)}, {Type: "bytes", Value: nil}}},
+	}
+
+	out, _ := BinDataPoints(points, nil, BinOptions{Count: 1})
+	s.Require().Len(out, 2)
+	s.Equal("ms", out[0].Stats[0].Type)
+	s.Equal("[1, 2]", out[0].XAxis)
+	s.Equal(2.0, *out[0].Stats[0].Value)
+	s.Equal("bytes", out[1].Stats[0].Type)
+	s.Equal("100", out[1].XAxis, "a single value is its own bin")
+	s.Equal(1.0, *out[1].Stats[0].Value, "nil values are not counted")
+}
+
+func (s *HistogramSuite) TestFreedmanDiaconisBins() {
+	sorted := make([]float64, 100)
+	for i := range sorted {
+		sorted[i] = float64(i + 1)
+	}
+	s.Equal(5, freedmanDiaconisBins(sorted))
+	// Zero IQR falls back to Sturges: ceil(log2 8) + 1.
+	s.Equal(4, freedmanDiaconisBins([]float64{1, 1, 1, 1, 1, 1, 1, 2}))
+}
+
+func (s *HistogramSuite) TestWidthMethodCapsBinCount() {
+	out, _ := BinDataPoints(valuePoints("", 0, 1e6), nil, BinOptions{Width: 1})
+	s.Len(out, 500)
+}
+
+func (s *HistogramSuite) TestFormatBinEdge() {
+	s.Equal("0", formatBinEdge(0))
+	s.Equal("1.5", formatBinEdge(1.5))
+	s.Equal("12350", formatBinEdge(12345.6))
+	s.Equal("0.0001235", formatBinEdge(0.000123456))
+	s.Equal("-2.5", formatBinEdge(-2.5))
+}
+
+func TestHistogramSuite(t *testing.T) {
+	suite.Run(t, new(HistogramSuite))
+}
@@ -1,6 +1,8 @@
 package shared
 
 import (
+	"encoding/json"
+	"maps"
 	"slices"
 	"sort"
 	"strings"
@@ -18,7 +20,19 @@ const noTagKey = "__no_tag__"
 // When both legacy and tagged benchmarks share a Name they are combined into
 // one object so accumulated data is preserved across incremental merges.
 // dim controls which inner data dimension receives the benchmark tag annotation.
+// Stats measuring one quantity in different units are first converted to the
+// newest run's unit (see ReconcileStatUnits), so they stay one series.
+// Tags are ordered by commit time where runs record a Commit, else by
+// Timestamp; see MergeDatasetsWithAncestry to order by commit ancestry.
 func MergeDatasets(benchmarks []Dataset, dim Dimension) []Dataset {
+	return MergeDatasetsWithAncestry(benchmarks, dim, nil)
+}
+
+// MergeDatasetsWithAncestry is MergeDatasets with tags that record a Commit
+// ordered by ancestry first: a run on a descendant commit is newer than one
+// on its ancestor whatever their timestamps say. isAncestor may be nil.
+func MergeDatasetsWithAncestry(benchmarks []Dataset, dim Dimension, isAncestor AncestryFunc) []Dataset {
+	benchmarks = ReconcileStatUnits(benchmarks)
 	nameOrder := make([]string, 0)
 	groups := make(map[string]map[string]*Dataset)
 
@@ -47,6 +61,7 @@ func MergeDatasets(benchmarks []Dataset, dim Dimension) []Dataset {
 				merged := newer
 				merged.Themes = mergeThemes(datasetThemes(newer), datasetThemes(older))
 				merged.Theme = ""
+				merged.Annotations = mergeAnnotations(older.Annotations, newer.Annotations)
 				tags[tag] = &merged
 				continue
 			}
@@ -58,6 +73,7 @@ func MergeDatasets(benchmarks []Dataset, dim Dimension) []Dataset {
 				newer, older = ds, *existing
 			}
 			replaced := replaceTagData(pickAccumulatedBase(older, newer), newer, dim)
+			replaced.Annotations = mergeAnnotations(older.Annotations, newer.Annotations)
 			tags[tag] = &replaced
 			continue
 		}
@@ -73,13 +89,16 @@ func MergeDatasets(benchmarks []Dataset, dim Dimension) []Dataset {
 		noTag := tags[noTagKey]
 		delete(tags, noTagKey)
 
+		// Start from tag order so ties don't depend on map iteration.
+		runs := make([]run, 0, len(tags))
+		tagOrder := slices.Sorted(maps.Keys(tags))
+		for _, tag := range tagOrder {
+			runs = append(runs, datasetRun(*tags[tag]))
+		}
 		tagged := make([]Dataset, 0, len(tags))
-		for _, b := range tags {
-			tagged = append(tagged, *b)
+		for _, i := range orderRuns(runs, isAncestor) {
+			tagged = append(tagged, *tags[tagOrder[i]])
 		}
-		sort.SliceStable(tagged, func(i, j int) bool {
-			return tagged[i].Timestamp < tagged[j].Timestamp
-		})
 
 		switch {
 		case noTag != nil && len(tagged) == 0:
@@ -95,7 +114,9 @@ func MergeDatasets(benchmarks []Dataset, dim Dimension) []Dataset {
 				base.Timestamp = latest.Timestamp
 				base.Themes = foldThemes(allDatasets)
 				base.Theme = ""
-				base.History = buildHistory(allDatasets, latest.Tag)
+				base.Commit = latest.Commit
+				base.History = buildHistory(allDatasets, latest.Tag, isAncestor)
+				base.Annotations = foldAnnotations(allDatasets)
 				base.Data = mergeData(allDatasets, dim)
 				base.Axes = EnsureAxis(base.Axes, dim)
 				result = append(result, base)
@@ -106,7 +127,8 @@ func MergeDatasets(benchmarks []Dataset, dim Dimension) []Dataset {
 			base := deepCloneDataset(latest)
 			base.Themes = foldThemes(tagged)
 			base.Theme = ""
-			base.History = buildHistory(tagged, latest.Tag)
+			base.History = buildHistory(tagged, latest.Tag, isAncestor)
+			base.Annotations = foldAnnotations(tagged)
 			base.Data = mergeData(tagged, dim)
 			base.Axes = EnsureAxis(base.Axes, dim)
 			result = append(result, base)
@@ -128,14 +150,8 @@ func deepCloneDataset(src Dataset) Dataset {
 		copy(dst.History, src.History)
 	}
 
-	if src.Meta != nil {
-		m := *src.Meta
-		if src.Meta.CPU != nil {
-			cpu := *src.Meta.CPU
-			m.CPU = &cpu
-		}
-		dst.Meta = &m
-	}
+	dst.Meta = src.Meta.Clone()
+	dst.Commit = src.Commit.Clone()
 
 	if src.Axes != nil {
 		dst.Axes = make([]Axis, len(src.Axes))
@@ -151,6 +167,11 @@ func deepCloneDataset(src Dataset) Dataset {
 		dst.Themes = cloneThemes(src.Themes)
 	}
 
+	if src.Annotations != nil {
+		dst.Annotations = make([]Annotation, len(src.Annotations))
+		copy(dst.Annotations, src.Annotations)
+	}
+
 	return dst
 }
 
@@ -225,6 +246,33 @@ func foldThemes(datasets []Dataset) []Theme {
 	return result
 }
 
+// mergeAnnotations unions annotation lists in order, dropping exact repeats.
+func mergeAnnotations(lists ...[]Annotation) []Annotation {
+	var out []Annotation
+	seen := map[string]bool{}
+	for _, list := range lists {
+		for _, a := range list {
+			key, _ := json.Marshal(a)
+			if seen[string(key)] {
+				continue
+			}
+			seen[string(key)] = true
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// foldAnnotations unions the annotations of datasets in the given order
+// (chronological at the call sites), dropping exact repeats.
+func foldAnnotations(datasets []Dataset) []Annotation {
+	lists := make([][]Annotation, 0, len(datasets))
+	for _, ds := range datasets {
+		lists = append(lists, ds.Annotations)
+	}
+	return mergeAnnotations(lists...)
+}
+
 func cloneThemes(themes []Theme) []Theme {
 	if themes == nil {
 		return nil
@@ -249,47 +297,26 @@ func cloneTheme(t Theme) Theme {
 	return out
 }
 
-type historyCandidate struct {
-	timestamp string
-	meta      *Meta
-}
-
-// buildHistory collects tag+timestamp+meta from all benchmarks and their
-// existing History entries, excluding the latest tag. Entries are deduplicated
-// by tag (keeping the latest timestamp per tag) and sorted chronologically.
-func buildHistory(benchmarks []Dataset, latestTag string) []HistoryEntry {
-	seen := make(map[string]historyCandidate)
-	for _, ds := range benchmarks {
-		if ds.Tag != "" && ds.Tag != latestTag {
-			if c, ok := seen[ds.Tag]; !ok || ds.Timestamp > c.timestamp {
-				var metaPtr *Meta
-				if ds.Meta != nil {
-					metaCopy := *ds.Meta
-					if ds.Meta.CPU != nil {
-						cpu := *ds.Meta.CPU
-						metaCopy.CPU = &cpu
-					}
-					metaPtr = &metaCopy
-				}
-				seen[ds.Tag] = historyCandidate{timestamp: ds.Timestamp, meta: metaPtr}
-			}
+// buildHistory collects tag+timestamp+commit+meta from all benchmarks and
+// their existing History entries, excluding the latest tag. Entries are
+// deduplicated by tag (keeping the latest timestamp per tag) and sorted oldest
+// first (see orderRuns).
+func buildHistory(benchmarks []Dataset, latestTag string, isAncestor AncestryFunc) []HistoryEntry {
+	seen := make(map[string]HistoryEntry)
+	consider := func(entry HistoryEntry) {
+		if entry.Tag == "" || entry.Tag == latestTag {
+			return
 		}
+		if prev, ok := seen[entry.Tag]; !ok || entry.Timestamp > prev.Timestamp {
+			entry.Commit = entry.Commit.Clone()
+			entry.Meta = entry.Meta.Clone()
+			seen[entry.Tag] = entry
+		}
+	}
+	for _, ds := range benchmarks {
+		consider(HistoryEntry{Tag: ds.Tag, Timestamp: ds.Timestamp, Commit: ds.Commit, Meta: ds.Meta})
 		for _, entry := range ds.History {
-			if entry.Tag == latestTag {
-				continue
-			}
-			if c, ok := seen[entry.Tag]; !ok || entry.Timestamp > c.timestamp {
-				meta := entry.Meta
-				if meta != nil {
-					m := *meta
-					if meta.CPU != nil {
-						cpu := *meta.CPU
-						m.CPU = &cpu
-					}
-					meta = &m
-				}
-				seen[entry.Tag] = historyCandidate{timestamp: entry.Timestamp, meta: meta}
-			}
+			consider(entry)
 		}
 	}
 
@@ -297,16 +324,24 @@ func buildHistory(benchmarks []Dataset, latestTag string) []HistoryEntry {
 		return nil
 	}
 
+	// Start from tag order so ties don't depend on map iteration.
+	tagOrder := slices.Sorted(maps.Keys(seen))
+	runs := make([]run, len(tagOrder))
+	for i, tag := range tagOrder {
+		runs[i] = run{seen[tag].Timestamp, seen[tag].Commit}
+	}
 	entries := make([]HistoryEntry, 0, len(seen))
-	for tag, c := range seen {
-		entries = append(entries, HistoryEntry{Tag: tag, Timestamp: c.timestamp, Meta: c.meta})
+	for _, i := range orderRuns(runs, isAncestor) {
+		entries = append(entries, seen[tagOrder[i]])
 	}
-	sort.Slice(entries, func(i, j int) bool {
-		return entries[i].Timestamp < entries[j].Timestamp
-	})
 	return entries
 }
 
+// datasetRun is the ordering view of a tagged dataset.
+func datasetRun(ds Dataset) run {
+	return run{timestamp: ds.Timestamp, commit: ds.Commit}
+}
+
 func pickAccumulatedBase(a, b Dataset) Dataset {
 	if len(a.History) != len(b.History) {
 		if len(a.History) > len(b.History) {
@@ -339,12 +374,7 @@ func replaceTagData(existing, incoming Dataset, dim Dimension) Dataset {
 	result.Themes = mergeThemes(datasetThemes(incoming), datasetThemes(existing))
 	result.Theme = ""
 	if incoming.Meta != nil {
-		m := *incoming.Meta
-		if incoming.Meta.CPU != nil {
-			cpu := *incoming.Meta.CPU
-			m.CPU = &cpu
-		}
-		result.Meta = &m
+		result.Meta = incoming.Meta.Clone()
 	}
 	return result
 }
@@ -0,0 +1,241 @@
+package shared
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Merge conflict kinds.
+const (
+	ConflictAxes     = "axes"     // inputs disagree on which axes exist
+	ConflictAxis     = "axis"     // inputs label one axis differently
+	ConflictUnit     = "unit"     // a stat's units can't be converted into one series
+	ConflictReplaced = "replaced" // a run was superseded by a newer one with the same name and tag
+)
+
+// MergeConflict is one disagreement between merge inputs that the merge
+// resolved by picking a side or by keeping series apart.
+type MergeConflict struct {
+	Dataset string
+	Kind    string
+	Message string
+}
+
+func (c MergeConflict) String() string {
+	return fmt.Sprintf("%s: %s", c.Dataset, c.Message)
+}
+
+// MergeConflicts explains how merging inputs into merged lost or split data.
+// sources names each input (a file path, say) and must be as long as inputs;
+// merged is the result of merging inputs. Conflicts are grouped by dataset
+// name in input order.
+func MergeConflicts(inputs []Dataset, sources []string, merged []Dataset) []MergeConflict {
+	var names []string
+	byName := map[string][]int{}
+	for i, ds := range inputs {
+		if _, ok := byName[ds.Name]; !ok {
+			names = append(names, ds.Name)
+		}
+		byName[ds.Name] = append(byName[ds.Name], i)
+	}
+	result := map[string]Dataset{}
+	for _, ds := range merged {
+		result[ds.Name] = ds
+	}
+
+	var out []MergeConflict
+	for _, name := range names {
+		idx := byName[name]
+		if len(idx) < 2 {
+			continue
+		}
+		add := func(kind, format string, args ...any) {
+			out = append(out, MergeConflict{Dataset: name, Kind: kind, Message: fmt.Sprintf(format, args...)})
+		}
+		for _, msg := range axesConflicts(inputs, sources, idx) {
+			add(ConflictAxes, "%s", msg)
+		}
+		for _, msg := range axisLabelConflicts(inputs, sources, idx, result[name]) {
+			add(ConflictAxis, "%s", msg)
+		}
+		for _, msg := range unitConflicts(inputs, sources, idx) {
+			add(ConflictUnit, "%s", msg)
+		}
+		for _, msg := range replacedRuns(inputs, sources, idx) {
+			add(ConflictReplaced, "%s", msg)
+		}
+	}
+	return out
+}
+
+// axesConflicts reports inputs whose sets of axis keys differ: a changed
+// --group or --group-pattern between runs.
+func axesConflicts(inputs []Dataset, sources []string, idx []int) []string {
+	bySet := map[string][]string{}
+	var order []string
+	for _, i := range idx {
+		var keys []string
+		for _, a := range inputs[i].Axes {
+			keys = append(keys, a.Key)
+		}
+		set := strings.Join(keys, ",")
+		if _, ok := bySet[set]; !ok {
+			order = append(order, set)
+		}
+		bySet[set] = appendUnique(bySet[set], sources[i])
+	}
+	if len(order) < 2 {
+		return nil
+	}
+	parts := make([]string, len(order))
+	for i, set := range order {
+		if set == "" {
+			set = "none"
+		}
+		parts[i] = fmt.Sprintf("[%s] in %s", set, strings.Join(bySet[set], ", "))
+	}
+	return []string{"axes differ: " + strings.Join(parts, "; ")}
+}
+
+// axisLabelConflicts reports axes labelled differently across inputs and the
+// label the merged dataset kept.
+func axisLabelConflicts(inputs []Dataset, sources []string, idx []int, merged Dataset) []string {
+	type labelled struct {
+		order   []string
+		sources map[string][]string
+	}
+	byKey := map[string]*labelled{}
+	var keys []string
+	for _, i := range idx {
+		for _, a := range inputs[i].Axes {
+			if a.Label == "" {
+				continue
+			}
+			l, ok := byKey[a.Key]
+			if !ok {
+				l = &labelled{sources: map[string][]string{}}
+				byKey[a.Key] = l
+				keys = append(keys, a.Key)
+			}
+			if _, seen := l.sources[a.Label]; !seen {
+				l.order = append(l.order, a.Label)
+			}
+			l.sources[a.Label] = appendUnique(l.sources[a.Label], sources[i])
+		}
+	}
+	var out []string
+	for _, key := range keys {
+		l := byKey[key]
+		if len(l.order) < 2 {
+			continue
+		}
+		parts := make([]string, len(l.order))
+		for i, label := range l.order {
+			parts[i] = fmt.Sprintf("%q in %s", label, strings.Join(l.sources[label], ", "))
+		}
+		kept := ""
+		for _, a := range merged.Axes {
+			if a.Key == key {
+				kept = a.Label
+			}
+		}
+		out = append(out, fmt.Sprintf("axis %s labelled %s; kept %q (rename with --rename-axis)",
+			key, strings.Join(parts, ", "), kept))
+	}
+	return out
+}
+
+// unitConflicts reports stats that measure one quantity in units merge can't
+// convert between, which therefore stay separate series.
+func unitConflicts(inputs []Dataset, sources []string, idx []int) []string {
+	type quantity struct{ base, per string }
+	units := map[quantity]map[string][]string{}
+	var order []quantity
+	for _, i := range idx {
+		for _, p := range inputs[i].Data {
+			for _, st := range p.Stats {
+				if st.Unit == "" && st.Per == "" {
+					continue
+				}
+				q := quantity{st.BaseType(), st.Per}
+				if _, ok := units[q]; !ok {
+					units[q] = map[string][]string{}
+					order = append(order, q)
+				}
+				units[q][st.Unit] = appendUnique(units[q][st.Unit], sources[i])
+			}
+		}
+	}
+	var out []string
+	for _, q := range order {
+		found := slices.Sorted(maps.Keys(units[q]))
+		if len(found) < 2 {
+			continue
+		}
+		convertible := true
+		for _, u := range found[1:] {
+			if _, ok := ConvertUnit(1, found[0], u); !ok {
+				convertible = false
+				break
+			}
+		}
+		if convertible {
+			continue
+		}
+		parts := make([]string, len(found))
+		for i, u := range found {
+			shown := u
+			if shown == "" {
+				shown = "no unit"
+			}
+			parts[i] = fmt.Sprintf("%s in %s", shown, strings.Join(units[q][u], ", "))
+		}
+		out = append(out, fmt.Sprintf("stat %q has incompatible units (%s); kept as separate series",
+			StatLabel(q.base, "", q.per), strings.Join(parts, "; ")))
+	}
+	return out
+}
+
+// replacedRuns reports inputs merge dropped because another input has the same
+// name and tag with a newer timestamp, mirroring MergeDatasets' choice: the
+// newer run wins and the earlier input wins ties.
+func replacedRuns(inputs []Dataset, sources []string, idx []int) []string {
+	winner := map[string]int{}
+	var out []string
+	for _, i := range idx {
+		ds := inputs[i]
+		prev, ok := winner[ds.Tag]
+		if !ok {
+			winner[ds.Tag] = i
+			continue
+		}
+		older, newer := i, prev
+		if inputs[prev].Timestamp < ds.Timestamp {
+			older, newer = prev, i
+			winner[ds.Tag] = i
+		}
+		what := "untagged run"
+		if ds.Tag != "" {
+			what = fmt.Sprintf("tag %q", ds.Tag)
+		}
+		out = append(out, fmt.Sprintf("%s from %s replaced by %s (%s)",
+			what, sources[older], sources[newer], orNone(inputs[newer].Timestamp)))
+	}
+	return out
+}
+
+func appendUnique(list []string, s string) []string {
+	if slices.Contains(list, s) {
+		return list
+	}
+	return append(list, s)
+}
+
+func orNone(timestamp string) string {
+	if timestamp == "" {
+		return "no timestamp"
+	}
+	return timestamp
+}
@@ -0,0 +1,77 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MergeConflictsSuite struct {
+	suite.Suite
+}
+
+func (s *MergeConflictsSuite) conflicts(inputs []Dataset, sources ...string) []MergeConflict {
+	return MergeConflicts(inputs, sources, MergeDatasets(inputs, DimensionXAxis))
+}
+
+func (s *MergeConflictsSuite) TestNoConflictsForAgreeingInputs() {
+	inputs := []Dataset{
+		{Name: "Bench", Tag: "v1", Timestamp: "t1", Axes: []Axis{{Key: "x", Label: "size"}}},
+		{Name: "Bench", Tag: "v2", Timestamp: "t2", Axes: []Axis{{Key: "x", Label: "size"}}},
+		{Name: "Other", Tag: "v1", Timestamp: "t1"},
+	}
+	s.Empty(s.conflicts(inputs, "a.json", "b.json", "c.json"))
+}
+
+func (s *MergeConflictsSuite) TestAxesAndLabels() {
+	inputs := []Dataset{
+		{Name: "Bench", Tag: "v1", Timestamp: "t1", Axes: []Axis{{Key: "x", Label: "n"}, {Key: "y"}}},
+		{Name: "Bench", Tag: "v2", Timestamp: "t2", Axes: []Axis{{Key: "x", Label: "size"}}},
+	}
+	got := s.conflicts(inputs, "old.json", "new.json")
+	s.Equal([]MergeConflict{
+		{Dataset: "Bench", Kind: ConflictAxes, Message: "axes differ: [x,y] in old.json; [x] in new.json"},
+		{Dataset: "Bench", Kind: ConflictAxis,
+			Message: `axis x labelled "n" in old.json, "size" in new.json; kept "size" (rename with --rename-axis)`},
+	}, got)
+	s.Equal(`Bench: axes differ: [x,y] in old.json; [x] in new.json`, got[0].String())
+}
+
+func (s *MergeConflictsSuite) TestIncompatibleUnits() {
+	stat := func(unit string) []DataPoint {
+		return []DataPoint{{Name: "Sort", Stats: []Stat{{Type: StatLabel("Memory", unit, "op"), Unit: unit, Per: "op", Value: F64(1)}}}}
+	}
+	inputs := []Dataset{
+		{Name: "Bench", Tag: "v1", Timestamp: "t1", Data: stat("B")},
+		{Name: "Bench", Tag: "v2", Timestamp: "t2", Data: stat("ns")},
+		{Name: "Bench", Tag: "v3", Timestamp: "t3", Data: stat("KB")},
+	}
+	got := s.conflicts(inputs, "a.json", "b.json", "c.json")
+	s.Require().Len(got, 1)
+	s.Equal(ConflictUnit, got[0].Kind)
+	s.Equal(`stat "Memory/op" has incompatible units (B in a.json; KB in c.json; ns in b.json); kept as separate series`,
+		got[0].Message)
+
+	convertible := []Dataset{inputs[0], inputs[2]}
+	s.Empty(s.conflicts(convertible, "a.json", "c.json"))
+}
+
+func (s *MergeConflictsSuite) TestReplacedRuns() {
+	inputs := []Dataset{
+		{Name: "Bench", Tag: "v1", Timestamp: "2026-01-02T00:00:00Z"},
+		{Name: "Bench", Tag: "v1", Timestamp: "2026-01-01T00:00:00Z"},
+		{Name: "Bench", Timestamp: "2026-01-01T00:00:00Z"},
+		{Name: "Bench"},
+	}
+	got := s.conflicts(inputs, "rerun.json", "first.json", "base.json", "base-copy.json")
+	s.Equal([]MergeConflict{
+		{Dataset: "Bench", Kind: ConflictReplaced,
+			Message: `tag "v1" from first.json replaced by rerun.json (2026-01-02T00:00:00Z)`},
+		{Dataset: "Bench", Kind: ConflictReplaced,
+			Message: "untagged run from base-copy.json replaced by base.json (2026-01-01T00:00:00Z)"},
+	}, got)
+}
+
+func TestMergeConflictsSuite(t *testing.T) {
+	suite.Run(t, new(MergeConflictsSuite))
+}
@@ -278,6 +278,7 @@ func (s *MergeSuite) TestMergeDatasetsHistoryMetaPropagation() {
 		OS:   "linux",
 		Arch: "amd64",
 		Pkg:  "github.com/foo/bar",
+		Env:  map[string]string{"go": "1.22.3", "git.commit": "abc123"},
 	}
 	bench2 := makeBench("2", "Test", "2026-05-13T10:05:00Z", []DataPoint{{Name: "b"}})
 	bench2.Meta = &Meta{
@@ -305,9 +306,12 @@ func (s *MergeSuite) TestMergeDatasetsHistoryMetaPropagation() {
 	s.Equal("linux", entry.Meta.OS)
 	s.Equal("amd64", entry.Meta.Arch)
 	s.Equal("github.com/foo/bar", entry.Meta.Pkg)
+	s.Equal(map[string]string{"go": "1.22.3", "git.commit": "abc123"}, entry.Meta.Env)
 
 	// Pointer independence: history CPU must not alias the source dataset's CPU.
 	s.NotSame(datasets[0].Meta.CPU, entry.Meta.CPU, "history Meta.CPU aliases source dataset Meta.CPU; expected a deep copy")
+	entry.Meta.Env["go"] = "1.23.0"
+	s.Equal("1.22.3", datasets[0].Meta.Env["go"], "history Meta.Env aliases source dataset Meta.Env; expected a deep copy")
 }
 
 func (s *MergeSuite) TestMergeDatasetsDifferentNames() {
@@ -335,6 +339,21 @@ func (s *MergeSuite) TestMergeDatasetsNoMutation() {
 	s.Len(result, 1)
 }
 
+func (s *MergeSuite) TestMergeConvertsStatUnits() {
+	ns := Stat{Type: "Execution Time (ns/op)", Value: F64(1500), Unit: "ns", Per: "op"}
+	us := Stat{Type: "Execution Time (us/op)", Value: F64(2), Unit: "us", Per: "op"}
+	mem := Stat{Type: "Memory Usage (B/op)", Value: F64(64), Unit: "B", Per: "op"}
+	older := makeBench("v1", "T", "2026-05-13T10:00:00Z", []DataPoint{{Name: "a", Stats: []Stat{ns, mem}}})
+	newer := makeBench("v2", "T", "2026-05-13T10:05:00Z", []DataPoint{{Name: "a", Stats: []Stat{us}}})
+
+	result := MergeDatasets([]Dataset{older, newer}, DimensionXAxis)
+	s.Require().Len(result, 1)
+	s.Equal([]string{"Execution Time (us/op)", "Memory Usage (B/op)"}, result[0].StatTypes(), "one series in the newest unit")
+	s.Equal(1.5, *result[0].Data[0].Stats[0].Value)
+	s.Equal("us", result[0].Data[0].Stats[0].Unit)
+	s.Equal(1500.0, *older.Data[0].Stats[0].Value, "inputs are not modified")
+}
+
 func (s *MergeSuite) TestMergeDatasetsSameNameSameTagDedup() {
 	bench1 := makeBench("v1", "Bench", "2026-05-13T10:00:00Z",
 		[]DataPoint{{Name: "", XAxis: "speed", YAxis: "1e4"}})
@@ -537,6 +556,43 @@ func themeNamesContain(themes []Theme, name string) bool {
 	return false
 }
 
+func (s *MergeSuite) TestMergeUnionsAnnotations() {
+	slo := Annotation{Type: "hline", Stat: "ns/op", Value: F64(50)}
+	zero := Annotation{Type: "hline", Stat: "allocs/op", Value: F64(0)}
+	band := Annotation{Type: "band", From: F64(10), To: F64(20)}
+
+	s.Run("tagged", func() {
+		b1 := makeBench("v1", "D", "t1", nil)
+		b1.Annotations = []Annotation{slo, zero}
+		b2 := makeBench("v2", "D", "t2", nil)
+		b2.Annotations = []Annotation{slo, band}
+
+		result := MergeDatasets([]Dataset{b2, b1}, DimensionName)
+		s.Require().Len(result, 1)
+		s.Equal([]Annotation{slo, zero, band}, result[0].Annotations)
+	})
+	s.Run("untagged base", func() {
+		base := makeBench("", "D", "t0", nil)
+		base.Annotations = []Annotation{band}
+		b1 := makeBench("v1", "D", "t1", nil)
+		b1.Annotations = []Annotation{slo}
+
+		result := MergeDatasets([]Dataset{base, b1}, DimensionName)
+		s.Require().Len(result, 1)
+		s.Equal([]Annotation{band, slo}, result[0].Annotations)
+	})
+	s.Run("same tag replaced", func() {
+		older := makeBench("v1", "D", "t1", nil)
+		older.Annotations = []Annotation{zero}
+		newer := makeBench("v1", "D", "t2", nil)
+		newer.Annotations = []Annotation{slo}
+
+		result := MergeDatasets([]Dataset{older, newer}, DimensionName)
+		s.Require().Len(result, 1)
+		s.Equal([]Annotation{zero, slo}, result[0].Annotations)
+	})
+}
+
 func (s *MergeSuite) TestMergeDatasetsSameNameNoTagDedup() {
 	bench1 := Dataset{Name: "Bench", Data: []DataPoint{{Name: "first"}}}
 	bench2 := Dataset{Name: "Bench", Data: []DataPoint{{Name: "second"}}}
@@ -568,6 +624,97 @@ func (s *MergeSuite) TestMergeDatasetsTagOrderChronological() {
 	}, result[0].History)
 }
 
+func (s *MergeSuite) TestMergeDatasetsOrdersByCommitTime() {
+	point := []DataPoint{{Name: "", XAxis: "speed", YAxis: "1e4"}}
+	// v1 was benchmarked last but sits on the oldest commit.
+	v1 := makeBench("v1", "Bench", "2026-05-20T10:00:00Z", point)
+	v1.Commit = &Commit{SHA: "aaa", Time: "2026-05-01T10:00:00+02:00", Subject: "first"}
+	v2 := makeBench("v2", "Bench", "2026-05-13T10:00:00Z", point)
+	v2.Commit = &Commit{SHA: "bbb", Time: "2026-05-02T10:00:00Z", Subject: "second"}
+	v3 := makeBench("v3", "Bench", "2026-05-14T10:00:00Z", point)
+	v3.Commit = &Commit{SHA: "ccc", Time: "2026-05-03T10:00:00Z", Subject: "third"}
+
+	result := MergeDatasets([]Dataset{v2, v1, v3}, DimensionName)
+	s.Require().Len(result, 1)
+	s.Equal("v3", result[0].Tag)
+	s.Equal("ccc", result[0].Commit.SHA)
+	s.Equal([]string{"v1", "v2", "v3"}, []string{
+		result[0].Data[0].Name, result[0].Data[1].Name, result[0].Data[2].Name,
+	})
+	s.Equal([]HistoryEntry{
+		{Tag: "v1", Timestamp: "2026-05-20T10:00:00Z", Commit: v1.Commit},
+		{Tag: "v2", Timestamp: "2026-05-13T10:00:00Z", Commit: v2.Commit},
+	}, result[0].History)
+
+	s.NotSame(v1.Commit, result[0].History[0].Commit)
+	result[0].History[0].Commit.Subject = "mutated"
+	s.Equal("first", v1.Commit.Subject)
+}
+
+func (s *MergeSuite) TestMergeDatasetsAncestryBeatsCommitTime() {
+	point := []DataPoint{{Name: "", XAxis: "speed", YAxis: "1e4"}}
+	// The rebased child carries an older committer date than its parent.
+	parent := makeBench("parent", "Bench", "2026-05-13T10:00:00Z", point)
+	parent.Commit = &Commit{SHA: "aaa", Time: "2026-05-02T10:00:00Z"}
+	child := makeBench("child", "Bench", "2026-05-12T10:00:00Z", point)
+	child.Commit = &Commit{SHA: "bbb", Time: "2026-05-01T10:00:00Z"}
+
+	byTime := MergeDatasets([]Dataset{parent, child}, DimensionName)
+	s.Equal("parent", byTime[0].Tag)
+
+	isAncestor := func(a, b string) bool { return a == "aaa" && b == "bbb" }
+	byAncestry := MergeDatasetsWithAncestry([]Dataset{parent, child}, DimensionName, isAncestor)
+	s.Require().Len(byAncestry, 1)
+	s.Equal("child", byAncestry[0].Tag)
+	s.Equal([]HistoryEntry{
+		{Tag: "parent", Timestamp: "2026-05-13T10:00:00Z", Commit: parent.Commit},
+	}, byAncestry[0].History)
+}
+
+func (s *MergeSuite) TestMergeDatasetsWithoutCommitFallsBackToTimestamp() {
+	point := []DataPoint{{Name: "", XAxis: "speed", YAxis: "1e4"}}
+	old := makeBench("old", "Bench", "2026-05-12T10:00:00Z", point)
+	old.Commit = &Commit{SHA: "aaa", Time: "2026-05-30T10:00:00Z"}
+	latest := makeBench("latest", "Bench", "2026-05-13T10:00:00Z", point)
+
+	result := MergeDatasets([]Dataset{latest, old}, DimensionName)
+	s.Require().Len(result, 1)
+	s.Equal("latest", result[0].Tag)
+	s.Nil(result[0].Commit)
+}
+
+func (s *MergeSuite) TestMergeDatasetsOrderIgnoresInputOrder() {
+	point := []DataPoint{{Name: "", XAxis: "speed", YAxis: "1e4"}}
+	x := makeBench("x", "Bench", "2026-04-01T10:00:00Z", point)
+	x.Commit = &Commit{SHA: "xxx", Time: "2026-04-01T09:00:00Z"}
+	l := makeBench("l", "Bench", "2026-05-01T10:00:00Z", point)
+	r := makeBench("r", "Bench", "2026-10-01T10:00:00Z", point)
+	r.Commit = &Commit{SHA: "rrr", Time: "2026-01-01T09:00:00Z"}
+
+	// Pairwise, x<r by commit, r>l and l>x by timestamp: no total order unless
+	// one rule orders the whole group.
+	for _, inputs := range [][]Dataset{{x, l, r}, {x, r, l}, {l, x, r}, {l, r, x}, {r, x, l}, {r, l, x}} {
+		result := MergeDatasets(inputs, DimensionName)
+		s.Require().Len(result, 1)
+		s.Equal("r", result[0].Tag)
+		s.Equal([]string{"x", "l"}, []string{result[0].History[0].Tag, result[0].History[1].Tag})
+	}
+
+	isAncestor := func(a, b string) bool { return a == "ccc" && b == "aaa" }
+	a := makeBench("a", "Bench", "2026-05-01T10:00:00Z", point)
+	a.Commit = &Commit{SHA: "aaa", Time: "2026-01-01T09:00:00Z"}
+	b := makeBench("b", "Bench", "2026-05-02T10:00:00Z", point)
+	b.Commit = &Commit{SHA: "bbb", Time: "2026-02-01T09:00:00Z"}
+	c := makeBench("c", "Bench", "2026-05-03T10:00:00Z", point)
+	c.Commit = &Commit{SHA: "ccc", Time: "2026-03-01T09:00:00Z"}
+	for _, inputs := range [][]Dataset{{a, b, c}, {c, b, a}, {b, a, c}} {
+		result := MergeDatasetsWithAncestry(inputs, DimensionName, isAncestor)
+		s.Require().Len(result, 1)
+		s.Equal("a", result[0].Tag, "c is a's ancestor despite its later commit time")
+		s.Equal([]string{"b", "c"}, []string{result[0].History[0].Tag, result[0].History[1].Tag})
+	}
+}
+
 func (s *MergeSuite) TestMergeReplaceSameTagPreservesOlderVersions() {
 	accumulated := makeBench("v1.8.0", "Bench", "2026-07-04T05:09:39Z", []DataPoint{
 		{Name: "Add", XAxis: "v1.3.0", YAxis: "Queue"},
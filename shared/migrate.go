@@ -1,28 +1,71 @@
 package shared
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
+	"slices"
 
 	internal_charts "github.com/goptics/vizb/internal/charts"
 )
 
-// MigrateDataset applies in-memory schema migrations to ds. The raw bytes from
-// which ds was unmarshalled must be supplied so migrations that need the
-// original field set (e.g. legacy v0.12.0 shapes that the typed structs no
-// longer accept) can recover the values. Pass nil to skip migration.
+// Dataset wire versions. A file without schemaVersion is version 0: any shape
+// vizb has ever written. Each version after it is the target of one step in
+// migrations.
+const (
+	// SchemaVersionMeta folds the flat cpu/os/arch/pkg fields into meta.
+	SchemaVersionMeta = 1
+	// SchemaVersionSettings turns the v0.12.0 settings object into the
+	// per-chart settings array.
+	SchemaVersionSettings = 2
+	// SchemaVersionThemes expands the theme string into the themes catalog.
+	SchemaVersionThemes = 3
+	// SchemaVersionStatUnits splits the unit baked into stat labels out into
+	// Stat.Unit and Stat.Per.
+	SchemaVersionStatUnits = 4
+
+	// CurrentSchemaVersion is the version this build writes.
+	CurrentSchemaVersion = SchemaVersionStatUnits
+)
+
+// Migration is one step of the Dataset upgrade chain: it brings a dataset at
+// Version-1 up to Version.
+type Migration struct {
+	Version     int
+	Description string
+	// apply upgrades ds in place, reading legacy fields from raw, and reports
+	// whether it changed anything.
+	apply func(ds *Dataset, raw []byte) bool
+}
+
+// migrations is the upgrade chain, in version order. Append a step (and bump
+// CurrentSchemaVersion) whenever the wire format changes shape.
+var migrations = []Migration{
+	{Version: SchemaVersionMeta, Description: "flat cpu/os/arch/pkg fields → meta", apply: migrateFlatMeta},
+	{Version: SchemaVersionSettings, Description: "v0.12.0 settings object → per-chart settings", apply: migrateSettingsObject},
+	{Version: SchemaVersionThemes, Description: "theme string → themes", apply: migrateThemeString},
+	{Version: SchemaVersionStatUnits, Description: "stat labels → unit/per", apply: migrateStatUnits},
+}
+
+// Migrations returns the upgrade chain, in version order.
+func Migrations() []Migration {
+	return slices.Clone(migrations)
+}
+
+// MigrateDataset upgrades ds to CurrentSchemaVersion in memory and returns the
+// steps that changed it. The raw bytes from which ds was unmarshalled must be
+// supplied so steps that need the original field set (legacy shapes the typed
+// structs no longer accept) can recover the values. Pass nil to skip
+// migration.
 //
-// Two passes run in order:
-//  1. Legacy flat meta fields (cpu/os/arch/pkg) → ds.Meta. Pre-dates the Meta
-//     struct. Unchanged from the previous version.
-//  2. v0.12.0 settings struct (charts/sort/showLabels/scale) → per-chart
-//     typed Configs in ds.Settings. Only fires when ds.Settings is empty AND
-//     the legacy struct is present. Unregistered chart types in the legacy
-//     file are silently dropped (buildLegacyConfig returns an error which
-//     the caller skips).
+// Only the steps above ds.SchemaVersion run, so a file that declares its
+// version is never second-guessed. A dataset from a newer vizb
+// (SchemaVersion > CurrentSchemaVersion) is left untouched; callers decide
+// whether to warn or refuse.
 //
 // The v0.12.0 → new-shape helpers (axesFromDataPoints, buildLegacyConfig)
-// live in this file alongside the migration entry point so the whole
-// migration is in one place. They were originally planned to live in
+// live in this file alongside the migration chain so the whole migration is
+// in one place. They were originally planned to live in
 // config/charts/migrate.go (per the design spec, section 5), but the import
 // graph is fixed in the wrong direction: per-chart packages import shared
 // (for Sort and DataPoint), and the migration helpers need to dispatch back
@@ -31,12 +74,27 @@ import (
 // config/charts) means config/charts/migrate.go imports shared while
 // shared imports config/charts for Dataset.Settings. The only cycle-free
 // home is shared itself, so the helpers stay here.
-func MigrateDataset(ds *Dataset, rawJSON []byte) {
-	if len(rawJSON) == 0 {
-		return
+func MigrateDataset(ds *Dataset, rawJSON []byte) []Migration {
+	if len(rawJSON) == 0 || ds.SchemaVersion > CurrentSchemaVersion {
+		return nil
 	}
+	var applied []Migration
+	for _, m := range migrations {
+		if m.Version <= ds.SchemaVersion {
+			continue
+		}
+		if m.apply(ds, rawJSON) {
+			applied = append(applied, m)
+		}
+		ds.SchemaVersion = m.Version
+	}
+	return applied
+}
 
-	// Pass 1: legacy flat meta fields (cpu/os/arch/pkg → Meta).
+// migrateFlatMeta moves the flat cpu/os/arch/pkg fields (and cpu/os on
+// history entries), which pre-date the Meta struct, into Meta. An existing
+// Meta wins.
+func migrateFlatMeta(ds *Dataset, rawJSON []byte) bool {
 	var legacyMeta struct {
 		CPU     *CPUInfo `json:"cpu"`
 		OS      string   `json:"os"`
@@ -48,13 +106,15 @@ func MigrateDataset(ds *Dataset, rawJSON []byte) {
 		} `json:"history"`
 	}
 	if err := json.Unmarshal(rawJSON, &legacyMeta); err != nil {
-		return
+		return false
 	}
 
+	changed := false
 	if ds.Meta == nil {
 		m := &Meta{CPU: legacyMeta.CPU, OS: legacyMeta.OS, Arch: legacyMeta.Arch, Pkg: legacyMeta.Pkg}
 		if m.CPU != nil || m.OS != "" || m.Arch != "" || m.Pkg != "" {
 			ds.Meta = m
+			changed = true
 		}
 	}
 
@@ -67,13 +127,21 @@ func MigrateDataset(ds *Dataset, rawJSON []byte) {
 			m := &Meta{CPU: leg.CPU, OS: leg.OS}
 			if m.CPU != nil || m.OS != "" {
 				ds.History[i].Meta = m
+				changed = true
 			}
 		}
 	}
+	return changed
+}
 
-	// Pass 2: v0.12.0 settings struct → per-chart typed Configs.
+// migrateSettingsObject converts the v0.12.0 settings struct
+// (charts/sort/showLabels/scale) to per-chart typed Configs. It only fires
+// when ds.Settings is empty AND the legacy struct is present. Unregistered
+// chart types in the legacy file are silently dropped (buildLegacyConfig
+// returns an error which the caller skips).
+func migrateSettingsObject(ds *Dataset, rawJSON []byte) bool {
 	if len(ds.Settings) > 0 {
-		return // already in new shape
+		return false // already in new shape
 	}
 	var legacySettings struct {
 		Settings struct {
@@ -84,10 +152,10 @@ func MigrateDataset(ds *Dataset, rawJSON []byte) {
 		} `json:"settings"`
 	}
 	if err := json.Unmarshal(rawJSON, &legacySettings); err != nil {
-		return
+		return false
 	}
 	if len(legacySettings.Settings.Charts) == 0 {
-		return
+		return false
 	}
 
 	// Derive Axes from data points (v0.12.0 had no axes field). Empty
@@ -105,6 +173,66 @@ func MigrateDataset(ds *Dataset, rawJSON []byte) {
 		}
 		ds.Settings = append(ds.Settings, cfg)
 	}
+	return true
+}
+
+// migrateThemeString expands the legacy theme string into Themes. Dataset's
+// UnmarshalJSON already does this for every caller that decodes a file, so
+// here it mostly reports the change (the raw bytes still carry the string)
+// and covers datasets built in memory. An unparsable spec is kept, as there.
+func migrateThemeString(ds *Dataset, rawJSON []byte) bool {
+	var legacy struct {
+		Theme string `json:"theme"`
+	}
+	_ = json.Unmarshal(rawJSON, &legacy)
+	hadTheme := legacy.Theme != "" || ds.Theme != ""
+	ds.migrateLegacyTheme()
+	return hadTheme && ds.Theme == ""
+}
+
+// migrateStatUnits recovers Stat.Unit and Stat.Per from labels such as
+// "Execution Time (ns/op)", so files written before the structured fields
+// convert on merge like new ones. Unrecognised labels are left without a unit.
+func migrateStatUnits(ds *Dataset, _ []byte) bool {
+	return InferStatUnits(ds.Data)
+}
+
+// DecodeDatasets decodes Dataset JSON, a single object or an array, and
+// migrates every dataset to the current schema.
+func DecodeDatasets(content []byte) ([]Dataset, error) {
+	trimmed := bytes.TrimLeft(content, " \t\r\n")
+	if len(trimmed) == 0 {
+		return nil, fmt.Errorf("file is empty")
+	}
+
+	switch trimmed[0] {
+	case '[':
+		// Two-pass: decode each element from its own raw bytes so MigrateDataset
+		// can recover the legacy top-level axisLabels field (lost after Unmarshal).
+		var rawElems []json.RawMessage
+		if err := json.Unmarshal(content, &rawElems); err != nil {
+			return nil, fmt.Errorf("invalid data set array: %w", err)
+		}
+		dataSets := make([]Dataset, 0, len(rawElems))
+		for _, rawElem := range rawElems {
+			var ds Dataset
+			if err := json.Unmarshal(rawElem, &ds); err != nil {
+				return nil, fmt.Errorf("invalid data set array: %w", err)
+			}
+			MigrateDataset(&ds, rawElem)
+			dataSets = append(dataSets, ds)
+		}
+		return dataSets, nil
+	case '{':
+		var ds Dataset
+		if err := json.Unmarshal(content, &ds); err != nil {
+			return nil, fmt.Errorf("invalid data set object: %w", err)
+		}
+		MigrateDataset(&ds, content)
+		return []Dataset{ds}, nil
+	default:
+		return nil, fmt.Errorf("not valid JSON")
+	}
 }
 
 // axesFromDataPoints derives a minimal Axis list from the data points in a
@@ -66,6 +66,7 @@ func (s *MigrateExternalSuite) TestMigrateDatasetV0120Settings() {
 	shared.MigrateDataset(&ds, raw)
 
 	s.Require().Len(ds.Settings, 2)
+	s.Equal(shared.CurrentSchemaVersion, ds.SchemaVersion)
 
 	// settings[0] should be a *bar.Config.
 	barCfg, ok := ds.Settings[0].(*bar.Config)
@@ -132,7 +133,7 @@ func (s *MigrateExternalSuite) TestMigrateDatasetPassthrough() {
 		preType = ds.Settings[0].ChartType()
 	}
 
-	shared.MigrateDataset(&ds, raw)
+	s.Empty(shared.MigrateDataset(&ds, raw), "no step has anything to do")
 
 	s.Len(ds.Settings, preCount)
 	if preCount > 0 {
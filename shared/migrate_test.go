@@ -94,6 +94,133 @@ func (s *MigrateSuite) TestMigrateDataset() {
 	})
 }
 
+func (s *MigrateSuite) TestMigrationChain() {
+	steps := Migrations()
+	s.Require().NotEmpty(steps)
+	for i, m := range steps {
+		s.Equal(i+1, m.Version, "steps must be contiguous from version 1")
+		s.NotEmpty(m.Description)
+	}
+	s.Equal(CurrentSchemaVersion, steps[len(steps)-1].Version)
+}
+
+func (s *MigrateSuite) TestMigrateFlatMetaStep() {
+	ds := &Dataset{History: []HistoryEntry{{Tag: "v1"}, {Tag: "v2"}}}
+	changed := migrateFlatMeta(ds, []byte(`{"arch":"arm64","history":[{"os":"linux"},{}]}`))
+	s.True(changed)
+	s.Equal(&Meta{Arch: "arm64"}, ds.Meta)
+	s.Equal(&Meta{OS: "linux"}, ds.History[0].Meta)
+	s.Nil(ds.History[1].Meta)
+
+	s.False(migrateFlatMeta(ds, []byte(`{"os":"darwin"}`)), "existing Meta wins")
+	s.Equal("", ds.Meta.OS)
+	s.False(migrateFlatMeta(&Dataset{}, []byte(`{"name":"x"}`)))
+	s.False(migrateFlatMeta(&Dataset{}, []byte(`[]`)), "undecodable raw is a no-op")
+}
+
+func (s *MigrateSuite) TestMigrateThemeStringStep() {
+	s.Run("reports a theme UnmarshalJSON already expanded", func() {
+		raw := []byte(`{"name":"t","theme":"roma",` + baseSettings + `,"data":[]}`)
+		var ds Dataset
+		s.Require().NoError(json.Unmarshal(raw, &ds))
+		s.Require().NotEmpty(ds.Themes)
+		s.True(migrateThemeString(&ds, raw))
+	})
+
+	s.Run("expands a theme set in memory", func() {
+		ds := &Dataset{Theme: "roma"}
+		s.True(migrateThemeString(ds, []byte(`{}`)))
+		s.Require().Len(ds.Themes, 1)
+		s.Equal("roma", ds.Themes[0].Name)
+		s.Empty(ds.Theme)
+	})
+
+	s.Run("default theme is dropped", func() {
+		ds := &Dataset{Theme: "default"}
+		s.True(migrateThemeString(ds, []byte(`{}`)))
+		s.Empty(ds.Themes)
+	})
+
+	s.Run("unparsable spec is kept", func() {
+		ds := &Dataset{Theme: "no-such-theme"}
+		s.False(migrateThemeString(ds, []byte(`{}`)))
+		s.Equal("no-such-theme", ds.Theme)
+	})
+
+	s.Run("no theme", func() {
+		s.False(migrateThemeString(&Dataset{}, []byte(`{}`)))
+	})
+}
+
+func (s *MigrateSuite) TestMigrateStatUnitsStep() {
+	ds := &Dataset{Data: []DataPoint{{Stats: []Stat{
+		{Type: "Execution Time (ns/op)"},
+		{Type: "Allocations/op"},
+		{Type: "Requests (total)"},
+		{Type: "Latency (ms)", Unit: "us"},
+	}}}}
+	s.True(migrateStatUnits(ds, nil))
+	s.Equal([]Stat{
+		{Type: "Execution Time (ns/op)", Unit: "ns", Per: "op"},
+		{Type: "Allocations/op", Per: "op"},
+		{Type: "Requests (total)"},
+		{Type: "Latency (ms)", Unit: "us"},
+	}, ds.Data[0].Stats, "unknown units and set fields are left alone")
+	s.False(migrateStatUnits(ds, nil))
+}
+
+func (s *MigrateSuite) TestMigrateDatasetVersioning() {
+	s.Run("stamps the current version and lists the steps that changed it", func() {
+		raw := []byte(`{"name":"t","os":"linux","theme":"roma","settings":[],"data":[]}`)
+		var ds Dataset
+		s.Require().NoError(json.Unmarshal(raw, &ds))
+		applied := MigrateDataset(&ds, raw)
+		s.Equal(CurrentSchemaVersion, ds.SchemaVersion)
+		var versions []int
+		for _, m := range applied {
+			versions = append(versions, m.Version)
+		}
+		s.Equal([]int{SchemaVersionMeta, SchemaVersionThemes}, versions, "settings are already an array")
+	})
+
+	s.Run("declared version skips earlier steps", func() {
+		raw := []byte(`{"schemaVersion":1,"name":"t","os":"linux","settings":[],"data":[]}`)
+		var ds Dataset
+		s.Require().NoError(json.Unmarshal(raw, &ds))
+		s.Empty(MigrateDataset(&ds, raw))
+		s.Nil(ds.Meta, "flat meta is not read from a version-1 file")
+		s.Equal(CurrentSchemaVersion, ds.SchemaVersion)
+	})
+
+	s.Run("current file is a no-op", func() {
+		raw := []byte(`{"schemaVersion":4,"name":"t","settings":[],"data":[]}`)
+		var ds Dataset
+		s.Require().NoError(json.Unmarshal(raw, &ds))
+		s.Empty(MigrateDataset(&ds, raw))
+	})
+
+	s.Run("newer version is left untouched", func() {
+		raw := []byte(`{"schemaVersion":99,"name":"t","os":"linux","settings":[],"data":[]}`)
+		var ds Dataset
+		s.Require().NoError(json.Unmarshal(raw, &ds))
+		s.Empty(MigrateDataset(&ds, raw))
+		s.Equal(99, ds.SchemaVersion)
+		s.Nil(ds.Meta)
+	})
+
+	s.Run("nil raw does not stamp", func() {
+		ds := &Dataset{}
+		MigrateDataset(ds, nil)
+		s.Zero(ds.SchemaVersion)
+	})
+
+	s.Run("settings object is an error past its version", func() {
+		var ds Dataset
+		err := json.Unmarshal([]byte(`{"schemaVersion":2,"name":"t","settings":{"charts":["bar"]},"data":[]}`), &ds)
+		s.ErrorContains(err, "expected JSON array at schemaVersion 2")
+	})
+}
+
 func TestMigrateSuite(t *testing.T) {
 	suite.Run(t, new(MigrateSuite))
 }
@@ -0,0 +1,243 @@
+package shared
+
+import (
+	"cmp"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+)
+
+// Reconcile maps names that changed between releases onto their current form
+// before a merge, so a renamed benchmark, a regrouped axis or a relabelled
+// stat keeps one history instead of splitting into disjoint series. Each map
+// goes old → new; renames chain (A→B, B→C sends A to C).
+type Reconcile struct {
+	// Aliases rename dataset names and data point values on every dimension.
+	Aliases map[string]string `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+	// AxisLabels rename axis labels.
+	AxisLabels map[string]string `json:"axisLabels,omitempty" yaml:"axisLabels,omitempty"`
+	// Stats rename stat types, matched on the full label or on the label
+	// without its unit ("Execution Time" renames "Execution Time (ns/op)").
+	Stats map[string]string `json:"stats,omitempty" yaml:"stats,omitempty"`
+}
+
+// IsZero reports whether r renames nothing.
+func (r Reconcile) IsZero() bool {
+	return len(r.Aliases) == 0 && len(r.AxisLabels) == 0 && len(r.Stats) == 0
+}
+
+// With returns r with other's entries added, other winning on the same key.
+func (r Reconcile) With(other Reconcile) Reconcile {
+	merge := func(a, b map[string]string) map[string]string {
+		if len(a) == 0 && len(b) == 0 {
+			return nil
+		}
+		out := maps.Clone(a)
+		if out == nil {
+			out = map[string]string{}
+		}
+		maps.Copy(out, b)
+		return out
+	}
+	return Reconcile{
+		Aliases:    merge(r.Aliases, other.Aliases),
+		AxisLabels: merge(r.AxisLabels, other.AxisLabels),
+		Stats:      merge(r.Stats, other.Stats),
+	}
+}
+
+// Validate rejects empty names and rename cycles.
+func (r Reconcile) Validate() error {
+	for _, m := range []struct {
+		name  string
+		table map[string]string
+	}{{"alias", r.Aliases}, {"axis label", r.AxisLabels}, {"stat", r.Stats}} {
+		for _, from := range slices.Sorted(maps.Keys(m.table)) {
+			to := m.table[from]
+			if from == "" || to == "" {
+				return fmt.Errorf("%s %q=%q: names must not be empty", m.name, from, to)
+			}
+			if _, ok := resolveRename(m.table, from); !ok {
+				return fmt.Errorf("%s %q: renames form a cycle", m.name, from)
+			}
+		}
+	}
+	return nil
+}
+
+// ParseRenameRule reads one "old=new" flag value. Names that contain "=",
+// such as Go sub-benchmarks ("Sort/n=1024"), use "=>" instead:
+// "Sort/n=1024=>Sort/size=1024".
+func ParseRenameRule(raw string) (from, to string, err error) {
+	sep := "=>"
+	if !strings.Contains(raw, sep) {
+		if strings.Count(raw, "=") != 1 {
+			return "", "", fmt.Errorf("%q must be old=new (use old=>new when a name contains '=')", raw)
+		}
+		sep = "="
+	}
+	from, to, _ = strings.Cut(raw, sep)
+	from, to = strings.TrimSpace(from), strings.TrimSpace(to)
+	if from == "" || to == "" {
+		return "", "", fmt.Errorf("%q must be old=new with both names set", raw)
+	}
+	return from, to, nil
+}
+
+// resolveRename follows name through table to its final form. ok is false on
+// a cycle.
+func resolveRename(table map[string]string, name string) (string, bool) {
+	for range len(table) + 1 {
+		next, ok := table[name]
+		if !ok {
+			return name, true
+		}
+		name = next
+	}
+	return name, false
+}
+
+// renamer applies one rename table and records which of its rules fired.
+type renamer struct {
+	table map[string]string
+	// origin maps a derived key (an alias without its Benchmark prefix) to
+	// the rule it came from.
+	origin map[string]string
+	used   map[string]bool
+}
+
+func newRenamer(table map[string]string) *renamer {
+	return &renamer{table: table, origin: map[string]string{}, used: map[string]bool{}}
+}
+
+// aliasRenamer also matches each "Benchmark..." alias on the name without
+// its prefix, which is how the Go parser stores benchmark names:
+// BenchmarkOld=BenchmarkNew renames Old to New.
+func aliasRenamer(aliases map[string]string) *renamer {
+	n := newRenamer(maps.Clone(aliases))
+	for from, to := range aliases {
+		short, ok := strings.CutPrefix(from, "Benchmark")
+		if !ok || short == "" {
+			continue
+		}
+		if _, taken := aliases[short]; taken {
+			continue
+		}
+		if shortTo := strings.TrimPrefix(to, "Benchmark"); shortTo != "" && shortTo != short {
+			n.table[short] = shortTo
+			n.origin[short] = from
+		}
+	}
+	return n
+}
+
+// rename returns name's final form. Names caught in a cycle stay as they are.
+func (n *renamer) rename(name string) string {
+	if name == "" {
+		return name
+	}
+	to, ok := resolveRename(n.table, name)
+	if !ok {
+		return name
+	}
+	for from := name; from != to; from = n.table[from] {
+		n.used[cmp.Or(n.origin[from], from)] = true
+	}
+	return to
+}
+
+// unused returns the rules of table that never fired, sorted.
+func (n *renamer) unused(table map[string]string) []string {
+	var out []string
+	for _, from := range slices.Sorted(maps.Keys(table)) {
+		if !n.used[from] {
+			out = append(out, from)
+		}
+	}
+	return out
+}
+
+// Apply returns datasets with every rename applied. Datasets are deep-copied;
+// the input is not modified. Call Validate first: names caught in a cycle are
+// left as they are.
+func (r Reconcile) Apply(datasets []Dataset) []Dataset {
+	if r.IsZero() {
+		return datasets
+	}
+	out, _, _, _ := r.apply(datasets)
+	return out
+}
+
+// Unmatched describes the rules that rename nothing in datasets, e.g.
+// `alias "BenchmarkOld"`, so callers can report likely typos. A rule reached
+// only through a chain counts as matched.
+func (r Reconcile) Unmatched(datasets []Dataset) []string {
+	if r.IsZero() {
+		return nil
+	}
+	_, aliases, axisLabels, stats := r.apply(datasets)
+	var out []string
+	for _, m := range []struct {
+		name   string
+		unused []string
+	}{
+		{"alias", aliases.unused(r.Aliases)},
+		{"axis label", axisLabels.unused(r.AxisLabels)},
+		{"stat", stats.unused(r.Stats)},
+	} {
+		for _, from := range m.unused {
+			out = append(out, fmt.Sprintf("%s %q", m.name, from))
+		}
+	}
+	return out
+}
+
+func (r Reconcile) apply(datasets []Dataset) (out []Dataset, aliases, axisLabels, stats *renamer) {
+	aliases, axisLabels, stats = aliasRenamer(r.Aliases), newRenamer(r.AxisLabels), newRenamer(r.Stats)
+	out = make([]Dataset, len(datasets))
+	for i, src := range datasets {
+		ds := deepCloneDataset(src)
+		ds.Name = aliases.rename(ds.Name)
+		for j := range ds.Axes {
+			ds.Axes[j].Label = axisLabels.rename(ds.Axes[j].Label)
+		}
+		for j := range ds.Data {
+			p := &ds.Data[j]
+			p.Name = aliases.rename(p.Name)
+			p.XAxis = aliases.rename(p.XAxis)
+			p.YAxis = aliases.rename(p.YAxis)
+			p.ZAxis = aliases.rename(p.ZAxis)
+			for k := range p.Stats {
+				p.Stats[k] = renameStat(stats, p.Stats[k])
+			}
+		}
+		for j := range ds.Annotations {
+			a := &ds.Annotations[j]
+			a.Stat = stats.rename(a.Stat)
+			a.At = aliases.rename(a.At)
+		}
+		out[i] = ds
+	}
+	return out, aliases, axisLabels, stats
+}
+
+// renameStat matches a stat rule on the full label, then on the label without
+// its unit.
+func renameStat(stats *renamer, st Stat) Stat {
+	if len(stats.table) == 0 {
+		return st
+	}
+	if to := stats.rename(st.Type); to != st.Type {
+		st.Type = to
+		return st
+	}
+	base := st.BaseType()
+	if base == st.Type {
+		return st
+	}
+	if to := stats.rename(base); to != base {
+		st.Type = StatLabel(to, st.Unit, st.Per)
+	}
+	return st
+}
@@ -0,0 +1,128 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ReconcileSuite struct {
+	suite.Suite
+}
+
+func (s *ReconcileSuite) TestZeroReconcileReturnsInput() {
+	in := []Dataset{{Name: "a"}}
+	s.True(Reconcile{}.IsZero())
+	s.Equal(in, Reconcile{}.Apply(in))
+}
+
+func (s *ReconcileSuite) TestApplyRenamesNamesValuesAxesAndStats() {
+	in := []Dataset{{
+		Name: "BenchmarkOld",
+		Axes: []Axis{{Key: "name", Label: "bench"}, {Key: "x", Label: "n"}},
+		Data: []DataPoint{{
+			Name:  "BenchmarkOld",
+			XAxis: "1024",
+			YAxis: "BenchmarkOld",
+			Stats: []Stat{
+				{Type: "Execution Time (ns/op)", Unit: "ns", Per: "op", Value: F64(3)},
+				{Type: "Allocs", Value: F64(1)},
+			},
+		}},
+		Annotations: []Annotation{{Type: "vline", At: "BenchmarkOld"}, {Type: "hline", Stat: "Allocs"}},
+	}}
+	r := Reconcile{
+		Aliases:    map[string]string{"BenchmarkOld": "BenchmarkNew"},
+		AxisLabels: map[string]string{"n": "size"},
+		Stats:      map[string]string{"Execution Time": "Time", "Allocs": "Allocations"},
+	}
+
+	out := r.Apply(in)
+	s.Require().Len(out, 1)
+	ds := out[0]
+	s.Equal("BenchmarkNew", ds.Name)
+	s.Equal([]Axis{{Key: "name", Label: "bench"}, {Key: "x", Label: "size"}}, ds.Axes)
+	s.Equal("BenchmarkNew", ds.Data[0].Name)
+	s.Equal("1024", ds.Data[0].XAxis)
+	s.Equal("BenchmarkNew", ds.Data[0].YAxis)
+	s.Equal("Time (ns/op)", ds.Data[0].Stats[0].Type)
+	s.Equal("ns", ds.Data[0].Stats[0].Unit)
+	s.Equal("Allocations", ds.Data[0].Stats[1].Type)
+	s.Equal("BenchmarkNew", ds.Annotations[0].At)
+	s.Equal("Allocations", ds.Annotations[1].Stat)
+
+	s.Equal("BenchmarkOld", in[0].Name, "input is not modified")
+	s.Equal("n", in[0].Axes[1].Label)
+	s.Equal("Execution Time (ns/op)", in[0].Data[0].Stats[0].Type)
+}
+
+func (s *ReconcileSuite) TestRenamesChain() {
+	r := Reconcile{Aliases: map[string]string{"A": "B", "B": "C"}}
+	s.Require().NoError(r.Validate())
+	out := r.Apply([]Dataset{{Name: "A"}, {Name: "B"}, {Name: "D"}})
+	s.Equal("C", out[0].Name)
+	s.Equal("C", out[1].Name)
+	s.Equal("D", out[2].Name)
+}
+
+func (s *ReconcileSuite) TestAliasesMatchGoBenchmarkNames() {
+	// The Go parser stores BenchmarkOld/1024 as Old at x=1024.
+	r := Reconcile{Aliases: map[string]string{"BenchmarkOld": "BenchmarkNew", "BenchmarkFoo": "Bar"}}
+	out := r.Apply([]Dataset{{
+		Name: "Benchmarks",
+		Data: []DataPoint{{Name: "Old", XAxis: "1024"}, {Name: "Foo"}, {Name: "BenchmarkOld"}},
+	}})
+	s.Equal([]string{"New", "Bar", "BenchmarkNew"}, []string{out[0].Data[0].Name, out[0].Data[1].Name, out[0].Data[2].Name})
+	s.Empty(r.Unmatched([]Dataset{{Name: "Old"}, {Name: "Foo"}}))
+}
+
+func (s *ReconcileSuite) TestUnmatched() {
+	r := Reconcile{
+		Aliases:    map[string]string{"A": "B", "B": "C", "Typo": "X"},
+		AxisLabels: map[string]string{"n": "size", "m": "count"},
+		Stats:      map[string]string{"Execution Time": "Time", "Allocs": "Allocations"},
+	}
+	in := []Dataset{{
+		Name: "A",
+		Axes: []Axis{{Key: "x", Label: "n"}},
+		Data: []DataPoint{{Stats: []Stat{{Type: "Execution Time (ns/op)", Unit: "ns", Per: "op"}}}},
+	}}
+	s.Equal([]string{`alias "Typo"`, `axis label "m"`, `stat "Allocs"`}, r.Unmatched(in), "B is reached through A")
+	s.Nil(Reconcile{}.Unmatched(in))
+}
+
+func (s *ReconcileSuite) TestValidate() {
+	s.ErrorContains(Reconcile{Aliases: map[string]string{"A": "B", "B": "A"}}.Validate(), "cycle")
+	s.ErrorContains(Reconcile{Stats: map[string]string{"A": ""}}.Validate(), "must not be empty")
+	s.NoError(Reconcile{AxisLabels: map[string]string{"n": "size"}}.Validate())
+}
+
+func (s *ReconcileSuite) TestWithOverridesByKey() {
+	base := Reconcile{Aliases: map[string]string{"A": "B", "C": "D"}}
+	got := base.With(Reconcile{Aliases: map[string]string{"A": "Z"}, Stats: map[string]string{"s": "t"}})
+	s.Equal(map[string]string{"A": "Z", "C": "D"}, got.Aliases)
+	s.Equal(map[string]string{"s": "t"}, got.Stats)
+	s.Nil(got.AxisLabels)
+	s.Equal("B", base.Aliases["A"], "receiver is not modified")
+}
+
+func (s *ReconcileSuite) TestParseRenameRule() {
+	from, to, err := ParseRenameRule("BenchmarkOld=BenchmarkNew")
+	s.Require().NoError(err)
+	s.Equal("BenchmarkOld", from)
+	s.Equal("BenchmarkNew", to)
+
+	from, to, err = ParseRenameRule(" Sort/n=1024 => Sort/size=1024 ")
+	s.Require().NoError(err)
+	s.Equal("Sort/n=1024", from)
+	s.Equal("Sort/size=1024", to)
+
+	for _, bad := range []string{"old", "a=b=c", "=new", "old=>"} {
+		_, _, err := ParseRenameRule(bad)
+		s.Error(err, bad)
+	}
+}
+
+func TestReconcileSuite(t *testing.T) {
+	suite.Run(t, new(ReconcileSuite))
+}
@@ -0,0 +1,240 @@
+package shared
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ThinPeriod is the bucket Retention.ThinAfter keeps one run per.
+type ThinPeriod string
+
+const (
+	ThinDay  ThinPeriod = "day"
+	ThinWeek ThinPeriod = "week"
+)
+
+// ValidThinPeriods is the ordered list of accepted ThinPeriod values.
+var ValidThinPeriods = []string{string(ThinDay), string(ThinWeek)}
+
+// Retention decides which tags a merged dataset keeps. Rules combine as a
+// union: a tag survives when any configured rule keeps it, and the current
+// tag always survives. The zero Retention keeps everything.
+type Retention struct {
+	// KeepLast keeps the newest N tags.
+	KeepLast int
+	// KeepWithin keeps tags whose run is younger than this.
+	KeepWithin time.Duration
+	// ThinAfter keeps every tag younger than this and, among older ones, only
+	// the newest of each ThinEvery period (UTC days or ISO weeks).
+	ThinAfter time.Duration
+	ThinEvery ThinPeriod
+	// Pinned tags are always kept. Entries are path.Match patterns, so
+	// "v*.0.0" pins every major release. Pins only protect tags from the
+	// other rules; on their own they prune nothing.
+	Pinned []string
+	// Now is the reference time for ages; zero means time.Now().
+	Now time.Time
+}
+
+// IsZero reports whether r has no pruning rule and so keeps every tag,
+// whatever it pins.
+func (r Retention) IsZero() bool {
+	return r.KeepLast == 0 && r.KeepWithin == 0 && r.ThinAfter == 0
+}
+
+// Validate rejects negative counts and ages, unknown periods and malformed
+// pin patterns.
+func (r Retention) Validate() error {
+	switch {
+	case r.KeepLast < 0:
+		return fmt.Errorf("keep-last must not be negative, got %d", r.KeepLast)
+	case r.KeepWithin < 0:
+		return fmt.Errorf("keep-within must not be negative, got %s", r.KeepWithin)
+	case r.ThinAfter < 0:
+		return fmt.Errorf("thin-after must not be negative, got %s", r.ThinAfter)
+	case r.ThinEvery != "" && !slices.Contains(ValidThinPeriods, string(r.ThinEvery)):
+		return fmt.Errorf("thin-every %q is invalid (must be %s)", r.ThinEvery, strings.Join(ValidThinPeriods, ", "))
+	}
+	for _, p := range r.Pinned {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("pin %q: %w", p, err)
+		}
+	}
+	return nil
+}
+
+// ParseAge reads a retention age: a Go duration ("36h") or a whole number of
+// days or weeks ("30d", "2w").
+func ParseAge(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			count, err := strconv.Atoi(n)
+			if err != nil {
+				return 0, fmt.Errorf("age %q is invalid (want e.g. 30d, 2w or 36h)", s)
+			}
+			return time.Duration(count) * unit, nil
+		}
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("age %q is invalid (want e.g. 30d, 2w or 36h)", s)
+	}
+	return d, nil
+}
+
+// Runs returns one entry per tag of the dataset, oldest first: its History
+// followed by the current run. An untagged dataset has none.
+func (d *Dataset) Runs() []HistoryEntry {
+	if d.Tag == "" {
+		return nil
+	}
+	runs := make([]HistoryEntry, 0, len(d.History)+1)
+	for _, h := range d.History {
+		if h.Tag != "" && h.Tag != d.Tag {
+			runs = append(runs, h)
+		}
+	}
+	return append(runs, HistoryEntry{Tag: d.Tag, Timestamp: d.Timestamp, Commit: d.Commit, Meta: d.Meta})
+}
+
+// Expired returns the tags of runs (oldest first, as Dataset.Runs returns
+// them) that r does not keep, in the same order. The last run is current and
+// always kept. Runs without a parseable timestamp never age out. Without a
+// KeepLast, KeepWithin or ThinAfter rule nothing expires.
+func (r Retention) Expired(runs []HistoryEntry) []string {
+	if r.IsZero() || len(runs) < 2 {
+		return nil
+	}
+	now := r.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	keep := make([]bool, len(runs))
+	keep[len(runs)-1] = true
+	if r.KeepLast > 0 {
+		for i := max(0, len(runs)-r.KeepLast); i < len(runs); i++ {
+			keep[i] = true
+		}
+	}
+	winners := map[string]bool{}
+	for i := len(runs) - 1; i >= 0; i-- {
+		run := runs[i]
+		if slices.ContainsFunc(r.Pinned, func(p string) bool { ok, _ := path.Match(p, run.Tag); return ok }) {
+			keep[i] = true
+		}
+		at, err := time.Parse(time.RFC3339, run.Timestamp)
+		if err != nil {
+			if r.KeepWithin > 0 || r.ThinAfter > 0 {
+				keep[i] = true
+			}
+			continue
+		}
+		age := now.Sub(at)
+		if r.KeepWithin > 0 && age < r.KeepWithin {
+			keep[i] = true
+		}
+		if r.ThinAfter > 0 {
+			// Walking newest first, the first run seen in a period is its newest.
+			bucket := thinBucket(at, r.ThinEvery)
+			if age < r.ThinAfter || !winners[bucket] {
+				keep[i] = true
+			}
+			winners[bucket] = true
+		}
+	}
+	var expired []string
+	for i, run := range runs {
+		if !keep[i] {
+			expired = append(expired, run.Tag)
+		}
+	}
+	return expired
+}
+
+func thinBucket(t time.Time, period ThinPeriod) string {
+	t = t.UTC()
+	if period == ThinWeek {
+		year, week := t.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", year, week)
+	}
+	return t.Format(time.DateOnly)
+}
+
+// ApplyRetention drops the runs r does not keep from a merged dataset (see
+// RemoveTags) and returns the result with the removed tags, oldest first.
+func ApplyRetention(ds Dataset, dim Dimension, r Retention) (Dataset, []string) {
+	expired := r.Expired(ds.Runs())
+	if len(expired) == 0 {
+		return ds, nil
+	}
+	pruned, err := RemoveTags(ds, dim, expired)
+	if err != nil {
+		// Expired never lists the current tag, so removal can't empty the dataset.
+		return ds, nil
+	}
+	return pruned, expired
+}
+
+// RemoveTags deletes tags from a merged dataset: their History entries and
+// the data points whose dim value is the tag, so the tag axis and History
+// stay consistent. Removing the current tag promotes the newest remaining
+// History entry. It fails for tags the dataset doesn't have and when no tag
+// would remain.
+func RemoveTags(ds Dataset, dim Dimension, tags []string) (Dataset, error) {
+	runs := ds.Runs()
+	var unknown []string
+	for _, tag := range tags {
+		if !slices.ContainsFunc(runs, func(h HistoryEntry) bool { return h.Tag == tag }) {
+			unknown = append(unknown, tag)
+		}
+	}
+	if len(unknown) > 0 {
+		return ds, fmt.Errorf("dataset %q has no tag %s", ds.Name, strings.Join(unknown, ", "))
+	}
+	remaining := slices.DeleteFunc(runs, func(h HistoryEntry) bool { return slices.Contains(tags, h.Tag) })
+	if len(remaining) == 0 {
+		return ds, fmt.Errorf("dataset %q would have no tags left", ds.Name)
+	}
+
+	out := deepCloneDataset(ds)
+	out.Data = slices.DeleteFunc(out.Data, func(p DataPoint) bool {
+		return slices.Contains(tags, dimFieldValue(p, dim))
+	})
+	current := remaining[len(remaining)-1]
+	if current.Tag != ds.Tag {
+		out.Tag, out.Timestamp = current.Tag, current.Timestamp
+		out.Commit = current.Commit.Clone()
+		out.Meta = current.Meta.Clone()
+	}
+	out.History = nil
+	for _, h := range remaining[:len(remaining)-1] {
+		h.Commit = h.Commit.Clone()
+		h.Meta = h.Meta.Clone()
+		out.History = append(out.History, h)
+	}
+	return out, nil
+}
+
+// TagDimension returns the dimension vizb merge injected this dataset's tags
+// onto, inferred from its data (see TagAxes).
+func (d *Dataset) TagDimension() (Dimension, bool) {
+	axes := d.TagAxes()
+	if len(axes) == 0 {
+		return "", false
+	}
+	switch axes[0] {
+	case "x":
+		return DimensionXAxis, true
+	case "y":
+		return DimensionYAxis, true
+	case "z":
+		return DimensionZAxis, true
+	default:
+		return DimensionName, true
+	}
+}
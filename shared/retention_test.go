@@ -0,0 +1,195 @@
+package shared
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RetentionSuite struct {
+	suite.Suite
+	now time.Time
+}
+
+func (s *RetentionSuite) SetupTest() {
+	s.now = time.Date(2026, 6, 30, 12, 0, 0, 0, time.UTC)
+}
+
+// daysAgo builds an entry for a run the given number of days before s.now.
+func (s *RetentionSuite) daysAgo(tag string, days int) HistoryEntry {
+	return HistoryEntry{Tag: tag, Timestamp: s.now.AddDate(0, 0, -days).Format(time.RFC3339)}
+}
+
+// merged builds a dataset with tags on the name dimension, oldest first.
+func (s *RetentionSuite) merged(runs ...HistoryEntry) Dataset {
+	ds := Dataset{Name: "Bench", Axes: []Axis{{Key: "name"}, {Key: "x"}}}
+	for _, r := range runs {
+		ds.Data = append(ds.Data, DataPoint{Name: r.Tag, XAxis: "sort"})
+	}
+	last := runs[len(runs)-1]
+	ds.Tag, ds.Timestamp, ds.Meta = last.Tag, last.Timestamp, last.Meta
+	ds.History = runs[:len(runs)-1]
+	return ds
+}
+
+func (s *RetentionSuite) TestZeroRetentionKeepsEverything() {
+	runs := []HistoryEntry{s.daysAgo("a", 400), s.daysAgo("b", 1)}
+	s.True(Retention{}.IsZero())
+	s.Nil(Retention{}.Expired(runs))
+}
+
+func (s *RetentionSuite) TestPinsAloneKeepEverything() {
+	runs := []HistoryEntry{s.daysAgo("v1", 3), s.daysAgo("v2", 2), s.daysAgo("v3", 1)}
+	r := Retention{Pinned: []string{"v1"}, Now: s.now}
+	s.True(r.IsZero())
+	s.Nil(r.Expired(runs))
+}
+
+func (s *RetentionSuite) TestKeepLast() {
+	runs := []HistoryEntry{s.daysAgo("a", 4), s.daysAgo("b", 3), s.daysAgo("c", 2), s.daysAgo("d", 1)}
+	s.Equal([]string{"a", "b"}, Retention{KeepLast: 2}.Expired(runs))
+	s.Nil(Retention{KeepLast: 10}.Expired(runs))
+}
+
+func (s *RetentionSuite) TestKeepWithinAlwaysKeepsCurrent() {
+	runs := []HistoryEntry{s.daysAgo("a", 40), s.daysAgo("b", 10), s.daysAgo("c", 35)}
+	r := Retention{KeepWithin: 30 * 24 * time.Hour, Now: s.now}
+	s.Equal([]string{"a"}, r.Expired(runs), "c is current even though it is old")
+}
+
+func (s *RetentionSuite) TestRulesCombineAsUnion() {
+	runs := []HistoryEntry{s.daysAgo("v1.0.0", 90), s.daysAgo("a", 60), s.daysAgo("b", 50), s.daysAgo("c", 5)}
+	r := Retention{KeepLast: 2, KeepWithin: 7 * 24 * time.Hour, Pinned: []string{"v*.0.0"}, Now: s.now}
+	s.Equal([]string{"a"}, r.Expired(runs))
+}
+
+func (s *RetentionSuite) TestThinKeepsNewestPerDay() {
+	day := func(tag string, daysAgo, hour int) HistoryEntry {
+		at := time.Date(2026, 6, 30-daysAgo, hour, 0, 0, 0, time.UTC)
+		return HistoryEntry{Tag: tag, Timestamp: at.Format(time.RFC3339)}
+	}
+	runs := []HistoryEntry{
+		day("old-am", 20, 8), day("old-pm", 20, 20),
+		day("older-am", 10, 8), day("older-pm", 10, 20),
+		day("recent-am", 2, 8), day("recent-pm", 2, 11),
+	}
+	r := Retention{ThinAfter: 7 * 24 * time.Hour, ThinEvery: ThinDay, Now: s.now}
+	s.Equal([]string{"old-am", "older-am"}, r.Expired(runs))
+}
+
+func (s *RetentionSuite) TestThinKeepsNewestPerISOWeek() {
+	// 2026-06-01 is a Monday; the 3rd and 5th share its week, the 8th starts the next.
+	at := func(tag string, d int) HistoryEntry {
+		return HistoryEntry{Tag: tag, Timestamp: time.Date(2026, 6, d, 9, 0, 0, 0, time.UTC).Format(time.RFC3339)}
+	}
+	runs := []HistoryEntry{at("w1a", 1), at("w1b", 3), at("w1c", 5), at("w2a", 8), at("w2b", 9), at("now", 30)}
+	r := Retention{ThinAfter: 24 * time.Hour, ThinEvery: ThinWeek, Now: s.now}
+	s.Equal([]string{"w1a", "w1b", "w2a"}, r.Expired(runs))
+}
+
+func (s *RetentionSuite) TestUnparseableTimestampsNeverAgeOut() {
+	runs := []HistoryEntry{{Tag: "legacy", Timestamp: "yesterday"}, s.daysAgo("old", 90), s.daysAgo("cur", 0)}
+	r := Retention{KeepWithin: 24 * time.Hour, Now: s.now}
+	s.Equal([]string{"old"}, r.Expired(runs))
+}
+
+func (s *RetentionSuite) TestValidate() {
+	s.NoError(Retention{KeepLast: 3, ThinAfter: time.Hour, ThinEvery: ThinWeek, Pinned: []string{"v1.*"}}.Validate())
+	s.ErrorContains(Retention{KeepLast: -1}.Validate(), "keep-last")
+	s.ErrorContains(Retention{KeepWithin: -time.Hour}.Validate(), "keep-within")
+	s.ErrorContains(Retention{ThinEvery: "month"}.Validate(), "thin-every")
+	s.ErrorContains(Retention{Pinned: []string{"v1.["}}.Validate(), "pin")
+}
+
+func (s *RetentionSuite) TestParseAge() {
+	for in, want := range map[string]time.Duration{
+		"30d": 30 * 24 * time.Hour,
+		"2w":  14 * 24 * time.Hour,
+		"36h": 36 * time.Hour,
+		" 0d": 0,
+	} {
+		got, err := ParseAge(in)
+		s.Require().NoError(err, in)
+		s.Equal(want, got, in)
+	}
+	for _, in := range []string{"", "d", "1.5d", "soon"} {
+		_, err := ParseAge(in)
+		s.Error(err, in)
+	}
+}
+
+func (s *RetentionSuite) TestApplyRetentionKeepsDataAndHistoryConsistent() {
+	ds := s.merged(s.daysAgo("a", 3), s.daysAgo("b", 2), s.daysAgo("c", 1))
+	ds.Data = append(ds.Data, DataPoint{Name: "", XAxis: "legacy"})
+
+	pruned, removed := ApplyRetention(ds, DimensionName, Retention{KeepLast: 2})
+	s.Equal([]string{"a"}, removed)
+	s.Equal([]string{"b", "c"}, pruned.Tags())
+	s.Equal([]string{"b", "c", ""}, []string{pruned.Data[0].Name, pruned.Data[1].Name, pruned.Data[2].Name})
+	s.Len(ds.Data, 4, "input is not mutated")
+	s.Len(ds.History, 2)
+}
+
+func (s *RetentionSuite) TestRemoveCurrentTagPromotesNewestHistory() {
+	b := s.daysAgo("b", 2)
+	b.Commit = &Commit{SHA: "bbb"}
+	b.Meta = &Meta{OS: "linux"}
+	ds := s.merged(s.daysAgo("a", 3), b, s.daysAgo("c", 1))
+	ds.Meta = &Meta{OS: "darwin"}
+
+	out, err := RemoveTags(ds, DimensionName, []string{"c"})
+	s.Require().NoError(err)
+	s.Equal("b", out.Tag)
+	s.Equal(b.Timestamp, out.Timestamp)
+	s.Equal("bbb", out.Commit.SHA)
+	s.NotSame(b.Commit, out.Commit)
+	s.Equal("linux", out.Meta.OS)
+	s.Equal([]HistoryEntry{s.daysAgo("a", 3)}, out.History)
+	s.Len(out.Data, 2)
+}
+
+func (s *RetentionSuite) TestRemoveCurrentTagDropsItsMeta() {
+	ds := s.merged(s.daysAgo("a", 2), s.daysAgo("b", 1))
+	ds.Meta = &Meta{OS: "darwin"}
+
+	out, err := RemoveTags(ds, DimensionName, []string{"b"})
+	s.Require().NoError(err)
+	s.Equal("a", out.Tag)
+	s.Nil(out.Meta, "the promoted run recorded no environment")
+}
+
+func (s *RetentionSuite) TestRemoveTagsErrors() {
+	ds := s.merged(s.daysAgo("a", 2), s.daysAgo("b", 1))
+
+	_, err := RemoveTags(ds, DimensionName, []string{"a", "zzz"})
+	s.ErrorContains(err, "no tag zzz")
+
+	_, err = RemoveTags(ds, DimensionName, []string{"a", "b"})
+	s.ErrorContains(err, "no tags left")
+
+	out, err := RemoveTags(ds, DimensionName, []string{"a"})
+	s.Require().NoError(err)
+	s.Nil(out.History)
+}
+
+func (s *RetentionSuite) TestTagDimension() {
+	ds := s.merged(s.daysAgo("a", 2), s.daysAgo("b", 1))
+	dim, ok := ds.TagDimension()
+	s.True(ok)
+	s.Equal(DimensionName, dim)
+
+	for i := range ds.Data {
+		ds.Data[i].Name, ds.Data[i].XAxis = "sort", ds.Data[i].Name
+	}
+	dim, ok = ds.TagDimension()
+	s.True(ok)
+	s.Equal(DimensionXAxis, dim)
+
+	_, ok = (&Dataset{Name: "plain"}).TagDimension()
+	s.False(ok)
+}
+
+func TestRetentionSuite(t *testing.T) {
+	suite.Run(t, new(RetentionSuite))
+}
@@ -0,0 +1,87 @@
+package shared
+
+import (
+	"encoding/json"
+	"path"
+	"reflect"
+	"strings"
+
+	internal_charts "github.com/goptics/vizb/internal/charts"
+	"github.com/goptics/vizb/internal/jsonschema"
+)
+
+// DatasetSchemaID is where the Dataset JSON Schema is published; point an
+// editor's schema mapping (json.schemas in VS Code) at it.
+const DatasetSchemaID = "https://raw.githubusercontent.com/goptics/vizb/main/api/dataset.schema.json"
+
+// DatasetSchema returns the JSON Schema of a vizb data file (one Dataset or an
+// array of them) at CurrentSchemaVersion, generated from the Go types. Chart
+// settings are a oneOf over the registered chart types, so every chart package
+// must be registered first (cmd/root.go blank-imports them all). Output is
+// indented with a trailing newline, ready to write to api/dataset.schema.json.
+func DatasetSchema() ([]byte, error) {
+	variants := map[string]reflect.Type{}
+	for _, spec := range internal_charts.Specs() {
+		variants[spec.Type] = reflect.TypeOf(spec.Factory())
+	}
+	g := jsonschema.Generator{
+		Overrides: map[reflect.Type]jsonschema.Schema{
+			reflect.TypeFor[AxisBound](): {
+				"description": "An absolute value, or a percentile of the plotted values such as \"p95\".",
+				"oneOf": []any{
+					jsonschema.Schema{"type": "number"},
+					jsonschema.Schema{"type": "string", "pattern": `^[pP][0-9]+(\.[0-9]+)?$`},
+				},
+			},
+			reflect.TypeFor[BorderRadius](): {
+				"description": "Corner radii in px: one value for all corners, or top-left, top-right, bottom-right, bottom-left.",
+				"type":        "array",
+				"items":       jsonschema.Schema{"type": "integer", "minimum": 0},
+				"minItems":    1,
+				"maxItems":    4,
+			},
+			reflect.TypeFor[Direction](): {
+				"description": "Which way a stat improves; omitted when unknown.",
+				"type":        "string",
+				"enum":        ValidDirections,
+			},
+		},
+		Unions: map[reflect.Type]jsonschema.Union{
+			reflect.TypeFor[internal_charts.ChartConfig](): {Discriminator: "type", Variants: variants},
+		},
+		// Every chart package names its struct Config; key them as the API
+		// contract does (bar.Config → BarChartConfig).
+		Name: func(t reflect.Type) string {
+			if t.Name() == "Config" {
+				pkg := path.Base(t.PkgPath())
+				return strings.ToUpper(pkg[:1]) + pkg[1:] + "ChartConfig"
+			}
+			return t.Name()
+		},
+	}
+	dataset := g.Schema(reflect.TypeFor[Dataset]())
+	properties := g.Defs["Dataset"]["properties"].(jsonschema.Schema)
+	properties["schemaVersion"] = jsonschema.Schema{
+		"type":        "integer",
+		"minimum":     0,
+		"maximum":     CurrentSchemaVersion,
+		"description": "Wire version the file was written at; vizb migrate upgrades older files.",
+	}
+	properties["theme"] = jsonschema.Schema{
+		"type":        "string",
+		"deprecated":  true,
+		"description": "Legacy single theme name or palette, migrated into themes on load.",
+	}
+
+	doc, err := json.MarshalIndent(jsonschema.Schema{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$id":     DatasetSchemaID,
+		"title":   "vizb dataset",
+		"oneOf":   []any{dataset, jsonschema.Schema{"type": "array", "items": dataset}},
+		"$defs":   g.Defs,
+	}, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(doc, '\n'), nil
+}
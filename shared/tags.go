@@ -0,0 +1,31 @@
+package shared
+
+import "slices"
+
+// Tags returns the dataset's tags in chronological order: every History entry
+// (oldest first, as vizb merge writes them) followed by the current Tag.
+// Duplicates and empty tags are dropped.
+func (d *Dataset) Tags() []string {
+	var out []string
+	add := func(tag string) {
+		if tag != "" && !slices.Contains(out, tag) {
+			out = append(out, tag)
+		}
+	}
+	for _, h := range d.History {
+		add(h.Tag)
+	}
+	add(d.Tag)
+	return out
+}
+
+// TagAxes returns the keys of the dimensions vizb merge injected tags onto:
+// those whose values are all tags of this dataset. An untagged dataset has
+// none.
+func (d *Dataset) TagAxes() []string {
+	tags := d.Tags()
+	if len(tags) == 0 {
+		return nil
+	}
+	return d.axesWhere(func(v string) bool { return slices.Contains(tags, v) })
+}
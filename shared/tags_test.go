@@ -0,0 +1,53 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TagsSuite struct {
+	suite.Suite
+}
+
+func (s *TagsSuite) TestTagsFollowHistoryThenCurrent() {
+	d := &Dataset{
+		Tag:     "v3",
+		History: []HistoryEntry{{Tag: "v1"}, {Tag: ""}, {Tag: "v2"}, {Tag: "v1"}},
+	}
+	s.Equal([]string{"v1", "v2", "v3"}, d.Tags())
+	s.Empty((&Dataset{}).Tags())
+}
+
+func (s *TagsSuite) TestTagAxesFindsInjectedDimension() {
+	d := &Dataset{
+		Tag:     "v2",
+		History: []HistoryEntry{{Tag: "v1"}},
+		Axes:    []Axis{{Key: "name"}, {Key: "x"}, {Key: "y"}},
+		Data: []DataPoint{
+			{Name: "v1", XAxis: "Sort", YAxis: "v1"},
+			{Name: "v2", XAxis: "Sort", YAxis: "1024"},
+			{Name: "v2", XAxis: "Map"},
+		},
+	}
+	s.Equal([]string{"name"}, d.TagAxes(), "y mixes tags with other values")
+
+	d.Tag, d.History = "", nil
+	s.Empty(d.TagAxes(), "an untagged dataset has no tag dimension")
+}
+
+func (s *TagsSuite) TestTagsSurviveMerge() {
+	v1 := Dataset{Name: "bench", Tag: "v1", Timestamp: "2024-01-01T00:00:00Z", Axes: []Axis{{Key: "x"}},
+		Data: []DataPoint{{XAxis: "Sort", Stats: []Stat{{Type: "ns/op", Value: F64(10)}}}}}
+	v2 := v1
+	v2.Tag, v2.Timestamp = "v2", "2024-02-01T00:00:00Z"
+
+	merged := MergeDatasets([]Dataset{v2, v1}, DimensionName)
+	s.Require().Len(merged, 1)
+	s.Equal([]string{"v1", "v2"}, merged[0].Tags())
+	s.Equal([]string{"name"}, merged[0].TagAxes())
+}
+
+func TestTagsSuite(t *testing.T) {
+	suite.Run(t, new(TagsSuite))
+}
@@ -0,0 +1,202 @@
+package shared
+
+import (
+	"fmt"
+	"strings"
+
+	internal_charts "github.com/goptics/vizb/internal/charts"
+)
+
+// unitFamilies are the unit systems vizb converts between, each mapping a
+// unit to its size in the family's base unit. Resolution is per pair of
+// units, so "B" reads as bytes next to "KB" and as billions next to "K".
+// The count family's "" is a bare count.
+var unitFamilies = []map[string]float64{
+	{"ns": 1, "us": 1e3, "µs": 1e3, "ms": 1e6, "s": 1e9},
+	{"b": 1.0 / 8, "B": 1, "KB": 1 << 10, "MB": 1 << 20, "GB": 1 << 30},
+	{"": 1, "K": 1e3, "M": 1e6, "B": 1e9, "T": 1e12},
+}
+
+// KnownUnit reports whether unit belongs to one of the unit families vizb can
+// convert (time, memory or count scale).
+func KnownUnit(unit string) bool {
+	if unit == "" {
+		return false
+	}
+	for _, family := range unitFamilies {
+		if _, ok := family[unit]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// ConvertUnit converts v from one unit to another of the same family, e.g.
+// 1.5 "ms" → 1500000 "ns". ok is false when the units don't share a family;
+// equal units always convert.
+func ConvertUnit(v float64, from, to string) (float64, bool) {
+	if from == to {
+		return v, true
+	}
+	for _, family := range unitFamilies {
+		f, okFrom := family[from]
+		t, okTo := family[to]
+		if okFrom && okTo {
+			return v * f / t, true
+		}
+	}
+	return v, false
+}
+
+// StatLabel builds the human label of a stat from its name, unit and per
+// value: "Execution Time (ns/op)", "Latency avg (ms)", "Allocations/op".
+func StatLabel(name, unit, per string) string {
+	switch {
+	case unit != "" && per != "":
+		return fmt.Sprintf("%s (%s/%s)", name, unit, per)
+	case unit != "":
+		return fmt.Sprintf("%s (%s)", name, unit)
+	case per != "":
+		return fmt.Sprintf("%s/%s", name, per)
+	}
+	return name
+}
+
+// ParseStatLabel recovers name, unit and per from a label StatLabel built.
+// Only known units are recognised — "Requests (total)" stays whole — and a
+// bare per suffix only as "/op", so names such as "I/O" are left alone.
+func ParseStatLabel(label string) (name, unit, per string) {
+	if base, inner := internal_charts.SplitStatUnit(label); inner != "" {
+		u, p, _ := strings.Cut(inner, "/")
+		if KnownUnit(u) && !strings.ContainsAny(p, " /") {
+			return base, u, p
+		}
+		return label, "", ""
+	}
+	if base, ok := strings.CutSuffix(label, "/op"); ok && base != "" {
+		return base, "", "op"
+	}
+	return label, "", ""
+}
+
+// BaseType is the stat's label without its unit suffix ("Execution Time" for
+// "Execution Time (ns/op)"). Labels that don't end in the suffix StatLabel
+// would build from Unit and Per come back whole.
+func (s Stat) BaseType() string {
+	if s.Unit == "" && s.Per == "" {
+		return s.Type
+	}
+	if base, ok := strings.CutSuffix(s.Type, StatLabel("", s.Unit, s.Per)); ok {
+		return strings.TrimSpace(base)
+	}
+	return s.Type
+}
+
+// ConvertTo returns the stat expressed in unit: the value scaled and, when the
+// label carries the unit, the label rewritten to match. ok is false when the
+// units aren't compatible; the stat is then returned unchanged.
+func (s Stat) ConvertTo(unit string) (Stat, bool) {
+	if s.Unit == unit {
+		return s, true
+	}
+	out := s
+	if s.Value != nil {
+		v, ok := ConvertUnit(*s.Value, s.Unit, unit)
+		if !ok {
+			return s, false
+		}
+		out.Value = F64(v)
+	} else if _, ok := ConvertUnit(1, s.Unit, unit); !ok {
+		return s, false
+	}
+	if base := s.BaseType(); base != s.Type {
+		out.Type = StatLabel(base, unit, s.Per)
+	}
+	out.Unit = unit
+	return out, true
+}
+
+// Comparable reports whether two stats measure the same quantity, possibly in
+// different units of one family: same base label, same per value and
+// convertible units. Stats without structured units compare by Type.
+func (s Stat) Comparable(other Stat) bool {
+	if s.BaseType() != other.BaseType() || s.Per != other.Per {
+		return false
+	}
+	_, ok := ConvertUnit(1, s.Unit, other.Unit)
+	return ok
+}
+
+// InferStatUnits fills Unit and Per on stats that lack them by parsing their
+// labels (see ParseStatLabel), and reports whether any stat changed.
+func InferStatUnits(points []DataPoint) bool {
+	changed := false
+	for i := range points {
+		for j := range points[i].Stats {
+			st := &points[i].Stats[j]
+			if st.Unit != "" || st.Per != "" {
+				continue
+			}
+			if _, unit, per := ParseStatLabel(st.Type); unit != "" || per != "" {
+				st.Unit, st.Per = unit, per
+				changed = true
+			}
+		}
+	}
+	return changed
+}
+
+// ReconcileStatUnits puts every stat that measures the same quantity under one
+// unit across datasets sharing a Name, so a run recorded in ns and one in ms
+// merge into a single series. The newest dataset's unit wins (by Timestamp;
+// later inputs win ties). Incompatible units are left as separate series.
+// Datasets whose stats change get their own copy of Data; the input is not
+// modified.
+func ReconcileStatUnits(datasets []Dataset) []Dataset {
+	type quantity struct{ dataset, base, per string }
+	type choice struct{ unit, timestamp string }
+	targets := map[quantity]choice{}
+	for _, ds := range datasets {
+		for _, p := range ds.Data {
+			for _, st := range p.Stats {
+				if st.Unit == "" && st.Per == "" {
+					continue
+				}
+				key := quantity{ds.Name, st.BaseType(), st.Per}
+				if prev, ok := targets[key]; !ok || ds.Timestamp >= prev.timestamp {
+					targets[key] = choice{st.Unit, ds.Timestamp}
+				}
+			}
+		}
+	}
+
+	out := make([]Dataset, len(datasets))
+	for i, ds := range datasets {
+		out[i] = ds
+		cloned := false
+		for pi, p := range ds.Data {
+			for si, st := range p.Stats {
+				if st.Unit == "" && st.Per == "" {
+					continue
+				}
+				target, ok := targets[quantity{ds.Name, st.BaseType(), st.Per}]
+				if !ok || target.unit == st.Unit {
+					continue
+				}
+				converted, ok := st.ConvertTo(target.unit)
+				if !ok {
+					continue
+				}
+				if !cloned {
+					out[i].Data = make([]DataPoint, len(ds.Data))
+					for k := range ds.Data {
+						out[i].Data[k] = deepCloneData(ds.Data[k])
+					}
+					cloned = true
+				}
+				out[i].Data[pi].Stats[si] = converted
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,99 @@
+package shared
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type UnitsSuite struct {
+	suite.Suite
+}
+
+func (s *UnitsSuite) TestConvertUnit() {
+	tests := []struct {
+		from, to string
+		in, want float64
+		ok       bool
+	}{
+		{"ms", "ns", 1.5, 1.5e6, true},
+		{"ns", "s", 2e9, 2, true},
+		{"µs", "us", 3, 3, true},
+		{"KB", "B", 2, 2048, true},
+		{"B", "b", 1, 8, true},
+		{"K", "B", 2e6, 2, true}, // count scale: B is billions next to K
+		{"", "K", 1500, 1.5, true},
+		{"ms", "MB", 1, 1, false},
+		{"ops", "ops", 7, 7, true},
+		{"ops", "K", 7, 7, false},
+	}
+	for _, tt := range tests {
+		got, ok := ConvertUnit(tt.in, tt.from, tt.to)
+		s.Equal(tt.ok, ok, "%s → %s", tt.from, tt.to)
+		s.InDelta(tt.want, got, 1e-9, "%s → %s", tt.from, tt.to)
+	}
+}
+
+func (s *UnitsSuite) TestStatLabelRoundTrip() {
+	tests := []struct{ name, unit, per, label string }{
+		{"Execution Time", "ns", "op", "Execution Time (ns/op)"},
+		{"Latency avg", "ms", "", "Latency avg (ms)"},
+		{"Allocations", "", "op", "Allocations/op"},
+		{"Throughput", "MB", "s", "Throughput (MB/s)"},
+		{"Samples", "", "", "Samples"},
+	}
+	for _, tt := range tests {
+		s.Equal(tt.label, StatLabel(tt.name, tt.unit, tt.per))
+		name, unit, per := ParseStatLabel(tt.label)
+		s.Equal([]string{tt.name, tt.unit, tt.per}, []string{name, unit, per}, tt.label)
+	}
+}
+
+func (s *UnitsSuite) TestParseStatLabelLeavesUnknownSuffixes() {
+	for _, label := range []string{"Requests (total)", "I/O", "RME (%)", "Score (ns per op)", "/op"} {
+		name, unit, per := ParseStatLabel(label)
+		s.Equal([]string{label, "", ""}, []string{name, unit, per}, label)
+	}
+}
+
+func (s *UnitsSuite) TestStatConvertTo() {
+	st := Stat{Type: "Execution Time (ns/op)", Value: F64(2500), Unit: "ns", Per: "op", Symbol: "±"}
+	s.Equal("Execution Time", st.BaseType())
+
+	got, ok := st.ConvertTo("us")
+	s.Require().True(ok)
+	s.Equal(Stat{Type: "Execution Time (us/op)", Value: F64(2.5), Unit: "us", Per: "op", Symbol: "±"}, got)
+	s.Equal(2500.0, *st.Value, "the receiver is not modified")
+
+	_, ok = st.ConvertTo("MB")
+	s.False(ok)
+
+	custom := Stat{Type: "Wall clock", Value: F64(1), Unit: "s"}
+	s.Equal("Wall clock", custom.BaseType())
+	got, ok = custom.ConvertTo("ms")
+	s.Require().True(ok)
+	s.Equal("Wall clock", got.Type, "a label without the unit is kept")
+	s.Equal(1000.0, *got.Value)
+}
+
+func (s *UnitsSuite) TestComparable() {
+	ns := Stat{Type: "Execution Time (ns/op)", Unit: "ns", Per: "op"}
+	s.True(ns.Comparable(Stat{Type: "Execution Time (ms/op)", Unit: "ms", Per: "op"}))
+	s.False(ns.Comparable(Stat{Type: "Execution Time (ms)", Unit: "ms"}), "per differs")
+	s.False(ns.Comparable(Stat{Type: "Memory Usage (B/op)", Unit: "B", Per: "op"}))
+	s.True(Stat{Type: "Samples"}.Comparable(Stat{Type: "Samples"}))
+}
+
+func (s *UnitsSuite) TestReconcileStatUnitsKeepsIncompatibleSeries() {
+	a := Dataset{Name: "T", Timestamp: "1", Data: []DataPoint{{Stats: []Stat{{Type: "Metric (ms)", Value: F64(1), Unit: "ms"}}}}}
+	b := Dataset{Name: "T", Timestamp: "2", Data: []DataPoint{{Stats: []Stat{{Type: "Metric (MB)", Value: F64(1), Unit: "MB"}}}}}
+	other := Dataset{Name: "U", Timestamp: "3", Data: []DataPoint{{Stats: []Stat{{Type: "Metric (s)", Value: F64(1), Unit: "s"}}}}}
+
+	out := ReconcileStatUnits([]Dataset{a, b, other})
+	s.Equal("Metric (ms)", out[0].Data[0].Stats[0].Type, "ms can't become MB")
+	s.Equal("Metric (s)", out[2].Data[0].Stats[0].Type, "other dataset names are independent")
+}
+
+func TestUnitsSuite(t *testing.T) {
+	suite.Run(t, new(UnitsSuite))
+}
@@ -1,20 +1,9 @@
 package utils
 
-import "fmt"
+import "github.com/goptics/vizb/shared"
 
 // CreateStatType generates a formatted stat type based on the stat type, unit, and per value.
+// Parsers set the same unit and per on Stat.Unit and Stat.Per.
 func CreateStatType(name, unit, per string) string {
-	if unit != "" && per != "" {
-		return fmt.Sprintf("%s (%s/%s)", name, unit, per)
-	}
-
-	if unit != "" {
-		return fmt.Sprintf("%s (%s)", name, unit)
-	}
-
-	if per != "" {
-		return fmt.Sprintf("%s/%s", name, per)
-	}
-
-	return name
+	return shared.StatLabel(name, unit, per)
 }
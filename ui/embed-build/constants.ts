@@ -11,5 +11,12 @@ export const CHART_ROOT_PREFIX: Record<string, string> = {
   ChartRadar: 'radar',
   ChartSankey: 'sankey',
   ChartChord: 'chord',
+  ChartTreemap: 'treemap',
+  ChartSunburst: 'sunburst',
+  ChartHistogram: 'histogram',
+  ChartParallel: 'parallel',
+  ChartCalendar: 'calendar',
+  ChartBump: 'bump',
+  ChartWaterfall: 'waterfall',
   Chart3D: '3d',
 }
@@ -74,6 +74,13 @@ describe('types + constants touch', () => {
     expect(CHART_ROOT_PREFIX.ChartBar).toBe('bar')
     expect(CHART_ROOT_PREFIX.ChartSankey).toBe('sankey')
     expect(CHART_ROOT_PREFIX.ChartChord).toBe('chord')
+    expect(CHART_ROOT_PREFIX.ChartTreemap).toBe('treemap')
+    expect(CHART_ROOT_PREFIX.ChartSunburst).toBe('sunburst')
+    expect(CHART_ROOT_PREFIX.ChartHistogram).toBe('histogram')
+    expect(CHART_ROOT_PREFIX.ChartParallel).toBe('parallel')
+    expect(CHART_ROOT_PREFIX.ChartCalendar).toBe('calendar')
+    expect(CHART_ROOT_PREFIX.ChartBump).toBe('bump')
+    expect(CHART_ROOT_PREFIX.ChartWaterfall).toBe('waterfall')
   })
 })
 
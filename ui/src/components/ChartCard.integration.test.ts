@@ -243,8 +243,21 @@ describe('ChartCard', () => {
     expect(w.text()).toMatch(/Total/)
   })
 
-  it('routes pie/heatmap/radar/sankey/chord past 3D', async () => {
-    for (const t of ['pie', 'heatmap', 'radar', 'sankey', 'chord'] as ChartType[]) {
+  it('routes pie/heatmap/radar/sankey/chord/treemap/sunburst/histogram/parallel/calendar/bump/waterfall past 3D', async () => {
+    for (const t of [
+      'pie',
+      'heatmap',
+      'radar',
+      'sankey',
+      'chord',
+      'treemap',
+      'sunburst',
+      'histogram',
+      'parallel',
+      'calendar',
+      'bump',
+      'waterfall',
+    ] as ChartType[]) {
       holder.chartType = t
       chartTypeRef.value = t
       holder.threeD = true
@@ -72,6 +72,7 @@ const holder = vi.hoisted(() => ({
   setShowLabels: vi.fn(),
   setSmooth: vi.fn(),
   setHorizontal: vi.fn(),
+  setCumulative: vi.fn(),
   setThreeDRotate: vi.fn(),
   setSwap: vi.fn(),
   setThreeD: vi.fn(),
@@ -102,6 +103,7 @@ vi.mock('@/composables/useSettingsStore', () => ({
     setShowLabels: holder.setShowLabels,
     setSmooth: holder.setSmooth,
     setHorizontal: holder.setHorizontal,
+    setCumulative: holder.setCumulative,
     setThreeDRotate: holder.setThreeDRotate,
     setSwap: holder.setSwap,
     setThreeD: holder.setThreeD,
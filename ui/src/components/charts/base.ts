@@ -5,6 +5,9 @@ import {
   LegendComponent,
   ToolboxComponent,
   DataZoomComponent,
+  MarkLineComponent,
+  MarkAreaComponent,
+  MarkPointComponent,
 } from 'echarts/components'
 
 // Universal 2D ECharts modules shared by every 2D renderer (bar/line/pie).
@@ -19,3 +22,7 @@ export const BASE_2D = [
   ToolboxComponent,
   DataZoomComponent,
 ]
+
+// Reference-mark overlays (--mark / Dataset.annotations) for the 2D cartesian
+// renderers (bar/line/scatter) only.
+export const MARKS_2D = [MarkLineComponent, MarkAreaComponent, MarkPointComponent]
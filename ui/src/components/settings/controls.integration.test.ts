@@ -208,6 +208,7 @@ describe('settings controls', () => {
     'showLabels',
     'smooth',
     'horizontal',
+    'cumulative',
     'threeD',
     'threeDRotate',
     'threeDVisualMap',
@@ -429,6 +429,31 @@ describe('DatasetHeader', () => {
     expect(w.text()).toContain('2 cores')
   })
 
+  it('shows the run environment and what changed in history', () => {
+    const w = mount(DatasetHeader, {
+      props: {
+        dataset: {
+          ...baseDataset,
+          meta: { ...baseDataset.meta, env: { go: '1.23.0', hostname: 'ci' } },
+          history: [
+            {
+              tag: 'v1',
+              timestamp: '2024-01-01T00:00:00.000Z',
+              meta: { env: { go: '1.22.3', hostname: 'ci' } },
+            },
+          ],
+        },
+        datasets: [{ name: 'Bench' }],
+        activeDatasetId: 0,
+        resultGroups: [{ name: 'g0' }],
+        activeGroupId: 0,
+      },
+    })
+    expect(w.text()).toContain('Env')
+    expect(w.text()).toContain('go 1.23.0')
+    expect(w.text()).toContain('go 1.22.3 → 1.23.0')
+  })
+
   it('falls back to raw timestamp when the date is invalid', () => {
     const w = mount(DatasetHeader, {
       props: {
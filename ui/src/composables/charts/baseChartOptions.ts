@@ -1,6 +1,18 @@
 import type { Ref } from 'vue'
 import type { EChartsOption } from 'echarts'
-import type { Axis, BarBackground, ChartData, Sort, ScaleType, ChartType } from '@/types'
+import type {
+  Axis,
+  BarBackground,
+  CalendarYears,
+  ChartData,
+  DataPoint,
+  ParallelBrush,
+  Sort,
+  ScaleType,
+  ChartType,
+  SortOrder,
+  TagAxis,
+} from '@/types'
 import { createTooltipConfig, createToolboxConfig, getChartStyling } from './shared/chartConfig'
 import { fontSize } from './shared/common'
 import { is3D } from '@/lib/utils'
@@ -41,6 +53,35 @@ export interface BaseChartConfig {
   arrangementTarget?: Ref<string>
   chartAxes?: Ref<Axis[] | undefined>
   chartType?: Ref<ChartType>
+  /**
+   * The dataset's raw rows (every name group, every stat). Treemap/sunburst
+   * nest them name → x → y → z and size nodes by any stat (with the CLI-baked
+   * --leaf-depth / --label-levels / --value-stat); parallel draws one line per
+   * row across every stat.
+   */
+  datasetRows?: Ref<DataPoint[] | undefined>
+  leafDepth?: Ref<number | undefined>
+  labelLevels?: Ref<number | undefined>
+  valueStat?: Ref<string | undefined>
+  /** Histogram only: draw running totals as a percentage (CDF) instead of bin counts. */
+  cumulative?: Ref<boolean>
+  /** Parallel only: per-stat-axis scale overrides (--axis-scale) and brushing style (--brush). */
+  axisScale?: Ref<Record<string, ScaleType> | undefined>
+  brush?: Ref<ParallelBrush | undefined>
+  /** Calendar only: first day of the week, the year grids drawn (--years) and cell edge in px. */
+  weekStart?: Ref<'sunday' | 'monday' | undefined>
+  years?: Ref<CalendarYears | undefined>
+  cellSize?: Ref<number | undefined>
+  /**
+   * Bump/waterfall only: the merged history's tags (oldest first), the
+   * dimension holding them (--tag-axis; detected when unset), the rank order
+   * (--rank) and the waterfall's two tags (--from/--to).
+   */
+  tags?: Ref<string[] | undefined>
+  tagAxis?: Ref<TagAxis | undefined>
+  rank?: Ref<SortOrder | undefined>
+  fromTag?: Ref<string | undefined>
+  toTag?: Ref<string | undefined>
 }
 
 export const getBaseOptions = (config: BaseChartConfig): Partial<EChartsOption> => {
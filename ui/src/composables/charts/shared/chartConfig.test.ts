@@ -440,6 +440,23 @@ describe('createTooltipConfig item + empty branches', () => {
       '<strong>S</strong>'
     )
   })
+
+  it('appends the stat unit to values and the sum', () => {
+    const axis = createTooltipConfig(true, false, undefined, 'ms/op') as {
+      formatter: (p: unknown) => string
+    }
+    const html = axis.formatter([
+      { name: 'X', seriesName: 'A', value: 10, color: '#a00', marker: 'm' },
+      { name: 'X', seriesName: 'B', value: 5, color: '#0a0', marker: 'm' },
+    ])
+    expect(html).toContain('A: 10 ms/op')
+    expect(html).toContain('<b>15 ms/op</b>')
+
+    const item = createTooltipConfig(false, false, undefined, '%') as {
+      formatter: (p: unknown) => string
+    }
+    expect(item.formatter({ marker: '*', name: 'N', value: 1 })).toContain('1%')
+  })
 })
 
 describe('createLegendConfig', () => {
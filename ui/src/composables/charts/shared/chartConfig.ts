@@ -3,6 +3,7 @@ import type { ScaleType } from '@/types'
 import { fontSize } from './common'
 import { describe } from '@/lib/stats'
 import { formatChartNumber } from '@/lib/utils'
+import { withValueUnit } from '@/lib/units'
 
 export const LARGE_X_THRESHOLD = 50
 /** Initial visible share of a large category X-axis when dataZoom first renders. */
@@ -469,11 +470,12 @@ export function createHorizontalAxisConfig(
 }
 
 /**
- * Format a tooltip value, showing 0 for null/undefined values
+ * Format a tooltip value, showing — for null/undefined values. Numbers get the
+ * stat's unit appended when one is given ("1.5 ms/op").
  */
-export function formatTooltipValue(value: any): string {
+export function formatTooltipValue(value: any, unit?: string): string {
   if (value === null || value === undefined) return '—'
-  if (typeof value === 'number') return formatChartNumber(value)
+  if (typeof value === 'number') return withValueUnit(formatChartNumber(value), unit)
   return String(value)
 }
 
@@ -684,11 +686,13 @@ export function createPinnedAxisTooltip(isDark = false): EChartsOption['tooltip'
  * @param hasXYAxis - Whether the chart has both X and Y axes
  * @param isDark - Dark mode flag for tooltip theming
  * @param seriesTotals - Per-series totals across all x, appended after each name
+ * @param unit - Stat unit appended to every value (ChartData.statUnit)
  */
 export function createTooltipConfig(
   hasXYAxis: boolean,
   isDark = false,
-  seriesTotals?: Map<string, number>
+  seriesTotals?: Map<string, number>,
+  unit?: string
 ): EChartsOption['tooltip'] {
   const theme = getTooltipTheme(isDark)
   const styling = getChartStyling(isDark)
@@ -708,7 +712,7 @@ export function createTooltipConfig(
         const legendRows = present.map((cur) => {
           const seriesSum = seriesTotals?.get(cur.seriesName ?? '')
           const sumTag = seriesSum === undefined ? '' : ` (Σ${formatChartNumber(seriesSum)})`
-          return `${cur.marker} ${cur.seriesName}${sumTag}: ${formatTooltipValue(cur.value)}`
+          return `${cur.marker} ${cur.seriesName}${sumTag}: ${formatTooltipValue(cur.value, unit)}`
         })
         const body = `<strong>${params[0]?.name}</strong><br/>${renderTooltipLegendColumns(legendRows)}`
 
@@ -720,7 +724,7 @@ export function createTooltipConfig(
           0
         )
         const xName = params[0]?.name ?? ''
-        const sumLine = `${tooltipDivider(isDark)}Σ ${xName}: <b>${formatChartNumber(total)}</b>`
+        const sumLine = `${tooltipDivider(isDark)}Σ ${xName}: <b>${formatTooltipValue(total, unit)}</b>`
         const spread = tooltipSpreadRows(
           present.map((p) => (typeof p.value === 'number' ? p.value : NaN)),
           isDark
@@ -753,7 +757,7 @@ export function createTooltipConfig(
         name = seriesName
       }
 
-      return `${params.marker} <strong>${name}</strong><br/>${formatTooltipValue(params.value)}`
+      return `${params.marker} <strong>${name}</strong><br/>${formatTooltipValue(params.value, unit)}`
     },
   }
 }
@@ -0,0 +1,70 @@
+import type { EChartsOption } from 'echarts'
+import { getNextColorFor } from '@/lib/utils'
+import { buildHierarchy, hierarchyLevels, type HierarchyNode } from '@/lib/hierarchy'
+import { presentAxisString } from '@/lib/swap'
+import { identityStringFromAxes } from '@/lib/transform'
+import type { BaseChartConfig } from '../baseChartOptions'
+import { formatTooltipValue, getTooltipTheme } from './chartConfig'
+
+export type PreparedHierarchyChart = {
+  data: (HierarchyNode & { itemStyle?: { color?: string } })[]
+  /** Levels actually drawn (after --leaf-depth), ≥ 1 when data is non-empty. */
+  depth: number
+  /** Levels (from the top) that carry labels. */
+  labelLevels: number
+  /** Stat type sizing the nodes (--value-stat, else the active chart's stat). */
+  statType: string
+  total: number
+  tooltip: EChartsOption['tooltip']
+}
+
+/** Node tree + item tooltip shared by treemap and sunburst. Series stay with the caller. */
+export function prepareHierarchyChart(config: BaseChartConfig): PreparedHierarchyChart {
+  const chartData = config.chartData.value
+  const rows = config.datasetRows?.value ?? []
+  // Benchmark datasets may carry no axes; fall back to the fields rows fill.
+  const identity = identityStringFromAxes(config.chartAxes?.value ?? []) || presentAxisString(rows)
+  const levels = hierarchyLevels(identity, config.arrangementTarget?.value)
+  const statType = config.valueStat?.value || chartData.statType
+  const sort = config.sort.value
+  const tree = buildHierarchy(
+    rows,
+    levels,
+    statType,
+    config.leafDepth?.value,
+    sort.enabled ? sort.order : undefined
+  )
+  const depth = Math.min(config.leafDepth?.value ?? levels.length, levels.length)
+  const total = tree.reduce((sum, n) => sum + n.value, 0)
+  const share = (value: number) => (total > 0 ? ((value / total) * 100).toFixed(2) : '0.00')
+
+  return {
+    // Top-level nodes get palette colors; descendants inherit them.
+    data: tree.map((n) => ({ ...n, itemStyle: { color: getNextColorFor(n.name) } })),
+    depth,
+    labelLevels: Math.min(config.labelLevels?.value ?? depth, depth),
+    statType,
+    total,
+    tooltip: {
+      trigger: 'item',
+      ...getTooltipTheme(config.isDark.value),
+      formatter: (params: any) => {
+        const path = (params.treePathInfo ?? [])
+          .slice(1)
+          .map((p: { name: string }) => p.name)
+          .join(' / ')
+        return `${params.marker ?? ''} <strong>${path || params.name}</strong><br/>${statType}: ${formatTooltipValue(params.value)} (${share(Number(params.value) || 0)}%)`
+      },
+    } as EChartsOption['tooltip'],
+  }
+}
+
+/** Label text: the node name, plus value and share of the total when labels are on. */
+export function hierarchyLabelFormatter(showLabels: boolean, total: number) {
+  return (params: { name: string; value?: number | number[] }) => {
+    if (!showLabels) return params.name
+    const value = Number(Array.isArray(params.value) ? params.value[0] : params.value) || 0
+    const pct = total > 0 ? ((value / total) * 100).toFixed(1) : '0.0'
+    return `${params.name}\n${formatTooltipValue(value)} (${pct}%)`
+  }
+}
@@ -25,6 +25,7 @@ import { getChartStyling, getTooltipTheme } from './shared/chartConfig'
 import { resolve3DSymbolProps } from './shared/seriesConfig'
 import { buildMixedAxes3DOptions } from './shared/mixedMode'
 import type { Series3DData } from '@/types'
+import { withLabelUnit } from '@/lib/units'
 
 export type Chart3DKind = 'bar3D' | 'line3D' | 'scatter3D'
 
@@ -85,9 +86,7 @@ export function use3DChartOptions(config: BaseChartConfig, kind: Chart3DKind) {
     const symbolSizeOverride = symbolSize?.value
 
     if (isValueMode) {
-      const valueLabel = chartData.value.statUnit
-        ? `${chartData.value.title} (${chartData.value.statUnit})`
-        : chartData.value.title
+      const valueLabel = withLabelUnit(chartData.value.title, chartData.value.statUnit)
       const cellTotals = render.cellTotals ?? {}
 
       return {
@@ -162,7 +162,7 @@ export function useBarChartOptions(config: BaseChartConfig) {
           top: 8,
           containLabel: true,
         },
-        tooltip: createTooltipConfig(false, isDark.value),
+        tooltip: createTooltipConfig(false, isDark.value, undefined, chartData.value.statUnit),
         legend: { show: false },
         ...createHorizontalAxisConfig(styling, xAxisData, yScale, xLabel, largeX),
         ...(largeX ? { dataZoom: createHorizontalDataZoomConfig(styling) } : {}),
@@ -191,7 +191,7 @@ export function useBarChartOptions(config: BaseChartConfig) {
       return {
         ...baseOptions,
         grid: createGridConfig(1, largeX),
-        tooltip: createTooltipConfig(false, isDark.value),
+        tooltip: createTooltipConfig(false, isDark.value, undefined, chartData.value.statUnit),
         legend: { show: false },
         ...createAxisConfig(styling, xAxisData, yScale, xLabel, largeX),
         ...(largeX ? { dataZoom: createDataZoomConfig(xAxisData, styling) } : {}),
@@ -262,7 +262,12 @@ export function useBarChartOptions(config: BaseChartConfig) {
           top: 8,
           containLabel: true,
         },
-        tooltip: createTooltipConfig(hasXAxis(chartData), isDark.value, seriesTotals),
+        tooltip: createTooltipConfig(
+          hasXAxis(chartData),
+          isDark.value,
+          seriesTotals,
+          chartData.value.statUnit
+        ),
         legend: createLegendConfig(
           transposedSeries.map((s) => ({ xAxis: s.name })),
           styling,
@@ -279,7 +284,12 @@ export function useBarChartOptions(config: BaseChartConfig) {
       ...baseOptions,
       ...(showLegendTitle ? { title: makeLegendTitle(yLabel!, styling) } : {}),
       grid: createGridConfig(transposedSeries.length, largeX),
-      tooltip: createTooltipConfig(hasXAxis(chartData), isDark.value, seriesTotals),
+      tooltip: createTooltipConfig(
+        hasXAxis(chartData),
+        isDark.value,
+        seriesTotals,
+        chartData.value.statUnit
+      ),
       legend: createLegendConfig(
         transposedSeries.map((s) => ({ xAxis: s.name })),
         styling,
@@ -0,0 +1,70 @@
+import { describe, it, expect, beforeAll, afterAll } from 'vitest'
+import { ref } from 'vue'
+import { baseConfig, emptyChartData, installDevicePixelRatio } from '@/test-utils'
+import type { DataPoint, SortOrder, TagAxis } from '@/types'
+import { useBumpChartOptions } from './useBumpChartOptions'
+
+let restoreDpr: () => void
+beforeAll(() => {
+  restoreDpr = installDevicePixelRatio()
+})
+afterAll(() => restoreDpr())
+
+const chartData = emptyChartData({ title: 'speed', statType: 'ns/op' })
+
+const stat = (value: number) => [{ type: 'ns/op', value }]
+// Tags on name (vizb merge's default --tag-axis n).
+const rows: DataPoint[] = [
+  { name: 'v1', xAxis: 'Sort', stats: stat(30) },
+  { name: 'v1', xAxis: 'Map', stats: stat(10) },
+  { name: 'v2', xAxis: 'Sort', stats: stat(5) },
+  { name: 'v2', xAxis: 'Map', stats: stat(12) },
+]
+
+type BumpOption = {
+  xAxis: { data: string[] }
+  yAxis: { inverse: boolean; min: number; max: number }
+  series: { name: string; type: string; data: (number | null)[]; label: { show: boolean } }[]
+}
+
+const build = (
+  opts: { tags?: string[]; tagAxis?: TagAxis; rank?: SortOrder; showLabels?: boolean } = {}
+) => {
+  const cfg = baseConfig({ chartData, chartType: 'bump', showLabels: opts.showLabels })
+  const { options } = useBumpChartOptions({
+    ...cfg,
+    datasetRows: ref(rows),
+    tags: ref(opts.tags ?? ['v1', 'v2']),
+    tagAxis: ref(opts.tagAxis),
+    rank: ref(opts.rank),
+  })
+  return options.value as unknown as BumpOption
+}
+
+describe('useBumpChartOptions', () => {
+  it('draws one line per benchmark with its rank at each tag', () => {
+    const opt = build()
+    expect(opt.xAxis.data).toEqual(['v1', 'v2'])
+    expect(opt.series.map((s) => [s.name, s.type, s.data])).toEqual([
+      ['Sort', 'line', [2, 1]],
+      ['Map', 'line', [1, 2]],
+    ])
+  })
+
+  it('puts rank 1 on top', () => {
+    expect(build().yAxis).toMatchObject({ inverse: true, min: 1, max: 2 })
+  })
+
+  it('ranks the highest value first with --rank desc', () => {
+    expect(build({ rank: 'desc' }).series[0]!.data).toEqual([1, 2])
+  })
+
+  it('draws nothing when no dimension holds the tags', () => {
+    expect(build({ tags: [] }).series).toEqual([])
+  })
+
+  it('toggles point labels', () => {
+    expect(build().series[0]!.label.show).toBe(false)
+    expect(build({ showLabels: true }).series[0]!.label.show).toBe(true)
+  })
+})
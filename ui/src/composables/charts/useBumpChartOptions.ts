@@ -0,0 +1,83 @@
+import { computed } from 'vue'
+import type { EChartsOption } from 'echarts'
+import { type BaseChartConfig, getBaseOptions } from './baseChartOptions'
+import { formatChartNumber, getNextColorFor } from '@/lib/utils'
+import { buildBump, rankOrderFor, tagField } from '@/lib/history'
+import {
+  createAxisConfig,
+  createGridConfig,
+  createLegendConfig,
+  getChartStyling,
+  getTooltipTheme,
+} from './shared/chartConfig'
+
+export function useBumpChartOptions(config: BaseChartConfig) {
+  const { chartData, showLabels, isDark, tags, tagAxis, rank } = config
+
+  const options = computed<EChartsOption>(() => {
+    const styling = getChartStyling(isDark.value)
+    const rows = config.datasetRows?.value ?? []
+    const tagList = tags?.value ?? []
+    const key = tagField(rows, tagList, tagAxis?.value)
+    // Without --rank, the stat's better end ranks first.
+    const order = rank?.value ?? rankOrderFor(chartData.value.statBetter)
+    const lines = key ? buildBump(rows, tagList, key, chartData.value.statType, order) : []
+    const maxRank = Math.max(1, lines.length)
+
+    const series = lines.map((l) => ({
+      name: l.name,
+      type: 'line' as const,
+      data: l.ranks,
+      symbol: 'circle',
+      symbolSize: 10,
+      // Missing tags break the line instead of bridging to the next rank.
+      connectNulls: false,
+      endLabel: { show: lines.length <= 20, formatter: l.name, color: styling.textColor },
+      label: {
+        show: showLabels.value,
+        position: 'top' as const,
+        formatter: (params: any) => formatChartNumber(l.values[params.dataIndex] ?? 0),
+        color: styling.textColor,
+      },
+      itemStyle: { color: getNextColorFor(l.name) },
+      emphasis: { focus: 'series' as const },
+    }))
+
+    const axes = createAxisConfig(styling, tagList)
+    return {
+      ...getBaseOptions(config),
+      grid: { ...createGridConfig(series.length), right: 120 },
+      legend: createLegendConfig(
+        series.map((s) => ({ xAxis: s.name })),
+        styling,
+        series.length > 1
+      ),
+      tooltip: {
+        trigger: 'item',
+        ...getTooltipTheme(isDark.value),
+        formatter: (params: any) => {
+          const line = lines[params.seriesIndex]
+          const value = line?.values[params.dataIndex]
+          return (
+            `<b>${params.seriesName}</b> @ ${tagList[params.dataIndex]}<br/>` +
+            `Rank: <b>#${params.value}</b><br/>` +
+            `${chartData.value.statType}: <b>${value == null ? '-' : formatChartNumber(value)}</b>`
+          )
+        },
+      },
+      xAxis: { ...axes.xAxis, boundaryGap: false },
+      // Rank 1 sits on top; integer ticks only.
+      yAxis: {
+        ...axes.yAxis,
+        inverse: true,
+        min: 1,
+        max: maxRank,
+        interval: 1,
+        axisLabel: { ...axes.yAxis.axisLabel, formatter: '#{value}' },
+      },
+      series,
+    } as EChartsOption
+  })
+
+  return { options }
+}
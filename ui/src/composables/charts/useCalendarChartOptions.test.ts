@@ -0,0 +1,98 @@
+import { describe, it, expect, beforeAll, afterAll } from 'vitest'
+import { ref } from 'vue'
+import { baseConfig, emptyChartData, installDevicePixelRatio } from '@/test-utils'
+import type { CalendarYears } from '@/types'
+import { useCalendarChartOptions } from './useCalendarChartOptions'
+
+let restoreDpr: () => void
+beforeAll(() => {
+  restoreDpr = installDevicePixelRatio()
+})
+afterAll(() => restoreDpr())
+
+const chartData = emptyChartData({
+  title: 'contributions',
+  statType: 'commits',
+  yAxis: ['feat', 'fix'],
+  series: [
+    { xAxis: '2023-12-30', values: [1, 1], benchmarkId: '' },
+    { xAxis: '2024-01-02', values: [3, 0], benchmarkId: '' },
+    { xAxis: '2024-01-03', values: [5, 2], benchmarkId: '' },
+  ],
+})
+
+type CalendarOption = {
+  calendar: {
+    top: number
+    range: string
+    cellSize: number[]
+    dayLabel: { firstDay: number }
+  }[]
+  visualMap: { show: boolean; min: number; max: number }
+  series: { type: string; calendarIndex: number; data: [string, number][]; label: { show: boolean } }[]
+}
+
+const build = (
+  opts: {
+    weekStart?: 'sunday' | 'monday'
+    years?: CalendarYears
+    cellSize?: number
+    visualMap?: boolean
+    showLabels?: boolean
+  } = {}
+) => {
+  const cfg = baseConfig({ chartData, chartType: 'calendar', showLabels: opts.showLabels })
+  const { options } = useCalendarChartOptions({
+    ...cfg,
+    visualMap: ref(opts.visualMap ?? false),
+    weekStart: ref(opts.weekStart),
+    years: ref(opts.years),
+    cellSize: ref(opts.cellSize),
+  })
+  return options.value as unknown as CalendarOption
+}
+
+describe('useCalendarChartOptions', () => {
+  it('draws one grid and heatmap series per year in the data', () => {
+    const opt = build()
+    expect(opt.calendar.map((c) => c.range)).toEqual(['2023', '2024'])
+    expect(opt.series.map((s) => [s.type, s.calendarIndex])).toEqual([
+      ['heatmap', 0],
+      ['heatmap', 1],
+    ])
+    expect(opt.series[0]!.data).toEqual([['2023-12-30', 2]])
+    expect(opt.series[1]!.data).toEqual([
+      ['2024-01-02', 3],
+      ['2024-01-03', 7],
+    ])
+  })
+
+  it('stacks year grids by cell size', () => {
+    const opt = build({ cellSize: 10 })
+    expect(opt.calendar[0]!.cellSize).toEqual([10, 10])
+    expect(opt.calendar[1]!.top - opt.calendar[0]!.top).toBeGreaterThan(70)
+  })
+
+  it('limits grids to the --years range', () => {
+    const opt = build({ years: { from: 2024, to: 2025 } })
+    expect(opt.calendar.map((c) => c.range)).toEqual(['2024', '2025'])
+    expect(opt.series[1]!.data).toEqual([])
+  })
+
+  it('maps week start to the first day label', () => {
+    expect(build().calendar[0]!.dayLabel.firstDay).toBe(0)
+    expect(build({ weekStart: 'monday' }).calendar[0]!.dayLabel.firstDay).toBe(1)
+  })
+
+  it('always colours cells but only shows the scale with --visualmap', () => {
+    const hidden = build()
+    expect(hidden.visualMap.show).toBe(false)
+    expect([hidden.visualMap.min, hidden.visualMap.max]).toEqual([2, 7])
+    expect(build({ visualMap: true }).visualMap.show).toBe(true)
+  })
+
+  it('toggles cell labels', () => {
+    expect(build().series[0]!.label.show).toBe(false)
+    expect(build({ showLabels: true }).series[0]!.label.show).toBe(true)
+  })
+})
@@ -0,0 +1,100 @@
+import { computed } from 'vue'
+import type { EChartsOption } from 'echarts'
+import { type BaseChartConfig, getBaseOptions } from './baseChartOptions'
+import { formatChartNumber } from '@/lib/utils'
+import { resolveVisualMapColors } from '@/lib/themes'
+import { buildCalendarDays, calendarYears } from '@/lib/calendar'
+import { getChartStyling, getTooltipTheme } from './shared/chartConfig'
+import { fontSize } from './shared/common'
+
+// Square cell edge in px when --cell-size is unset; 53 weeks then fit ~900px.
+const DEFAULT_CELL_SIZE = 16
+// Space above each year grid for the month labels and the year title.
+const YEAR_GAP = 40
+const TOP = 50
+
+export function useCalendarChartOptions(config: BaseChartConfig) {
+  const { chartData, isDark, showLabels, visualMap, weekStart, years, cellSize } = config
+
+  const options = computed<EChartsOption>(() => {
+    const styling = getChartStyling(isDark.value)
+    const data = chartData.value
+    const days = buildCalendarDays(data)
+    const yearList = calendarYears(days, years?.value)
+    const size = cellSize?.value ?? DEFAULT_CELL_SIZE
+    const firstDay = weekStart?.value === 'monday' ? 1 : 0
+    const showScale = visualMap?.value ?? false
+
+    let min = Infinity
+    let max = -Infinity
+    for (const d of days) {
+      if (d.value < min) min = d.value
+      if (d.value > max) max = d.value
+    }
+    if (!Number.isFinite(min)) {
+      min = 0
+      max = 0
+    }
+    if (min === max) max = min + 1
+
+    const calendar = yearList.map((year, i) => ({
+      top: TOP + i * (size * 7 + YEAR_GAP),
+      left: 60,
+      right: 30,
+      cellSize: [size, size],
+      range: String(year),
+      orient: 'horizontal' as const,
+      splitLine: { show: true, lineStyle: { color: styling.axisColor, width: 1 } },
+      itemStyle: { color: 'transparent', borderColor: styling.axisColor, borderWidth: 0.5 },
+      yearLabel: { show: true, position: 'left' as const, color: styling.textColor, fontSize },
+      monthLabel: { color: styling.textColor, fontSize: 11 },
+      dayLabel: { firstDay, nameMap: 'en', color: styling.textColor, fontSize: 10 },
+    }))
+
+    return {
+      ...getBaseOptions(config),
+      legend: { show: false },
+      tooltip: {
+        trigger: 'item',
+        ...getTooltipTheme(isDark.value),
+        formatter: (params: any) => {
+          const [date, value] = params.data ?? []
+          return `<b>${date}</b><br/>${data.statType}: <b>${formatChartNumber(value)}</b>`
+        },
+      },
+      // The colour scale always maps values onto cells; --visualmap only
+      // decides whether its draggable handles are shown.
+      visualMap: {
+        show: showScale,
+        type: 'continuous' as const,
+        min,
+        max,
+        calculable: true,
+        orient: 'horizontal' as const,
+        left: 'center',
+        top: 0,
+        inRange: { color: resolveVisualMapColors() },
+        textStyle: { color: styling.textColor },
+      },
+      calendar,
+      // One heatmap per year grid: a calendar series draws only on its own
+      // calendarIndex, so days are split by year.
+      series: yearList.map((year, i) => ({
+        name: String(year),
+        type: 'heatmap' as const,
+        coordinateSystem: 'calendar' as const,
+        calendarIndex: i,
+        data: days.filter((d) => d.date.startsWith(`${year}-`)).map((d) => [d.date, d.value]),
+        label: {
+          show: showLabels.value,
+          formatter: (params: any) => formatChartNumber(params.data[1]),
+          color: styling.textColor,
+          fontSize: Math.max(8, Math.min(11, size / 2)),
+        },
+        emphasis: { itemStyle: { shadowBlur: 6, shadowColor: 'rgba(0,0,0,0.4)' } },
+      })),
+    } as EChartsOption
+  })
+
+  return { options }
+}
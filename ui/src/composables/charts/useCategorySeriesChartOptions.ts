@@ -151,7 +151,12 @@ export function useCategorySeriesChartOptions(config: BaseChartConfig, kind: Cat
         styling,
         1
       ),
-      tooltip: createTooltipConfig(showXBreakdown, isDark.value, seriesTotals),
+      tooltip: createTooltipConfig(
+        showXBreakdown,
+        isDark.value,
+        seriesTotals,
+        chartData.value.statUnit
+      ),
       ...createAxisConfig(styling, xAxisData, yScale, xLabel, largeX, true),
       ...(largeX ? { dataZoom: createDataZoomConfig(xAxisData, styling) } : {}),
       legend: createLegendConfig(
@@ -0,0 +1,86 @@
+import { describe, it, expect, beforeAll, afterAll } from 'vitest'
+import { ref } from 'vue'
+import { baseConfig, makeGroupedChartData, installDevicePixelRatio } from '@/test-utils'
+import type { Point3D, Sort } from '@/types'
+import { useHistogramChartOptions } from './useHistogramChartOptions'
+
+let restoreDpr: () => void
+beforeAll(() => {
+  restoreDpr = installDevicePixelRatio()
+})
+afterAll(() => restoreDpr())
+
+const points: Point3D[] = [
+  { xAxis: '[0, 10)', yAxis: 'api', zAxis: '', value: 3 },
+  { xAxis: '[10, 20]', yAxis: 'api', zAxis: '', value: 1 },
+  { xAxis: '[0, 10)', yAxis: 'web', zAxis: '', value: 2 },
+  { xAxis: '[10, 20]', yAxis: 'web', zAxis: '', value: 6 },
+]
+
+type HistogramSeries = {
+  name: string
+  type: string
+  data: number[]
+  barGap?: string
+  itemStyle: { opacity?: number }
+}
+
+const build = (opts: { cumulative?: boolean; sort?: Sort; rows?: Point3D[] } = {}) => {
+  const cfg = baseConfig({
+    chartData: makeGroupedChartData({ statType: 'latency', points: opts.rows ?? points }),
+    chartType: 'histogram',
+    sort: opts.sort,
+  })
+  const { options } = useHistogramChartOptions({
+    ...cfg,
+    cumulative: ref(opts.cumulative ?? false),
+  })
+  return options.value as {
+    xAxis: { data: string[] }
+    yAxis: { max?: number }
+    legend: { show: boolean }
+    series: HistogramSeries[]
+  }
+}
+
+describe('useHistogramChartOptions', () => {
+  it('overlays one bar series per y value on the bin axis', () => {
+    const opts = build()
+    expect(opts.xAxis.data).toEqual(['[0, 10)', '[10, 20]'])
+    expect(opts.series.map((s) => [s.name, s.type, s.data])).toEqual([
+      ['api', 'bar', [3, 1]],
+      ['web', 'bar', [2, 6]],
+    ])
+    expect(opts.series.every((s) => s.barGap === '-100%')).toBe(true)
+    expect(opts.series[0]!.itemStyle.opacity).toBeLessThan(1)
+    expect(opts.legend.show).toBe(true)
+  })
+
+  it('draws a single unnamed series opaque under the chart title', () => {
+    const opts = build({
+      rows: [
+        { xAxis: '[0, 10)', yAxis: '', zAxis: '', value: 3 },
+        { xAxis: '[10, 20]', yAxis: '', zAxis: '', value: 1 },
+      ],
+    })
+    expect(opts.series).toHaveLength(1)
+    expect(opts.series[0]!.name).toBe('revenue')
+    expect(opts.series[0]!.itemStyle.opacity).toBe(1)
+    expect(opts.legend.show).toBe(false)
+  })
+
+  it('cumulative switches to percentage lines capped at 100', () => {
+    const opts = build({ cumulative: true })
+    expect(opts.series.map((s) => [s.type, s.data])).toEqual([
+      ['line', [75, 100]],
+      ['line', [25, 100]],
+    ])
+    expect(opts.yAxis.max).toBe(100)
+  })
+
+  it('sort orders overlay series by total and leaves bins in place', () => {
+    const opts = build({ sort: { enabled: true, order: 'desc' } })
+    expect(opts.series.map((s) => s.name)).toEqual(['web', 'api'])
+    expect(opts.xAxis.data).toEqual(['[0, 10)', '[10, 20]'])
+  })
+})
@@ -0,0 +1,98 @@
+import { computed } from 'vue'
+import type { EChartsOption } from 'echarts'
+import { type BaseChartConfig, getBaseOptions } from './baseChartOptions'
+import { getNextColorFor } from '@/lib/utils'
+import { cumulativePercent, histogramSeries } from '@/lib/histogram'
+import {
+  createAxisConfig,
+  createDataZoomConfig,
+  createGridConfig,
+  createLabelConfig,
+  createLegendConfig,
+  createTooltipConfig,
+  getChartStyling,
+  isLargeXAxis,
+  makeLegendTitle,
+} from './shared/chartConfig'
+
+// Overlaid bars stay readable when every series shares a bin column.
+const OVERLAY_OPACITY = 0.55
+
+export function useHistogramChartOptions(config: BaseChartConfig) {
+  const { chartData, sort, showLabels, isDark, cumulative } = config
+
+  const options = computed<EChartsOption>(() => {
+    const styling = getChartStyling(isDark.value)
+    const { bins, series } = histogramSeries(chartData.value.points)
+    const isCumulative = cumulative?.value === true
+
+    // Bins keep their ascending order; sort only reorders the overlay series
+    // by total count, which decides which one draws on top.
+    if (sort.value.enabled) {
+      const total = (counts: number[]) => counts.reduce((sum, c) => sum + c, 0)
+      series.sort((a, b) =>
+        sort.value.order === 'asc'
+          ? total(a.counts) - total(b.counts)
+          : total(b.counts) - total(a.counts)
+      )
+    }
+
+    const overlay = series.length > 1
+    const drawn = series.map((s) => {
+      const name = s.name || chartData.value.title
+      const color = getNextColorFor(name)
+      if (isCumulative) {
+        return {
+          name,
+          type: 'line' as const,
+          step: 'end' as const,
+          symbol: 'none',
+          data: cumulativePercent(s.counts).map((v) => Math.round(v * 100) / 100),
+          label: createLabelConfig(showLabels.value, styling),
+          itemStyle: { color },
+        }
+      }
+      return {
+        name,
+        type: 'bar' as const,
+        data: s.counts,
+        // Bars of every series sit in the same column instead of side by side.
+        barGap: '-100%',
+        barCategoryGap: '4%',
+        label: createLabelConfig(showLabels.value, styling),
+        itemStyle: { color, opacity: overlay ? OVERLAY_OPACITY : 1 },
+      }
+    })
+
+    const largeX = isLargeXAxis(bins)
+    const xLabel = chartData.value.axisLabels?.x
+    const yLabel = chartData.value.axisLabels?.y
+    const showLegendTitle = overlay && !!yLabel
+    const axes = createAxisConfig(styling, bins, 'linear', xLabel, largeX)
+    if (isCumulative) {
+      axes.yAxis = {
+        ...axes.yAxis,
+        max: 100,
+        axisLabel: { ...axes.yAxis.axisLabel, formatter: '{value}%' },
+      }
+    }
+
+    return {
+      ...getBaseOptions(config),
+      ...(showLegendTitle ? { title: makeLegendTitle(yLabel!, styling) } : {}),
+      grid: createGridConfig(drawn.length, largeX),
+      tooltip: createTooltipConfig(true, isDark.value),
+      legend: createLegendConfig(
+        drawn.map((s) => ({ xAxis: s.name })),
+        styling,
+        overlay,
+        showLegendTitle ? { top: 24 } : undefined
+      ),
+      ...axes,
+      ...(largeX ? { dataZoom: createDataZoomConfig(bins, styling) } : {}),
+      series: drawn,
+    } as EChartsOption
+  })
+
+  return { options }
+}
@@ -0,0 +1,104 @@
+import { describe, it, expect, beforeAll, afterAll } from 'vitest'
+import { ref } from 'vue'
+import { baseConfig, makeGroupedChartData, installDevicePixelRatio } from '@/test-utils'
+import type { Axis, DataPoint, ParallelBrush, ScaleType, Sort } from '@/types'
+import { useParallelChartOptions } from './useParallelChartOptions'
+
+let restoreDpr: () => void
+beforeAll(() => {
+  restoreDpr = installDevicePixelRatio()
+})
+afterAll(() => restoreDpr())
+
+const stats = (ns: number, bytes: number, allocs: number) => [
+  { type: 'ns/op', value: ns },
+  { type: 'B/op', value: bytes },
+  { type: 'allocs/op', value: allocs },
+]
+
+const rows: DataPoint[] = [
+  { xAxis: 'Sort', yAxis: '1024', stats: stats(300, 4096, 2) },
+  { xAxis: 'Sort', yAxis: '4096', stats: stats(900, 16384, 0) },
+  { xAxis: 'Map', yAxis: '1024', stats: stats(500, 64, 1) },
+]
+
+type ParallelSeries = {
+  name: string
+  type: string
+  activeOpacity: number
+  inactiveOpacity: number
+  data: { name: string; value: (number | null)[] }[]
+}
+type ParallelAxis = { dim: number; name: string; type: string; nameTextStyle: { fontWeight: string } }
+
+const build = (
+  opts: {
+    scale?: ScaleType
+    axisScale?: Record<string, ScaleType>
+    brush?: ParallelBrush
+    sort?: Sort
+  } = {}
+) => {
+  const cfg = baseConfig({
+    chartData: makeGroupedChartData({ statType: 'ns/op' }),
+    chartType: 'parallel',
+    scale: opts.scale,
+    sort: opts.sort,
+  })
+  const { options } = useParallelChartOptions({
+    ...cfg,
+    chartAxes: ref<Axis[]>([{ key: 'x' }, { key: 'y' }]),
+    datasetRows: ref(rows),
+    axisScale: ref(opts.axisScale),
+    brush: ref(opts.brush),
+  })
+  return options.value as unknown as {
+    parallelAxis: ParallelAxis[]
+    parallel: { parallelAxisDefault: { areaSelectStyle: Record<string, unknown> } }
+    legend: { show: boolean }
+    series: ParallelSeries[]
+  }
+}
+
+describe('useParallelChartOptions', () => {
+  it('draws one axis per stat and one line per row, grouped by the top dimension', () => {
+    const opts = build()
+    expect(opts.parallelAxis.map((a) => [a.dim, a.name, a.type])).toEqual([
+      [0, 'ns/op', 'value'],
+      [1, 'B/op', 'value'],
+      [2, 'allocs/op', 'value'],
+    ])
+    expect(opts.parallelAxis[0]!.nameTextStyle.fontWeight).toBe('bold')
+    expect(opts.series.map((s) => [s.name, s.type, s.data.map((d) => d.name)])).toEqual([
+      ['Sort', 'parallel', ['Sort / 1024', 'Sort / 4096']],
+      ['Map', 'parallel', ['Map / 1024']],
+    ])
+    expect(opts.legend.show).toBe(true)
+  })
+
+  it('axisScale overrides the chart scale per axis and gaps non-positive log values', () => {
+    const opts = build({ axisScale: { 'b/op': 'log', 'allocs/op': 'log' } })
+    expect(opts.parallelAxis.map((a) => a.type)).toEqual(['value', 'log', 'log'])
+    expect(opts.series[0]!.data[1]!.value).toEqual([900, 16384, null])
+
+    const allLog = build({ scale: 'log', axisScale: { 'ns/op': 'linear' } })
+    expect(allLog.parallelAxis.map((a) => a.type)).toEqual(['value', 'log', 'log'])
+  })
+
+  it('brush styles the selection box and line opacities', () => {
+    const defaults = build()
+    expect(defaults.parallel.parallelAxisDefault.areaSelectStyle).toEqual({})
+    expect(defaults.series[0]!.inactiveOpacity).toBe(0.05)
+
+    const opts = build({ brush: { color: '#888', width: 2, inactiveOpacity: 0.01 } })
+    expect(opts.parallel.parallelAxisDefault.areaSelectStyle).toEqual({ color: '#888', width: 2 })
+    expect(opts.series[0]!.inactiveOpacity).toBe(0.01)
+    expect(opts.series[0]!.activeOpacity).toBe(1)
+  })
+
+  it('sort orders lines by the active stat', () => {
+    const opts = build({ sort: { enabled: true, order: 'desc' } })
+    expect(opts.series.map((s) => s.name)).toEqual(['Sort', 'Map'])
+    expect(opts.series[0]!.data.map((d) => d.value[0])).toEqual([900, 300])
+  })
+})
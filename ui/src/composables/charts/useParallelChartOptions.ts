@@ -0,0 +1,122 @@
+import { computed } from 'vue'
+import type { EChartsOption } from 'echarts'
+import { type BaseChartConfig, getBaseOptions } from './baseChartOptions'
+import { getNextColorFor } from '@/lib/utils'
+import { hierarchyLevels } from '@/lib/hierarchy'
+import { axisScaleFor, buildParallel } from '@/lib/parallel'
+import { presentAxisString } from '@/lib/swap'
+import { identityStringFromAxes } from '@/lib/transform'
+import {
+  createLegendConfig,
+  formatTooltipValue,
+  getChartStyling,
+  getTooltipTheme,
+} from './shared/chartConfig'
+import { fontSize, resolveLogScale } from './shared/common'
+
+// ECharts' own brushing defaults, restated so a partial --brush keeps them.
+const BRUSH_DEFAULTS = { activeOpacity: 1, inactiveOpacity: 0.05 }
+
+export function useParallelChartOptions(config: BaseChartConfig) {
+  const { chartData, sort, isDark, scale, axisScale, brush } = config
+
+  const options = computed<EChartsOption>(() => {
+    const styling = getChartStyling(isDark.value)
+    const rows = config.datasetRows?.value ?? []
+    // Benchmark datasets may carry no axes; fall back to the fields rows fill.
+    const identity = identityStringFromAxes(config.chartAxes?.value ?? []) || presentAxisString(rows)
+    const levels = hierarchyLevels(identity, config.arrangementTarget?.value)
+    const activeStat = chartData.value.statType
+    const { axes, lines } = buildParallel(
+      rows,
+      levels,
+      sort.value.enabled ? sort.value.order : undefined,
+      activeStat
+    )
+
+    // Each axis resolves its own scale; a log axis with no positive value
+    // falls back to linear, and non-positive values become gaps on log axes.
+    const scales = axes.map((stat, i) =>
+      resolveLogScale(
+        axisScaleFor(stat, scale?.value ?? 'linear', axisScale?.value),
+        lines.map((l) => l.values[i] ?? null)
+      )
+    )
+    const plotted = lines.map((l) => ({
+      ...l,
+      values: l.values.map((v, i) => (scales[i] === 'log' && v !== null && v <= 0 ? null : v)),
+    }))
+
+    const groups = [...new Set(plotted.map((l) => l.group))]
+    const multiGroup = groups.length > 1
+    const b = brush?.value ?? {}
+
+    const parallelAxis = axes.map((stat, i) => {
+      const active = stat.toLowerCase() === activeStat.toLowerCase()
+      return {
+        dim: i,
+        name: stat,
+        type: scales[i] === 'log' ? ('log' as const) : ('value' as const),
+        nameTextStyle: {
+          fontSize,
+          color: styling.textColor,
+          fontWeight: active ? ('bold' as const) : ('normal' as const),
+        },
+        axisLine: { lineStyle: { color: active ? styling.textColor : styling.axisColor } },
+        axisLabel: { color: styling.textColor, formatter: (v: number) => formatTooltipValue(v) },
+        splitLine: { show: false },
+      }
+    })
+
+    return {
+      ...getBaseOptions(config),
+      legend: createLegendConfig(
+        groups.map((g) => ({ xAxis: g })),
+        styling,
+        multiGroup
+      ),
+      tooltip: {
+        trigger: 'item',
+        ...getTooltipTheme(isDark.value),
+        formatter: (params: any) => {
+          const rowsHtml = axes
+            .map((stat, i) => `${stat}: <strong>${formatTooltipValue(params.value?.[i])}</strong>`)
+            .join('<br/>')
+          return `${params.marker ?? ''} <strong>${params.name || params.seriesName}</strong><br/>${rowsHtml}`
+        },
+      },
+      parallel: {
+        left: 60,
+        right: 80,
+        top: multiGroup ? 60 : 40,
+        bottom: 40,
+        parallelAxisDefault: {
+          nameLocation: 'end',
+          nameGap: 16,
+          areaSelectStyle: {
+            ...(b.color ? { color: b.color } : {}),
+            ...(b.width !== undefined ? { width: b.width } : {}),
+            ...(b.opacity !== undefined ? { opacity: b.opacity } : {}),
+          },
+        },
+      },
+      parallelAxis,
+      // One series per top-level group so the legend toggles and colors whole
+      // groups; each line keeps its full dimension path as its name.
+      series: groups.map((group) => ({
+        name: group || chartData.value.title,
+        type: 'parallel' as const,
+        smooth: false,
+        activeOpacity: b.activeOpacity ?? BRUSH_DEFAULTS.activeOpacity,
+        inactiveOpacity: b.inactiveOpacity ?? BRUSH_DEFAULTS.inactiveOpacity,
+        lineStyle: { width: 1.5, opacity: 0.7, color: getNextColorFor(group || chartData.value.title) },
+        emphasis: { lineStyle: { width: 3, opacity: 1 } },
+        data: plotted
+          .filter((l) => l.group === group)
+          .map((l) => ({ name: l.name, value: l.values })),
+      })),
+    } as EChartsOption
+  })
+
+  return { options }
+}
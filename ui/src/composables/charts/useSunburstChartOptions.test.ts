@@ -0,0 +1,74 @@
+import { describe, it, expect, beforeAll, afterAll } from 'vitest'
+import { ref } from 'vue'
+import { baseConfig, makeGroupedChartData, installDevicePixelRatio } from '@/test-utils'
+import type { Axis, DataPoint } from '@/types'
+import { useSunburstChartOptions } from './useSunburstChartOptions'
+
+let restoreDpr: () => void
+beforeAll(() => {
+  restoreDpr = installDevicePixelRatio()
+})
+afterAll(() => restoreDpr())
+
+const rows: DataPoint[] = [
+  { name: 'pkg/a', xAxis: 'Parse', yAxis: 'small', stats: [{ type: 'ns', value: 10 }] },
+  { name: 'pkg/a', xAxis: 'Encode', yAxis: 'small', stats: [{ type: 'ns', value: 30 }] },
+  { name: 'pkg/b', xAxis: 'Parse', yAxis: 'large', stats: [{ type: 'ns', value: 60 }] },
+]
+
+type SunburstSeries = {
+  type: string
+  data: { name: string; value: number; children?: unknown[] }[]
+  sort: null | string
+  levels: { label: { show: boolean; rotate: string } }[]
+}
+
+const build = (opts: { leafDepth?: number; labelLevels?: number } = {}) => {
+  const cfg = baseConfig({
+    chartData: makeGroupedChartData({ statType: 'ns' }),
+    chartType: 'sunburst',
+  })
+  const { options } = useSunburstChartOptions({
+    ...cfg,
+    arrangementTarget: ref('nxy'),
+    chartAxes: ref<Axis[]>([{ key: 'name' }, { key: 'x' }, { key: 'y' }]),
+    datasetRows: ref(rows),
+    leafDepth: ref(opts.leafDepth),
+    labelLevels: ref(opts.labelLevels),
+  })
+  return (options.value.series as SunburstSeries[])[0]!
+}
+
+describe('useSunburstChartOptions', () => {
+  it('nests name → x → y with summed values', () => {
+    const series = build()
+    expect(series.type).toBe('sunburst')
+    expect(series.data.map((n) => [n.name, n.value])).toEqual([
+      ['pkg/a', 40],
+      ['pkg/b', 60],
+    ])
+    // center + three rings; series keeps the builder's order
+    expect(series.levels).toHaveLength(4)
+    expect(series.sort).toBeNull()
+  })
+
+  it('leafDepth trims the drawn levels', () => {
+    const series = build({ leafDepth: 1 })
+    expect(series.data.every((n) => n.children === undefined)).toBe(true)
+    expect(series.levels).toHaveLength(2)
+  })
+
+  it('labelLevels switches labels off below the given depth', () => {
+    const series = build({ labelLevels: 1 })
+    expect(series.levels.map((l) => l.label.show)).toEqual([false, true, false, false])
+  })
+
+  it('labels the outermost ring tangentially and inner rings radially', () => {
+    const series = build()
+    expect(series.levels.slice(1).map((l) => l.label.rotate)).toEqual([
+      'radial',
+      'radial',
+      'tangential',
+    ])
+  })
+})
@@ -0,0 +1,55 @@
+import { computed } from 'vue'
+import type { EChartsOption } from 'echarts'
+import { type BaseChartConfig, getBaseOptions } from './baseChartOptions'
+import { getChartStyling } from './shared/chartConfig'
+import { fontSize } from './shared/common'
+import { hierarchyLabelFormatter, prepareHierarchyChart } from './shared/hierarchyChart'
+
+const sunburstSeriesDefaults = {
+  type: 'sunburst' as const,
+  center: ['50%', '52%'],
+  radius: ['12%', '90%'],
+  // Keep the builder's order (first-seen, or --sort); ECharts sorts desc by default.
+  sort: null,
+  nodeClick: 'rootToNode' as const,
+  emphasis: { focus: 'ancestor' as const },
+}
+
+export function useSunburstChartOptions(config: BaseChartConfig) {
+  const { chartData, showLabels, isDark } = config
+
+  const options = computed<EChartsOption>(() => {
+    const styling = getChartStyling(isDark.value)
+    const { data, depth, labelLevels, total, tooltip } = prepareHierarchyChart(config)
+    const formatter = hierarchyLabelFormatter(showLabels.value, total)
+
+    // levels[0] is the center (drill-up target); levels[i] is the i-th ring.
+    // Inner rings label radially, the outermost tangentially so long leaf
+    // names fit; rings past --label-levels stay unlabelled.
+    const levels = Array.from({ length: depth + 1 }, (_, i) => ({
+      itemStyle: { borderColor: styling.backgroundColor ?? '#fff', borderWidth: i > 0 ? 1 : 0 },
+      label: {
+        show: i > 0 && i <= labelLevels,
+        rotate: i === depth ? ('tangential' as const) : ('radial' as const),
+        formatter,
+        fontSize,
+      },
+    }))
+
+    return {
+      ...getBaseOptions(config),
+      legend: { show: false },
+      tooltip,
+      series: [
+        {
+          ...sunburstSeriesDefaults,
+          name: chartData.value.title,
+          data,
+          levels,
+        },
+      ],
+    } as EChartsOption
+  })
+
+  return { options }
+}
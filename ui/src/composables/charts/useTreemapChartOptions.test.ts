@@ -0,0 +1,67 @@
+import { describe, it, expect, beforeAll, afterAll } from 'vitest'
+import { ref } from 'vue'
+import { baseConfig, makeGroupedChartData, installDevicePixelRatio } from '@/test-utils'
+import type { Axis, DataPoint } from '@/types'
+import { useTreemapChartOptions } from './useTreemapChartOptions'
+
+let restoreDpr: () => void
+beforeAll(() => {
+  restoreDpr = installDevicePixelRatio()
+})
+afterAll(() => restoreDpr())
+
+const rows: DataPoint[] = [
+  { name: 'pkg/a', xAxis: 'Parse', yAxis: 'small', stats: [{ type: 'ns', value: 10 }] },
+  { name: 'pkg/a', xAxis: 'Encode', yAxis: 'small', stats: [{ type: 'ns', value: 30 }] },
+  { name: 'pkg/b', xAxis: 'Parse', yAxis: 'large', stats: [{ type: 'ns', value: 60 }] },
+]
+
+type TreemapSeries = {
+  type: string
+  data: { name: string; value: number; children?: unknown[] }[]
+  levels: { label: { show: boolean }; upperLabel: { show: boolean } }[]
+  breadcrumb: { show: boolean }
+}
+
+const build = (opts: { leafDepth?: number; labelLevels?: number } = {}) => {
+  const cfg = baseConfig({
+    chartData: makeGroupedChartData({ statType: 'ns' }),
+    chartType: 'treemap',
+  })
+  const { options } = useTreemapChartOptions({
+    ...cfg,
+    arrangementTarget: ref('nxy'),
+    chartAxes: ref<Axis[]>([{ key: 'name' }, { key: 'x' }, { key: 'y' }]),
+    datasetRows: ref(rows),
+    leafDepth: ref(opts.leafDepth),
+    labelLevels: ref(opts.labelLevels),
+  })
+  return (options.value.series as TreemapSeries[])[0]!
+}
+
+describe('useTreemapChartOptions', () => {
+  it('nests name → x → y with summed values', () => {
+    const series = build()
+    expect(series.type).toBe('treemap')
+    expect(series.data.map((n) => [n.name, n.value])).toEqual([
+      ['pkg/a', 40],
+      ['pkg/b', 60],
+    ])
+    // root + three hierarchy levels
+    expect(series.levels).toHaveLength(4)
+    expect(series.breadcrumb.show).toBe(true)
+  })
+
+  it('leafDepth trims the drawn levels', () => {
+    const series = build({ leafDepth: 1 })
+    expect(series.data.every((n) => n.children === undefined)).toBe(true)
+    expect(series.levels).toHaveLength(2)
+    expect(series.breadcrumb.show).toBe(false)
+  })
+
+  it('labelLevels switches labels off below the given depth', () => {
+    const series = build({ labelLevels: 1 })
+    expect(series.levels.map((l) => l.label.show)).toEqual([false, true, false, false])
+    expect(series.levels.map((l) => l.upperLabel.show)).toEqual([false, true, false, false])
+  })
+})
@@ -0,0 +1,61 @@
+import { computed } from 'vue'
+import type { EChartsOption } from 'echarts'
+import { type BaseChartConfig, getBaseOptions } from './baseChartOptions'
+import { getChartStyling } from './shared/chartConfig'
+import { fontSize } from './shared/common'
+import { hierarchyLabelFormatter, prepareHierarchyChart } from './shared/hierarchyChart'
+
+const treemapSeriesDefaults = {
+  type: 'treemap' as const,
+  left: '2%',
+  right: '2%',
+  top: 36,
+  bottom: 36,
+  roam: false,
+  nodeClick: 'zoomToNode' as const,
+  emphasis: { focus: 'descendant' as const },
+}
+
+export function useTreemapChartOptions(config: BaseChartConfig) {
+  const { chartData, showLabels, isDark } = config
+
+  const options = computed<EChartsOption>(() => {
+    const styling = getChartStyling(isDark.value)
+    const { data, depth, labelLevels, total, tooltip } = prepareHierarchyChart(config)
+    const formatter = hierarchyLabelFormatter(showLabels.value, total)
+
+    // levels[0] styles the virtual root; levels[i] the i-th hierarchy level.
+    // Parents show their name in a header band (upperLabel) so nested tiles
+    // stay readable; --label-levels switches labels off below that depth.
+    const levels = Array.from({ length: depth + 1 }, (_, i) => ({
+      itemStyle: {
+        borderColor: styling.axisColor,
+        borderWidth: i < depth ? 1 : 0,
+        gapWidth: i < depth ? 2 : 1,
+      },
+      upperLabel: { show: i > 0 && i < depth && i <= labelLevels, height: 20, fontSize },
+      label: { show: i > 0 && i <= labelLevels, formatter, fontSize },
+    }))
+
+    return {
+      ...getBaseOptions(config),
+      legend: { show: false },
+      tooltip,
+      series: [
+        {
+          ...treemapSeriesDefaults,
+          name: chartData.value.title,
+          data,
+          levels,
+          breadcrumb: {
+            show: depth > 1,
+            bottom: 4,
+            itemStyle: { textStyle: { color: styling.textColor } },
+          },
+        },
+      ],
+    } as EChartsOption
+  })
+
+  return { options }
+}
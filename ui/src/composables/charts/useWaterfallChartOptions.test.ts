@@ -0,0 +1,65 @@
+import { describe, it, expect, beforeAll, afterAll } from 'vitest'
+import { ref } from 'vue'
+import { baseConfig, emptyChartData, installDevicePixelRatio } from '@/test-utils'
+import type { DataPoint, Sort } from '@/types'
+import { useWaterfallChartOptions } from './useWaterfallChartOptions'
+
+let restoreDpr: () => void
+beforeAll(() => {
+  restoreDpr = installDevicePixelRatio()
+})
+afterAll(() => restoreDpr())
+
+const chartData = emptyChartData({ title: 'speed', statType: 'ns/op' })
+
+const stat = (value: number) => [{ type: 'ns/op', value }]
+const rows: DataPoint[] = [
+  { name: 'v1', xAxis: 'Sort', stats: stat(30) },
+  { name: 'v1', xAxis: 'Map', stats: stat(10) },
+  { name: 'v2', xAxis: 'Sort', stats: stat(5) },
+  { name: 'v2', xAxis: 'Map', stats: stat(12) },
+  { name: 'v3', xAxis: 'Sort', stats: stat(6) },
+  { name: 'v3', xAxis: 'Map', stats: stat(20) },
+]
+
+type WaterfallOption = {
+  xAxis: { data: string[] }
+  series: { stack: string; data: (number | { value: number })[] }[]
+}
+
+const build = (opts: { from?: string; to?: string; sort?: Sort } = {}) => {
+  const cfg = baseConfig({ chartData, chartType: 'waterfall', sort: opts.sort })
+  const { options } = useWaterfallChartOptions({
+    ...cfg,
+    datasetRows: ref(rows),
+    tags: ref(['v1', 'v2', 'v3']),
+    fromTag: ref(opts.from),
+    toTag: ref(opts.to),
+  })
+  return options.value as unknown as WaterfallOption
+}
+
+const heights = (opt: WaterfallOption) =>
+  opt.series[1]!.data.map((d) => (typeof d === 'number' ? d : d.value))
+
+describe('useWaterfallChartOptions', () => {
+  it('walks from the oldest to the latest total, one step per benchmark', () => {
+    const opt = build()
+    expect(opt.xAxis.data).toEqual(['v1', 'Sort', 'Map', 'v3'])
+    // Totals 40 → 26; Sort drops 24, Map grows 10.
+    expect(heights(opt)).toEqual([40, 24, 10, 26])
+    expect(opt.series[0]!.data).toEqual([0, 16, 16, 0])
+    expect(opt.series.every((s) => s.stack === 'waterfall')).toBe(true)
+  })
+
+  it('honours --from/--to', () => {
+    const opt = build({ from: 'v2', to: 'v3' })
+    expect(opt.xAxis.data).toEqual(['v2', 'Sort', 'Map', 'v3'])
+    expect(heights(opt)).toEqual([17, 1, 8, 26])
+  })
+
+  it('sorts steps by change', () => {
+    const opt = build({ sort: { enabled: true, order: 'desc' } })
+    expect(opt.xAxis.data).toEqual(['v1', 'Map', 'Sort', 'v3'])
+  })
+})
@@ -0,0 +1,130 @@
+import { computed } from 'vue'
+import type { EChartsOption } from 'echarts'
+import type { Direction } from '@/types'
+import { type BaseChartConfig, getBaseOptions } from './baseChartOptions'
+import { formatChartNumber } from '@/lib/utils'
+import { buildWaterfall, deltaVerdict, tagField } from '@/lib/history'
+import {
+  createAxisConfig,
+  createGridConfig,
+  getChartStyling,
+  getTooltipTheme,
+  isLargeXAxis,
+} from './shared/chartConfig'
+
+// Steps are coloured by what they mean for the stat: a rise in ns/op is a
+// regression, a rise in ops/s an improvement. Neutral stats get a plain
+// rise/fall palette; stats without a direction read as lower-is-better, as
+// they did before stats carried one.
+const STEP_COLORS = {
+  improved: '#22c55e',
+  regressed: '#ef4444',
+}
+const INCREASE_COLOR = '#f59e0b'
+const DECREASE_COLOR = '#3b82f6'
+const TOTAL_COLOR = '#64748b'
+
+function stepColor(delta: number, better: Direction = 'lower'): string {
+  const verdict = deltaVerdict(delta, better)
+  if (verdict !== 'neutral') return STEP_COLORS[verdict]
+  return delta > 0 ? INCREASE_COLOR : DECREASE_COLOR
+}
+
+export function useWaterfallChartOptions(config: BaseChartConfig) {
+  const { chartData, sort, showLabels, isDark, tags, tagAxis, fromTag, toTag } = config
+
+  const options = computed<EChartsOption>(() => {
+    const styling = getChartStyling(isDark.value)
+    const rows = config.datasetRows?.value ?? []
+    const tagList = tags?.value ?? []
+    const key = tagField(rows, tagList, tagAxis?.value)
+    const w = key
+      ? buildWaterfall(
+          rows,
+          tagList,
+          key,
+          chartData.value.statType,
+          fromTag?.value,
+          toTag?.value,
+          sort.value.enabled ? sort.value.order : undefined
+        )
+      : { from: '', to: '', start: 0, end: 0, steps: [] }
+
+    // Each step floats on a transparent base bar at the running total before
+    // it: increases grow up from it, decreases hang down to it.
+    const categories = [w.from, ...w.steps.map((s) => s.name), w.to]
+    const base: number[] = [0]
+    const bars: { value: number; itemStyle: { color: string }; delta?: number }[] = [
+      { value: w.start, itemStyle: { color: TOTAL_COLOR } },
+    ]
+    let running = w.start
+    for (const s of w.steps) {
+      const next = running + s.delta
+      base.push(Math.min(running, next))
+      bars.push({
+        value: Math.abs(s.delta),
+        delta: s.delta,
+        itemStyle: { color: stepColor(s.delta, chartData.value.statBetter) },
+      })
+      running = next
+    }
+    base.push(0)
+    bars.push({ value: w.end, itemStyle: { color: TOTAL_COLOR } })
+
+    const largeX = isLargeXAxis(categories)
+    const axes = createAxisConfig(styling, categories, 'linear', undefined, false)
+    return {
+      ...getBaseOptions(config),
+      legend: { show: false },
+      grid: createGridConfig(1, false),
+      tooltip: {
+        trigger: 'item',
+        ...getTooltipTheme(isDark.value),
+        formatter: (params: any) => {
+          if (params.seriesIndex === 0) return ''
+          const bar = bars[params.dataIndex]
+          const stat = chartData.value.statType
+          if (bar?.delta === undefined) {
+            return `<b>${params.name}</b> total<br/>${stat}: <b>${formatChartNumber(bar?.value ?? 0)}</b>`
+          }
+          const sign = bar.delta > 0 ? '+' : ''
+          return `<b>${params.name}</b><br/>${w.from} → ${w.to}: <b>${sign}${formatChartNumber(bar.delta)}</b>`
+        },
+      },
+      xAxis: {
+        ...axes.xAxis,
+        axisLabel: { ...axes.xAxis.axisLabel, interval: largeX ? 'auto' : 0 },
+      },
+      yAxis: axes.yAxis,
+      series: [
+        {
+          name: 'base',
+          type: 'bar' as const,
+          stack: 'waterfall',
+          silent: true,
+          data: base,
+          itemStyle: { color: 'transparent', borderColor: 'transparent' },
+          emphasis: { disabled: true },
+        },
+        {
+          name: chartData.value.statType,
+          type: 'bar' as const,
+          stack: 'waterfall',
+          data: bars,
+          label: {
+            show: showLabels.value,
+            position: 'top' as const,
+            color: styling.textColor,
+            formatter: (params: any) => {
+              const bar = bars[params.dataIndex]
+              if (bar?.delta === undefined) return formatChartNumber(bar?.value ?? 0)
+              return `${bar.delta > 0 ? '+' : ''}${formatChartNumber(bar.delta)}`
+            },
+          },
+        },
+      ],
+    } as EChartsOption
+  })
+
+  return { options }
+}
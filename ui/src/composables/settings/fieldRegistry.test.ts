@@ -8,6 +8,9 @@ import type {
   RadarConfig,
   SankeyConfig,
   ChordConfig,
+  HistogramConfig,
+  ParallelConfig,
+  CalendarConfig,
 } from '@/types'
 // Side-effect: top-level vi.mock for every settings control SFC fieldRegistry imports.
 import '@/test-utils/mockSettingsControls'
@@ -21,6 +24,7 @@ const BOOLEAN_KEYS = [
   'showLabels',
   'smooth',
   'horizontal',
+  'cumulative',
   'threeD',
   'threeDVisualMap',
   'visualMap',
@@ -28,9 +32,10 @@ const BOOLEAN_KEYS = [
 ] as const
 
 describe('fieldRegistry', () => {
-  it('exposes the eleven known field controls', () => {
+  it('exposes the twelve known field controls', () => {
     expect(Object.keys(fieldRegistry).sort()).toEqual(
       [
+        'cumulative',
         'horizontal',
         'threeDRotate',
         'scale',
@@ -106,19 +111,53 @@ describe('fieldRegistry', () => {
     expect(fieldRegistry['smooth']!.visible?.({ rendering3D: true })).toBe(false)
   })
 
-  it('sort, showLabels, and swap apply to all eight chart types', () => {
-    for (const key of ['sort', 'showLabels', 'swap'] as const) {
-      expect(fieldRegistry[key]!.appliesTo).toEqual([
-        'bar',
-        'line',
-        'scatter',
-        'pie',
-        'heatmap',
-        'radar',
-        'sankey',
-        'chord',
-      ])
-    }
+  it('sort skips calendar and showLabels skips parallel', () => {
+    const labelled = [
+      'bar',
+      'line',
+      'scatter',
+      'pie',
+      'heatmap',
+      'radar',
+      'sankey',
+      'chord',
+      'treemap',
+      'sunburst',
+      'histogram',
+    ]
+    expect(fieldRegistry.sort.appliesTo).toEqual([...labelled, 'parallel', 'waterfall'])
+    expect(fieldRegistry.showLabels.appliesTo).toEqual([
+      ...labelled,
+      'calendar',
+      'bump',
+      'waterfall',
+    ])
+  })
+
+  it('swap skips bump and waterfall (the tag dimension stays put)', () => {
+    expect(fieldRegistry.swap.appliesTo).not.toContain('bump')
+    expect(fieldRegistry.swap.appliesTo).not.toContain('waterfall')
+  })
+
+  it('swap skips histogram (bins always sit on x)', () => {
+    expect(fieldRegistry.swap.appliesTo).not.toContain('histogram')
+    expect(fieldRegistry.swap.appliesTo).toHaveLength(12)
+  })
+
+  it('scale applies to the cartesian charts and parallel', () => {
+    expect(fieldRegistry.scale.appliesTo).toEqual(['bar', 'line', 'scatter', 'parallel'])
+  })
+
+  it('visualMap applies to scatter and calendar', () => {
+    expect(fieldRegistry.visualMap.appliesTo).toEqual(['scatter', 'calendar'])
+  })
+
+  it('cumulative applies only to histogram', () => {
+    expect(fieldRegistry.cumulative).toMatchObject({
+      appliesTo: ['histogram'],
+      id: 'cumulative-switch',
+      separator: true,
+    })
   })
 })
 
@@ -282,6 +321,29 @@ describe('getRenderableFields', () => {
     ])
   })
 
+  it('returns sort, showLabels, and cumulative for a histogram config', () => {
+    const cfg: HistogramConfig = { type: 'histogram' }
+    expect(getRenderableFields(cfg).map((f) => f.key)).toEqual([
+      'sort',
+      'showLabels',
+      'cumulative',
+    ])
+  })
+
+  it('returns sort, scale, and swap for a parallel config (no labels)', () => {
+    const cfg: ParallelConfig = { type: 'parallel' }
+    expect(getRenderableFields(cfg).map((f) => f.key)).toEqual(['sort', 'scale', 'swap'])
+  })
+
+  it('returns showLabels, visualMap, and swap for a calendar config (no sort or scale)', () => {
+    const cfg: CalendarConfig = { type: 'calendar' }
+    expect(getRenderableFields(cfg, { dimension: '2D' }).map((f) => f.key)).toEqual([
+      'showLabels',
+      'visualMap',
+      'swap',
+    ])
+  })
+
   it('returns 3 entries for a chord config (no scale/threeDRotate; dimension is irrelevant)', () => {
     const cfg: ChordConfig = { type: 'chord' }
     expect(getRenderableFields(cfg).map((f) => f.key)).toEqual(['sort', 'showLabels', 'swap'])
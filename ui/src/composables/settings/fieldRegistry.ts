@@ -14,6 +14,7 @@ export type SettingFieldValueMap = {
   showLabels: boolean
   smooth: boolean
   horizontal: boolean
+  cumulative: boolean
   threeDRotate: boolean
   threeD: boolean
   threeDVisualMap: boolean
@@ -43,11 +44,25 @@ type FieldMeta = {
 export const fieldRegistry: Record<SettingFieldKey, FieldMeta> = {
   sort: {
     component: SortControl,
-    appliesTo: ['bar', 'line', 'scatter', 'pie', 'heatmap', 'radar', 'sankey', 'chord'],
+    appliesTo: [
+      'bar',
+      'line',
+      'scatter',
+      'pie',
+      'heatmap',
+      'radar',
+      'sankey',
+      'chord',
+      'treemap',
+      'sunburst',
+      'histogram',
+      'parallel',
+      'waterfall',
+    ],
   },
   scale: {
     component: ScaleControl,
-    appliesTo: ['bar', 'line', 'scatter'],
+    appliesTo: ['bar', 'line', 'scatter', 'parallel'],
   },
   stack: {
     component: BooleanControl,
@@ -64,7 +79,22 @@ export const fieldRegistry: Record<SettingFieldKey, FieldMeta> = {
   },
   showLabels: {
     component: BooleanControl,
-    appliesTo: ['bar', 'line', 'scatter', 'pie', 'heatmap', 'radar', 'sankey', 'chord'],
+    appliesTo: [
+      'bar',
+      'line',
+      'scatter',
+      'pie',
+      'heatmap',
+      'radar',
+      'sankey',
+      'chord',
+      'treemap',
+      'sunburst',
+      'histogram',
+      'calendar',
+      'bump',
+      'waterfall',
+    ],
     id: 'labels-switch',
     label: 'Show labels',
     description: 'Display data labels on chart elements.',
@@ -88,6 +118,14 @@ export const fieldRegistry: Record<SettingFieldKey, FieldMeta> = {
     separator: true,
     visible: (ctx) => ctx.rendering3D !== true,
   },
+  cumulative: {
+    component: BooleanControl,
+    appliesTo: ['histogram'],
+    id: 'cumulative-switch',
+    label: 'Cumulative',
+    description: 'Show the running share of each series (CDF) instead of bin counts.',
+    separator: true,
+  },
   threeD: {
     component: BooleanControl,
     appliesTo: ['bar', 'line', 'scatter'],
@@ -107,10 +145,10 @@ export const fieldRegistry: Record<SettingFieldKey, FieldMeta> = {
   },
   visualMap: {
     component: BooleanControl,
-    appliesTo: ['scatter'],
+    appliesTo: ['scatter', 'calendar'],
     id: 'visualmap-switch',
     label: 'Visual map',
-    description: 'Color scatter points by metric value using a gradient scale.',
+    description: 'Color scatter points by metric value, or show the calendar color scale.',
     visible: (ctx) => ctx.rendering3D !== true,
   },
   threeDRotate: {
@@ -123,7 +161,20 @@ export const fieldRegistry: Record<SettingFieldKey, FieldMeta> = {
   },
   swap: {
     component: SwapControl,
-    appliesTo: ['bar', 'line', 'scatter', 'pie', 'heatmap', 'radar', 'sankey', 'chord'],
+    appliesTo: [
+      'bar',
+      'line',
+      'scatter',
+      'pie',
+      'heatmap',
+      'radar',
+      'sankey',
+      'chord',
+      'treemap',
+      'sunburst',
+      'parallel',
+      'calendar',
+    ],
   },
 }
 
@@ -122,12 +122,15 @@ describe('useActiveChartShape', () => {
     expect(scale.value).toBe('log')
   })
 
-  it('reads sort/threeD/visualMap/stat/symbol/smooth/horizontal/borderRadius/background fields', async () => {
+  it('reads sort/threeD/visualMap/stat/symbol/smooth/horizontal/borderRadius/background/y2/axisRange/mark fields', async () => {
     holder.ref = ref(
       ds([
         {
           type: 'line' as ChartType,
           sort: { enabled: true, order: 'desc' },
+          y2: ['auto'],
+          min: 'p5',
+          zoom: 'slider',
           threeD: true,
           threeDVisualMap: true,
           stat: { enabled: true, math: [] },
@@ -146,6 +149,7 @@ describe('useActiveChartShape', () => {
           horizontal: true,
           borderRadius: [8],
           background: { active: true, color: 'rgba(180, 180, 180, 0.2)', shadowBlur: 10 },
+          mark: [{ type: 'hline', value: 50, label: 'SLO' }],
         },
       ])
     )
@@ -159,9 +163,12 @@ describe('useActiveChartShape', () => {
     expect(shape.symbol.value).toBe('diamond')
     expect(shape.symbolSize.value).toBe(12)
     expect(shape.smooth.value).toBe(true)
+    expect(shape.y2.value).toEqual(['auto'])
+    expect(shape.axisRange.value).toMatchObject({ min: 'p5', zoom: 'slider' })
     expect(shape.horizontal.value).toBe(false)
     expect(shape.borderRadius.value).toBeUndefined()
     expect(shape.background.value).toBeUndefined()
+    expect(shape.mark.value).toBeUndefined()
 
     holder.activeIndex = 1
     shape = useActiveChartShape()
@@ -169,6 +176,7 @@ describe('useActiveChartShape', () => {
     expect(shape.symbol.value).toBe('circle')
     expect(shape.symbolSize.value).toBe(8)
     expect(shape.smooth.value).toBe(false)
+    expect(shape.y2.value).toBeUndefined()
     expect(shape.borderRadius.value).toBeUndefined()
     expect(shape.background.value).toBeUndefined()
 
@@ -181,6 +189,7 @@ describe('useActiveChartShape', () => {
       color: 'rgba(180, 180, 180, 0.2)',
       shadowBlur: 10,
     })
+    expect(shape.mark.value).toEqual([{ type: 'hline', value: 50, label: 'SLO' }])
     expect(shape.threeD.value).toBe(false)
     expect(shape.visualMap.value).toBe(false)
     expect(shape.stat.value).toBeUndefined()
@@ -1,13 +1,26 @@
 import { computed } from 'vue'
 import type {
+  Annotation,
   BarBackground,
   BarConfig,
+  BumpConfig,
+  CalendarConfig,
+  CalendarYears,
+  HistogramConfig,
   LineConfig,
+  ParallelBrush,
+  ParallelConfig,
   ScatterConfig,
   ScaleType,
   Sort,
+  SortOrder,
   StatConfig,
+  SunburstConfig,
+  TagAxis,
+  TreemapConfig,
+  WaterfallConfig,
 } from '../types'
+import type { AxisRange } from '../lib/axisRange'
 import { arrangementHasChartZ } from '../lib/swap'
 import { canOfferValue3D } from '../lib/utils'
 import { useDataPoint } from './useDataPoint'
@@ -46,9 +59,13 @@ export function useActiveChartShape() {
     () => (activeConfig.value as { threeDRotate?: boolean } | undefined)?.threeDRotate ?? false
   )
 
-  const showLabels = computed<boolean>(() => activeConfig.value?.showLabels ?? false)
+  const showLabels = computed<boolean>(
+    () => (activeConfig.value as { showLabels?: boolean } | undefined)?.showLabels ?? false
+  )
 
-  const sort = computed<Sort | undefined>(() => activeConfig.value?.sort)
+  const sort = computed<Sort | undefined>(
+    () => (activeConfig.value as { sort?: Sort } | undefined)?.sort
+  )
 
   const threeD = computed<boolean>(
     () =>
@@ -72,7 +89,8 @@ export function useActiveChartShape() {
   )
 
   const visualMap = computed<boolean>(
-    () => (activeConfig.value as ScatterConfig | undefined)?.visualMap ?? false
+    () =>
+      (activeConfig.value as ScatterConfig | CalendarConfig | undefined)?.visualMap ?? false
   )
 
   const stat = computed<StatConfig | undefined>(() => activeConfig.value?.stat)
@@ -101,6 +119,73 @@ export function useActiveChartShape() {
     () => (activeConfig.value as BarConfig | undefined)?.background
   )
 
+  const leafDepth = computed<number | undefined>(
+    () => (activeConfig.value as TreemapConfig | SunburstConfig | undefined)?.leafDepth
+  )
+
+  const labelLevels = computed<number | undefined>(
+    () => (activeConfig.value as TreemapConfig | SunburstConfig | undefined)?.labelLevels
+  )
+
+  const valueStat = computed<string | undefined>(
+    () => (activeConfig.value as TreemapConfig | SunburstConfig | undefined)?.valueStat
+  )
+
+  const cumulative = computed<boolean>(
+    () => (activeConfig.value as HistogramConfig | undefined)?.cumulative ?? false
+  )
+
+  const axisScale = computed<Record<string, ScaleType> | undefined>(
+    () => (activeConfig.value as ParallelConfig | undefined)?.axisScale
+  )
+
+  const brush = computed<ParallelBrush | undefined>(
+    () => (activeConfig.value as ParallelConfig | undefined)?.brush
+  )
+
+  const weekStart = computed<CalendarConfig['weekStart']>(
+    () => (activeConfig.value as CalendarConfig | undefined)?.weekStart
+  )
+
+  const years = computed<CalendarYears | undefined>(
+    () => (activeConfig.value as CalendarConfig | undefined)?.years
+  )
+
+  const cellSize = computed<number | undefined>(
+    () => (activeConfig.value as CalendarConfig | undefined)?.cellSize
+  )
+
+  const tagAxis = computed<TagAxis | undefined>(
+    () => (activeConfig.value as BumpConfig | WaterfallConfig | undefined)?.tagAxis
+  )
+
+  const rank = computed<SortOrder | undefined>(
+    () => (activeConfig.value as BumpConfig | undefined)?.rank
+  )
+
+  const fromTag = computed<string | undefined>(
+    () => (activeConfig.value as WaterfallConfig | undefined)?.from
+  )
+
+  const toTag = computed<string | undefined>(
+    () => (activeConfig.value as WaterfallConfig | undefined)?.to
+  )
+
+  const y2 = computed<string[] | undefined>(
+    () => (activeConfig.value as BarConfig | LineConfig | undefined)?.y2
+  )
+
+  const axisRange = computed<AxisRange | undefined>(() => {
+    const cfg = activeConfig.value as BarConfig | LineConfig | ScatterConfig | undefined
+    if (!cfg) return undefined
+    const { min, max, logBase, zeroBaseline, inverse, zoom } = cfg
+    return { min, max, logBase, zeroBaseline, inverse, zoom }
+  })
+
+  const mark = computed<Annotation[] | undefined>(
+    () => (activeConfig.value as BarConfig | LineConfig | ScatterConfig | undefined)?.mark
+  )
+
   return {
     scale,
     stack,
@@ -119,5 +204,21 @@ export function useActiveChartShape() {
     horizontal,
     borderRadius,
     background,
+    leafDepth,
+    labelLevels,
+    valueStat,
+    cumulative,
+    axisScale,
+    brush,
+    weekStart,
+    years,
+    cellSize,
+    tagAxis,
+    rank,
+    fromTag,
+    toTag,
+    y2,
+    axisRange,
+    mark,
   }
 }
@@ -1,6 +1,14 @@
 import { describe, it, expect, beforeAll, afterAll } from 'vitest'
 import { ref } from 'vue'
-import type { BarBackground, ChartData, ChartType, ScaleType, Axis } from '@/types'
+import type {
+  Annotation,
+  BarBackground,
+  ChartData,
+  ChartType,
+  DataPoint,
+  ScaleType,
+  Axis,
+} from '@/types'
 import {
   baseConfig,
   makeGroupedChartData,
@@ -11,6 +19,7 @@ import {
   installDevicePixelRatio,
 } from '@/test-utils'
 import { useChartOptions } from './useChartOptions'
+import type { AxisRange } from '../lib/axisRange'
 
 let restoreDpr: () => void
 beforeAll(() => {
@@ -26,6 +35,11 @@ function dispatch(
     arrangementTarget?: string
     chartAxes?: Axis[]
     background?: BarBackground
+    rows?: DataPoint[]
+    tags?: string[]
+    marks?: Annotation[]
+    y2?: string[]
+    axisRange?: AxisRange
   } = {}
 ) {
   // baseConfig is the shared shape; useChartOptions takes loose refs in chart-card order.
@@ -55,7 +69,25 @@ function dispatch(
     cfg.smooth ?? ref(false),
     cfg.horizontal ?? ref(false),
     cfg.borderRadius ?? ref(undefined),
-    cfg.background ?? ref(opts.background)
+    cfg.background ?? ref(opts.background),
+    ref(opts.rows),
+    ref(undefined),
+    ref(undefined),
+    ref(undefined),
+    ref(false),
+    ref(undefined),
+    ref(undefined),
+    ref(undefined),
+    ref(undefined),
+    ref(undefined),
+    ref(opts.tags),
+    ref(undefined),
+    ref(undefined),
+    ref(undefined),
+    ref(undefined),
+    ref(opts.marks),
+    ref(opts.y2),
+    ref(opts.axisRange)
   )
 }
 
@@ -132,6 +164,30 @@ describe('useChartOptions dispatch', () => {
         }),
       expected: 'chord',
     },
+    {
+      chartType: 'treemap' as const,
+      threeD: false,
+      data: () => makeGroupedChartData(),
+      expected: 'treemap',
+    },
+    {
+      chartType: 'sunburst' as const,
+      threeD: false,
+      data: () => makeGroupedChartData(),
+      expected: 'sunburst',
+    },
+    {
+      chartType: 'histogram' as const,
+      threeD: false,
+      data: () =>
+        makeGroupedChartData({
+          points: [
+            { xAxis: '[0, 10)', yAxis: 'api', zAxis: '', value: 3 },
+            { xAxis: '[10, 20]', yAxis: 'api', zAxis: '', value: 1 },
+          ],
+        }),
+      expected: 'bar',
+    },
     { chartType: 'bar' as const, threeD: true, data: grouped3DData, expected: 'bar3D' },
     { chartType: 'line' as const, threeD: true, data: grouped3DData, expected: 'line3D' },
     {
@@ -163,11 +219,80 @@ describe('useChartOptions dispatch', () => {
     expect(firstSeriesType(options.value)).toBe('chord')
   })
 
+  it('treemap and sunburst stay 2D even when chart data is 3D-shaped', () => {
+    for (const t of ['treemap', 'sunburst'] as const) {
+      const { options } = dispatch(t, grouped3DData(), { threeD: true })
+      expect(firstSeriesType(options.value)).toBe(t)
+    }
+  })
+
   it('default branch falls back to bar options for unknown chart types', () => {
     const { options } = dispatch('unknown' as ChartType, makeGroupedChartData(), { threeD: false })
     expect(firstSeriesType(options.value)).toBe('bar')
   })
 
+  it('histogram stays 2D even when chart data is 3D-shaped', () => {
+    const { options } = dispatch('histogram', grouped3DData(), { threeD: true })
+    expect(firstSeriesType(options.value)).toBe('bar')
+  })
+
+  it('parallel stays 2D and draws one line per dataset row', () => {
+    const rows: DataPoint[] = [
+      { xAxis: 'West', stats: [{ type: 'revenue', value: 5 }, { type: 'B/op', value: 64 }] },
+      { xAxis: 'East', stats: [{ type: 'revenue', value: 9 }, { type: 'B/op', value: 32 }] },
+    ]
+    const { options } = dispatch('parallel', grouped3DData(), { threeD: true, rows })
+    expect(firstSeriesType(options.value)).toBe('parallel')
+    expect((options.value as { parallelAxis: unknown[] }).parallelAxis).toHaveLength(2)
+  })
+
+  it('calendar stays 2D and draws one heatmap per year on calendar grids', () => {
+    const data = makeGroupedChartData({
+      series: [
+        { xAxis: '2024-01-01', values: [1, 2], benchmarkId: '' },
+        { xAxis: '2024-01-02', values: [3, 4], benchmarkId: '' },
+      ],
+    })
+    const { options } = dispatch('calendar', data, { threeD: true })
+    expect(firstSeriesType(options.value)).toBe('heatmap')
+    expect((options.value as { calendar: { range: string }[] }).calendar).toEqual([
+      expect.objectContaining({ range: '2024' }),
+    ])
+  })
+
+  it('bump stays 2D and ranks each benchmark per tag', () => {
+    const rows: DataPoint[] = [
+      { name: 'v1', xAxis: 'Sort', stats: [{ type: 'sum', value: 9 }] },
+      { name: 'v1', xAxis: 'Map', stats: [{ type: 'sum', value: 3 }] },
+      { name: 'v2', xAxis: 'Sort', stats: [{ type: 'sum', value: 1 }] },
+      { name: 'v2', xAxis: 'Map', stats: [{ type: 'sum', value: 3 }] },
+    ]
+    const { options } = dispatch('bump', grouped3DData(), {
+      threeD: true,
+      rows,
+      tags: ['v1', 'v2'],
+    })
+    expect(firstSeriesType(options.value)).toBe('line')
+    expect((options.value.series as { data: number[] }[]).map((s) => s.data)).toEqual([
+      [2, 1],
+      [1, 2],
+    ])
+  })
+
+  it('waterfall stays 2D and steps between the first and last tag', () => {
+    const rows: DataPoint[] = [
+      { name: 'v1', xAxis: 'Sort', stats: [{ type: 'sum', value: 9 }] },
+      { name: 'v2', xAxis: 'Sort', stats: [{ type: 'sum', value: 4 }] },
+    ]
+    const { options } = dispatch('waterfall', grouped3DData(), {
+      threeD: true,
+      rows,
+      tags: ['v1', 'v2'],
+    })
+    expect(firstSeriesType(options.value)).toBe('bar')
+    expect((options.value.xAxis as { data: string[] }).data).toEqual(['v1', 'Sort', 'v2'])
+  })
+
   it('default branch falls back to bar3D when use3D is true', () => {
     const { options } = dispatch('unknown' as ChartType, grouped3DData(), { threeD: true })
     expect(firstSeriesType(options.value)).toBe('bar3D')
@@ -214,4 +339,53 @@ describe('useChartOptions dispatch', () => {
     expect(series.every((s) => s.showBackground === undefined)).toBe(true)
     expect(series.every((s) => s.backgroundStyle === undefined)).toBe(true)
   })
+
+  it('overlays reference marks on 2D bar/line/scatter, filtered by stat', () => {
+    const marks: Annotation[] = [
+      { type: 'hline', value: 5, label: 'SLO' },
+      { type: 'band', from: 1, to: 2, stat: 'no-such-stat' },
+    ]
+    for (const chartType of ['bar', 'line', 'scatter'] as const) {
+      const { options } = dispatch(chartType, makeGroupedChartData(), { marks })
+      const [first] = options.value.series as {
+        markLine?: { data: unknown[] }
+        markArea?: unknown
+      }[]
+      expect(first.markLine?.data).toHaveLength(1)
+      expect(first.markArea).toBeUndefined()
+    }
+  })
+
+  it('ignores reference marks on 3D and non-cartesian charts', () => {
+    const marks: Annotation[] = [{ type: 'hline', value: 5 }]
+    const bar3D = dispatch('bar', grouped3DData(), { threeD: true, marks })
+    const pie = dispatch('pie', makePieChartData(), { marks })
+    for (const { options } of [bar3D, pie]) {
+      const series = options.value.series as { markLine?: unknown }[]
+      expect(series.every((s) => s.markLine === undefined)).toBe(true)
+    }
+  })
+
+  it('splits 2D bar/line series across two value axes with y2', () => {
+    for (const chartType of ['bar', 'line'] as const) {
+      const { options } = dispatch(chartType, makeGroupedChartData(), { y2: ['Software'] })
+      expect(options.value.yAxis).toHaveLength(2)
+      const series = options.value.series as { name: string; yAxisIndex?: number }[]
+      expect(series.find((s) => s.name === 'Software')?.yAxisIndex).toBe(1)
+      expect(series.find((s) => s.name === 'Hardware')?.yAxisIndex).toBeUndefined()
+    }
+    const scatter = dispatch('scatter', makeGroupedChartData(), { y2: ['Software'] })
+    expect(Array.isArray(scatter.options.value.yAxis)).toBe(false)
+  })
+
+  it('applies axis ranges and zoom to 2D bar/line/scatter only', () => {
+    const axisRange: AxisRange = { min: 1, max: 'p100', inverse: true, zoom: 'inside' }
+    for (const chartType of ['bar', 'line', 'scatter'] as const) {
+      const { options } = dispatch(chartType, makeGroupedChartData(), { axisRange })
+      expect(options.value.yAxis).toMatchObject({ min: 1, inverse: true })
+      expect(options.value.dataZoom).toEqual([expect.objectContaining({ type: 'inside' })])
+    }
+    const pie = dispatch('pie', makePieChartData(), { axisRange })
+    expect(pie.options.value.dataZoom).toBeUndefined()
+  })
 })
@@ -1,5 +1,19 @@
 import { computed, type Ref } from 'vue'
-import type { Axis, BarBackground, ChartData, Sort, ChartType, ScaleType } from '../types'
+import type {
+  Annotation,
+  Axis,
+  BarBackground,
+  CalendarConfig,
+  CalendarYears,
+  ChartData,
+  DataPoint,
+  ParallelBrush,
+  Sort,
+  SortOrder,
+  ChartType,
+  ScaleType,
+  TagAxis,
+} from '../types'
 import type { EChartsOption } from 'echarts'
 import { useBarChartOptions } from './charts/useBarChartOptions'
 import { useLineChartOptions } from './charts/useLineChartOptions'
@@ -8,12 +22,26 @@ import { useHeatmapChartOptions } from './charts/useHeatmapChartOptions'
 import { useRadarChartOptions } from './charts/useRadarChartOptions'
 import { useSankeyChartOptions } from './charts/useSankeyChartOptions'
 import { useChordChartOptions } from './charts/useChordChartOptions'
+import { useTreemapChartOptions } from './charts/useTreemapChartOptions'
+import { useSunburstChartOptions } from './charts/useSunburstChartOptions'
+import { useHistogramChartOptions } from './charts/useHistogramChartOptions'
+import { useParallelChartOptions } from './charts/useParallelChartOptions'
+import { useCalendarChartOptions } from './charts/useCalendarChartOptions'
+import { useBumpChartOptions } from './charts/useBumpChartOptions'
+import { useWaterfallChartOptions } from './charts/useWaterfallChartOptions'
 import { useBar3DChartOptions } from './charts/useBar3DChartOptions'
 import { useLine3DChartOptions } from './charts/useLine3DChartOptions'
 import { useScatterChartOptions } from './charts/useScatterChartOptions'
 import { useScatter3DChartOptions } from './charts/useScatter3DChartOptions'
 import type { BaseChartConfig } from './charts/baseChartOptions'
 import { is3D } from '../lib/utils'
+import { applyMarks, marksFor } from '../lib/marks'
+import { applySecondaryAxis } from '../lib/dualAxis'
+import { applyAxisRange, type AxisRange } from '../lib/axisRange'
+import { getChartStyling } from './charts/shared/chartConfig'
+
+const MARKED_CHARTS: ChartType[] = ['bar', 'line', 'scatter']
+const DUAL_AXIS_CHARTS: ChartType[] = ['bar', 'line']
 
 export function useChartOptions(
   chartData: Ref<ChartData>,
@@ -35,7 +63,25 @@ export function useChartOptions(
   smooth: Ref<boolean>,
   horizontal: Ref<boolean>,
   borderRadius: Ref<number[] | undefined>,
-  background: Ref<BarBackground | undefined>
+  background: Ref<BarBackground | undefined>,
+  datasetRows?: Ref<DataPoint[] | undefined>,
+  leafDepth?: Ref<number | undefined>,
+  labelLevels?: Ref<number | undefined>,
+  valueStat?: Ref<string | undefined>,
+  cumulative?: Ref<boolean>,
+  axisScale?: Ref<Record<string, ScaleType> | undefined>,
+  brush?: Ref<ParallelBrush | undefined>,
+  weekStart?: Ref<CalendarConfig['weekStart']>,
+  years?: Ref<CalendarYears | undefined>,
+  cellSize?: Ref<number | undefined>,
+  tags?: Ref<string[] | undefined>,
+  tagAxis?: Ref<TagAxis | undefined>,
+  rank?: Ref<SortOrder | undefined>,
+  fromTag?: Ref<string | undefined>,
+  toTag?: Ref<string | undefined>,
+  marks?: Ref<Annotation[] | undefined>,
+  y2?: Ref<string[] | undefined>,
+  axisRange?: Ref<AxisRange | undefined>
 ) {
   const config: BaseChartConfig = {
     chartData,
@@ -58,6 +104,21 @@ export function useChartOptions(
     arrangementTarget,
     chartAxes,
     chartType,
+    datasetRows,
+    leafDepth,
+    labelLevels,
+    valueStat,
+    cumulative,
+    axisScale,
+    brush,
+    weekStart,
+    years,
+    cellSize,
+    tags,
+    tagAxis,
+    rank,
+    fromTag,
+    toTag,
   }
 
   const barOptions = useBarChartOptions(config)
@@ -67,6 +128,13 @@ export function useChartOptions(
   const radarOptions = useRadarChartOptions(config)
   const sankeyOptions = useSankeyChartOptions(config)
   const chordOptions = useChordChartOptions(config)
+  const treemapOptions = useTreemapChartOptions(config)
+  const sunburstOptions = useSunburstChartOptions(config)
+  const histogramOptions = useHistogramChartOptions(config)
+  const parallelOptions = useParallelChartOptions(config)
+  const calendarOptions = useCalendarChartOptions(config)
+  const bumpOptions = useBumpChartOptions(config)
+  const waterfallOptions = useWaterfallChartOptions(config)
   const bar3DOptions = useBar3DChartOptions(config)
   const line3DOptions = useLine3DChartOptions(config)
   const scatterOptions = useScatterChartOptions(config)
@@ -74,7 +142,7 @@ export function useChartOptions(
 
   const options = computed<EChartsOption>(() => {
     // When x, y AND z are all present, bar/line render as 3D charts.
-    // Pie/heatmap/radar/sankey/chord have no 3D equivalent — always their 2D layout.
+    // Pie/heatmap/radar/sankey/chord/treemap/sunburst/histogram/parallel/calendar/bump/waterfall have no 3D equivalent — always their 2D layout.
     const use3D = is3D(
       chartData,
       threeD.value,
@@ -83,6 +151,21 @@ export function useChartOptions(
       chartType.value
     )
 
+    if (use3D) return chartOptions(use3D)
+    // Dual value axes (bar/line), axis ranges and reference marks
+    // (bar/line/scatter) are 2D-only post-passes (Go: Y2Flag, MinFlag…ZoomFlag
+    // and MarkFlag rules). Axes split first so percentile bounds resolve per
+    // axis and marks land on the primary one.
+    const split = DUAL_AXIS_CHARTS.includes(chartType.value)
+      ? applySecondaryAxis(chartOptions(use3D), y2?.value)
+      : chartOptions(use3D)
+    if (!MARKED_CHARTS.includes(chartType.value)) return split
+    const { textColor } = getChartStyling(isDark.value)
+    const option = applyAxisRange(split, axisRange?.value ?? {}, textColor)
+    return applyMarks(option, marksFor(marks?.value, chartData.value.statType), textColor)
+  })
+
+  function chartOptions(use3D: boolean): EChartsOption {
     switch (chartType.value) {
       case 'bar':
         return use3D ? bar3DOptions.options.value : barOptions.options.value
@@ -98,12 +181,26 @@ export function useChartOptions(
         return sankeyOptions.options.value
       case 'chord':
         return chordOptions.options.value
+      case 'treemap':
+        return treemapOptions.options.value
+      case 'sunburst':
+        return sunburstOptions.options.value
+      case 'histogram':
+        return histogramOptions.options.value
+      case 'parallel':
+        return parallelOptions.options.value
+      case 'calendar':
+        return calendarOptions.options.value
+      case 'bump':
+        return bumpOptions.options.value
+      case 'waterfall':
+        return waterfallOptions.options.value
       case 'scatter':
         return use3D ? scatter3DOptions.options.value : scatterOptions.options.value
       default:
         return use3D ? bar3DOptions.options.value : barOptions.options.value
     }
-  })
+  }
 
   return { options }
 }
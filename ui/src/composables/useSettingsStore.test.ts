@@ -219,6 +219,39 @@ describe('useSettingsStore', () => {
     expect((activeConfig.value as { smooth?: boolean } | undefined)?.smooth).toBeUndefined()
   })
 
+  it('setCumulative writes only to histogram configs', async () => {
+    holder.ref = ref(
+      ds([
+        { type: 'histogram', sort: { enabled: false, order: 'asc' } },
+        { type: 'bar', sort: { enabled: false, order: 'asc' } },
+      ])
+    )
+    const { useSettingsStore } = await import('./useSettingsStore')
+    const { activeConfig, setActiveChartIndex, setCumulative } = useSettingsStore()
+
+    setCumulative(true)
+    expect((activeConfig.value as { cumulative?: boolean } | undefined)?.cumulative).toBe(true)
+
+    setActiveChartIndex(1)
+    setCumulative(true)
+    expect((activeConfig.value as { cumulative?: boolean } | undefined)?.cumulative).toBeUndefined()
+  })
+
+  it('setVisualMap writes to scatter and calendar configs only', async () => {
+    holder.ref = ref(
+      ds([{ type: 'calendar' }, { type: 'bar', sort: { enabled: false, order: 'asc' } }])
+    )
+    const { useSettingsStore } = await import('./useSettingsStore')
+    const { activeConfig, setActiveChartIndex, setVisualMap } = useSettingsStore()
+
+    setVisualMap(true)
+    expect((activeConfig.value as { visualMap?: boolean } | undefined)?.visualMap).toBe(true)
+
+    setActiveChartIndex(1)
+    setVisualMap(true)
+    expect((activeConfig.value as { visualMap?: boolean } | undefined)?.visualMap).toBeUndefined()
+  })
+
   it('setStack writes even when the field is absent on the config', async () => {
     holder.ref = ref(
       ds([
@@ -137,12 +137,14 @@ export function useSettingsStore() {
   const setSmooth = (smooth: boolean) => patchActive({ smooth }, (cfg) => cfg.type === 'line')
   const setHorizontal = (horizontal: boolean) =>
     patchActive({ horizontal }, (cfg) => cfg.type === 'bar')
+  const setCumulative = (cumulative: boolean) =>
+    patchActive({ cumulative }, (cfg) => cfg.type === 'histogram')
   const setThreeDRotate = (rotate: boolean) => patchActive({ threeDRotate: rotate })
   const setSwap = (swap: string | undefined) => patchActive({ swap })
   const setThreeD = (enabled: boolean) => patchActive({ threeD: enabled })
   const setThreeDVisualMap = (enabled: boolean) => patchActive({ threeDVisualMap: enabled })
   const setVisualMap = (enabled: boolean) =>
-    patchActive({ visualMap: enabled }, (cfg) => cfg.type === 'scatter')
+    patchActive({ visualMap: enabled }, (cfg) => cfg.type === 'scatter' || cfg.type === 'calendar')
 
   return {
     activeChartIndex,
@@ -158,6 +160,7 @@ export function useSettingsStore() {
     setShowLabels,
     setSmooth,
     setHorizontal,
+    setCumulative,
     setThreeDRotate,
     setSwap,
     setThreeD,
@@ -41,6 +41,16 @@ describe('useSortedHistory', () => {
     expect(hasHistory.value).toBe(true)
   })
 
+  it('keeps merge order, newest first, when entries carry commits', () => {
+    const history = ref([
+      { ...entry('2024-03-01T00:00:00Z', 'parent'), commit: { sha: 'aaa' } },
+      { ...entry('2024-01-01T00:00:00Z', 'child'), commit: { sha: 'bbb' } },
+    ])
+    const { sortedHistory } = useSortedHistory(history)
+    expect(sortedHistory.value.map((e) => e.tag)).toEqual(['child', 'parent'])
+    expect(history.value[0].tag).toBe('parent')
+  })
+
   it('hasHistory is false when filter removes everything', () => {
     const history = ref([entry('2024-01-01T00:00:00Z', 'x')])
     const { sortedHistory, hasHistory } = useSortedHistory(history, () => false)
@@ -9,6 +9,8 @@ export function useSortedHistory(
   const sortedHistory = computed(() => {
     if (!history.value?.length) return []
     const entries = filterFn ? history.value.filter(filterFn) : [...history.value]
+    // Merge already orders commit-tagged history by ancestry, which timestamps can't reproduce.
+    if (entries.some((e) => e.commit)) return entries.reverse()
     return entries.sort((a, b) => new Date(b.timestamp).getTime() - new Date(a.timestamp).getTime())
   })
 
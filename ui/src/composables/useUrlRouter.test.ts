@@ -1,6 +1,6 @@
 import { describe, it, expect, beforeEach, afterEach, vi } from 'vitest'
 import { nextTick, ref, type Ref } from 'vue'
-import type { Dataset, BarConfig, LineConfig } from '../types'
+import type { Dataset, BarConfig, CalendarConfig, LineConfig } from '../types'
 import { ds } from '@/test-utils'
 
 const holder = vi.hoisted(() => ({
@@ -538,6 +538,21 @@ describe('useUrlRouter', () => {
     expect(replaceState).toHaveBeenCalledWith(null, '', '/?scatter.vm=true')
   })
 
+  it('round-trips the calendar visualMap toggle', async () => {
+    holder.datasets = ref([ds([{ type: 'calendar', showLabels: true }])])
+    mockWindow('?calendar.vm=true')
+    const { useUrlRouter } = await import('./useUrlRouter')
+    const router = useUrlRouter()
+    await router.initFromUrl()
+
+    const calendar = holder.datasets.value[0]!.settings![0] as CalendarConfig
+    expect(calendar.visualMap).toBe(true)
+
+    const replaceState = mockWindow('')
+    router.syncUrlToState()
+    expect(replaceState).toHaveBeenCalledWith(null, '', '/?calendar.l=true&calendar.vm=true')
+  })
+
   it('ignores config updates when settings or chart type are missing', async () => {
     // settings omitted entirely → applyConfigUpdate early-returns on !settings.
     // Avoid legacy s/l/sc (those read availableTypes via settings.map).
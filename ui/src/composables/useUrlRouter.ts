@@ -7,6 +7,8 @@ import type {
   BarConfig,
   LineConfig,
   ScatterConfig,
+  CalendarConfig,
+  Sort,
   Dataset,
 } from '../types'
 import { ALL_CHART_TYPES, SORT_ORDERS, SCALE_TYPES } from '../types'
@@ -74,8 +76,14 @@ const applyConfigUpdate = (type: ChartType, update: ConfigUpdate): boolean => {
   const cfg = settings.find((s) => s.type === type)
   if (!cfg) return false
 
-  if (update.sort) cfg.sort = update.sort
-  if (update.showLabels !== undefined) cfg.showLabels = update.showLabels
+  // Not every chart carries sort/labels (parallel has no labels, calendar no
+  // sort); the URL only ever names fields the chart's panel offers.
+  const common = cfg as { sort?: Sort; showLabels?: boolean }
+  if (update.sort) common.sort = update.sort
+  if (update.showLabels !== undefined) common.showLabels = update.showLabels
+  if (cfg.type === 'calendar' && update.visualMap !== undefined) {
+    ;(cfg as CalendarConfig).visualMap = update.visualMap
+  }
   if (cfg.type === 'bar' || cfg.type === 'line' || cfg.type === 'scatter') {
     const cartesian = cfg as BarConfig | LineConfig | ScatterConfig
     if (update.scale) cartesian.scale = update.scale
@@ -210,7 +218,7 @@ export function useUrlRouter() {
       if (d3rt === 'true') update.threeDRotate = true
       if (d3vm === 'true') update.threeDVisualMap = true
       else if (d3vm === 'false') update.threeDVisualMap = false
-      if (ct === 'scatter') {
+      if (ct === 'scatter' || ct === 'calendar') {
         if (vm === 'true') update.visualMap = true
         else if (vm === 'false') update.visualMap = false
       }
@@ -269,9 +277,14 @@ export function useUrlRouter() {
     // Per-chart settings
     for (const cfg of settings) {
       const ct = cfg.type
-      if (cfg.sort?.enabled) params[`${ct}.so`] = cfg.sort.order
-      if (cfg.showLabels === true) params[`${ct}.l`] = 'true'
-      else if (cfg.showLabels === false) params[`${ct}.l`] = 'false'
+      const common = cfg as { sort?: Sort; showLabels?: boolean }
+      if (common.sort?.enabled) params[`${ct}.so`] = common.sort.order
+      if (common.showLabels === true) params[`${ct}.l`] = 'true'
+      else if (common.showLabels === false) params[`${ct}.l`] = 'false'
+      if (cfg.type === 'calendar') {
+        if (cfg.visualMap === true) params[`${ct}.vm`] = 'true'
+        else if (cfg.visualMap === false) params[`${ct}.vm`] = 'false'
+      }
       if (cfg.type === 'bar' || cfg.type === 'line' || cfg.type === 'scatter') {
         const cartesian = cfg as BarConfig | LineConfig | ScatterConfig
         if (cartesian.scale && cartesian.scale !== 'linear') params[`${ct}.sc`] = cartesian.scale
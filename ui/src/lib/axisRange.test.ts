@@ -0,0 +1,120 @@
+import { describe, it, expect } from 'vitest'
+import type { EChartsOption } from 'echarts'
+import { applyAxisRange, parseAxisBound, percentile } from './axisRange'
+
+const vertical = (extra: Record<string, unknown> = {}): EChartsOption => ({
+  grid: { left: '3%', right: '3%', bottom: 28, containLabel: true },
+  xAxis: { type: 'category', data: ['a', 'b', 'c', 'd', 'e'] },
+  yAxis: { type: 'value', min: null, max: null },
+  series: [{ type: 'bar', name: 's', data: [10, 20, 30, 40, 50] }],
+  ...extra,
+})
+
+describe('parseAxisBound', () => {
+  it('reads numbers and percentiles', () => {
+    expect(parseAxisBound(-1.5)).toEqual({ value: -1.5, percentile: false })
+    expect(parseAxisBound('P99.9')).toEqual({ value: 99.9, percentile: true })
+    expect(parseAxisBound('p101')).toBeNull()
+    expect(parseAxisBound('tall')).toBeNull()
+  })
+})
+
+describe('percentile', () => {
+  it('interpolates between ranks', () => {
+    expect(percentile([50, 10, 30, 20, 40], 50)).toBe(30)
+    expect(percentile([10, 20], 25)).toBe(12.5)
+    expect(percentile([], 50)).toBeNaN()
+  })
+})
+
+describe('applyAxisRange', () => {
+  it('returns the option untouched when nothing is set', () => {
+    const o = vertical()
+    expect(applyAxisRange(o, {}, '#000')).toBe(o)
+  })
+
+  it('resolves absolute and percentile bounds on the value axis', () => {
+    const o = vertical()
+    const out = applyAxisRange(o, { min: 5, max: 'p75', inverse: true }, '#000')
+    expect(out.yAxis).toMatchObject({ min: 5, max: 40, inverse: true })
+    // Input option is not mutated.
+    expect((o.yAxis as Record<string, unknown>).min).toBeNull()
+  })
+
+  it('resolves percentiles per value axis and flips for horizontal bars', () => {
+    const o: EChartsOption = {
+      yAxis: { type: 'category', data: ['a', 'b'] },
+      xAxis: [{ type: 'value' }, { type: 'value', position: 'top' }],
+      series: [
+        { type: 'bar', data: [1, 3] },
+        { type: 'bar', data: [{ value: 100 }, { value: 300 }], xAxisIndex: 1 },
+      ],
+    }
+    const axes = applyAxisRange(o, { max: 'p100' }, '#000').xAxis as Record<string, unknown>[]
+    expect(axes.map((a) => a.max)).toEqual([3, 300])
+  })
+
+  it('reads the value dimension of [x, y] tuples', () => {
+    const o: EChartsOption = {
+      xAxis: { type: 'value' },
+      yAxis: { type: 'value' },
+      series: [
+        {
+          type: 'scatter',
+          data: [
+            [1, 7],
+            [2, 9],
+          ],
+        },
+      ],
+    }
+    expect(applyAxisRange(o, { min: 'p0' }, '#000').yAxis).toMatchObject({ min: 7 })
+  })
+
+  it('sets the log base on log axes only and lets zero baseline drop scale', () => {
+    const log = vertical({ yAxis: { type: 'log', min: 'dataMin' } })
+    expect(applyAxisRange(log, { logBase: 2 }, '#000').yAxis).toMatchObject({ logBase: 2 })
+    expect(applyAxisRange(vertical(), { logBase: 2 }, '#000').yAxis).not.toHaveProperty('logBase')
+
+    const fitted = vertical({ yAxis: { type: 'value', scale: true, min: 3 } })
+    const y = applyAxisRange(fitted, { zeroBaseline: true }, '#000').yAxis
+    expect(y).toMatchObject({ scale: false })
+    expect(y).not.toHaveProperty('min')
+  })
+
+  it('adds a full-range slider and makes room for it', () => {
+    const out = applyAxisRange(vertical(), { zoom: 'slider' }, '#eee')
+    expect(out.dataZoom).toEqual([
+      expect.objectContaining({ type: 'slider', xAxisIndex: 0, start: 0, end: 100, bottom: 8 }),
+    ])
+    expect(out.grid).toMatchObject({ bottom: 68 })
+  })
+
+  it('keeps the window and slider placement of a chart that already zooms', () => {
+    const o = vertical({
+      grid: { left: 55, bottom: 100, containLabel: false },
+      dataZoom: [
+        { type: 'inside', xAxisIndex: 0, start: 0, end: 20 },
+        { type: 'slider', xAxisIndex: 0, start: 0, end: 20, bottom: 34, height: 28 },
+      ],
+    })
+    const out = applyAxisRange(o, { zoom: 'both' }, '#eee')
+    expect(out.dataZoom).toEqual([
+      expect.objectContaining({ type: 'inside', end: 20 }),
+      expect.objectContaining({ type: 'slider', end: 20, bottom: 34 }),
+    ])
+    expect(out.grid).toBe(o.grid)
+  })
+
+  it('zooms the y axis of horizontal bars', () => {
+    const o: EChartsOption = {
+      grid: { right: '3%', containLabel: true },
+      xAxis: { type: 'value' },
+      yAxis: { type: 'category', data: ['a'] },
+      series: [{ type: 'bar', data: [1] }],
+    }
+    const out = applyAxisRange(o, { zoom: 'both' }, '#000')
+    expect((out.dataZoom as Record<string, unknown>[]).map((z) => z.yAxisIndex)).toEqual([0, 0])
+    expect(out.grid).toMatchObject({ right: 56 })
+  })
+})
@@ -0,0 +1,149 @@
+import type { EChartsOption } from 'echarts'
+import type { AxisBound, ZoomKind } from '@/types'
+
+export interface AxisRange {
+  min?: AxisBound
+  max?: AxisBound
+  logBase?: number
+  zeroBaseline?: boolean
+  inverse?: boolean
+  zoom?: ZoomKind
+}
+
+// Mirrors Go charts.ParseAxisBound: a number is absolute, "p95" is the 95th
+// percentile of the values plotted on the axis.
+export function parseAxisBound(bound: AxisBound): { value: number; percentile: boolean } | null {
+  if (typeof bound === 'number') {
+    return Number.isFinite(bound) ? { value: bound, percentile: false } : null
+  }
+  const m = /^p(\d+(?:\.\d+)?)$/i.exec(bound.trim())
+  if (!m) return null
+  const value = Number(m[1])
+  return value <= 100 ? { value, percentile: true } : null
+}
+
+// The p-th percentile (0–100) of values, interpolating linearly between the
+// closest ranks. NaN for an empty list.
+export function percentile(values: number[], p: number): number {
+  if (values.length === 0) return NaN
+  const sorted = [...values].sort((a, b) => a - b)
+  const rank = (p / 100) * (sorted.length - 1)
+  const lo = Math.floor(rank)
+  const hi = Math.ceil(rank)
+  return sorted[lo] + (sorted[hi] - sorted[lo]) * (rank - lo)
+}
+
+type AxisLike = Record<string, unknown> & { type?: string }
+type SeriesLike = Record<string, unknown>
+
+const asList = <T>(v: unknown): T[] => (Array.isArray(v) ? v : v ? [v] : []) as T[]
+
+// Plotted values of one series along the value axis: plain numbers, { value }
+// items, and [x, y] tuples (the value sits at `at`).
+function seriesValues(s: SeriesLike, at: number): number[] {
+  const out: number[] = []
+  for (const d of asList<unknown>(s.data)) {
+    const item =
+      d && typeof d === 'object' && !Array.isArray(d) ? (d as { value?: unknown }).value : d
+    const v = Array.isArray(item) ? item[at] : item
+    if (typeof v === 'number' && Number.isFinite(v)) out.push(v)
+  }
+  return out
+}
+
+// Slider/inside zoom entries for the category axis. A chart that already
+// zooms (long axes) keeps its initial window; otherwise the full range shows.
+function zoomEntries(
+  zoom: ZoomKind,
+  indexKey: 'xAxisIndex' | 'yAxisIndex',
+  existing: Record<string, unknown>[],
+  textColor: string
+): Record<string, unknown>[] {
+  const span = existing[0]
+    ? { start: existing[0].start, end: existing[0].end }
+    : { start: 0, end: 100 }
+  const out: Record<string, unknown>[] = []
+  if (zoom !== 'slider') out.push({ type: 'inside', [indexKey]: 0, ...span, filterMode: 'filter' })
+  if (zoom !== 'inside') {
+    const prior = existing.find((z) => z.type === 'slider')
+    const place = indexKey === 'yAxisIndex' ? { right: 8, width: 20 } : { bottom: 8, height: 28 }
+    out.push({
+      type: 'slider',
+      [indexKey]: 0,
+      ...span,
+      ...(prior ? {} : place),
+      ...prior,
+      filterMode: 'filter',
+      textStyle: { color: textColor },
+    })
+  }
+  return out
+}
+
+// Apply --min/--max/--log-base/--zero-baseline/--inverse to every value axis
+// of a 2D cartesian option, and --zoom to its category axis. The value axis is
+// y unless the category axis is y (horizontal bars). Percentile bounds resolve
+// against the series plotted on each axis (see applySecondaryAxis). Go rules
+// already dropped combinations that cannot apply (log base off a log scale,
+// zero baseline with --min or a log scale). Returns a new option; the input is
+// never mutated.
+export function applyAxisRange(
+  option: EChartsOption,
+  range: AxisRange,
+  textColor: string
+): EChartsOption {
+  const { min, max, logBase, zeroBaseline, inverse, zoom } = range
+  const touchesValue = min != null || max != null || logBase != null || zeroBaseline || inverse
+  if (!touchesValue && !zoom) return option
+
+  const flipped = asList<AxisLike>(option.yAxis)[0]?.type === 'category'
+  const valueKey = flipped ? 'xAxis' : 'yAxis'
+  const categoryKey = flipped ? 'yAxis' : 'xAxis'
+  const indexKey = flipped ? 'xAxisIndex' : 'yAxisIndex'
+  const series = asList<SeriesLike>(option.series)
+  const out: Record<string, unknown> = { ...option }
+
+  if (touchesValue) {
+    const axes = asList<AxisLike>(option[valueKey]).map((axis, i) => {
+      const next: AxisLike = { ...axis }
+      const values = () =>
+        series
+          .filter((s) => ((s[indexKey] as number | undefined) ?? 0) === i)
+          .flatMap((s) => seriesValues(s, flipped ? 0 : 1))
+      const resolve = (bound: AxisBound | undefined) => {
+        const b = bound == null ? null : parseAxisBound(bound)
+        if (!b) return undefined
+        const v = b.percentile ? percentile(values(), b.value) : b.value
+        return Number.isFinite(v) ? v : undefined
+      }
+      if (zeroBaseline) {
+        next.scale = false
+        delete next.min
+      }
+      const lo = resolve(min)
+      const hi = resolve(max)
+      if (lo !== undefined) next.min = lo
+      if (hi !== undefined) next.max = hi
+      if (logBase != null && next.type === 'log') next.logBase = logBase
+      if (inverse) next.inverse = true
+      return next
+    })
+    out[valueKey] = Array.isArray(option[valueKey]) ? axes : axes[0]
+  }
+
+  if (zoom && option[categoryKey]) {
+    const existing = asList<Record<string, unknown>>(option.dataZoom)
+    out.dataZoom = zoomEntries(zoom, flipped ? 'yAxisIndex' : 'xAxisIndex', existing, textColor)
+    // A chart that was not zooming yet has no room for the slider: grow the
+    // grid's free edge by the slider's band (percent edges become fixed px),
+    // leaving containLabel in charge of the tick labels and axis name.
+    const grid = option.grid as Record<string, unknown> | undefined
+    if (zoom !== 'inside' && existing.length === 0 && grid) {
+      const edge = flipped ? 'right' : 'bottom'
+      const room = flipped ? 32 : 40
+      const current = grid[edge]
+      out.grid = { ...grid, [edge]: (typeof current === 'number' ? current : 24) + room }
+    }
+  }
+  return out as EChartsOption
+}
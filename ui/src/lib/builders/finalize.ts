@@ -1,4 +1,11 @@
-import type { ChartData, SeriesData, Point3D, AxisLabels, SortOrder } from '@/types'
+import type {
+  ChartData,
+  SeriesData,
+  Point3D,
+  AxisLabels,
+  SortOrder,
+  Direction,
+} from '@/types'
 import type { BuildContext } from './types'
 import {
   applyCanonicalOrder,
@@ -36,6 +43,7 @@ export function finalizeChart(
   pieces: {
     statType: string
     statUnit?: string
+    statBetter?: Direction
     title: string
     yAxisValues: string[]
     zAxisValues: string[]
@@ -85,6 +93,7 @@ export function finalizeChart(
     title: pieces.title,
     statType: pieces.statType,
     statUnit: pieces.statUnit,
+    statBetter: pieces.statBetter,
     yAxis: yAxisValues,
     zAxis: zAxisValues,
     series: pieces.series,
@@ -2,6 +2,7 @@ import type { ChartData, DataPoint, SeriesData, Point3D, ChartType } from '@/typ
 import type { GroupingBuilder, BuildContext } from './types'
 import { finalizeChart } from './finalize'
 import { toStatSignature } from '../transform'
+import { statUnitLabel } from '../units'
 
 // Shared by GroupedBuilder and PreserveRowsBuilder — same badge/total/3D
 // queries; only build() differs.
@@ -97,7 +98,8 @@ export class GroupedBuilder implements GroupingBuilder {
     return finalizeChart(
       {
         statType: statTemplate.type,
-        statUnit: statTemplate.unit,
+        statUnit: statUnitLabel(statTemplate),
+        statBetter: statTemplate.better,
         title: statTemplate.type,
         yAxisValues,
         zAxisValues: Array.from(zAxisSet),
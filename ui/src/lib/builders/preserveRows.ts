@@ -3,6 +3,7 @@ import type { GroupingBuilder, BuildContext } from './types'
 import { finalizeChart } from './finalize'
 import { groupedQueries } from './grouped'
 import { statsForSignature } from '../transform'
+import { statUnitLabel } from '../units'
 
 // PreserveRows chart shape: one row per data point (no averaging across
 // duplicate (x,y)). When all y values are empty, falls back to a category
@@ -85,7 +86,8 @@ export class PreserveRowsBuilder implements GroupingBuilder {
     return finalizeChart(
       {
         statType: statTemplate.type,
-        statUnit: statTemplate.unit,
+        statUnit: statUnitLabel(statTemplate),
+        statBetter: statTemplate.better,
         title: statTemplate.type,
         yAxisValues,
         zAxisValues: Array.from(zAxisSet),
@@ -0,0 +1,67 @@
+import { describe, it, expect } from 'vitest'
+import { emptyChartData } from '@/test-utils'
+import { buildCalendarDays, calendarYears, toDay } from './calendar'
+
+describe('toDay', () => {
+  it('accepts the layouts the CLI treats as dates', () => {
+    expect(toDay('2024-01-31')).toBe('2024-01-31')
+    expect(toDay('2024/01/31')).toBe('2024-01-31')
+    expect(toDay('2024.01.31')).toBe('2024-01-31')
+    expect(toDay('2024-01-31T10:00:00Z')).toBe('2024-01-31')
+    expect(toDay(' 2024-01-31 10:00:00 ')).toBe('2024-01-31')
+  })
+
+  it('rejects non-dates and impossible days', () => {
+    expect(toDay('Sort')).toBeUndefined()
+    expect(toDay('2024-1-31')).toBeUndefined()
+    expect(toDay('2024-02-30')).toBeUndefined()
+  })
+})
+
+describe('buildCalendarDays', () => {
+  it('sums 2D series values per day in date order', () => {
+    const data = emptyChartData({
+      yAxis: ['feat', 'fix'],
+      series: [
+        { xAxis: '2024-03-02', values: [1, 2], benchmarkId: 'a' },
+        { xAxis: '2024-03-01', values: [4, null], benchmarkId: 'b' },
+        { xAxis: 'not a date', values: [9, 9], benchmarkId: 'c' },
+        { xAxis: '2024/03/02', values: [1, 0], benchmarkId: 'd' },
+      ],
+    })
+    expect(buildCalendarDays(data)).toEqual([
+      { date: '2024-03-01', value: 4 },
+      { date: '2024-03-02', value: 4 },
+    ])
+  })
+
+  it('sums 3D points over y and z', () => {
+    const data = emptyChartData({
+      points: [
+        { xAxis: '2023-12-31', yAxis: 'a', zAxis: 'p', value: 2 },
+        { xAxis: '2023-12-31', yAxis: 'b', zAxis: 'q', value: 3 },
+        { xAxis: '2024-01-01', yAxis: 'a', zAxis: 'p', value: 1 },
+      ],
+    })
+    expect(buildCalendarDays(data)).toEqual([
+      { date: '2023-12-31', value: 5 },
+      { date: '2024-01-01', value: 1 },
+    ])
+  })
+})
+
+describe('calendarYears', () => {
+  const days = [
+    { date: '2024-05-01', value: 1 },
+    { date: '2022-01-01', value: 1 },
+    { date: '2024-06-01', value: 1 },
+  ]
+
+  it('defaults to every year in the data', () => {
+    expect(calendarYears(days)).toEqual([2022, 2024])
+  })
+
+  it('expands a --years range, including years without data', () => {
+    expect(calendarYears(days, { from: 2023, to: 2025 })).toEqual([2023, 2024, 2025])
+  })
+})
@@ -0,0 +1,56 @@
+import type { CalendarYears, ChartData } from '@/types'
+
+export type CalendarDay = {
+  /** ISO day, e.g. "2024-01-31". */
+  date: string
+  /** Stat summed over every row that falls on this day. */
+  value: number
+}
+
+// The layouts Go's shared.ParseDate accepts: a year-month-day date separated by
+// "-", "/" or ".", optionally followed by a time. Only the date part is kept.
+const DATE_RE = /^(\d{4})[-/.](\d{2})[-/.](\d{2})(?:[T ].*)?$/
+
+// Normalise a dimension value to its ISO day, or undefined when it is not a date.
+export function toDay(value: string): string | undefined {
+  const m = DATE_RE.exec(value.trim())
+  if (!m) return undefined
+  const [, y, mo, d] = m
+  const date = new Date(Date.UTC(Number(y), Number(mo) - 1, Number(d)))
+  // Reject rollovers such as 2024-02-30.
+  if (date.getUTCMonth() !== Number(mo) - 1 || date.getUTCDate() !== Number(d)) return undefined
+  return `${y}-${mo}-${d}`
+}
+
+// Sum the active stat per day. The x dimension carries the dates; values are
+// summed across y (2D series) or y/z (3D points). Non-date x values are
+// dropped. Days come back in date order.
+export function buildCalendarDays(data: ChartData): CalendarDay[] {
+  const totals = new Map<string, number>()
+  const add = (x: string, v: number | null | undefined) => {
+    const day = toDay(x)
+    if (!day || v == null || !Number.isFinite(v)) return
+    totals.set(day, (totals.get(day) ?? 0) + v)
+  }
+
+  if (data.points?.length) {
+    for (const p of data.points) add(p.xAxis, p.value)
+  } else {
+    for (const s of data.series) for (const v of s.values) add(s.xAxis, v)
+  }
+
+  return [...totals.entries()]
+    .sort(([a], [b]) => (a < b ? -1 : a > b ? 1 : 0))
+    .map(([date, value]) => ({ date, value }))
+}
+
+// Years to draw one grid each: the --years range when set, else every year
+// present in the data (ascending).
+export function calendarYears(days: CalendarDay[], range?: CalendarYears): number[] {
+  if (range) {
+    const years: number[] = []
+    for (let y = range.from; y <= range.to; y++) years.push(y)
+    return years
+  }
+  return [...new Set(days.map((d) => Number(d.date.slice(0, 4))))].sort((a, b) => a - b)
+}
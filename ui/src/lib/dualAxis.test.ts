@@ -0,0 +1,100 @@
+import { describe, it, expect } from 'vitest'
+import type { EChartsOption } from 'echarts'
+import { applySecondaryAxis, resolveY2Series, splitStatUnit, y2Matches } from './dualAxis'
+
+const TIME = 'Execution Time (ns)'
+const MEM = 'Memory Usage (B)'
+
+const grouped = (names: string[], extra: Record<string, unknown> = {}): EChartsOption => ({
+  grid: { left: '3%', right: '3%', containLabel: true },
+  xAxis: { type: 'category', data: ['Sort', 'Map'] },
+  yAxis: { type: 'value', name: 'ops', splitLine: { lineStyle: { opacity: 0.4 } } },
+  series: names.map((name) => ({ type: 'bar', name, data: [1, 2], ...extra })),
+})
+
+describe('splitStatUnit', () => {
+  it('splits the trailing unit off a typed stat', () => {
+    expect(splitStatUnit(MEM)).toEqual({ name: 'Memory Usage', unit: 'B' })
+    expect(splitStatUnit('Allocations/op')).toEqual({ name: 'Allocations/op', unit: '' })
+  })
+})
+
+describe('y2Matches', () => {
+  it('matches the whole name or the name without its unit', () => {
+    expect(y2Matches(MEM, 'memory usage')).toBe(true)
+    expect(y2Matches(MEM, MEM)).toBe(true)
+    expect(y2Matches(MEM, 'B')).toBe(false)
+    expect(y2Matches(TIME, 'Memory Usage')).toBe(false)
+  })
+})
+
+describe('resolveY2Series', () => {
+  it('auto moves every unit after the first', () => {
+    expect([...resolveY2Series([TIME, MEM, 'Allocs', 'Peak (B)'], ['auto'])]).toEqual([
+      MEM,
+      'Peak (B)',
+    ])
+  })
+
+  it('auto keeps one-unit charts on one axis', () => {
+    expect(resolveY2Series(['a (ns)', 'b (ns)', 'c'], ['auto']).size).toBe(0)
+  })
+
+  it('moves named series but never all of them', () => {
+    expect([...resolveY2Series([TIME, MEM], ['Memory Usage'])]).toEqual([MEM])
+    expect(resolveY2Series([TIME, MEM], ['Memory Usage', TIME]).size).toBe(0)
+    expect(resolveY2Series([TIME, MEM], undefined).size).toBe(0)
+  })
+})
+
+describe('applySecondaryAxis', () => {
+  it('adds a right-hand axis named by unit and points moved series at it', () => {
+    const o = grouped([TIME, MEM])
+    const out = applySecondaryAxis(o, ['auto'])
+    const axes = out.yAxis as Record<string, unknown>[]
+    expect(axes).toHaveLength(2)
+    expect(axes[0]).toBe(o.yAxis)
+    expect(axes[1]).toMatchObject({
+      type: 'value',
+      position: 'right',
+      name: 'B',
+      splitLine: { show: false },
+    })
+    const series = out.series as { yAxisIndex?: number }[]
+    expect(series.map((s) => s.yAxisIndex)).toEqual([undefined, 1])
+    // Input option is not mutated.
+    expect(Array.isArray(o.yAxis)).toBe(false)
+  })
+
+  it('drops the copied axis name when the moved series carry no unit', () => {
+    const out = applySecondaryAxis(grouped(['latency', 'memory']), ['memory'])
+    expect((out.yAxis as Record<string, unknown>[])[1].name).toBeUndefined()
+  })
+
+  it('uses a top x axis for horizontal bars', () => {
+    const o: EChartsOption = {
+      xAxis: { type: 'value' },
+      yAxis: { type: 'category', data: ['Sort'] },
+      series: [
+        { type: 'bar', name: TIME, data: [1] },
+        { type: 'bar', name: MEM, data: [2] },
+      ],
+    }
+    const out = applySecondaryAxis(o, ['auto'])
+    expect((out.xAxis as Record<string, unknown>[])[1]).toMatchObject({ position: 'top' })
+    expect((out.series as { xAxisIndex?: number }[])[1].xAxisIndex).toBe(1)
+  })
+
+  it('reserves right-hand room on a dataZoom grid', () => {
+    const o = { ...grouped([TIME, MEM]), grid: { left: 55, right: 24, containLabel: false } }
+    expect(applySecondaryAxis(o, ['auto']).grid).toMatchObject({ right: 55 })
+  })
+
+  it('leaves stacked and unmatched charts alone', () => {
+    const stacked = grouped([TIME, MEM], { stack: 'total' })
+    expect(applySecondaryAxis(stacked, ['auto'])).toBe(stacked)
+    const o = grouped([TIME, MEM])
+    expect(applySecondaryAxis(o, ['nope'])).toBe(o)
+    expect(applySecondaryAxis(o, undefined)).toBe(o)
+  })
+})
@@ -0,0 +1,87 @@
+import type { EChartsOption } from 'echarts'
+
+// Mirrors Go charts.SplitStatUnit: "Memory Usage (B/op)" → name "Memory Usage",
+// unit "B/op". Types without a trailing "(unit)" come back whole.
+export function splitStatUnit(statType: string): { name: string; unit: string } {
+  const m = /^(.*)\(([^()]*)\)$/.exec(statType)
+  if (!m) return { name: statType, unit: '' }
+  return { name: m[1].trim(), unit: m[2] }
+}
+
+// Mirrors Go charts.Y2Matches: a --y2 name selects a series by its whole name
+// or by the name without its unit ("Memory Usage" → "Memory Usage (B/op)").
+export function y2Matches(series: string, name: string): boolean {
+  const want = name.toLowerCase()
+  if (series.toLowerCase() === want) return true
+  const { name: base, unit } = splitStatUnit(series)
+  return unit !== '' && base.toLowerCase() === want
+}
+
+// The series names that move to the secondary value axis. "auto" keeps the
+// first unit seen (and unit-less series) on the primary axis and moves every
+// other unit; otherwise the listed names are moved. Empty when either axis
+// would be left without a series — one axis is all a single unit needs.
+export function resolveY2Series(names: string[], y2: string[] | undefined): Set<string> {
+  if (!y2?.length) return new Set()
+  let picked: string[]
+  if (y2.length === 1 && y2[0] === 'auto') {
+    const units = names.map((n) => splitStatUnit(n).unit)
+    const primary = units.find((u) => u !== '')
+    picked = names.filter((_, i) => units[i] !== '' && units[i] !== primary)
+  } else {
+    picked = names.filter((n) => y2.some((name) => y2Matches(n, name)))
+  }
+  return picked.length === 0 || picked.length === names.length ? new Set() : new Set(picked)
+}
+
+type AxisLike = Record<string, unknown> & { type?: string }
+
+const firstAxis = (axis: unknown): AxisLike | undefined =>
+  (Array.isArray(axis) ? axis[0] : axis) as AxisLike | undefined
+
+// Split a 2D grouped option across two value axes. The secondary axis clones
+// the primary one on the opposite side (right, or top for horizontal bars)
+// without split lines, and is named by the units it carries. Stacked series
+// stay on one axis (Go: Y2Conflicts). Returns a new option; the input is
+// never mutated.
+export function applySecondaryAxis(
+  option: EChartsOption,
+  y2: string[] | undefined
+): EChartsOption {
+  if (!y2?.length || !Array.isArray(option.series)) return option
+  const series = option.series as Record<string, unknown>[]
+  if (series.some((s) => s.stack)) return option
+
+  const onY2 = resolveY2Series(series.map((s) => String(s.name ?? '')), y2)
+  if (onY2.size === 0) return option
+
+  const flipped = firstAxis(option.yAxis)?.type === 'category'
+  const valueKey = flipped ? 'xAxis' : 'yAxis'
+  const indexKey = flipped ? 'xAxisIndex' : 'yAxisIndex'
+  const primary = firstAxis(option[valueKey])
+  if (!primary) return option
+
+  const units = [...new Set([...onY2].map((n) => splitStatUnit(n).unit).filter(Boolean))]
+  const secondary: AxisLike = {
+    ...primary,
+    position: flipped ? 'top' : 'right',
+    splitLine: { show: false },
+  }
+  delete secondary.name
+  if (units.length) {
+    Object.assign(secondary, { name: units.join(', '), nameLocation: 'middle', nameGap: 45 })
+  }
+
+  // A dataZoom grid drops containLabel and reserves fixed px on the left
+  // only; give the right-hand axis labels the same room.
+  const grid = option.grid as Record<string, unknown> | undefined
+  const roomyGrid =
+    !flipped && grid && grid.containLabel === false ? { ...grid, right: grid.left } : grid
+
+  return {
+    ...option,
+    ...(roomyGrid ? { grid: roomyGrid } : {}),
+    [valueKey]: [primary, secondary],
+    series: series.map((s) => (onY2.has(String(s.name ?? '')) ? { ...s, [indexKey]: 1 } : s)),
+  } as EChartsOption
+}
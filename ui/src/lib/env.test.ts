@@ -0,0 +1,41 @@
+import { describe, it, expect } from 'vitest'
+import { describeEnvChanges, envChanges, envSummary, formatEnvValue } from './env'
+
+const v1 = {
+  hostname: 'ci-1',
+  go: '1.22.3',
+  'git.commit': '0123abcdef',
+  'git.branch': 'main',
+  'git.dirty': 'false',
+}
+
+describe('envSummary', () => {
+  it('leads with the git ref, then toolchains', () => {
+    expect(envSummary(v1)).toBe('main@0123abc · go 1.22.3')
+    expect(envSummary({ ...v1, 'git.dirty': 'true' })).toBe('main@0123abc* · go 1.22.3')
+  })
+
+  it('falls back to the hostname', () => {
+    expect(envSummary({ hostname: 'box', kernel: '6.8' })).toBe('box')
+    expect(envSummary(undefined)).toBe('')
+  })
+})
+
+describe('envChanges', () => {
+  it('lists differing keys, toolchains and source first', () => {
+    const v2 = { ...v1, hostname: 'ci-2', go: '1.23.0', memory: '16.0 GiB' }
+    expect(envChanges(v1, v2)).toEqual([
+      { key: 'go', from: '1.22.3', to: '1.23.0' },
+      { key: 'memory', from: undefined, to: '16.0 GiB' },
+      { key: 'hostname', from: 'ci-1', to: 'ci-2' },
+    ])
+  })
+
+  it('describes changes as text', () => {
+    expect(describeEnvChanges(v1, { ...v1, 'git.commit': '89abcdef01' })).toBe(
+      'git.commit 0123abc → 89abcde'
+    )
+    expect(describeEnvChanges(v1, v1)).toBe('same')
+    expect(formatEnvValue('go', undefined)).toBe('–')
+  })
+})
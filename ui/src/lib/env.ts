@@ -0,0 +1,72 @@
+import type { Meta } from '@/types'
+
+type Env = Meta['env']
+
+// Keys in the order they best explain a difference between two runs:
+// toolchains and source first, then hardware, then the host itself.
+const ENV_ORDER = [
+  'go',
+  'rust',
+  'node',
+  'git.commit',
+  'git.branch',
+  'git.dirty',
+  'cpu.model',
+  'cpu.cores',
+  'memory',
+  'kernel',
+  'hostname',
+]
+
+const TOOLCHAINS = ['go', 'rust', 'node']
+
+const rank = (key: string) => {
+  const i = ENV_ORDER.indexOf(key)
+  return i === -1 ? ENV_ORDER.length : i
+}
+
+// Shortens values for display; commits show as their 7-char abbreviation.
+export function formatEnvValue(key: string, value?: string): string {
+  if (value === undefined || value === '') return '–'
+  return key === 'git.commit' ? value.slice(0, 7) : value
+}
+
+// A one-line summary of a run's environment for the header badge:
+// "main@0123abc* · go 1.22.3", falling back to the hostname.
+export function envSummary(env: Env): string {
+  if (!env) return ''
+  const parts: string[] = []
+  const commit = env['git.commit']
+  if (commit) {
+    const dirty = env['git.dirty'] === 'true' ? '*' : ''
+    const branch = env['git.branch'] ? `${env['git.branch']}@` : ''
+    parts.push(`${branch}${formatEnvValue('git.commit', commit)}${dirty}`)
+  }
+  for (const key of TOOLCHAINS) {
+    if (env[key]) parts.push(`${key} ${env[key]}`)
+  }
+  if (parts.length === 0 && env.hostname) parts.push(env.hostname)
+  return parts.join(' · ')
+}
+
+export type EnvChange = { key: string; from?: string; to?: string }
+
+// Every key whose value differs between two environments, most telling first.
+export function envChanges(from: Env, to: Env): EnvChange[] {
+  const a = from ?? {}
+  const b = to ?? {}
+  const keys = new Set([...Object.keys(a), ...Object.keys(b)])
+  return [...keys]
+    .filter((key) => a[key] !== b[key])
+    .sort((x, y) => rank(x) - rank(y) || x.localeCompare(y))
+    .map((key) => ({ key, from: a[key], to: b[key] }))
+}
+
+// envChanges as text: "go 1.22.3 → 1.23.0, git.commit 0123abc → 89abcde".
+export function describeEnvChanges(from: Env, to: Env): string {
+  const changes = envChanges(from, to)
+  if (changes.length === 0) return 'same'
+  return changes
+    .map((c) => `${c.key} ${formatEnvValue(c.key, c.from)} → ${formatEnvValue(c.key, c.to)}`)
+    .join(', ')
+}
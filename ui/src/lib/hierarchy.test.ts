@@ -0,0 +1,89 @@
+import { describe, it, expect } from 'vitest'
+import type { DataPoint } from '../types'
+import { buildHierarchy, hierarchyLevels } from './hierarchy'
+
+const row = (name: string, xAxis: string, yAxis: string, allocs: number): DataPoint => ({
+  name,
+  xAxis,
+  yAxis,
+  stats: [
+    { type: 'ns/op', value: 100 },
+    { type: 'allocs/op', value: allocs },
+  ],
+})
+
+const rows = [
+  row('pkg/a', 'Parse', 'small', 2),
+  row('pkg/a', 'Parse', 'large', 6),
+  row('pkg/a', 'Encode', 'small', 4),
+  row('pkg/b', 'Parse', 'small', 8),
+]
+
+describe('hierarchyLevels', () => {
+  it('nests in serial order for the identity arrangement', () => {
+    expect(hierarchyLevels('nxy', 'nxy')).toEqual(['name', 'xAxis', 'yAxis'])
+  })
+
+  it('follows the swap: the field moved onto name becomes the top level', () => {
+    expect(hierarchyLevels('nxy', 'xny')).toEqual(['xAxis', 'name', 'yAxis'])
+  })
+
+  it('falls back to identity order on a length mismatch', () => {
+    expect(hierarchyLevels('nx', 'xyz')).toEqual(['name', 'xAxis'])
+  })
+})
+
+describe('buildHierarchy', () => {
+  it('sums the chosen stat up the name → x → y path', () => {
+    const tree = buildHierarchy(rows, ['name', 'xAxis', 'yAxis'], 'allocs/op')
+    expect(tree.map((n) => [n.name, n.value])).toEqual([
+      ['pkg/a', 12],
+      ['pkg/b', 8],
+    ])
+    const parse = tree[0]!.children![0]!
+    expect(parse).toEqual({
+      name: 'Parse',
+      value: 8,
+      children: [
+        { name: 'small', value: 2 },
+        { name: 'large', value: 6 },
+      ],
+    })
+  })
+
+  it('matches the stat type case-insensitively', () => {
+    const tree = buildHierarchy(rows, ['name'], 'ALLOCS/OP')
+    expect(tree.map((n) => n.value)).toEqual([12, 8])
+  })
+
+  it('leafDepth rolls deeper levels into their parent', () => {
+    const tree = buildHierarchy(rows, ['name', 'xAxis', 'yAxis'], 'allocs/op', 2)
+    expect(tree[0]!.children).toEqual([
+      { name: 'Parse', value: 8 },
+      { name: 'Encode', value: 4 },
+    ])
+  })
+
+  it('sorts siblings by value when an order is given', () => {
+    const tree = buildHierarchy(rows, ['name', 'xAxis'], 'allocs/op', undefined, 'asc')
+    expect(tree[0]!.children!.map((n) => n.name)).toEqual(['Encode', 'Parse'])
+  })
+
+  it('skips rows without the stat or with no positive area', () => {
+    const tree = buildHierarchy(
+      [...rows, row('pkg/c', 'Parse', 'small', 0), { name: 'pkg/d', xAxis: 'X' }],
+      ['name', 'xAxis'],
+      'allocs/op'
+    )
+    expect(tree.map((n) => n.name)).toEqual(['pkg/a', 'pkg/b'])
+  })
+
+  it('an empty level ends the path but still counts toward the parent', () => {
+    const tree = buildHierarchy(
+      [row('pkg/a', '', '', 3), row('pkg/a', 'Parse', '', 1)],
+      ['name', 'xAxis'],
+      'allocs/op'
+    )
+    expect(tree).toEqual([{ name: 'pkg/a', value: 4, children: [{ name: 'Parse', value: 1 }] }])
+  })
+})
@@ -0,0 +1,82 @@
+import type { DataPoint, SortOrder } from '@/types'
+import { translateAxisKey, type AxisKey } from './swap'
+
+export type HierarchyNode = {
+  name: string
+  value: number
+  children?: HierarchyNode[]
+}
+
+// Hierarchy levels always nest in serial axis order, whatever the swap.
+const LEVEL_ORDER: AxisKey[] = ['name', 'xAxis', 'yAxis', 'zAxis']
+
+// Raw fields feeding each hierarchy level, top first. The swap target decides
+// which source field lands on which dimension (identity[i] → target[i]); the
+// dimensions then nest name → x → y → z. A length mismatch falls back to the
+// identity order.
+export function hierarchyLevels(identity: string, target: string | undefined): AxisKey[] {
+  const identityKeys = translateAxisKey(identity)
+  const targetKeys = translateAxisKey(target || identity)
+  if (identityKeys.length !== targetKeys.length) {
+    return LEVEL_ORDER.filter((k) => identityKeys.includes(k))
+  }
+  return LEVEL_ORDER.filter((k) => targetKeys.includes(k)).map(
+    (k) => identityKeys[targetKeys.indexOf(k)]!
+  )
+}
+
+type Building = HierarchyNode & { index?: Map<string, Building> }
+
+// Build the node tree for treemap/sunburst from raw rows. Each row's stat of
+// type `statType` (case-insensitive) is summed into its path, so a parent's
+// value is the total of everything beneath it. leafDepth keeps only the top N
+// levels (deeper rows roll up into their ancestor). Rows without the stat, or
+// with a non-positive value, have no area to draw and are skipped; an empty
+// level value ends the path early so the row still counts toward its parent.
+export function buildHierarchy(
+  rows: DataPoint[],
+  levels: AxisKey[],
+  statType: string,
+  leafDepth?: number,
+  order?: SortOrder
+): HierarchyNode[] {
+  const depth = Math.max(1, Math.min(leafDepth ?? levels.length, levels.length))
+  const used = levels.slice(0, depth)
+  const want = statType.toLowerCase()
+  const root: Building = { name: '', value: 0 }
+
+  for (const row of rows) {
+    const value = row.stats?.find((s) => s.type.toLowerCase() === want)?.value
+    if (value == null || !Number.isFinite(value) || value <= 0) continue
+
+    let node = root
+    node.value += value
+    for (const field of used) {
+      const key = (row as unknown as Record<string, string | undefined>)[field] ?? ''
+      if (key === '') break
+      node.index ??= new Map()
+      let child = node.index.get(key)
+      if (!child) {
+        child = { name: key, value: 0 }
+        node.index.set(key, child)
+      }
+      child.value += value
+      node = child
+    }
+  }
+
+  return finish(root, order).children ?? []
+}
+
+function finish(node: Building, order?: SortOrder): HierarchyNode {
+  const out: HierarchyNode = { name: node.name, value: node.value }
+  if (node.index) {
+    const children = Array.from(node.index.values(), (c) => finish(c, order))
+    if (order) {
+      const multiplier = order === 'asc' ? 1 : -1
+      children.sort((a, b) => multiplier * (a.value - b.value) || a.name.localeCompare(b.name))
+    }
+    out.children = children
+  }
+  return out
+}
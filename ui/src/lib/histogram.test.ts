@@ -0,0 +1,44 @@
+import { describe, it, expect } from 'vitest'
+import type { Point3D } from '../types'
+import { cumulativePercent, histogramSeries } from './histogram'
+
+const pt = (xAxis: string, yAxis: string, value: number): Point3D => ({
+  xAxis,
+  yAxis,
+  zAxis: '',
+  value,
+})
+
+describe('histogramSeries', () => {
+  it('keeps bin order and splits overlay series', () => {
+    const { bins, series } = histogramSeries([
+      pt('[0, 10)', 'api', 3),
+      pt('[10, 20]', 'api', 1),
+      pt('[0, 10)', 'web', 0),
+      pt('[10, 20]', 'web', 2),
+    ])
+    expect(bins).toEqual(['[0, 10)', '[10, 20]'])
+    expect(series).toEqual([
+      { name: 'api', counts: [3, 1] },
+      { name: 'web', counts: [0, 2] },
+    ])
+  })
+
+  it('fills bins a series never reached with zero', () => {
+    const { series } = histogramSeries([pt('a', 's1', 1), pt('b', 's2', 4)])
+    expect(series).toEqual([
+      { name: 's1', counts: [1, 0] },
+      { name: 's2', counts: [0, 4] },
+    ])
+  })
+})
+
+describe('cumulativePercent', () => {
+  it('accumulates to 100', () => {
+    expect(cumulativePercent([1, 1, 2])).toEqual([25, 50, 100])
+  })
+
+  it('stays at zero for an empty series', () => {
+    expect(cumulativePercent([0, 0])).toEqual([0, 0])
+  })
+})
@@ -0,0 +1,45 @@
+import type { Point3D } from '@/types'
+
+export type HistogramSeries = { name: string; counts: number[] }
+
+// Regroup Go-binned points (x = bin label, y = series, value = count) into
+// per-series count arrays. Bins keep first-seen order, which is ascending:
+// the binner emits every bin for every series in order, so the UI sort
+// setting never reorders a histogram.
+export function histogramSeries(points: Point3D[]): {
+  bins: string[]
+  series: HistogramSeries[]
+} {
+  const binIndex = new Map<string, number>()
+  const bySeries = new Map<string, Map<number, number>>()
+  for (const p of points) {
+    let i = binIndex.get(p.xAxis)
+    if (i === undefined) {
+      i = binIndex.size
+      binIndex.set(p.xAxis, i)
+    }
+    let counts = bySeries.get(p.yAxis)
+    if (!counts) {
+      counts = new Map()
+      bySeries.set(p.yAxis, counts)
+    }
+    counts.set(i, (counts.get(i) ?? 0) + p.value)
+  }
+  const bins = Array.from(binIndex.keys())
+  const series = Array.from(bySeries, ([name, counts]) => ({
+    name,
+    counts: bins.map((_, i) => counts.get(i) ?? 0),
+  }))
+  return { bins, series }
+}
+
+// Running share of the series total per bin, in percent (the empirical CDF at
+// each bin's upper edge). An all-zero series stays at 0.
+export function cumulativePercent(counts: number[]): number[] {
+  const total = counts.reduce((sum, c) => sum + c, 0)
+  let running = 0
+  return counts.map((c) => {
+    running += c
+    return total > 0 ? (running / total) * 100 : 0
+  })
+}
@@ -0,0 +1,128 @@
+import { describe, it, expect } from 'vitest'
+import type { DataPoint } from '../types'
+import {
+  buildBump,
+  buildWaterfall,
+  datasetTags,
+  deltaVerdict,
+  rankOrderFor,
+  tagField,
+} from './history'
+
+const row = (name: string, xAxis: string, value: number, type = 'ns/op'): DataPoint => ({
+  name,
+  xAxis,
+  stats: [{ type, value }],
+})
+
+// Three benchmarks across v1 → v2 → v3, tags on the name dimension.
+const rows: DataPoint[] = [
+  row('v1', 'Sort', 30),
+  row('v1', 'Map', 10),
+  row('v1', 'Hash', 20),
+  row('v2', 'Sort', 5),
+  row('v2', 'Map', 12),
+  row('v2', 'Hash', 20),
+  row('v3', 'Sort', 5),
+  row('v3', 'Map', 40),
+]
+const tags = ['v1', 'v2', 'v3']
+
+describe('datasetTags', () => {
+  it('lists history tags, then the current tag, without repeats', () => {
+    const history = [
+      { tag: 'v1', timestamp: 't1' },
+      { tag: '', timestamp: 't2' },
+      { tag: 'v2', timestamp: 't3' },
+    ]
+    expect(datasetTags(history, 'v3')).toEqual(['v1', 'v2', 'v3'])
+    expect(datasetTags(history, 'v2')).toEqual(['v1', 'v2'])
+    expect(datasetTags(undefined, undefined)).toEqual([])
+  })
+})
+
+describe('tagField', () => {
+  it('finds the dimension whose values are all tags', () => {
+    expect(tagField(rows, tags)).toBe('name')
+    expect(tagField(rows.map((r) => ({ ...r, name: undefined, yAxis: r.name })), tags)).toBe(
+      'yAxis'
+    )
+  })
+
+  it('prefers --tag-axis and finds nothing without tags', () => {
+    expect(tagField(rows, tags, 'x')).toBe('xAxis')
+    expect(tagField(rows, [])).toBeUndefined()
+    expect(tagField(rows, ['v9'])).toBeUndefined()
+  })
+})
+
+describe('buildBump', () => {
+  it('ranks benchmarks per tag, lowest value first', () => {
+    expect(buildBump(rows, tags, 'name', 'NS/OP')).toEqual([
+      { name: 'Sort', ranks: [3, 1, 1], values: [30, 5, 5] },
+      { name: 'Map', ranks: [1, 2, 2], values: [10, 12, 40] },
+      { name: 'Hash', ranks: [2, 3, null], values: [20, 20, null] },
+    ])
+  })
+
+  it('ranks the highest value first when desc', () => {
+    const lines = buildBump(rows, tags, 'name', 'ns/op', 'desc')
+    expect(lines.map((l) => l.ranks[0])).toEqual([1, 3, 2])
+  })
+
+  it('sums duplicate rows and ignores other stats', () => {
+    const extra = [...rows, row('v1', 'Map', 25), row('v1', 'Sort', 1, 'B/op')]
+    const lines = buildBump(extra, ['v1'], 'name', 'ns/op')
+    expect(lines.find((l) => l.name === 'Map')?.values).toEqual([35])
+    expect(lines.find((l) => l.name === 'Map')?.ranks).toEqual([3])
+  })
+})
+
+describe('rankOrderFor', () => {
+  it('ranks the better end first', () => {
+    expect(rankOrderFor('higher')).toBe('desc')
+    expect(rankOrderFor('lower')).toBe('asc')
+    expect(rankOrderFor()).toBe('asc')
+  })
+})
+
+describe('deltaVerdict', () => {
+  it('reads a change against the stat direction', () => {
+    expect(deltaVerdict(5, 'lower')).toBe('regressed')
+    expect(deltaVerdict(-5, 'lower')).toBe('improved')
+    expect(deltaVerdict(5, 'higher')).toBe('improved')
+    expect(deltaVerdict(-5, 'higher')).toBe('regressed')
+    expect(deltaVerdict(5, 'neutral')).toBe('neutral')
+    expect(deltaVerdict(5)).toBe('neutral')
+    expect(deltaVerdict(0, 'lower')).toBe('neutral')
+  })
+})
+
+describe('buildWaterfall', () => {
+  it('steps from the oldest to the latest tag by default', () => {
+    expect(buildWaterfall(rows, tags, 'name', 'ns/op')).toEqual({
+      from: 'v1',
+      to: 'v3',
+      start: 60,
+      end: 45,
+      steps: [
+        { name: 'Sort', delta: -25 },
+        { name: 'Map', delta: 30 },
+        { name: 'Hash', delta: -20 },
+      ],
+    })
+  })
+
+  it('uses --from/--to and sorts steps by change', () => {
+    const w = buildWaterfall(rows, tags, 'name', 'ns/op', 'v1', 'v2', 'asc')
+    expect(w.start).toBe(60)
+    expect(w.end).toBe(37)
+    expect(w.steps.map((s) => s.name)).toEqual(['Sort', 'Hash', 'Map'])
+  })
+
+  it('starts from zero when the from tag is absent', () => {
+    const w = buildWaterfall(rows, tags, 'name', 'ns/op', 'v0', 'v1')
+    expect(w.start).toBe(0)
+    expect(w.end).toBe(60)
+  })
+})
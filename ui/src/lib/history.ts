@@ -0,0 +1,158 @@
+import type { DataPoint, Direction, HistoryEntry, SortOrder, TagAxis } from '@/types'
+import type { AxisKey } from './swap'
+
+const FIELDS: AxisKey[] = ['name', 'xAxis', 'yAxis', 'zAxis']
+const TAG_FIELDS: Record<TagAxis, AxisKey> = { n: 'name', x: 'xAxis', y: 'yAxis', z: 'zAxis' }
+
+const field = (row: DataPoint, key: AxisKey) =>
+  (row as unknown as Record<string, string | undefined>)[key] ?? ''
+
+// The tags of a merged dataset, oldest first: every history tag, then the
+// current one (mirrors Go's Dataset.Tags). Empty and repeated tags drop out.
+export function datasetTags(history?: HistoryEntry[], tag?: string): string[] {
+  const out: string[] = []
+  for (const t of [...(history ?? []).map((h) => h.tag), tag]) {
+    if (t && !out.includes(t)) out.push(t)
+  }
+  return out
+}
+
+// The dimension holding the tags: the --tag-axis one when set, else the first
+// dimension whose non-empty values are all tags (mirrors Go's
+// Dataset.TagAxes). Undefined when the data carries no tag dimension.
+export function tagField(
+  rows: DataPoint[],
+  tags: string[],
+  tagAxis?: TagAxis
+): AxisKey | undefined {
+  if (tagAxis) return TAG_FIELDS[tagAxis]
+  if (!tags.length) return undefined
+  return FIELDS.find((key) => {
+    const values = rows.map((r) => field(r, key)).filter((v) => v !== '')
+    return values.length > 0 && values.every((v) => tags.includes(v))
+  })
+}
+
+// Sum the active stat (matched case-insensitively) per tag and benchmark. A
+// benchmark is named by its other dimension values, e.g. "Sort / 1024".
+// Benchmarks come back in first-seen order.
+function tagTotals(rows: DataPoint[], tagKey: AxisKey, stat: string) {
+  const want = stat.toLowerCase()
+  const names: string[] = []
+  const totals = new Map<string, Map<string, number>>()
+  for (const row of rows) {
+    const tag = field(row, tagKey)
+    const s = row.stats?.find((st) => st.type.toLowerCase() === want)
+    if (!tag || s?.value == null || !Number.isFinite(s.value)) continue
+    const name = FIELDS.filter((k) => k !== tagKey)
+      .map((k) => field(row, k))
+      .filter((v) => v !== '')
+      .join(' / ')
+    if (!names.includes(name)) names.push(name)
+    const perTag = totals.get(tag) ?? new Map<string, number>()
+    perTag.set(name, (perTag.get(name) ?? 0) + s.value)
+    totals.set(tag, perTag)
+  }
+  return { names, totals }
+}
+
+export type BumpLine = {
+  /** Benchmark name, e.g. "Sort / 1024". */
+  name: string
+  /** 1-based rank per tag; null where the benchmark is missing at that tag. */
+  ranks: (number | null)[]
+  /** The stat behind each rank. */
+  values: (number | null)[]
+}
+
+// The rank order that puts a stat's better end first: desc for
+// higher-is-better stats, asc otherwise (the better end of ns/op).
+export function rankOrderFor(better?: Direction): SortOrder {
+  return better === 'higher' ? 'desc' : 'asc'
+}
+
+// Whether a change in a stat is an improvement, a regression, or neither
+// (no change, or a stat with no known better end).
+export function deltaVerdict(
+  delta: number,
+  better?: Direction
+): 'improved' | 'regressed' | 'neutral' {
+  if (delta === 0 || (better !== 'lower' && better !== 'higher')) return 'neutral'
+  return (delta < 0) === (better === 'lower') ? 'improved' : 'regressed'
+}
+
+// Rank every benchmark at each tag by the active stat. `order` asc ranks the
+// lowest value first; ties keep first-seen order.
+export function buildBump(
+  rows: DataPoint[],
+  tags: string[],
+  tagKey: AxisKey,
+  stat: string,
+  order: SortOrder = 'asc'
+): BumpLine[] {
+  const { names, totals } = tagTotals(rows, tagKey, stat)
+  const multiplier = order === 'asc' ? 1 : -1
+  const lines = names.map((name) => ({
+    name,
+    ranks: new Array<number | null>(tags.length).fill(null),
+    values: new Array<number | null>(tags.length).fill(null),
+  }))
+  tags.forEach((tag, t) => {
+    const perTag = totals.get(tag)
+    if (!perTag) return
+    const present = lines.filter((l) => perTag.has(l.name))
+    present.sort((a, b) => multiplier * (perTag.get(a.name)! - perTag.get(b.name)!))
+    present.forEach((l, i) => {
+      l.ranks[t] = i + 1
+      l.values[t] = perTag.get(l.name)!
+    })
+  })
+  return lines
+}
+
+export type WaterfallStep = {
+  name: string
+  /** Stat at the `to` tag minus the stat at `from`; a missing side counts as 0. */
+  delta: number
+}
+
+export type Waterfall = {
+  from: string
+  to: string
+  /** Stat total at `from`. */
+  start: number
+  /** Stat total at `to` (start plus every step). */
+  end: number
+  steps: WaterfallStep[]
+}
+
+// Walk from the stat total at `from` to the total at `to`, one step per
+// benchmark (default: oldest → latest tag). `order` sorts steps by change.
+export function buildWaterfall(
+  rows: DataPoint[],
+  tags: string[],
+  tagKey: AxisKey,
+  stat: string,
+  from?: string,
+  to?: string,
+  order?: SortOrder
+): Waterfall {
+  const fromTag = from ?? tags[0] ?? ''
+  const toTag = to ?? tags[tags.length - 1] ?? ''
+  const { names, totals } = tagTotals(rows, tagKey, stat)
+  const before = totals.get(fromTag) ?? new Map<string, number>()
+  const after = totals.get(toTag) ?? new Map<string, number>()
+
+  const steps = names
+    .filter((name) => before.has(name) || after.has(name))
+    .map((name) => ({ name, delta: (after.get(name) ?? 0) - (before.get(name) ?? 0) }))
+  if (order) {
+    const multiplier = order === 'asc' ? 1 : -1
+    steps.sort((a, b) => multiplier * (a.delta - b.delta))
+  }
+
+  let start = 0
+  for (const v of before.values()) start += v
+  const end = steps.reduce((sum, s) => sum + s.delta, start)
+  return { from: fromTag, to: toTag, start, end, steps }
+}
@@ -0,0 +1,136 @@
+import { describe, it, expect } from 'vitest'
+import type { EChartsOption } from 'echarts'
+import type { Annotation } from '../types'
+import { applyMarks, markStatMatches, marksFor } from './marks'
+
+type Series = Record<string, { data: unknown[] } | undefined>
+
+const vertical = (): EChartsOption => ({
+  xAxis: { type: 'category', data: ['Sort', 'Map'] },
+  yAxis: { type: 'value' },
+  series: [
+    { type: 'bar', name: 'a', data: [1, 2] },
+    { type: 'bar', name: 'b', data: [3, 4] },
+  ],
+})
+
+const firstSeries = (o: EChartsOption) => (o.series as Series[])[0]
+
+describe('markStatMatches', () => {
+  it('matches the full stat type or its unit, case-insensitively', () => {
+    expect(markStatMatches('Execution Time (ns/op)', 'execution time (ns/op)')).toBe(true)
+    expect(markStatMatches('Execution Time (ns/op)', 'NS/op')).toBe(true)
+    expect(markStatMatches('Execution Time (ns/op)', 'B/op')).toBe(false)
+    expect(markStatMatches('latency', 'ms')).toBe(false)
+  })
+})
+
+describe('marksFor', () => {
+  it('keeps unkeyed marks and those keyed to the chart stat', () => {
+    const marks: Annotation[] = [
+      { type: 'hline', value: 1 },
+      { type: 'hline', value: 2, stat: 'ns/op' },
+      { type: 'hline', value: 3, stat: 'allocs/op' },
+    ]
+    expect(marksFor(marks, 'Execution Time (ns/op)').map((m) => m.value)).toEqual([1, 2])
+    expect(marksFor(undefined, 'x')).toEqual([])
+  })
+})
+
+describe('applyMarks', () => {
+  it('returns the option untouched when there is nothing to draw', () => {
+    const o = vertical()
+    expect(applyMarks(o, [], '#000')).toBe(o)
+  })
+
+  it('attaches lines, bands and points to the first series only', () => {
+    const o = vertical()
+    const out = applyMarks(
+      o,
+      [
+        { type: 'hline', value: 50, label: 'p99 SLO', color: 'red' },
+        { type: 'vline', at: 'Map' },
+        { type: 'band', from: 10, to: 20 },
+        { type: 'point', at: 'Sort', value: 1, label: 'regression' },
+      ],
+      '#000'
+    )
+    const s = firstSeries(out)
+    expect(s.markLine?.data).toEqual([
+      {
+        yAxis: 50,
+        label: { formatter: 'p99 SLO', color: 'red' },
+        lineStyle: { color: 'red', type: 'dashed' },
+      },
+      {
+        xAxis: 'Map',
+        label: { formatter: 'Map', color: '#000' },
+        lineStyle: { color: '#000', type: 'dashed' },
+      },
+    ])
+    expect(s.markArea?.data).toEqual([
+      [
+        {
+          yAxis: 10,
+          name: '',
+          label: { color: '#000' },
+          itemStyle: { color: '#000', opacity: 0.12 },
+        },
+        { yAxis: 20 },
+      ],
+    ])
+    expect(s.markPoint?.data).toEqual([
+      { coord: ['Sort', 1], value: 'regression', itemStyle: { color: '#000' } },
+    ])
+    expect((out.series as Series[])[1]).toEqual((o.series as Series[])[1])
+    // Input option is not mutated.
+    expect(firstSeries(o).markLine).toBeUndefined()
+  })
+
+  it('flips every mark when the category axis is y (horizontal bars)', () => {
+    const o: EChartsOption = {
+      xAxis: { type: 'value' },
+      yAxis: { type: 'category', data: ['Sort'] },
+      series: [{ type: 'bar', data: [1] }],
+    }
+    const s = firstSeries(
+      applyMarks(
+        o,
+        [
+          { type: 'hline', value: 5 },
+          { type: 'vline', at: 'Sort' },
+          { type: 'point', at: 'Sort', value: 1 },
+        ],
+        '#000'
+      )
+    )
+    expect(s.markLine?.data.map((d) => Object.keys(d as object)[0])).toEqual(['xAxis', 'yAxis'])
+    expect(s.markPoint?.data).toEqual([
+      { coord: [1, 'Sort'], value: 1, itemStyle: { color: '#000' } },
+    ])
+  })
+
+  it('attaches to the first series on the primary value axis', () => {
+    const o: EChartsOption = {
+      xAxis: { type: 'category', data: ['Sort'] },
+      yAxis: [{ type: 'value' }, { type: 'value', position: 'right' }],
+      series: [
+        { type: 'bar', name: 'mem', data: [1], yAxisIndex: 1 },
+        { type: 'bar', name: 'time', data: [2] },
+      ],
+    }
+    const series = applyMarks(o, [{ type: 'hline', value: 5 }], '#000').series as Series[]
+    expect(series[0].markLine).toBeUndefined()
+    expect(series[1].markLine?.data).toHaveLength(1)
+  })
+
+  it('converts at to a number on a value x axis', () => {
+    const o: EChartsOption = {
+      xAxis: { type: 'value' },
+      yAxis: { type: 'value' },
+      series: [{ type: 'scatter', data: [[1, 2]] }],
+    }
+    const s = firstSeries(applyMarks(o, [{ type: 'vline', at: '1024' }], '#000'))
+    expect(s.markLine?.data[0]).toMatchObject({ xAxis: 1024 })
+  })
+})
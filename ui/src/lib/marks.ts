@@ -0,0 +1,90 @@
+import type { EChartsOption } from 'echarts'
+import type { Annotation } from '@/types'
+import { splitStatUnit } from './dualAxis'
+
+// Mirrors Go charts.MarkStatMatches: a mark's stat matches the chart's stat
+// type by name ("Execution Time (ns/op)") or by its trailing unit ("ns/op").
+export function markStatMatches(statType: string, stat: string): boolean {
+  const want = stat.toLowerCase()
+  if (statType.toLowerCase() === want) return true
+  const { unit } = splitStatUnit(statType)
+  return unit !== '' && unit.toLowerCase() === want
+}
+
+// The marks that apply to one chart: unkeyed marks apply to every stat.
+export function marksFor(marks: Annotation[] | undefined, statType: string): Annotation[] {
+  return (marks ?? []).filter((m) => !m.stat || markStatMatches(statType, m.stat))
+}
+
+type AxisLike = { type?: string } | undefined
+
+const firstAxis = (axis: unknown): AxisLike =>
+  (Array.isArray(axis) ? axis[0] : axis) as AxisLike
+
+// Overlay marks on the option's first primary-axis series (see
+// applySecondaryAxis) as ECharts markLine/markArea/markPoint. The value axis
+// is y unless the category axis is y (horizontal bars), in which case every
+// mark flips. `at` is passed through as a category name, or converted to a
+// number when the other axis is a value axis (scatter).
+// Returns a new option; the input is never mutated.
+export function applyMarks(
+  option: EChartsOption,
+  marks: Annotation[],
+  color: string
+): EChartsOption {
+  const series = (Array.isArray(option.series) ? option.series : [option.series]).filter(Boolean)
+  if (marks.length === 0 || series.length === 0) return option
+
+  const flipped = firstAxis(option.yAxis)?.type === 'category'
+  const valueKey = flipped ? 'xAxis' : 'yAxis'
+  const atKey = flipped ? 'yAxis' : 'xAxis'
+  const atAxis = firstAxis(flipped ? option.yAxis : option.xAxis)
+  const numericAt = atAxis?.type === 'value' || atAxis?.type === 'log'
+  const at = (m: Annotation) => (numericAt ? Number(m.at) : m.at)
+
+  const lines: object[] = []
+  const areas: object[][] = []
+  const points: object[] = []
+  for (const m of marks) {
+    const c = m.color ?? color
+    switch (m.type) {
+      case 'hline':
+      case 'vline': {
+        const key = m.type === 'hline' ? valueKey : atKey
+        const v = m.type === 'hline' ? m.value : at(m)
+        lines.push({
+          [key]: v,
+          label: { formatter: m.label ?? String(v), color: c },
+          lineStyle: { color: c, type: 'dashed' },
+        })
+        break
+      }
+      case 'band':
+        areas.push([
+          {
+            [valueKey]: m.from,
+            name: m.label ?? '',
+            label: { color: c },
+            itemStyle: { color: c, opacity: 0.12 },
+          },
+          { [valueKey]: m.to },
+        ])
+        break
+      case 'point':
+        points.push({
+          coord: flipped ? [m.value, at(m)] : [at(m), m.value],
+          value: m.label ?? m.value,
+          itemStyle: { color: c },
+        })
+        break
+    }
+  }
+
+  const list = series as Record<string, unknown>[]
+  const target = Math.max(0, list.findIndex((s) => !s.yAxisIndex && !s.xAxisIndex))
+  const marked: Record<string, unknown> = { ...list[target] }
+  if (lines.length) marked.markLine = { symbol: 'none', silent: true, data: lines }
+  if (areas.length) marked.markArea = { silent: true, data: areas }
+  if (points.length) marked.markPoint = { data: points }
+  return { ...option, series: list.map((s, i) => (i === target ? marked : s)) } as EChartsOption
+}
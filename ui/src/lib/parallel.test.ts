@@ -0,0 +1,56 @@
+import { describe, it, expect } from 'vitest'
+import type { DataPoint } from '../types'
+import { axisScaleFor, buildParallel } from './parallel'
+
+const row = (xAxis: string, yAxis: string, stats: Record<string, number>): DataPoint => ({
+  xAxis,
+  yAxis,
+  stats: Object.entries(stats).map(([type, value]) => ({ type, value })),
+})
+
+const rows: DataPoint[] = [
+  row('Sort', '1024', { 'sec/op': 3, 'B/op': 512 }),
+  row('Sort', '4096', { 'sec/op': 9, 'B/op': 2048, 'allocs/op': 4 }),
+  row('Map', '1024', { 'sec/op': 5, 'b/op': 64 }),
+]
+
+describe('buildParallel', () => {
+  it('makes one axis per stat type in first-seen order', () => {
+    const { axes } = buildParallel(rows, ['xAxis', 'yAxis'])
+    expect(axes).toEqual(['sec/op', 'B/op', 'allocs/op'])
+  })
+
+  it('makes one line per row named by its dimensions', () => {
+    const { lines } = buildParallel(rows, ['xAxis', 'yAxis'])
+    expect(lines).toEqual([
+      { name: 'Sort / 1024', group: 'Sort', values: [3, 512, null] },
+      { name: 'Sort / 4096', group: 'Sort', values: [9, 2048, 4] },
+      { name: 'Map / 1024', group: 'Map', values: [5, 64, null] },
+    ])
+  })
+
+  it('follows the swapped level order', () => {
+    const { lines } = buildParallel(rows, ['yAxis', 'xAxis'])
+    expect(lines.map((l) => [l.name, l.group])).toEqual([
+      ['1024 / Sort', '1024'],
+      ['4096 / Sort', '4096'],
+      ['1024 / Map', '1024'],
+    ])
+  })
+
+  it('sorts lines by the sort stat and puts missing values last', () => {
+    const { lines } = buildParallel(rows, ['xAxis', 'yAxis'], 'desc', 'ALLOCS/OP')
+    expect(lines.map((l) => l.name)).toEqual(['Sort / 4096', 'Sort / 1024', 'Map / 1024'])
+
+    const asc = buildParallel(rows, ['xAxis', 'yAxis'], 'asc', 'sec/op')
+    expect(asc.lines.map((l) => l.values[0])).toEqual([3, 5, 9])
+  })
+})
+
+describe('axisScaleFor', () => {
+  it('prefers a case-insensitive per-axis override', () => {
+    expect(axisScaleFor('B/op', 'linear', { 'b/op': 'log' })).toBe('log')
+    expect(axisScaleFor('sec/op', 'log', { 'b/op': 'linear' })).toBe('log')
+    expect(axisScaleFor('sec/op', 'linear', undefined)).toBe('linear')
+  })
+})
@@ -0,0 +1,71 @@
+import type { DataPoint, ScaleType, SortOrder } from '@/types'
+import type { AxisKey } from './swap'
+
+export type ParallelLine = {
+  /** Dimension values joined top-first, e.g. "Sort / 1024". */
+  name: string
+  /** Top dimension value; lines sharing it share a legend entry and color. */
+  group: string
+  /** One value per axis; null where the row has no finite value for that stat. */
+  values: (number | null)[]
+}
+
+// Lay raw rows out for parallel coordinates: one axis per stat type (first-seen
+// order, matched case-insensitively) and one line per row, named by its
+// dimension values in `levels` order. `order` sorts lines by their value on
+// `sortStat`; lines missing it go last.
+export function buildParallel(
+  rows: DataPoint[],
+  levels: AxisKey[],
+  order?: SortOrder,
+  sortStat?: string
+): { axes: string[]; lines: ParallelLine[] } {
+  const axisIndex = new Map<string, number>()
+  const axes: string[] = []
+  for (const row of rows) {
+    for (const s of row.stats ?? []) {
+      const key = s.type.toLowerCase()
+      if (axisIndex.has(key)) continue
+      axisIndex.set(key, axes.length)
+      axes.push(s.type)
+    }
+  }
+
+  const lines = rows.map((row) => {
+    const parts = levels
+      .map((field) => (row as unknown as Record<string, string | undefined>)[field] ?? '')
+      .filter((v) => v !== '')
+    const values = new Array<number | null>(axes.length).fill(null)
+    for (const s of row.stats ?? []) {
+      if (s.value == null || !Number.isFinite(s.value)) continue
+      values[axisIndex.get(s.type.toLowerCase())!] = s.value
+    }
+    return { name: parts.join(' / '), group: parts[0] ?? '', values }
+  })
+
+  const sortIndex = sortStat ? axisIndex.get(sortStat.toLowerCase()) : undefined
+  if (order && sortIndex !== undefined) {
+    const multiplier = order === 'asc' ? 1 : -1
+    lines.sort((a, b) => {
+      const va = a.values[sortIndex]
+      const vb = b.values[sortIndex]
+      if (va == null || vb == null) return (va == null ? 1 : 0) - (vb == null ? 1 : 0)
+      return multiplier * (va - vb)
+    })
+  }
+  return { axes, lines }
+}
+
+// Scale for one stat axis: an --axis-scale entry (keys match
+// case-insensitively) wins over the chart-wide scale.
+export function axisScaleFor(
+  stat: string,
+  scale: ScaleType,
+  axisScale: Record<string, ScaleType> | undefined
+): ScaleType {
+  const want = stat.toLowerCase()
+  for (const [key, value] of Object.entries(axisScale ?? {})) {
+    if (key.toLowerCase() === want) return value
+  }
+  return scale
+}
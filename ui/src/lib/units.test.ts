@@ -0,0 +1,33 @@
+import { describe, it, expect } from 'vitest'
+import { statUnitLabel, withLabelUnit, withValueUnit } from './units'
+
+describe('statUnitLabel', () => {
+  it('joins unit and per', () => {
+    expect(statUnitLabel({ unit: 'ns', per: 'op' })).toBe('ns/op')
+    expect(statUnitLabel({ unit: 'ms' })).toBe('ms')
+  })
+
+  it('has no unit for a per value alone or a missing stat', () => {
+    expect(statUnitLabel({ per: 'op' })).toBeUndefined()
+    expect(statUnitLabel(undefined)).toBeUndefined()
+  })
+})
+
+describe('withLabelUnit', () => {
+  it('keeps a label that already carries the unit', () => {
+    expect(withLabelUnit('Execution Time (ns/op)', 'ns/op')).toBe('Execution Time (ns/op)')
+  })
+
+  it('appends a missing unit', () => {
+    expect(withLabelUnit('Wall clock', 'ms')).toBe('Wall clock (ms)')
+    expect(withLabelUnit('Wall clock')).toBe('Wall clock')
+  })
+})
+
+describe('withValueUnit', () => {
+  it('separates units with a space and attaches percentages', () => {
+    expect(withValueUnit('1.5', 'ms/op')).toBe('1.5 ms/op')
+    expect(withValueUnit('12', '%')).toBe('12%')
+    expect(withValueUnit('3')).toBe('3')
+  })
+})
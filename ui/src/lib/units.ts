@@ -0,0 +1,23 @@
+import type { Stat } from '@/types'
+import { splitStatUnit } from './dualAxis'
+
+// Mirrors Go frame's statUnit: a stat's structured unit as shown next to its
+// values, "ns/op" or "ms". A per value alone ("Allocations/op") is part of the
+// name, not a unit.
+export function statUnitLabel(stat?: Pick<Stat, 'unit' | 'per'>): string | undefined {
+  if (!stat?.unit) return undefined
+  return stat.per ? `${stat.unit}/${stat.per}` : stat.unit
+}
+
+// A stat label with its unit: the label as is when it already ends in
+// "(unit)" ("Execution Time (ns/op)"), else the unit appended.
+export function withLabelUnit(label: string, unit?: string): string {
+  if (!unit || splitStatUnit(label).unit === unit) return label
+  return `${label} (${unit})`
+}
+
+// A formatted value with its unit: "1.5 ms/op". Percentages attach directly.
+export function withValueUnit(text: string, unit?: string): string {
+  if (!unit) return text
+  return unit === '%' ? `${text}%` : `${text} ${unit}`
+}
@@ -31,6 +31,7 @@ import {
   is3D,
   chartSeriesLabels,
   CPUtoString,
+  commitLabel,
 } from './utils'
 import { applyTheme } from './themes'
 
@@ -609,3 +610,14 @@ describe('CPUtoString', () => {
     expect(CPUtoString({} as Meta['cpu'])).toBe('')
   })
 })
+
+describe('commitLabel', () => {
+  it('shortens the hash and appends the subject', () => {
+    expect(commitLabel(undefined)).toBe('')
+    expect(commitLabel({ sha: '' })).toBe('')
+    expect(commitLabel({ sha: 'a1b2c3d4e5f6' })).toBe('a1b2c3d')
+    expect(commitLabel({ sha: 'a1b2c3d4e5f6', subject: 'speed up sort' })).toBe(
+      'a1b2c3d speed up sort'
+    )
+  })
+})
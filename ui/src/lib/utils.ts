@@ -1,6 +1,6 @@
 import { type ClassValue, clsx } from 'clsx'
 import { twMerge } from 'tailwind-merge'
-import type { ChartType, Meta, ChartData, DataPoint, Axis } from '../types'
+import type { ChartType, Commit, Meta, ChartData, DataPoint, Axis } from '../types'
 import type { Ref } from 'vue'
 import { arrangementHasChartZ } from './swap'
 import { builderForChart, pickBuilder } from './builders'
@@ -179,6 +179,13 @@ export const computeChartGrandTotal = (
   visibleZ?: Record<string, boolean>
 ): number => builderForChart(chart).grandTotal(chart, visibleZ)
 
+/** Short hash and subject of a run's commit, e.g. "a1b2c3d speed up sort". */
+export const commitLabel = (commit?: Commit) => {
+  if (!commit?.sha) return ''
+  const short = commit.sha.slice(0, 7)
+  return commit.subject ? `${short} ${commit.subject}` : short
+}
+
 export const CPUtoString = (cpu: Meta['cpu']) => {
   if (!cpu) {
     return ''
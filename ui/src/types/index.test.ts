@@ -12,6 +12,13 @@ describe('ALL_CHART_TYPES', () => {
       'radar',
       'sankey',
       'chord',
+      'treemap',
+      'sunburst',
+      'histogram',
+      'parallel',
+      'calendar',
+      'bump',
+      'waterfall',
     ])
   })
 })
@@ -25,6 +25,13 @@ export type ChartType =
   | 'radar'
   | 'sankey'
   | 'chord'
+  | 'treemap'
+  | 'sunburst'
+  | 'histogram'
+  | 'parallel'
+  | 'calendar'
+  | 'bump'
+  | 'waterfall'
 
 // Closed enumeration of chart types vizb knows how to render. Single source of
 // truth on the UI side — the wire format's `settings[i].type` discriminator is
@@ -39,16 +46,27 @@ export const ALL_CHART_TYPES: ChartType[] = [
   'radar',
   'sankey',
   'chord',
+  'treemap',
+  'sunburst',
+  'histogram',
+  'parallel',
+  'calendar',
+  'bump',
+  'waterfall',
 ]
 
 export type ScaleType = 'linear' | 'log'
 export const SCALE_TYPES: ScaleType[] = ['linear', 'log']
 
+// Which way a stat improves; unset means unknown and reads like neutral.
+export type Direction = 'lower' | 'higher' | 'neutral'
+
 export type Stat = {
   type: string
   value?: number
   unit?: string
   per?: string
+  better?: Direction
 }
 
 export type DataPoint = {
@@ -91,6 +109,28 @@ export type BarBackground = {
   opacity?: number
 }
 
+// Reference mark drawn over a 2D cartesian chart (wire: Go shared.Annotation).
+// Config-level marks come from `--mark`; Dataset-level ones from `annotations`.
+// `stat` keys the mark to one stat type (or its unit, e.g. "ns/op"); absent =
+// every stat. Values are on the value axis; `at` is an axis value (category).
+export type Annotation = {
+  type: 'hline' | 'vline' | 'band' | 'point'
+  stat?: string
+  value?: number
+  from?: number
+  to?: number
+  at?: string
+  label?: string
+  color?: string
+}
+
+// Value-axis bound (wire: Go shared.AxisBound): an absolute number, or "p95"
+// for a percentile of the values plotted on the axis.
+export type AxisBound = number | string
+
+// Category-axis data zoom (--zoom): drag slider, wheel/pinch inside, or both.
+export type ZoomKind = 'slider' | 'inside' | 'both'
+
 // Per-chart typed configs (wire format: `Dataset.Settings []ChartConfig`).
 // Each chart type carries only the fields that apply to it. The `type`
 // discriminator narrows the union at the call site — chart-rendering code may
@@ -103,7 +143,17 @@ export type BarConfig = {
   swap?: string
   sort?: Sort
   scale?: ScaleType
+  /** Value-axis range, log base, zero baseline and inversion (2D only). */
+  min?: AxisBound
+  max?: AxisBound
+  logBase?: number
+  zeroBaseline?: boolean
+  inverse?: boolean
+  /** Category-axis data zoom (2D only). */
+  zoom?: ZoomKind
   stack?: boolean
+  /** Series on a secondary value axis: ['auto'] (by unit) or series names (2D only). */
+  y2?: string[]
   showLabels?: boolean
   horizontal?: boolean
   /** Corner radii in px [TL, TR, BR, BL]; length 1–4 (ECharts expands [8] to all corners). */
@@ -113,6 +163,8 @@ export type BarConfig = {
   threeDRotate?: boolean
   threeD?: boolean
   threeDVisualMap?: boolean
+  /** Reference lines, bands and callouts (2D only). */
+  mark?: Annotation[]
   stat?: StatConfig
 }
 
@@ -121,7 +173,17 @@ export type LineConfig = {
   swap?: string
   sort?: Sort
   scale?: ScaleType
+  /** Value-axis range, log base, zero baseline and inversion (2D only). */
+  min?: AxisBound
+  max?: AxisBound
+  logBase?: number
+  zeroBaseline?: boolean
+  inverse?: boolean
+  /** Category-axis data zoom (2D only). */
+  zoom?: ZoomKind
   stack?: boolean
+  /** Series on a secondary value axis: ['auto'] (by unit) or series names (2D only). */
+  y2?: string[]
   showLabels?: boolean
   symbol?: string
   symbolSize?: number
@@ -129,6 +191,8 @@ export type LineConfig = {
   threeDRotate?: boolean
   threeD?: boolean
   threeDVisualMap?: boolean
+  /** Reference lines, bands and callouts (2D only). */
+  mark?: Annotation[]
   stat?: StatConfig
 }
 
@@ -137,6 +201,14 @@ export type ScatterConfig = {
   swap?: string
   sort?: Sort
   scale?: ScaleType
+  /** Value-axis range, log base, zero baseline and inversion (2D only). */
+  min?: AxisBound
+  max?: AxisBound
+  logBase?: number
+  zeroBaseline?: boolean
+  inverse?: boolean
+  /** Category-axis data zoom (2D only). */
+  zoom?: ZoomKind
   showLabels?: boolean
   symbol?: string
   symbolSize?: number
@@ -144,6 +216,8 @@ export type ScatterConfig = {
   threeD?: boolean
   threeDVisualMap?: boolean
   visualMap?: boolean
+  /** Reference lines, bands and callouts (2D only). */
+  mark?: Annotation[]
   stat?: StatConfig
 }
 
@@ -187,6 +261,106 @@ export type ChordConfig = {
   stat?: StatConfig
 }
 
+// Treemap/sunburst nest the name → x → y → z dimensions. The level counts and
+// value stat are CLI-baked (--leaf-depth, --label-levels, --value-stat); unset
+// means every level, labelled, sized by the active stat.
+export type HierarchyConfigFields = {
+  swap?: string
+  sort?: Sort
+  showLabels?: boolean
+  leafDepth?: number
+  labelLevels?: number
+  valueStat?: string
+  stat?: StatConfig
+}
+
+export type TreemapConfig = HierarchyConfigFields & { type: 'treemap' }
+
+export type SunburstConfig = HierarchyConfigFields & { type: 'sunburst' }
+
+// Histogram bins are computed in Go (points: x = bin, y = overlay series,
+// value = count); the bin options are carried for display only. `cumulative`
+// switches the counts to a per-series CDF and is a runtime toggle.
+export type HistogramConfig = {
+  type: 'histogram'
+  swap?: string
+  sort?: Sort
+  showLabels?: boolean
+  bins?: number
+  binWidth?: number
+  binMethod?: 'count' | 'width' | 'fd' | 'log'
+  cumulative?: boolean
+  stat?: StatConfig
+}
+
+// Axis brushing style for the parallel chart. Color/width/opacity style the
+// selection box; activeOpacity/inactiveOpacity apply to lines inside and
+// outside every selection. Unset fields keep the ECharts defaults.
+export type ParallelBrush = {
+  color?: string
+  width?: number
+  opacity?: number
+  activeOpacity?: number
+  inactiveOpacity?: number
+}
+
+// Parallel coordinates draw one axis per stat type and one line per row.
+// `scale` is the default for every axis; `axisScale` overrides it per stat
+// type (keys match case-insensitively).
+export type ParallelConfig = {
+  type: 'parallel'
+  swap?: string
+  sort?: Sort
+  scale?: ScaleType
+  axisScale?: Record<string, ScaleType>
+  brush?: ParallelBrush
+  stat?: StatConfig
+}
+
+// Calendar heatmaps key one cell per day off the x dimension (dates) and
+// colour it by the stat summed over the other dimensions. `years` limits the
+// grids drawn (default: every year in the data); `visualMap` shows the colour
+// scale, which stays hidden but still colours the cells when off.
+export type CalendarYears = {
+  from: number
+  to: number
+}
+
+export type CalendarConfig = {
+  type: 'calendar'
+  swap?: string
+  showLabels?: boolean
+  weekStart?: 'sunday' | 'monday'
+  years?: CalendarYears
+  cellSize?: number
+  visualMap?: boolean
+  stat?: StatConfig
+}
+
+// Bump and waterfall charts read the history `vizb merge` builds: the tags
+// sit on one dimension (`tagAxis`, detected when unset). Bump ranks every
+// benchmark per tag (`rank` asc: lowest value ranks first); waterfall steps
+// from the total at `from` to the total at `to` (default: oldest → latest).
+export type TagAxis = 'n' | 'x' | 'y' | 'z'
+
+export type BumpConfig = {
+  type: 'bump'
+  showLabels?: boolean
+  tagAxis?: TagAxis
+  rank?: SortOrder
+  stat?: StatConfig
+}
+
+export type WaterfallConfig = {
+  type: 'waterfall'
+  sort?: Sort
+  showLabels?: boolean
+  tagAxis?: TagAxis
+  from?: string
+  to?: string
+  stat?: StatConfig
+}
+
 export type ChartConfig =
   | BarConfig
   | LineConfig
@@ -196,6 +370,13 @@ export type ChartConfig =
   | RadarConfig
   | SankeyConfig
   | ChordConfig
+  | TreemapConfig
+  | SunburstConfig
+  | HistogramConfig
+  | ParallelConfig
+  | CalendarConfig
+  | BumpConfig
+  | WaterfallConfig
 
 // Human-readable label for each dimension, derived from the --group columns.
 // `name` is carried (though not rendered as an axis) so the swap feature can
@@ -218,11 +399,23 @@ export type Meta = {
   os?: string
   arch?: string
   pkg?: string
+  /** Run context from --capture-env: hostname, kernel, go, git.commit, … */
+  env?: Record<string, string>
+}
+
+/** Git commit a run was tagged from with `--tag auto`. */
+export type Commit = {
+  sha: string
+  /** Committer time, RFC 3339. */
+  time?: string
+  author?: string
+  subject?: string
 }
 
 export type HistoryEntry = {
   tag: string
   timestamp: string
+  commit?: Commit
   meta?: Meta
 }
 
@@ -235,6 +428,8 @@ export type Theme = {
 }
 
 export type Dataset = {
+  /** Wire version the dataset was written at; absent on files that pre-date it. */
+  schemaVersion?: number
   id?: string
   name: string
   description?: string
@@ -250,11 +445,14 @@ export type Dataset = {
   theme?: string
   tag?: string
   timestamp?: string
+  commit?: Commit
   history?: HistoryEntry[]
   meta?: Meta
   axes?: Axis[]
   /** Tabular csv/json: keep every input row; do not average duplicate axis keys. */
   preserveRows?: boolean
+  /** Dataset-level reference marks; drawn on every 2D bar/line/scatter chart. */
+  annotations?: Annotation[]
 
   settings: ChartConfig[]
   data: DataPoint[]
@@ -332,6 +530,7 @@ export type ChartData = {
   title: string
   statType: string
   statUnit?: string
+  statBetter?: Direction
   yAxis: string[]
   zAxis: string[]
   series: SeriesData[]